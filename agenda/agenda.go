@@ -0,0 +1,232 @@
+/*
+Package agenda aggregates tasks across all lists into a daily agenda.
+*/
+package agenda
+
+import (
+	"sort"
+	"time"
+
+	"github.com/robdimsdale/wl"
+)
+
+const (
+	// UpcomingDays is the number of days after today whose tasks
+	// are included in the Upcoming section.
+	UpcomingDays = 7
+)
+
+// Item is a task on the agenda, along with the title of its list.
+type Item struct {
+	Task      wl.Task `json:"task" yaml:"task"`
+	ListTitle string  `json:"list_title" yaml:"list_title"`
+}
+
+// ReminderItem is a reminder firing on the day of the agenda,
+// along with the task it belongs to.
+type ReminderItem struct {
+	Reminder wl.Reminder `json:"reminder" yaml:"reminder"`
+	Time     time.Time   `json:"time" yaml:"time"`
+	Item
+}
+
+// OccurrenceItem is a future occurrence of a recurring task.
+type OccurrenceItem struct {
+	Date       wl.Date       `json:"date" yaml:"date"`
+	Recurrence wl.Recurrence `json:"recurrence" yaml:"recurrence"`
+	Item
+}
+
+// Section is a titled group of agenda items.
+type Section struct {
+	Title string `json:"title" yaml:"title"`
+	Items []Item `json:"items" yaml:"items"`
+}
+
+// Agenda contains the uncompleted tasks relevant to a single day.
+// A task may appear in more than one section.
+type Agenda struct {
+	Date         time.Time        `json:"date" yaml:"date"`
+	Overdue      []Item           `json:"overdue" yaml:"overdue"`
+	Today        []Item           `json:"today" yaml:"today"`
+	Upcoming     []Item           `json:"upcoming" yaml:"upcoming"`
+	Starred      []Item           `json:"starred" yaml:"starred"`
+	AssignedToMe []Item           `json:"assigned_to_me" yaml:"assigned_to_me"`
+	Reminders    []ReminderItem   `json:"reminders" yaml:"reminders"`
+	Recurring    []OccurrenceItem `json:"recurring" yaml:"recurring"`
+}
+
+// Sections returns the task sections of the agenda in display order.
+func (a Agenda) Sections() []Section {
+	return []Section{
+		{Title: "Overdue", Items: a.Overdue},
+		{Title: "Today", Items: a.Today},
+		{Title: "Next 7 days", Items: a.Upcoming},
+		{Title: "Starred", Items: a.Starred},
+		{Title: "Assigned to me", Items: a.AssignedToMe},
+	}
+}
+
+// Build fetches the current user, lists, uncompleted tasks and reminders
+// via the provided client and computes the Agenda for the day containing now.
+func Build(client wl.Client, now time.Time) (Agenda, error) {
+	user, err := client.User()
+	if err != nil {
+		return Agenda{}, err
+	}
+
+	lists, err := client.Lists()
+	if err != nil {
+		return Agenda{}, err
+	}
+
+	tasks, err := client.CompletedTasks(false)
+	if err != nil {
+		return Agenda{}, err
+	}
+
+	reminders, err := client.Reminders()
+	if err != nil {
+		return Agenda{}, err
+	}
+
+	return Compute(tasks, lists, reminders, user.ID, now), nil
+}
+
+// Compute calculates the Agenda for the day containing now, in the location
+// of now. Completed tasks are ignored. Tasks assigned to userID are included
+// in the AssignedToMe section. Occurrences of recurring tasks after their
+// due date, from today until the end of the Upcoming section, are included
+// in Recurring.
+func Compute(
+	tasks []wl.Task,
+	lists []wl.List,
+	reminders []wl.Reminder,
+	userID uint,
+	now time.Time,
+) Agenda {
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	tomorrow := today.AddDate(0, 0, 1)
+	todayDate := wl.DateOf(now)
+	upcomingEnd := todayDate.AddDays(1 + UpcomingDays)
+
+	listTitles := map[uint]string{}
+	for _, l := range lists {
+		listTitles[l.ID] = l.Title
+	}
+
+	a := Agenda{
+		Date:         today,
+		Overdue:      []Item{},
+		Today:        []Item{},
+		Upcoming:     []Item{},
+		Starred:      []Item{},
+		AssignedToMe: []Item{},
+		Reminders:    []ReminderItem{},
+		Recurring:    []OccurrenceItem{},
+	}
+
+	tasksByID := map[uint]wl.Task{}
+	for _, t := range tasks {
+		if t.Completed {
+			continue
+		}
+		tasksByID[t.ID] = t
+
+		item := Item{Task: t, ListTitle: listTitles[t.ListID]}
+
+		if !t.DueDate.IsZero() {
+			switch due := t.DueDate; {
+			case due.Before(todayDate):
+				a.Overdue = append(a.Overdue, item)
+			case due == todayDate:
+				a.Today = append(a.Today, item)
+			case due.Before(upcomingEnd):
+				a.Upcoming = append(a.Upcoming, item)
+			}
+		}
+
+		recurrence := t.Recurrence()
+		for _, d := range recurrence.Between(t.DueDate, todayDate, upcomingEnd) {
+			a.Recurring = append(a.Recurring, OccurrenceItem{
+				Date:       d,
+				Recurrence: recurrence,
+				Item:       item,
+			})
+		}
+
+		if t.Starred {
+			a.Starred = append(a.Starred, item)
+		}
+
+		if userID != 0 && t.AssigneeID == userID {
+			a.AssignedToMe = append(a.AssignedToMe, item)
+		}
+	}
+
+	for _, r := range reminders {
+		t, ok := tasksByID[r.TaskID]
+		if !ok {
+			continue
+		}
+
+		fires := r.Date.In(loc)
+
+		if fires.Before(today) || !fires.Before(tomorrow) {
+			continue
+		}
+
+		a.Reminders = append(a.Reminders, ReminderItem{
+			Reminder: r,
+			Time:     fires,
+			Item:     Item{Task: t, ListTitle: listTitles[t.ListID]},
+		})
+	}
+
+	sort.Sort(byDueDate(a.Overdue))
+	sort.Sort(byDueDate(a.Today))
+	sort.Sort(byDueDate(a.Upcoming))
+	sort.Sort(byDueDate(a.Starred))
+	sort.Sort(byDueDate(a.AssignedToMe))
+	sort.Sort(byTime(a.Reminders))
+	sort.Sort(byOccurrence(a.Recurring))
+
+	return a
+}
+
+// byDueDate sorts items by due date, with items without a due date last,
+// then by title.
+type byDueDate []Item
+
+func (s byDueDate) Len() int      { return len(s) }
+func (s byDueDate) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byDueDate) Less(i, j int) bool {
+	di, dj := s[i].Task.DueDate, s[j].Task.DueDate
+	if di.IsZero() != dj.IsZero() {
+		return dj.IsZero()
+	}
+	if di != dj {
+		return di.Before(dj)
+	}
+	return s[i].Task.Title < s[j].Task.Title
+}
+
+// byTime sorts reminder items by the time they fire.
+type byTime []ReminderItem
+
+func (s byTime) Len() int           { return len(s) }
+func (s byTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byTime) Less(i, j int) bool { return s[i].Time.Before(s[j].Time) }
+
+// byOccurrence sorts occurrences by date, then by title.
+type byOccurrence []OccurrenceItem
+
+func (s byOccurrence) Len() int      { return len(s) }
+func (s byOccurrence) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byOccurrence) Less(i, j int) bool {
+	if s[i].Date != s[j].Date {
+		return s[i].Date.Before(s[j].Date)
+	}
+	return s[i].Task.Title < s[j].Task.Title
+}
@@ -0,0 +1,13 @@
+package agenda_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestAgenda(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Agenda Suite")
+}
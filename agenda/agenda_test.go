@@ -0,0 +1,139 @@
+package agenda_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/agenda"
+)
+
+func taskIDs(items []agenda.Item) []uint {
+	ids := []uint{}
+	for _, i := range items {
+		ids = append(ids, i.Task.ID)
+	}
+	return ids
+}
+
+var _ = Describe("Compute", func() {
+	var (
+		now    time.Time
+		today  time.Time
+		userID uint
+
+		lists     []wl.List
+		tasks     []wl.Task
+		reminders []wl.Reminder
+	)
+
+	BeforeEach(func() {
+		loc := time.FixedZone("test", -5*60*60)
+		now = time.Date(2016, time.January, 4, 22, 30, 0, 0, loc)
+		today = time.Date(2016, time.January, 4, 0, 0, 0, 0, loc)
+		userID = 10
+
+		lists = []wl.List{{ID: 1, Title: "Work"}}
+
+		tasks = []wl.Task{
+			{ID: 1, ListID: 1, Title: "overdue", DueDate: wl.NewDate(2016, 1, 2)},
+			{ID: 2, ListID: 1, Title: "today", DueDate: wl.NewDate(2016, 1, 4)},
+			{ID: 3, ListID: 1, Title: "tomorrow", DueDate: wl.NewDate(2016, 1, 5), Starred: true},
+			{ID: 4, ListID: 1, Title: "in a week", DueDate: wl.NewDate(2016, 1, 11)},
+			{ID: 5, ListID: 1, Title: "too far away", DueDate: wl.NewDate(2016, 1, 12)},
+			{ID: 6, ListID: 1, Title: "no due date", Starred: true, AssigneeID: userID},
+			{ID: 7, ListID: 1, Title: "someone else's", AssigneeID: 20},
+			{ID: 8, ListID: 1, Title: "completed", DueDate: wl.NewDate(2016, 1, 4), Completed: true},
+		}
+
+		reminders = []wl.Reminder{
+			{ID: 100, TaskID: 4, Date: time.Date(2016, time.January, 5, 2, 0, 0, 0, time.UTC)},
+			{ID: 101, TaskID: 2, Date: time.Date(2016, time.January, 4, 14, 0, 0, 0, time.UTC)},
+			{ID: 102, TaskID: 2, Date: time.Date(2016, time.January, 5, 6, 0, 0, 0, time.UTC)},
+			{ID: 103, TaskID: 8, Date: time.Date(2016, time.January, 4, 14, 0, 0, 0, time.UTC)},
+		}
+	})
+
+	It("sets the date to the start of today", func() {
+		a := agenda.Compute(tasks, lists, reminders, userID, now)
+
+		Expect(a.Date).To(Equal(today))
+	})
+
+	It("groups tasks by due date relative to today", func() {
+		a := agenda.Compute(tasks, lists, reminders, userID, now)
+
+		Expect(taskIDs(a.Overdue)).To(Equal([]uint{1}))
+		Expect(taskIDs(a.Today)).To(Equal([]uint{2}))
+		Expect(taskIDs(a.Upcoming)).To(Equal([]uint{3, 4}))
+	})
+
+	It("includes starred tasks, ordered by due date", func() {
+		a := agenda.Compute(tasks, lists, reminders, userID, now)
+
+		Expect(taskIDs(a.Starred)).To(Equal([]uint{3, 6}))
+	})
+
+	It("includes tasks assigned to the user", func() {
+		a := agenda.Compute(tasks, lists, reminders, userID, now)
+
+		Expect(taskIDs(a.AssignedToMe)).To(Equal([]uint{6}))
+	})
+
+	It("resolves list titles", func() {
+		a := agenda.Compute(tasks, lists, reminders, userID, now)
+
+		Expect(a.Today[0].ListTitle).To(Equal("Work"))
+	})
+
+	It("includes reminders firing today in the local timezone", func() {
+		a := agenda.Compute(tasks, lists, reminders, userID, now)
+
+		Expect(a.Reminders).To(HaveLen(2))
+		Expect(a.Reminders[0].Reminder.ID).To(Equal(uint(101)))
+		Expect(a.Reminders[0].Time.Hour()).To(Equal(9))
+		Expect(a.Reminders[1].Reminder.ID).To(Equal(uint(100)))
+		Expect(a.Reminders[1].Task.Title).To(Equal("in a week"))
+	})
+
+	It("includes upcoming occurrences of recurring tasks", func() {
+		tasks = append(tasks,
+			wl.Task{ID: 9, ListID: 1, Title: "standup", DueDate: wl.NewDate(2016, 1, 3), RecurrenceType: wl.RecurrenceDay, RecurrenceCount: 2},
+			wl.Task{ID: 10, ListID: 1, Title: "review", DueDate: wl.NewDate(2016, 1, 4), RecurrenceType: wl.RecurrenceWeek, RecurrenceCount: 1},
+			wl.Task{ID: 11, ListID: 1, Title: "done", DueDate: wl.NewDate(2016, 1, 4), RecurrenceType: wl.RecurrenceDay, RecurrenceCount: 1, Completed: true},
+		)
+
+		a := agenda.Compute(tasks, lists, reminders, userID, now)
+
+		occurrences := []string{}
+		for _, o := range a.Recurring {
+			occurrences = append(occurrences, o.Date.String()+" "+o.Task.Title)
+		}
+		Expect(occurrences).To(Equal([]string{
+			"2016-01-05 standup",
+			"2016-01-07 standup",
+			"2016-01-09 standup",
+			"2016-01-11 review",
+			"2016-01-11 standup",
+		}))
+		Expect(a.Recurring[0].ListTitle).To(Equal("Work"))
+		Expect(a.Recurring[0].Recurrence).To(Equal(wl.Recurrence{Type: wl.RecurrenceDay, Count: 2}))
+	})
+
+	It("returns sections in display order", func() {
+		a := agenda.Compute(tasks, lists, reminders, userID, now)
+
+		titles := []string{}
+		for _, s := range a.Sections() {
+			titles = append(titles, s.Title)
+		}
+		Expect(titles).To(Equal([]string{
+			"Overdue",
+			"Today",
+			"Next 7 days",
+			"Starred",
+			"Assigned to me",
+		}))
+	})
+})
@@ -0,0 +1,195 @@
+package agenda
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+const (
+	// FormatText renders the agenda as plain text.
+	FormatText = "text"
+
+	// FormatMarkdown renders the agenda as Markdown.
+	FormatMarkdown = "markdown"
+
+	// FormatHTML renders the agenda as a standalone HTML document.
+	FormatHTML = "html"
+
+	dateLayout = "Monday, 2 January 2006"
+	timeLayout = "15:04"
+)
+
+// Write renders the agenda to the writer in the provided format.
+func Write(w io.Writer, a Agenda, format string) error {
+	switch format {
+	case FormatText:
+		return WriteText(w, a)
+	case FormatMarkdown:
+		return WriteMarkdown(w, a)
+	case FormatHTML:
+		return WriteHTML(w, a)
+	default:
+		return fmt.Errorf("unrecognized format: %s", format)
+	}
+}
+
+// WriteText renders the agenda as plain text.
+func WriteText(w io.Writer, a Agenda) error {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "Agenda for %s\n", a.Date.Format(dateLayout))
+
+	for _, s := range a.Sections() {
+		fmt.Fprintf(&b, "\n%s\n", s.Title)
+		if len(s.Items) == 0 {
+			fmt.Fprintf(&b, "  (none)\n")
+		}
+		for _, i := range s.Items {
+			fmt.Fprintf(&b, "  - %s%s\n", i.Task.Title, details(i))
+		}
+	}
+
+	fmt.Fprintf(&b, "\nReminders today\n")
+	if len(a.Reminders) == 0 {
+		fmt.Fprintf(&b, "  (none)\n")
+	}
+	for _, r := range a.Reminders {
+		fmt.Fprintf(&b, "  - %s %s%s\n", r.Time.Format(timeLayout), r.Task.Title, details(r.Item))
+	}
+
+	fmt.Fprintf(&b, "\nRecurring\n")
+	if len(a.Recurring) == 0 {
+		fmt.Fprintf(&b, "  (none)\n")
+	}
+	for _, o := range a.Recurring {
+		fmt.Fprintf(&b, "  - %s %s%s\n", o.Date, o.Task.Title, occurrenceDetails(o))
+	}
+
+	_, err := b.WriteTo(w)
+	return err
+}
+
+// WriteMarkdown renders the agenda as Markdown.
+func WriteMarkdown(w io.Writer, a Agenda) error {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "# Agenda for %s\n", a.Date.Format(dateLayout))
+
+	for _, s := range a.Sections() {
+		fmt.Fprintf(&b, "\n## %s\n\n", s.Title)
+		if len(s.Items) == 0 {
+			fmt.Fprintf(&b, "_None_\n")
+		}
+		for _, i := range s.Items {
+			fmt.Fprintf(&b, "- %s%s\n", escapeMarkdown(i.Task.Title), details(i))
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Reminders today\n\n")
+	if len(a.Reminders) == 0 {
+		fmt.Fprintf(&b, "_None_\n")
+	}
+	for _, r := range a.Reminders {
+		fmt.Fprintf(
+			&b,
+			"- **%s** %s%s\n",
+			r.Time.Format(timeLayout),
+			escapeMarkdown(r.Task.Title),
+			details(r.Item),
+		)
+	}
+
+	fmt.Fprintf(&b, "\n## Recurring\n\n")
+	if len(a.Recurring) == 0 {
+		fmt.Fprintf(&b, "_None_\n")
+	}
+	for _, o := range a.Recurring {
+		fmt.Fprintf(
+			&b,
+			"- **%s** %s%s\n",
+			o.Date,
+			escapeMarkdown(o.Task.Title),
+			occurrenceDetails(o),
+		)
+	}
+
+	_, err := b.WriteTo(w)
+	return err
+}
+
+// details returns the list title and due date of the item
+// in the form " (list, due YYYY-MM-DD)", omitting whichever is not present.
+func details(i Item) string {
+	parts := []string{}
+	if i.ListTitle != "" {
+		parts = append(parts, i.ListTitle)
+	}
+	if !i.Task.DueDate.IsZero() {
+		parts = append(parts, "due "+i.Task.DueDate.String())
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// occurrenceDetails returns the list title and recurrence of the occurrence
+// in the form " (list, every 2 weeks)".
+func occurrenceDetails(o OccurrenceItem) string {
+	if o.ListTitle == "" {
+		return " (" + o.Recurrence.String() + ")"
+	}
+	return " (" + o.ListTitle + ", " + o.Recurrence.String() + ")"
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+var htmlTemplate = template.Must(template.New("agenda").Funcs(template.FuncMap{
+	"details":           details,
+	"occurrenceDetails": occurrenceDetails,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Agenda for {{.Date.Format "Monday, 2 January 2006"}}</title>
+</head>
+<body>
+<h1>Agenda for {{.Date.Format "Monday, 2 January 2006"}}</h1>
+{{range .Sections}}<h2>{{.Title}}</h2>
+{{if .Items}}<ul>
+{{range .Items}}<li>{{.Task.Title}}{{details .}}</li>
+{{end}}</ul>
+{{else}}<p><em>None</em></p>
+{{end}}{{end}}<h2>Reminders today</h2>
+{{if .Reminders}}<ul>
+{{range .Reminders}}<li><strong>{{.Time.Format "15:04"}}</strong> {{.Task.Title}}{{details .Item}}</li>
+{{end}}</ul>
+{{else}}<p><em>None</em></p>
+{{end}}<h2>Recurring</h2>
+{{if .Recurring}}<ul>
+{{range .Recurring}}<li><strong>{{.Date}}</strong> {{.Task.Title}}{{occurrenceDetails .}}</li>
+{{end}}</ul>
+{{else}}<p><em>None</em></p>
+{{end}}</body>
+</html>
+`))
+
+// WriteHTML renders the agenda as a standalone HTML document.
+func WriteHTML(w io.Writer, a Agenda) error {
+	return htmlTemplate.Execute(w, a)
+}
@@ -0,0 +1,87 @@
+package agenda_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/agenda"
+)
+
+var _ = Describe("Write", func() {
+	var (
+		a   agenda.Agenda
+		buf *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		a = agenda.Agenda{
+			Date: time.Date(2016, time.January, 4, 0, 0, 0, 0, time.UTC),
+			Today: []agenda.Item{
+				{
+					Task: wl.Task{
+						Title:   "Renew <certs>",
+						DueDate: wl.NewDate(2016, time.January, 4),
+					},
+					ListTitle: "Ops",
+				},
+			},
+			Reminders: []agenda.ReminderItem{
+				{
+					Time: time.Date(2016, time.January, 4, 9, 0, 0, 0, time.UTC),
+					Item: agenda.Item{Task: wl.Task{Title: "Call_Bob"}},
+				},
+			},
+			Recurring: []agenda.OccurrenceItem{
+				{
+					Date:       wl.NewDate(2016, time.January, 6),
+					Recurrence: wl.Recurrence{Type: wl.RecurrenceWeek, Count: 2},
+					Item:       agenda.Item{Task: wl.Task{Title: "Water <plants>"}, ListTitle: "Home"},
+				},
+			},
+		}
+
+		buf = &bytes.Buffer{}
+	})
+
+	It("renders text", func() {
+		err := agenda.Write(buf, a, agenda.FormatText)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(buf.String()).To(ContainSubstring("Agenda for Monday, 4 January 2016\n"))
+		Expect(buf.String()).To(ContainSubstring("\nOverdue\n  (none)\n"))
+		Expect(buf.String()).To(ContainSubstring("\nToday\n  - Renew <certs> (Ops, due 2016-01-04)\n"))
+		Expect(buf.String()).To(ContainSubstring("\nReminders today\n  - 09:00 Call_Bob\n"))
+		Expect(buf.String()).To(ContainSubstring("\nRecurring\n  - 2016-01-06 Water <plants> (Home, every 2 weeks)\n"))
+	})
+
+	It("renders markdown", func() {
+		err := agenda.Write(buf, a, agenda.FormatMarkdown)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(buf.String()).To(ContainSubstring("# Agenda for Monday, 4 January 2016\n"))
+		Expect(buf.String()).To(ContainSubstring("\n## Overdue\n\n_None_\n"))
+		Expect(buf.String()).To(ContainSubstring("\n## Today\n\n- Renew <certs> (Ops, due 2016-01-04)\n"))
+		Expect(buf.String()).To(ContainSubstring("- **09:00** Call\\_Bob\n"))
+		Expect(buf.String()).To(ContainSubstring("\n## Recurring\n\n- **2016-01-06** Water <plants> (Home, every 2 weeks)\n"))
+	})
+
+	It("renders HTML with escaped content", func() {
+		err := agenda.Write(buf, a, agenda.FormatHTML)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(buf.String()).To(ContainSubstring("<h1>Agenda for Monday, 4 January 2016</h1>"))
+		Expect(buf.String()).To(ContainSubstring("<li>Renew &lt;certs&gt; (Ops, due 2016-01-04)</li>"))
+		Expect(buf.String()).To(ContainSubstring("<li><strong>09:00</strong> Call_Bob</li>"))
+		Expect(buf.String()).To(ContainSubstring("<li><strong>2016-01-06</strong> Water &lt;plants&gt; (Home, every 2 weeks)</li>"))
+	})
+
+	Context("when the format is not recognized", func() {
+		It("returns an error", func() {
+			err := agenda.Write(buf, a, "pdf")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,36 @@
+package apply
+
+import (
+	"fmt"
+
+	"github.com/robdimsdale/wl"
+)
+
+// applier applies changes, keeping track of the IDs of lists by title
+// so that changes can refer to lists created earlier in the plan.
+type applier struct {
+	client  wl.Client
+	listIDs map[string]uint
+}
+
+// Apply applies the changes in the plan in order, and returns the number of
+// changes applied. It stops at the first change which fails.
+func Apply(client wl.Client, plan Plan) (int, error) {
+	a := &applier{client: client, listIDs: map[string]uint{}}
+	for title, id := range plan.listIDs {
+		a.listIDs[title] = id
+	}
+
+	for i, c := range plan.Changes {
+		if c.apply == nil {
+			return i, fmt.Errorf("%s %s %q was not planned", c.Action, c.Kind, c.Name)
+		}
+
+		err := c.apply(a)
+		if err != nil {
+			return i, fmt.Errorf("failed to %s %s %q: %v", c.Action, c.Kind, c.Name, err)
+		}
+	}
+
+	return len(plan.Changes), nil
+}
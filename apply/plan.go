@@ -0,0 +1,794 @@
+package apply
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/robdimsdale/wl"
+)
+
+// Actions of changes.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Kinds of resource changed.
+const (
+	KindFolder  = "folder"
+	KindList    = "list"
+	KindMember  = "member"
+	KindWebhook = "webhook"
+	KindTask    = "task"
+)
+
+// maxDiffLength is the maximum length of a note in a diff.
+const maxDiffLength = 40
+
+// Diff is a change to a field of a resource.
+// Empty values mean the field is not set.
+type Diff struct {
+	Field string `json:"field" yaml:"field"`
+	Old   string `json:"old" yaml:"old"`
+	New   string `json:"new" yaml:"new"`
+}
+
+// Change is a change to a resource. Name is the title of a folder, list or
+// task, the email address or user ID of a member, or the URL of a webhook.
+// List is the title of the list of a member, webhook or task.
+type Change struct {
+	Action string `json:"action" yaml:"action"`
+	Kind   string `json:"kind" yaml:"kind"`
+	Name   string `json:"name" yaml:"name"`
+	List   string `json:"list,omitempty" yaml:"list,omitempty"`
+	Diffs  []Diff `json:"diffs,omitempty" yaml:"diffs,omitempty"`
+
+	apply func(a *applier) error
+}
+
+// Plan is the changes needed to make an account match a spec, in the
+// order they are applied: lists are created before they are added to
+// folders and before their contents are changed.
+type Plan struct {
+	Changes []Change `json:"changes" yaml:"changes"`
+
+	// listIDs are the IDs of the existing lists matched by declared lists,
+	// by title.
+	listIDs map[string]uint
+}
+
+// Options configure a plan.
+type Options struct {
+	// Prune deletes the tasks, members and webhooks of declared lists which
+	// are not declared. Only incomplete tasks are deleted, and neither owners
+	// nor the current user are removed. Nothing outside declared lists is
+	// deleted.
+	Prune bool
+}
+
+// planner compares a spec with the account.
+type planner struct {
+	client wl.Client
+	opts   Options
+
+	// lists and folders are in the account, and matched are the existing
+	// lists matched by declared lists, by title.
+	lists   []wl.List
+	folders []wl.Folder
+	matched map[string]wl.List
+
+	// users and userID are fetched when first needed.
+	users  []wl.User
+	userID uint
+}
+
+// NewPlan returns the changes needed to make the account match the spec.
+// Folders are matched by title, lists declared in a folder by title within
+// a folder with that title, other lists by title, tasks by title within
+// their list, members by email address or user ID, and webhooks by URL.
+// Tasks which have been completed are not changed.
+func NewPlan(client wl.Client, spec Spec, opts Options) (Plan, error) {
+	err := spec.Validate()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	lists, err := client.Lists()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	p := &planner{client: client, opts: opts, lists: lists, matched: map[string]wl.List{}}
+	if len(spec.Folders) > 0 {
+		p.folders, err = client.Folders()
+		if err != nil {
+			return Plan{}, err
+		}
+	}
+
+	plan := Plan{Changes: []Change{}, listIDs: map[string]uint{}}
+	for _, fs := range spec.Folders {
+		for _, ls := range fs.Lists {
+			p.match(fs.Title, ls.Title)
+		}
+	}
+	for _, ls := range spec.Lists {
+		p.match("", ls.Title)
+	}
+	for title, l := range p.matched {
+		plan.listIDs[title] = l.ID
+	}
+
+	var contents []Change
+	for _, ls := range spec.AllLists() {
+		l, exists := p.matched[ls.Title]
+		if !exists {
+			plan.Changes = append(plan.Changes, createList(ls.Title))
+		}
+
+		changes, err := p.planList(ls, l, exists)
+		if err != nil {
+			return Plan{}, err
+		}
+		contents = append(contents, changes...)
+	}
+
+	plan.Changes = append(plan.Changes, p.planFolders(spec.Folders)...)
+	plan.Changes = append(plan.Changes, contents...)
+	return plan, nil
+}
+
+// match records the existing list matched by a declared list, if any.
+// Lists declared in a folder only match lists in a folder with that title,
+// so that lists with the same title in other folders are left alone.
+// Other lists match the first list with their title.
+func (p *planner) match(folder string, title string) {
+	for _, l := range p.lists {
+		if l.Title == title && (folder == "" || p.inFolder(l.ID, folder)) {
+			p.matched[title] = l
+			return
+		}
+	}
+}
+
+func (p *planner) inFolder(listID uint, folder string) bool {
+	for _, f := range p.folders {
+		if f.Title != folder {
+			continue
+		}
+		for _, id := range f.ListIDs {
+			if id == listID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func createList(title string) Change {
+	return Change{
+		Action: ActionCreate,
+		Kind:   KindList,
+		Name:   title,
+		apply: func(a *applier) error {
+			l, err := a.client.CreateList(title)
+			if err != nil {
+				return err
+			}
+			a.listIDs[title] = l.ID
+			return nil
+		},
+	}
+}
+
+// planFolders plans the folders, adding the declared lists which are
+// created to them. Existing folders are updated before new folders are
+// created.
+func (p *planner) planFolders(specs []FolderSpec) []Change {
+	var updated, created []Change
+	matched := map[string]bool{}
+
+	for _, f := range p.folders {
+		if matched[f.Title] {
+			continue
+		}
+
+		for _, fs := range specs {
+			if fs.Title != f.Title {
+				continue
+			}
+			matched[f.Title] = true
+
+			var add []string
+			for _, ls := range fs.Lists {
+				if _, exists := p.matched[ls.Title]; !exists {
+					add = append(add, ls.Title)
+				}
+			}
+			if len(add) > 0 {
+				updated = append(updated, p.updateFolder(f, add))
+			}
+		}
+	}
+
+	for _, fs := range specs {
+		if matched[fs.Title] {
+			continue
+		}
+
+		var titles []string
+		for _, ls := range fs.Lists {
+			titles = append(titles, ls.Title)
+		}
+		created = append(created, createFolder(fs.Title, titles))
+	}
+
+	return append(updated, created...)
+}
+
+func (p *planner) updateFolder(f wl.Folder, add []string) Change {
+	var oldTitles []string
+	for _, id := range f.ListIDs {
+		oldTitles = append(oldTitles, p.listTitle(id))
+	}
+	newTitles := append(append([]string{}, oldTitles...), add...)
+
+	return Change{
+		Action: ActionUpdate,
+		Kind:   KindFolder,
+		Name:   f.Title,
+		Diffs:  []Diff{{Field: "lists", Old: strings.Join(oldTitles, ", "), New: strings.Join(newTitles, ", ")}},
+		apply: func(a *applier) error {
+			f.ListIDs = append([]uint{}, f.ListIDs...)
+			for _, title := range add {
+				f.ListIDs = append(f.ListIDs, a.listIDs[title])
+			}
+			_, err := a.client.UpdateFolder(f)
+			return err
+		},
+	}
+}
+
+func createFolder(title string, lists []string) Change {
+	return Change{
+		Action: ActionCreate,
+		Kind:   KindFolder,
+		Name:   title,
+		Diffs:  []Diff{{Field: "lists", New: strings.Join(lists, ", ")}},
+		apply: func(a *applier) error {
+			var ids []uint
+			for _, l := range lists {
+				ids = append(ids, a.listIDs[l])
+			}
+			_, err := a.client.CreateFolder(title, ids)
+			return err
+		},
+	}
+}
+
+func (p *planner) listTitle(id uint) string {
+	for _, l := range p.lists {
+		if l.ID == id {
+			return l.Title
+		}
+	}
+	return fmt.Sprintf("list %d", id)
+}
+
+// planList plans the members, webhooks and tasks of the list. If the list
+// does not exist yet, everything declared in it is created.
+func (p *planner) planList(ls ListSpec, l wl.List, exists bool) ([]Change, error) {
+	members, err := p.planMembers(ls, l, exists)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := p.planWebhooks(ls, l, exists)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := p.planTasks(ls, l, exists)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append(members, webhooks...), tasks...), nil
+}
+
+func (p *planner) planMembers(ls ListSpec, l wl.List, exists bool) ([]Change, error) {
+	var memberships []wl.Membership
+	if exists && (len(ls.Members) > 0 || p.opts.Prune) {
+		var err error
+		memberships, err = p.client.MembershipsForListID(l.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []Change
+	matched := map[uint]bool{}
+
+	for _, m := range ls.Members {
+		userID := m.UserID
+		if m.Email != "" {
+			users, err := p.loadUsers()
+			if err != nil {
+				return nil, err
+			}
+			for _, u := range users {
+				if strings.EqualFold(u.Email, m.Email) {
+					userID = u.ID
+					break
+				}
+			}
+		}
+
+		found := false
+		for _, ms := range memberships {
+			if userID != 0 && ms.UserID == userID {
+				matched[ms.ID] = true
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		m := m
+		changes = append(changes, Change{
+			Action: ActionCreate,
+			Kind:   KindMember,
+			Name:   m.name(),
+			List:   ls.Title,
+			apply: func(a *applier) error {
+				var err error
+				if m.Email != "" {
+					_, err = a.client.AddMemberToListViaEmailAddress(m.Email, a.listIDs[ls.Title], m.Muted)
+				} else {
+					_, err = a.client.AddMemberToListViaUserID(m.UserID, a.listIDs[ls.Title], m.Muted)
+				}
+				return err
+			},
+		})
+	}
+
+	if !p.opts.Prune || len(memberships) == 0 {
+		return changes, nil
+	}
+
+	currentUserID, err := p.currentUserID()
+	if err != nil {
+		return nil, err
+	}
+	users, err := p.loadUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ms := range memberships {
+		if matched[ms.ID] || ms.Owner || ms.UserID == currentUserID {
+			continue
+		}
+
+		name := MemberSpec{UserID: ms.UserID}.name()
+		for _, u := range users {
+			if u.ID == ms.UserID && u.Email != "" {
+				name = strings.ToLower(u.Email)
+			}
+		}
+
+		ms := ms
+		changes = append(changes, Change{
+			Action: ActionDelete,
+			Kind:   KindMember,
+			Name:   name,
+			List:   ls.Title,
+			apply: func(a *applier) error {
+				return a.client.RemoveMemberFromList(ms)
+			},
+		})
+	}
+
+	return changes, nil
+}
+
+func (p *planner) loadUsers() ([]wl.User, error) {
+	if p.users != nil {
+		return p.users, nil
+	}
+
+	users, err := p.client.Users()
+	if err != nil {
+		return nil, err
+	}
+	p.users = append([]wl.User{}, users...)
+	return p.users, nil
+}
+
+func (p *planner) currentUserID() (uint, error) {
+	if p.userID != 0 {
+		return p.userID, nil
+	}
+
+	u, err := p.client.User()
+	if err != nil {
+		return 0, err
+	}
+	p.userID = u.ID
+	return p.userID, nil
+}
+
+// planWebhooks plans the webhooks of the list. Webhooks cannot be updated,
+// so a webhook with a different processor type or configuration is
+// deleted and created again.
+func (p *planner) planWebhooks(ls ListSpec, l wl.List, exists bool) ([]Change, error) {
+	var webhooks []wl.Webhook
+	if exists && (len(ls.Webhooks) > 0 || p.opts.Prune) {
+		var err error
+		webhooks, err = p.client.WebhooksForListID(l.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []Change
+	matched := map[uint]bool{}
+
+	for _, ws := range ls.Webhooks {
+		ws := ws
+		create := func(a *applier) error {
+			_, err := a.client.CreateWebhook(a.listIDs[ls.Title], ws.URL, ws.processorType(), ws.Configuration)
+			return err
+		}
+
+		var existing *wl.Webhook
+		for i, w := range webhooks {
+			if w.URL == ws.URL && !matched[w.ID] {
+				existing = &webhooks[i]
+				matched[w.ID] = true
+				break
+			}
+		}
+
+		if existing == nil {
+			changes = append(changes, Change{
+				Action: ActionCreate,
+				Kind:   KindWebhook,
+				Name:   ws.URL,
+				List:   ls.Title,
+				apply:  create,
+			})
+			continue
+		}
+
+		var diffs []Diff
+		if existing.ProcessorType != ws.processorType() {
+			diffs = append(diffs, Diff{Field: "processor_type", Old: existing.ProcessorType, New: ws.processorType()})
+		}
+		if existing.Configuration != ws.Configuration {
+			diffs = append(diffs, Diff{Field: "configuration", Old: existing.Configuration, New: ws.Configuration})
+		}
+		if len(diffs) == 0 {
+			continue
+		}
+
+		w := *existing
+		changes = append(changes, Change{
+			Action: ActionUpdate,
+			Kind:   KindWebhook,
+			Name:   ws.URL,
+			List:   ls.Title,
+			Diffs:  diffs,
+			apply: func(a *applier) error {
+				err := a.client.DeleteWebhook(w)
+				if err != nil {
+					return err
+				}
+				return create(a)
+			},
+		})
+	}
+
+	if p.opts.Prune {
+		for _, w := range webhooks {
+			if matched[w.ID] {
+				continue
+			}
+
+			w := w
+			changes = append(changes, Change{
+				Action: ActionDelete,
+				Kind:   KindWebhook,
+				Name:   w.URL,
+				List:   ls.Title,
+				apply: func(a *applier) error {
+					return a.client.DeleteWebhook(w)
+				},
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// existingTasks are the tasks of a list with their notes and subtasks.
+type existingTasks struct {
+	tasks    []wl.Task
+	notes    map[uint]wl.Note
+	subtasks map[uint][]string
+}
+
+func (p *planner) loadTasks(listID uint) (existingTasks, error) {
+	e := existingTasks{notes: map[uint]wl.Note{}, subtasks: map[uint][]string{}}
+
+	for _, completed := range []bool{false, true} {
+		tasks, err := p.client.CompletedTasksForListID(listID, completed)
+		if err != nil {
+			return existingTasks{}, err
+		}
+		e.tasks = append(e.tasks, tasks...)
+
+		subtasks, err := p.client.CompletedSubtasksForListID(listID, completed)
+		if err != nil {
+			return existingTasks{}, err
+		}
+		for _, s := range subtasks {
+			e.subtasks[s.TaskID] = append(e.subtasks[s.TaskID], s.Title)
+		}
+	}
+
+	notes, err := p.client.NotesForListID(listID)
+	if err != nil {
+		return existingTasks{}, err
+	}
+	for _, n := range notes {
+		e.notes[n.TaskID] = n
+	}
+
+	return e, nil
+}
+
+// find returns the incomplete task with the title, or otherwise the
+// completed task with the title.
+func (e existingTasks) find(title string) (wl.Task, bool) {
+	for _, t := range e.tasks {
+		if t.Title == title && !t.Completed {
+			return t, true
+		}
+	}
+	for _, t := range e.tasks {
+		if t.Title == title {
+			return t, true
+		}
+	}
+	return wl.Task{}, false
+}
+
+func (p *planner) planTasks(ls ListSpec, l wl.List, exists bool) ([]Change, error) {
+	var e existingTasks
+	if exists && (len(ls.Tasks) > 0 || p.opts.Prune) {
+		var err error
+		e, err = p.loadTasks(l.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []Change
+	declared := map[string]bool{}
+
+	for _, ts := range ls.Tasks {
+		declared[ts.Title] = true
+
+		t, ok := e.find(ts.Title)
+		if !ok {
+			changes = append(changes, createTask(ls.Title, ts))
+			continue
+		}
+		if t.Completed {
+			continue
+		}
+
+		if c, ok := updateTask(ls.Title, ts, t, e.notes[t.ID], e.subtasks[t.ID]); ok {
+			changes = append(changes, c)
+		}
+	}
+
+	if p.opts.Prune {
+		for _, t := range e.tasks {
+			if t.Completed || declared[t.Title] {
+				continue
+			}
+
+			t := t
+			changes = append(changes, Change{
+				Action: ActionDelete,
+				Kind:   KindTask,
+				Name:   t.Title,
+				List:   ls.Title,
+				apply: func(a *applier) error {
+					return a.client.DeleteTask(t)
+				},
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+func createTask(list string, ts TaskSpec) Change {
+	var diffs []Diff
+	var due wl.Date
+	if ts.Due != nil && !ts.Due.IsZero() {
+		due = *ts.Due
+		diffs = append(diffs, Diff{Field: "due", New: due.String()})
+	}
+	starred := ts.Starred != nil && *ts.Starred
+	if starred {
+		diffs = append(diffs, Diff{Field: "starred", New: "true"})
+	}
+	if ts.Note != "" {
+		diffs = append(diffs, Diff{Field: "note", New: summarize(ts.Note)})
+	}
+	if len(ts.Subtasks) > 0 {
+		diffs = append(diffs, Diff{Field: "subtasks", New: strings.Join(ts.Subtasks, ", ")})
+	}
+
+	return Change{
+		Action: ActionCreate,
+		Kind:   KindTask,
+		Name:   ts.Title,
+		List:   list,
+		Diffs:  diffs,
+		apply: func(a *applier) error {
+			t, err := a.client.CreateTask(ts.Title, a.listIDs[list], 0, false, wl.RecurrenceNone, 0, due, starred)
+			if err != nil {
+				return err
+			}
+
+			if ts.Note != "" {
+				_, err = a.client.CreateNote(ts.Note, t.ID)
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, s := range ts.Subtasks {
+				_, err = a.client.CreateSubtask(s, t.ID, false)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// updateTask returns the change to make the task match the spec,
+// or false if it already does.
+func updateTask(list string, ts TaskSpec, t wl.Task, note wl.Note, subtasks []string) (Change, bool) {
+	var diffs []Diff
+	updated := t
+
+	if ts.Due != nil && *ts.Due != t.DueDate {
+		updated.DueDate = *ts.Due
+		diffs = append(diffs, Diff{Field: "due", Old: dateString(t.DueDate), New: dateString(*ts.Due)})
+	}
+	if ts.Starred != nil && *ts.Starred != t.Starred {
+		updated.Starred = *ts.Starred
+		diffs = append(diffs, Diff{Field: "starred", Old: strconv.FormatBool(t.Starred), New: strconv.FormatBool(*ts.Starred)})
+	}
+
+	noteChanged := ts.Note != "" && ts.Note != note.Content
+	if noteChanged {
+		diffs = append(diffs, Diff{Field: "note", Old: summarize(note.Content), New: summarize(ts.Note)})
+	}
+
+	var missing []string
+	for _, s := range ts.Subtasks {
+		if !contains(subtasks, s) {
+			missing = append(missing, s)
+		}
+	}
+	if len(missing) > 0 {
+		diffs = append(diffs, Diff{
+			Field: "subtasks",
+			Old:   strings.Join(subtasks, ", "),
+			New:   strings.Join(append(append([]string{}, subtasks...), missing...), ", "),
+		})
+	}
+
+	if len(diffs) == 0 {
+		return Change{}, false
+	}
+
+	taskChanged := updated != t
+	return Change{
+		Action: ActionUpdate,
+		Kind:   KindTask,
+		Name:   t.Title,
+		List:   list,
+		Diffs:  diffs,
+		apply: func(a *applier) error {
+			if taskChanged {
+				_, err := a.client.UpdateTask(updated)
+				if err != nil {
+					return err
+				}
+			}
+
+			if noteChanged {
+				var err error
+				if note.ID != 0 {
+					n := note
+					n.Content = ts.Note
+					_, err = a.client.UpdateNote(n)
+				} else {
+					_, err = a.client.CreateNote(ts.Note, t.ID)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, s := range missing {
+				_, err := a.client.CreateSubtask(s, t.ID, false)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}, true
+}
+
+func dateString(d wl.Date) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.String()
+}
+
+// summarize returns the first line of the text, quoted and truncated
+// to maxDiffLength characters.
+func summarize(text string) string {
+	line := strings.SplitN(strings.TrimSpace(text), "\n", 2)
+	s := line[0]
+	if len(line) > 1 || utf8.RuneCountInString(s) > maxDiffLength {
+		runes := []rune(s)
+		if len(runes) > maxDiffLength {
+			runes = runes[:maxDiffLength]
+		}
+		s = string(runes) + "..."
+	}
+	return strconv.Quote(s)
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Counts returns the number of changes which create, update and delete.
+func (p Plan) Counts() (creates int, updates int, deletes int) {
+	for _, c := range p.Changes {
+		switch c.Action {
+		case ActionCreate:
+			creates++
+		case ActionUpdate:
+			updates++
+		case ActionDelete:
+			deletes++
+		}
+	}
+	return creates, updates, deletes
+}
@@ -0,0 +1,432 @@
+package apply_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/apply"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+func newClient() *wltest.Client {
+	client := wltest.NewClient()
+	client.SetUser(wl.User{ID: 7, Name: "Me", Email: "me@example.com"})
+	client.AddUser(wl.User{ID: 8, Name: "Jane", Email: "jane@example.com"})
+
+	client.AddList(wl.List{ID: 1, Title: "inbox", ListType: "inbox"})
+	client.AddList(wl.List{ID: 2, Title: "Work"})
+	client.AddList(wl.List{ID: 3, Title: "Home"})
+	client.AddFolder(wl.Folder{ID: 5, Title: "Personal", ListIDs: []uint{3}})
+
+	client.AddMembership(wl.Membership{ID: 30, UserID: 7, ListID: 2, Owner: true})
+	client.AddMembership(wl.Membership{ID: 31, UserID: 8, ListID: 2})
+	client.AddWebhook(wl.Webhook{ID: 40, ListID: 2, URL: "https://example.com/hook", ProcessorType: "generic"})
+
+	client.AddTask(wl.Task{ID: 10, ListID: 2, Title: "Write report", DueDate: wl.NewDate(2026, time.November, 1)})
+	client.AddTask(wl.Task{ID: 11, ListID: 2, Title: "Old task"})
+	client.AddTask(wl.Task{ID: 12, ListID: 2, Title: "Finished", Completed: true})
+	client.AddNote(wl.Note{ID: 50, TaskID: 10, Content: "Draft"})
+	client.AddSubtask(wl.Subtask{ID: 60, TaskID: 10, Title: "Outline"})
+	return client
+}
+
+func date(year int, month time.Month, day int) *wl.Date {
+	d := wl.NewDate(year, month, day)
+	return &d
+}
+
+func boolean(b bool) *bool {
+	return &b
+}
+
+var _ = Describe("NewPlan and Apply", func() {
+	var (
+		client *wltest.Client
+		spec   apply.Spec
+		opts   apply.Options
+	)
+
+	// list returns the list with the title created last.
+	list := func(title string) wl.List {
+		lists, err := client.Lists()
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := len(lists) - 1; i >= 0; i-- {
+			if lists[i].Title == title {
+				return lists[i]
+			}
+		}
+		Fail(fmt.Sprintf("no list %q", title))
+		return wl.List{}
+	}
+
+	folder := func(title string) wl.Folder {
+		folders, err := client.Folders()
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, f := range folders {
+			if f.Title == title {
+				return f
+			}
+		}
+		Fail(fmt.Sprintf("no folder %q", title))
+		return wl.Folder{}
+	}
+
+	tasks := func(listID uint) []string {
+		tasks, err := client.TasksForListID(listID)
+		Expect(err).NotTo(HaveOccurred())
+
+		var titles []string
+		for _, t := range tasks {
+			titles = append(titles, t.Title)
+		}
+		return titles
+	}
+
+	task := func(listID uint, title string) wl.Task {
+		tasks, err := client.TasksForListID(listID)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, t := range tasks {
+			if t.Title == title {
+				return t
+			}
+		}
+		Fail(fmt.Sprintf("no task %q in list %d", title, listID))
+		return wl.Task{}
+	}
+
+	subtasks := func(taskID uint) []string {
+		subtasks, err := client.SubtasksForTaskID(taskID)
+		Expect(err).NotTo(HaveOccurred())
+
+		var titles []string
+		for _, s := range subtasks {
+			titles = append(titles, s.Title)
+		}
+		return titles
+	}
+
+	notes := func(taskID uint) []string {
+		notes, err := client.NotesForTaskID(taskID)
+		Expect(err).NotTo(HaveOccurred())
+
+		var contents []string
+		for _, n := range notes {
+			contents = append(contents, n.Content)
+		}
+		return contents
+	}
+
+	members := func(listID uint) []uint {
+		memberships, err := client.MembershipsForListID(listID)
+		Expect(err).NotTo(HaveOccurred())
+
+		var ids []uint
+		for _, m := range memberships {
+			ids = append(ids, m.UserID)
+		}
+		return ids
+	}
+
+	webhooks := func(listID uint) []wl.Webhook {
+		webhooks, err := client.WebhooksForListID(listID)
+		Expect(err).NotTo(HaveOccurred())
+		return webhooks
+	}
+
+	BeforeEach(func() {
+		client = newClient()
+		opts = apply.Options{}
+		spec = apply.Spec{
+			Lists: []apply.ListSpec{{
+				Title: "Work",
+				Members: []apply.MemberSpec{
+					{Email: "Jane@example.com"},
+				},
+				Webhooks: []apply.WebhookSpec{
+					{URL: "https://example.com/hook"},
+				},
+				Tasks: []apply.TaskSpec{
+					{
+						Title:    "Write report",
+						Due:      date(2026, time.November, 1),
+						Note:     "Draft",
+						Subtasks: []string{"Outline"},
+					},
+					{Title: "Finished", Starred: boolean(true)},
+				},
+			}},
+		}
+	})
+
+	It("has no changes when the account matches the spec", func() {
+		plan, err := apply.NewPlan(client, spec, opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Changes).To(BeEmpty())
+
+		root, err := client.Root()
+		Expect(err).NotTo(HaveOccurred())
+
+		n, err := apply.Apply(client, plan)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(0))
+
+		Expect(client.Root()).To(Equal(root))
+	})
+
+	It("updates tasks which differ, without changing completed tasks", func() {
+		spec.Lists[0].Tasks[0].Due = date(2026, time.November, 2)
+		spec.Lists[0].Tasks[0].Starred = boolean(true)
+		spec.Lists[0].Tasks[0].Note = "Final draft"
+		spec.Lists[0].Tasks[0].Subtasks = []string{"Outline", "Proofread"}
+
+		plan, err := apply.NewPlan(client, spec, opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Changes).To(HaveLen(1))
+
+		c := plan.Changes[0]
+		Expect(c.Action).To(Equal(apply.ActionUpdate))
+		Expect(c.Kind).To(Equal(apply.KindTask))
+		Expect(c.Name).To(Equal("Write report"))
+		Expect(c.List).To(Equal("Work"))
+		Expect(c.Diffs).To(Equal([]apply.Diff{
+			{Field: "due", Old: "2026-11-01", New: "2026-11-02"},
+			{Field: "starred", Old: "false", New: "true"},
+			{Field: "note", Old: `"Draft"`, New: `"Final draft"`},
+			{Field: "subtasks", Old: "Outline", New: "Outline, Proofread"},
+		}))
+
+		_, err = apply.Apply(client, plan)
+		Expect(err).NotTo(HaveOccurred())
+
+		t, err := client.Task(10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.DueDate).To(Equal(wl.NewDate(2026, time.November, 2)))
+		Expect(t.Starred).To(BeTrue())
+		Expect(notes(10)).To(Equal([]string{"Final draft"}))
+		Expect(subtasks(10)).To(Equal([]string{"Outline", "Proofread"}))
+
+		finished, err := client.Task(12)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finished.Starred).To(BeFalse())
+	})
+
+	It("removes a due date declared as empty", func() {
+		spec.Lists[0].Tasks[0].Due = &wl.Date{}
+
+		plan, err := apply.NewPlan(client, spec, opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Changes).To(HaveLen(1))
+		Expect(plan.Changes[0].Diffs).To(Equal([]apply.Diff{{Field: "due", Old: "2026-11-01"}}))
+	})
+
+	It("creates missing members, webhooks and tasks", func() {
+		spec.Lists[0].Members = append(spec.Lists[0].Members, apply.MemberSpec{UserID: 9, Muted: true})
+		spec.Lists[0].Webhooks = append(spec.Lists[0].Webhooks, apply.WebhookSpec{URL: "https://example.com/other"})
+		spec.Lists[0].Tasks = append(spec.Lists[0].Tasks, apply.TaskSpec{
+			Title:    "Plan offsite",
+			Due:      date(2026, time.December, 1),
+			Note:     "Somewhere warm",
+			Subtasks: []string{"Book venue"},
+		})
+
+		plan, err := apply.NewPlan(client, spec, opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Changes).To(HaveLen(3))
+		Expect(plan.Changes[0].Name).To(Equal("user 9"))
+		Expect(plan.Changes[1].Name).To(Equal("https://example.com/other"))
+		Expect(plan.Changes[2].Diffs).To(Equal([]apply.Diff{
+			{Field: "due", New: "2026-12-01"},
+			{Field: "note", New: `"Somewhere warm"`},
+			{Field: "subtasks", New: "Book venue"},
+		}))
+
+		n, err := apply.Apply(client, plan)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(3))
+
+		memberships, err := client.MembershipsForListID(2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(memberships).To(HaveLen(3))
+		Expect(memberships[2].UserID).To(Equal(uint(9)))
+		Expect(memberships[2].Muted).To(BeTrue())
+
+		Expect(webhooks(2)).To(HaveLen(2))
+		Expect(webhooks(2)[1].URL).To(Equal("https://example.com/other"))
+
+		t := task(2, "Plan offsite")
+		Expect(t.DueDate).To(Equal(wl.NewDate(2026, time.December, 1)))
+		Expect(t.Starred).To(BeFalse())
+		Expect(notes(t.ID)).To(Equal([]string{"Somewhere warm"}))
+		Expect(subtasks(t.ID)).To(Equal([]string{"Book venue"}))
+	})
+
+	It("recreates webhooks whose configuration differs", func() {
+		spec.Lists[0].Webhooks[0].Configuration = "secret"
+
+		plan, err := apply.NewPlan(client, spec, opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Changes).To(HaveLen(1))
+		Expect(plan.Changes[0].Action).To(Equal(apply.ActionUpdate))
+		Expect(plan.Changes[0].Diffs).To(Equal([]apply.Diff{{Field: "configuration", New: "secret"}}))
+
+		_, err = apply.Apply(client, plan)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(webhooks(2)).To(HaveLen(1))
+		Expect(webhooks(2)[0].ID).NotTo(Equal(uint(40)))
+		Expect(webhooks(2)[0].URL).To(Equal("https://example.com/hook"))
+		Expect(webhooks(2)[0].Configuration).To(Equal("secret"))
+	})
+
+	It("creates missing lists with their contents and folders", func() {
+		spec = apply.Spec{
+			Folders: []apply.FolderSpec{{
+				Title: "Projects",
+				Lists: []apply.ListSpec{
+					{Title: "Launch", Members: []apply.MemberSpec{{Email: "jane@example.com"}}, Tasks: []apply.TaskSpec{{Title: "Announce"}}},
+				},
+			}},
+		}
+
+		plan, err := apply.NewPlan(client, spec, opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Changes).To(HaveLen(4))
+		Expect(plan.Changes[0].Kind).To(Equal(apply.KindList))
+		Expect(plan.Changes[1].Kind).To(Equal(apply.KindFolder))
+		Expect(plan.Changes[1].Action).To(Equal(apply.ActionCreate))
+
+		_, err = apply.Apply(client, plan)
+		Expect(err).NotTo(HaveOccurred())
+
+		launch := list("Launch")
+		Expect(folder("Projects").ListIDs).To(Equal([]uint{launch.ID}))
+		Expect(members(launch.ID)).To(Equal([]uint{8}))
+		Expect(tasks(launch.ID)).To(Equal([]string{"Announce"}))
+	})
+
+	It("adds created lists to existing folders", func() {
+		spec = apply.Spec{
+			Folders: []apply.FolderSpec{{
+				Title: "Personal",
+				Lists: []apply.ListSpec{{Title: "Home"}, {Title: "Garden"}},
+			}},
+		}
+
+		plan, err := apply.NewPlan(client, spec, opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Changes).To(HaveLen(2))
+		Expect(plan.Changes[0].Name).To(Equal("Garden"))
+		Expect(plan.Changes[1].Diffs).To(Equal([]apply.Diff{{Field: "lists", Old: "Home", New: "Home, Garden"}}))
+
+		_, err = apply.Apply(client, plan)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(folder("Personal").ListIDs).To(Equal([]uint{3, list("Garden").ID}))
+	})
+
+	It("only matches lists declared in a folder with lists in that folder", func() {
+		client.AddList(wl.List{ID: 20, Title: "Backlog"})
+		client.AddList(wl.List{ID: 21, Title: "Backlog"})
+		client.AddFolder(wl.Folder{ID: 22, Title: "Other", ListIDs: []uint{20}})
+		client.AddFolder(wl.Folder{ID: 23, Title: "Work", ListIDs: []uint{21}})
+		client.AddTask(wl.Task{ID: 24, ListID: 21, Title: "Triage"})
+
+		spec = apply.Spec{
+			Folders: []apply.FolderSpec{{
+				Title: "Work",
+				Lists: []apply.ListSpec{{Title: "Backlog", Tasks: []apply.TaskSpec{{Title: "Triage"}}}},
+			}},
+		}
+
+		plan, err := apply.NewPlan(client, spec, opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Changes).To(BeEmpty())
+
+		spec.Folders[0].Title = "Team"
+
+		plan, err = apply.NewPlan(client, spec, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = apply.Apply(client, plan)
+		Expect(err).NotTo(HaveOccurred())
+
+		backlog := list("Backlog")
+		Expect(folder("Team").ListIDs).To(Equal([]uint{backlog.ID}))
+		Expect(tasks(backlog.ID)).To(Equal([]string{"Triage"}))
+		Expect(folder("Work").ListIDs).To(Equal([]uint{21}))
+	})
+
+	It("does not move existing lists declared in another folder", func() {
+		spec = apply.Spec{
+			Folders: []apply.FolderSpec{{
+				Title: "Projects",
+				Lists: []apply.ListSpec{{Title: "Home"}},
+			}},
+		}
+
+		plan, err := apply.NewPlan(client, spec, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = apply.Apply(client, plan)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(folder("Projects").ListIDs).To(Equal([]uint{list("Home").ID}))
+		Expect(folder("Personal").ListIDs).To(Equal([]uint{3}))
+	})
+
+	Context("when pruning", func() {
+		BeforeEach(func() {
+			opts.Prune = true
+			client.AddMembership(wl.Membership{ID: 32, UserID: 9, ListID: 2})
+			client.AddWebhook(wl.Webhook{ID: 41, ListID: 2, URL: "https://example.com/old"})
+			client.AddTask(wl.Task{ID: 13, ListID: 3, Title: "Undeclared list"})
+		})
+
+		It("deletes undeclared resources of declared lists only", func() {
+			spec.Lists[0].Members = nil
+
+			plan, err := apply.NewPlan(client, spec, opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = apply.Apply(client, plan)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(members(2)).To(Equal([]uint{7}))
+			Expect(webhooks(2)).To(HaveLen(1))
+			Expect(webhooks(2)[0].ID).To(Equal(uint(40)))
+			Expect(tasks(2)).To(Equal([]string{"Write report"}))
+			Expect(tasks(3)).To(Equal([]string{"Undeclared list"}))
+		})
+	})
+
+	It("stops at the first change which fails", func() {
+		spec.Lists[0].Members = append(spec.Lists[0].Members, apply.MemberSpec{UserID: 9})
+		spec.Lists[0].Tasks = append(spec.Lists[0].Tasks, apply.TaskSpec{Title: "New"}, apply.TaskSpec{Title: "Newer"})
+
+		plan, err := apply.NewPlan(client, spec, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		client.Fail("CreateTask", errors.New("Unexpected response code 500 - expected 201"))
+
+		n, err := apply.Apply(client, plan)
+		Expect(err).To(MatchError(`failed to create task "New": Unexpected response code 500 - expected 201`))
+		Expect(n).To(Equal(1))
+
+		Expect(members(2)).To(Equal([]uint{7, 8, 9}))
+		Expect(tasks(2)).To(Equal([]string{"Write report", "Old task"}))
+	})
+
+	It("returns an error for an invalid spec", func() {
+		spec.Lists = append(spec.Lists, apply.ListSpec{Title: "Work"})
+
+		_, err := apply.NewPlan(client, spec, opts)
+		Expect(err).To(MatchError(`list "Work" is declared more than once`))
+	})
+})
@@ -0,0 +1,160 @@
+/*
+Package apply makes an account match a declared state of folders, lists and
+their members, webhooks and seed tasks, by planning the changes needed and
+then applying them.
+*/
+package apply
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/robdimsdale/wl"
+)
+
+const (
+	// DefaultProcessorType is the processor type of webhooks which do not
+	// declare one.
+	DefaultProcessorType = "generic"
+)
+
+// Spec is the declared state of an account. Lists in folders are added to
+// those folders; lists at the top level may be in any folder.
+type Spec struct {
+	Folders []FolderSpec `json:"folders" yaml:"folders"`
+	Lists   []ListSpec   `json:"lists" yaml:"lists"`
+}
+
+// FolderSpec is a folder containing the lists.
+type FolderSpec struct {
+	Title string     `json:"title" yaml:"title"`
+	Lists []ListSpec `json:"lists" yaml:"lists"`
+}
+
+// ListSpec is a list with its members, webhooks and seed tasks.
+type ListSpec struct {
+	Title    string        `json:"title" yaml:"title"`
+	Members  []MemberSpec  `json:"members" yaml:"members"`
+	Webhooks []WebhookSpec `json:"webhooks" yaml:"webhooks"`
+	Tasks    []TaskSpec    `json:"tasks" yaml:"tasks"`
+}
+
+// MemberSpec is a member of a list, identified by either email address
+// or user ID. Muted only applies when the member is added.
+type MemberSpec struct {
+	Email  string `json:"email" yaml:"email"`
+	UserID uint   `json:"user_id" yaml:"user_id"`
+	Muted  bool   `json:"muted" yaml:"muted"`
+}
+
+// WebhookSpec is a webhook of a list, identified by its URL.
+type WebhookSpec struct {
+	URL           string `json:"url" yaml:"url"`
+	ProcessorType string `json:"processor_type" yaml:"processor_type"`
+	Configuration string `json:"configuration" yaml:"configuration"`
+}
+
+// TaskSpec is a seed task, identified by its title within its list.
+// The due date, star and note are only managed if they are declared, and
+// declared subtasks are added if missing. A zero due date removes it.
+type TaskSpec struct {
+	Title    string   `json:"title" yaml:"title"`
+	Due      *wl.Date `json:"due" yaml:"due"`
+	Starred  *bool    `json:"starred" yaml:"starred"`
+	Note     string   `json:"note" yaml:"note"`
+	Subtasks []string `json:"subtasks" yaml:"subtasks"`
+}
+
+// AllLists returns the declared lists, those in folders first.
+func (s Spec) AllLists() []ListSpec {
+	var lists []ListSpec
+	for _, f := range s.Folders {
+		lists = append(lists, f.Lists...)
+	}
+	return append(lists, s.Lists...)
+}
+
+// Validate returns an error if a title, URL or member is missing, or if
+// resources are declared more than once in the same scope.
+func (s Spec) Validate() error {
+	folders := map[string]bool{}
+	for _, f := range s.Folders {
+		if f.Title == "" {
+			return fmt.Errorf("folder has no title")
+		}
+		if folders[f.Title] {
+			return fmt.Errorf("folder %q is declared more than once", f.Title)
+		}
+		folders[f.Title] = true
+	}
+
+	lists := map[string]bool{}
+	for _, l := range s.AllLists() {
+		if l.Title == "" {
+			return fmt.Errorf("list has no title")
+		}
+		if lists[l.Title] {
+			return fmt.Errorf("list %q is declared more than once", l.Title)
+		}
+		lists[l.Title] = true
+
+		err := l.validate()
+		if err != nil {
+			return fmt.Errorf("list %q: %v", l.Title, err)
+		}
+	}
+
+	return nil
+}
+
+func (l ListSpec) validate() error {
+	members := map[string]bool{}
+	for _, m := range l.Members {
+		if (m.Email == "") == (m.UserID == 0) {
+			return fmt.Errorf("member must have exactly one of email and user_id")
+		}
+		if members[m.name()] {
+			return fmt.Errorf("member %s is declared more than once", m.name())
+		}
+		members[m.name()] = true
+	}
+
+	webhooks := map[string]bool{}
+	for _, w := range l.Webhooks {
+		if w.URL == "" {
+			return fmt.Errorf("webhook has no url")
+		}
+		if webhooks[w.URL] {
+			return fmt.Errorf("webhook %s is declared more than once", w.URL)
+		}
+		webhooks[w.URL] = true
+	}
+
+	tasks := map[string]bool{}
+	for _, t := range l.Tasks {
+		if t.Title == "" {
+			return fmt.Errorf("task has no title")
+		}
+		if tasks[t.Title] {
+			return fmt.Errorf("task %q is declared more than once", t.Title)
+		}
+		tasks[t.Title] = true
+	}
+
+	return nil
+}
+
+// name returns the email address of the member, or its user ID.
+func (m MemberSpec) name() string {
+	if m.Email != "" {
+		return strings.ToLower(m.Email)
+	}
+	return fmt.Sprintf("user %d", m.UserID)
+}
+
+func (w WebhookSpec) processorType() string {
+	if w.ProcessorType == "" {
+		return DefaultProcessorType
+	}
+	return w.ProcessorType
+}
@@ -0,0 +1,63 @@
+package apply_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl/apply"
+)
+
+var _ = Describe("Spec", func() {
+	Describe("AllLists", func() {
+		It("returns the lists in folders first", func() {
+			spec := apply.Spec{
+				Folders: []apply.FolderSpec{{Title: "F", Lists: []apply.ListSpec{{Title: "B"}}}},
+				Lists:   []apply.ListSpec{{Title: "A"}},
+			}
+			Expect(spec.AllLists()).To(Equal([]apply.ListSpec{{Title: "B"}, {Title: "A"}}))
+		})
+	})
+
+	table.DescribeTable("Validate",
+		func(spec apply.Spec, message string) {
+			err := spec.Validate()
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(message))
+		},
+		table.Entry("valid", apply.Spec{
+			Folders: []apply.FolderSpec{{Title: "F", Lists: []apply.ListSpec{{Title: "A"}}}},
+			Lists: []apply.ListSpec{{
+				Title:    "B",
+				Members:  []apply.MemberSpec{{Email: "a@example.com"}, {UserID: 3}},
+				Webhooks: []apply.WebhookSpec{{URL: "https://example.com"}},
+				Tasks:    []apply.TaskSpec{{Title: "T"}},
+			}},
+		}, ""),
+		table.Entry("folder without title", apply.Spec{Folders: []apply.FolderSpec{{}}}, "folder has no title"),
+		table.Entry("duplicate folder", apply.Spec{Folders: []apply.FolderSpec{{Title: "F"}, {Title: "F"}}}, `folder "F" is declared more than once`),
+		table.Entry("list without title", apply.Spec{Lists: []apply.ListSpec{{}}}, "list has no title"),
+		table.Entry("list in a folder and at the top level", apply.Spec{
+			Folders: []apply.FolderSpec{{Title: "F", Lists: []apply.ListSpec{{Title: "A"}}}},
+			Lists:   []apply.ListSpec{{Title: "A"}},
+		}, `list "A" is declared more than once`),
+		table.Entry("member with both email and user ID", apply.Spec{Lists: []apply.ListSpec{{
+			Title:   "A",
+			Members: []apply.MemberSpec{{Email: "a@example.com", UserID: 3}},
+		}}}, `list "A": member must have exactly one of email and user_id`),
+		table.Entry("duplicate member", apply.Spec{Lists: []apply.ListSpec{{
+			Title:   "A",
+			Members: []apply.MemberSpec{{Email: "a@example.com"}, {Email: "A@example.com"}},
+		}}}, `list "A": member a@example.com is declared more than once`),
+		table.Entry("webhook without URL", apply.Spec{Lists: []apply.ListSpec{{
+			Title:    "A",
+			Webhooks: []apply.WebhookSpec{{}},
+		}}}, `list "A": webhook has no url`),
+		table.Entry("duplicate task", apply.Spec{Lists: []apply.ListSpec{{
+			Title: "A",
+			Tasks: []apply.TaskSpec{{Title: "T"}, {Title: "T"}},
+		}}}, `list "A": task "T" is declared more than once`),
+	)
+})
@@ -0,0 +1,51 @@
+package apply
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+var symbols = map[string]string{
+	ActionCreate: "+",
+	ActionUpdate: "~",
+	ActionDelete: "-",
+}
+
+// Write writes the plan as text: a line for each change, prefixed with
+// + for creates, ~ for updates and - for deletes, followed by its diffs,
+// and then a summary.
+func Write(w io.Writer, p Plan) error {
+	var b bytes.Buffer
+
+	if len(p.Changes) == 0 {
+		b.WriteString("No changes.\n")
+		_, err := b.WriteTo(w)
+		return err
+	}
+
+	for _, c := range p.Changes {
+		fmt.Fprintf(&b, "%s %s %q", symbols[c.Action], c.Kind, c.Name)
+		if c.List != "" {
+			fmt.Fprintf(&b, " in list %q", c.List)
+		}
+		b.WriteString("\n")
+
+		for _, d := range c.Diffs {
+			fmt.Fprintf(&b, "    %s: %s -> %s\n", d.Field, orNone(d.Old), orNone(d.New))
+		}
+	}
+
+	creates, updates, deletes := p.Counts()
+	fmt.Fprintf(&b, "\nPlan: %d to create, %d to update, %d to delete.\n", creates, updates, deletes)
+
+	_, err := b.WriteTo(w)
+	return err
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
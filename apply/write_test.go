@@ -0,0 +1,54 @@
+package apply_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/apply"
+)
+
+var _ = Describe("Write", func() {
+	It("writes each change with its diffs and a summary", func() {
+		client := newClient()
+		Expect(client.DeleteTask(wl.Task{ID: 11})).To(Succeed())
+		Expect(client.DeleteTask(wl.Task{ID: 12})).To(Succeed())
+		spec := apply.Spec{
+			Lists: []apply.ListSpec{
+				{Title: "Errands", Tasks: []apply.TaskSpec{{Title: "Post letter"}}},
+				{
+					Title:    "Work",
+					Webhooks: []apply.WebhookSpec{{URL: "https://example.com/hook"}},
+					Tasks: []apply.TaskSpec{{
+						Title: "Write report",
+						Due:   date(2026, time.November, 3),
+						Note:  "A note which is rather longer than is worth showing\nin a plan",
+					}},
+				},
+			},
+		}
+
+		plan, err := apply.NewPlan(client, spec, apply.Options{Prune: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		var b bytes.Buffer
+		Expect(apply.Write(&b, plan)).To(Succeed())
+		Expect(b.String()).To(Equal(`+ list "Errands"
++ task "Post letter" in list "Errands"
+- member "jane@example.com" in list "Work"
+~ task "Write report" in list "Work"
+    due: 2026-11-01 -> 2026-11-03
+    note: "Draft" -> "A note which is rather longer than is wo..."
+
+Plan: 2 to create, 1 to update, 1 to delete.
+`))
+	})
+
+	It("writes that there are no changes", func() {
+		var b bytes.Buffer
+		Expect(apply.Write(&b, apply.Plan{})).To(Succeed())
+		Expect(b.String()).To(Equal("No changes.\n"))
+	})
+})
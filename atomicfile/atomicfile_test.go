@@ -0,0 +1,66 @@
+package atomicfile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl/atomicfile"
+)
+
+var _ = Describe("WriteFile", func() {
+	var (
+		dir  string
+		path string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "atomicfile")
+		Expect(err).NotTo(HaveOccurred())
+
+		path = filepath.Join(dir, "todo.txt")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	mode := func() os.FileMode {
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		return info.Mode().Perm()
+	}
+
+	It("creates the file with the permissions", func() {
+		Expect(atomicfile.WriteFile(path, []byte("new"), 0640)).To(Succeed())
+
+		Expect(ioutil.ReadFile(path)).To(Equal([]byte("new")))
+		Expect(mode()).To(Equal(os.FileMode(0640)))
+	})
+
+	It("replaces the file, keeping its permissions", func() {
+		Expect(ioutil.WriteFile(path, []byte("old contents"), 0644)).To(Succeed())
+		Expect(os.Chmod(path, 0664)).To(Succeed())
+
+		Expect(atomicfile.WriteFile(path, []byte("new"), 0600)).To(Succeed())
+
+		Expect(ioutil.ReadFile(path)).To(Equal([]byte("new")))
+		Expect(mode()).To(Equal(os.FileMode(0664)))
+	})
+
+	It("does not leave temporary files", func() {
+		Expect(atomicfile.WriteFile(path, []byte("new"), 0600)).To(Succeed())
+
+		files, err := ioutil.ReadDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+	})
+
+	It("returns an error if the directory does not exist", func() {
+		err := atomicfile.WriteFile(filepath.Join(dir, "missing", "todo.txt"), []byte("new"), 0600)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,500 @@
+// Package caldav serves lists as CalDAV calendars of VTODOs, as described
+// in RFC 4791, so that calendar and task clients can read and edit tasks.
+package caldav
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/ical"
+)
+
+const (
+	wellKnownPath = "/.well-known/caldav"
+	principalPath = "/principal/"
+	homePath      = "/calendars/"
+
+	calendarContentType = "text/calendar; charset=utf-8"
+	todoContentType     = "text/calendar; charset=utf-8; component=VTODO"
+)
+
+// errNotFound is returned for paths which do not identify a task in the
+// calendar of the path.
+var errNotFound = errors.New("resource not found")
+
+// Options configure a Server.
+type Options struct {
+	// IncludeCompleted includes completed tasks in calendars.
+	IncludeCompleted bool
+
+	// Now returns the current time, which is the DTSTAMP of todos.
+	// It defaults to time.Now.
+	Now func() time.Time
+
+	// Location is the time zone of floating times in todos, and in which
+	// due times are converted to dates. It defaults to the local time zone.
+	Location *time.Location
+}
+
+// alias is the href and UID chosen by the client which created a task.
+type alias struct {
+	listID uint
+	href   string
+	uid    string
+}
+
+// Server is an http.Handler serving each list as a calendar collection,
+// with a resource for each task. Requests are handled one at a time.
+//
+// The principal is at /principal/, and the calendars are at
+// /calendars/<list ID>/, with tasks at /calendars/<list ID>/<task ID>.ics.
+// Tasks created by clients are also available at the href they were created
+// with, and keep the UID they were created with, until the server stops.
+//
+// The ETag of a task is derived from the revisions of the task, its note
+// and its reminders, which are all part of its todo. Edits which do not
+// match the ETag of the task, or which conflict with a newer revision,
+// fail with 412 Precondition Failed.
+type Server struct {
+	client wl.Client
+	opts   Options
+
+	mu      sync.Mutex
+	hrefs   map[string]uint
+	aliases map[uint]alias
+}
+
+// NewServer returns a Server for the lists of the client.
+func NewServer(client wl.Client, opts Options) *Server {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+
+	return &Server{
+		client:  client,
+		opts:    opts,
+		hrefs:   map[string]uint{},
+		aliases: map[uint]alias{},
+	}
+}
+
+type resourceKind int
+
+const (
+	rootResource resourceKind = iota
+	principalResource
+	homeResource
+	calendarResource
+	taskResource
+)
+
+// target is the resource identified by the path of a request.
+type target struct {
+	kind   resourceKind
+	listID uint
+	href   string
+}
+
+// parsePath returns the resource for the path, or false if there is none.
+func parsePath(p string) (target, bool) {
+	switch strings.TrimSuffix(p, "/") {
+	case "":
+		return target{kind: rootResource, href: "/"}, true
+	case strings.TrimSuffix(principalPath, "/"):
+		return target{kind: principalResource, href: principalPath}, true
+	case strings.TrimSuffix(homePath, "/"):
+		return target{kind: homeResource, href: homePath}, true
+	}
+
+	if !strings.HasPrefix(p, homePath) {
+		return target{}, false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(p, homePath), "/", 2)
+	listID, err := strconv.ParseUint(parts[0], 10, 0)
+	if err != nil || listID == 0 {
+		return target{}, false
+	}
+
+	if len(parts) == 1 || parts[1] == "" {
+		return target{kind: calendarResource, listID: uint(listID), href: calendarPath(uint(listID))}, true
+	}
+	if strings.Contains(parts[1], "/") {
+		return target{}, false
+	}
+	return target{kind: taskResource, listID: uint(listID), href: p}, true
+}
+
+func calendarPath(listID uint) string {
+	return fmt.Sprintf("%s%d/", homePath, listID)
+}
+
+func taskPath(listID uint, taskID uint) string {
+	return fmt.Sprintf("%s%d.ics", calendarPath(listID), taskID)
+}
+
+// etag returns the ETag for a revision.
+func etag(revision uint) string {
+	return fmt.Sprintf(`"%d"`, revision)
+}
+
+// entryETag returns the ETag for the todo of an entry, which changes when
+// the task, its note or any of its reminders changes.
+func entryETag(e ical.Entry) string {
+	parts := []string{fmt.Sprintf("task %d %d", e.Task.ID, e.Task.Revision)}
+	if e.Note.ID != 0 {
+		parts = append(parts, fmt.Sprintf("note %d %d", e.Note.ID, e.Note.Revision))
+	}
+	for _, r := range e.Reminders {
+		parts = append(parts, fmt.Sprintf("reminder %d %d", r.ID, r.Revision))
+	}
+	// Reminders are not returned in a consistent order.
+	sort.Strings(parts[1:])
+
+	h := fnv.New64a()
+	h.Write([]byte(strings.Join(parts, "\n")))
+	return fmt.Sprintf(`"%x"`, h.Sum64())
+}
+
+// resource is a todo with the href and ETag it is served at.
+type resource struct {
+	todo ical.Todo
+	href string
+	etag string
+}
+
+// ServeHTTP handles a WebDAV or CalDAV request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == wellKnownPath {
+		http.Redirect(w, r, "/", http.StatusMovedPermanently)
+		return
+	}
+
+	t, ok := parsePath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case r.Method == "OPTIONS":
+		w.Header().Set("DAV", "1, calendar-access")
+		w.Header().Set("Allow", "OPTIONS, PROPFIND, REPORT, GET, HEAD, PUT, DELETE")
+	case r.Method == "PROPFIND":
+		s.propfind(w, r, t)
+	case r.Method == "REPORT" && t.kind == calendarResource:
+		s.report(w, r, t)
+	case (r.Method == "GET" || r.Method == "HEAD") && t.kind == calendarResource:
+		s.getCalendar(w, t)
+	case (r.Method == "GET" || r.Method == "HEAD") && t.kind == taskResource:
+		s.getTask(w, t)
+	case r.Method == "PUT" && t.kind == taskResource:
+		s.put(w, r, t)
+	case r.Method == "DELETE" && t.kind == taskResource:
+		s.delete(w, r, t)
+	default:
+		http.Error(w, fmt.Sprintf("%s is not supported for %s", r.Method, r.URL.Path), http.StatusMethodNotAllowed)
+	}
+}
+
+// calendar returns the list and the resource of each of its todos.
+func (s *Server) calendar(listID uint) (wl.List, []resource, error) {
+	l, err := s.client.List(listID)
+	if err != nil {
+		return wl.List{}, nil, err
+	}
+
+	entries, err := ical.Entries(s.client, []uint{listID}, s.opts.IncludeCompleted)
+	if err != nil {
+		return wl.List{}, nil, err
+	}
+
+	resources := make([]resource, len(entries))
+	for i, e := range entries {
+		resources[i] = s.resource(listID, e)
+	}
+	return l, resources, nil
+}
+
+// lookup returns the task at the target, or errNotFound if it is not in
+// the list of the target.
+func (s *Server) lookup(t target) (wl.Task, error) {
+	id, ok := s.hrefs[t.href]
+	if !ok {
+		name := path.Base(t.href)
+		if !strings.HasSuffix(name, ".ics") {
+			return wl.Task{}, errNotFound
+		}
+
+		n, err := strconv.ParseUint(strings.TrimSuffix(name, ".ics"), 10, 0)
+		if err != nil || n == 0 {
+			return wl.Task{}, errNotFound
+		}
+		id = uint(n)
+	}
+
+	task, err := s.client.Task(id)
+	if err != nil {
+		return wl.Task{}, err
+	}
+	if task.ListID != t.listID {
+		return wl.Task{}, errNotFound
+	}
+	return task, nil
+}
+
+// entry returns the task at the target with its note and reminders.
+func (s *Server) entry(t target) (ical.Entry, error) {
+	task, err := s.lookup(t)
+	if err != nil {
+		return ical.Entry{}, err
+	}
+
+	notes, err := s.client.NotesForTaskID(task.ID)
+	if err != nil {
+		return ical.Entry{}, err
+	}
+
+	reminders, err := s.client.RemindersForTaskID(task.ID)
+	if err != nil {
+		return ical.Entry{}, err
+	}
+
+	e := ical.Entry{Task: task, Reminders: reminders}
+	if len(notes) > 0 {
+		e.Note = notes[0]
+	}
+	return e, nil
+}
+
+// resource returns the todo for the entry, with the UID chosen by the
+// client which created it, if any, and its href and ETag.
+func (s *Server) resource(listID uint, e ical.Entry) resource {
+	r := resource{
+		todo: e.Todo(),
+		href: taskPath(listID, e.Task.ID),
+		etag: entryETag(e),
+	}
+
+	a, ok := s.aliases[e.Task.ID]
+	if ok && a.listID == listID {
+		r.todo.UID = a.uid
+		r.href = a.href
+	}
+	return r
+}
+
+func (s *Server) getCalendar(w http.ResponseWriter, t target) {
+	l, resources, err := s.calendar(t.listID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	c := ical.Calendar{Name: l.Title, Todos: []ical.Todo{}}
+	for _, r := range resources {
+		c.Todos = append(c.Todos, r.todo)
+	}
+
+	writeCalendar(w, c, etag(l.Revision), s.opts.Now())
+}
+
+func (s *Server) getTask(w http.ResponseWriter, t target) {
+	e, err := s.entry(t)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	r := s.resource(t.listID, e)
+	writeCalendar(w, ical.Calendar{Todos: []ical.Todo{r.todo}}, r.etag, s.opts.Now())
+}
+
+func writeCalendar(w http.ResponseWriter, c ical.Calendar, tag string, stamp time.Time) {
+	var buf bytes.Buffer
+	err := c.Write(&buf, stamp)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", calendarContentType)
+	w.Header().Set("ETag", tag)
+	w.Write(buf.Bytes())
+}
+
+// put creates or updates the task at the target from the single todo in
+// the request, with its description as the note and its alarms as the
+// reminders.
+func (s *Server) put(w http.ResponseWriter, r *http.Request, t target) {
+	c, err := ical.Parse(r.Body, s.opts.Location)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(c.Todos) != 1 {
+		http.Error(w, "calendar must contain exactly one VTODO", http.StatusBadRequest)
+		return
+	}
+
+	todo := c.Todos[0]
+	if todo.Summary == "" {
+		http.Error(w, "VTODO must have a SUMMARY", http.StatusBadRequest)
+		return
+	}
+
+	e, err := s.entry(t)
+	exists := err == nil
+	if err != nil && !isNotFound(err) {
+		writeError(w, err)
+		return
+	}
+
+	var current string
+	if exists {
+		current = entryETag(e)
+	}
+	if !checkPreconditions(w, r, current) {
+		return
+	}
+
+	if exists {
+		e.Task, err = s.client.UpdateTask(ical.ApplyTodo(todo, e.Task))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		e, err = ical.UpdateDetails(s.client, e, todo)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.Header().Set("ETag", entryETag(e))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	task := ical.ApplyTodo(todo, wl.Task{ListID: t.listID})
+	task, err = s.client.CreateTask(
+		task.Title,
+		task.ListID,
+		task.AssigneeID,
+		task.Completed,
+		task.RecurrenceType,
+		task.RecurrenceCount,
+		task.DueDate,
+		task.Starred,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	uid := todo.UID
+	if uid == "" {
+		uid = ical.UID(task.ID)
+	}
+	if t.href != taskPath(t.listID, task.ID) || uid != ical.UID(task.ID) {
+		s.hrefs[t.href] = task.ID
+		s.aliases[task.ID] = alias{listID: t.listID, href: t.href, uid: uid}
+	}
+
+	e, err = ical.UpdateDetails(s.client, ical.Entry{Task: task}, todo)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", entryETag(e))
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, t target) {
+	e, err := s.entry(t)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if !checkPreconditions(w, r, entryETag(e)) {
+		return
+	}
+
+	err = s.client.DeleteTask(e.Task)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if a, ok := s.aliases[e.Task.ID]; ok {
+		delete(s.hrefs, a.href)
+		delete(s.aliases, e.Task.ID)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkPreconditions checks the If-Match and If-None-Match headers against
+// the ETag of the resource, which is empty if it does not exist. If they do
+// not match it responds with 412 Precondition Failed and returns false.
+func checkPreconditions(w http.ResponseWriter, r *http.Request, current string) bool {
+	ok := true
+
+	if h := r.Header.Get("If-Match"); h != "" {
+		ok = current != "" && (h == "*" || matchETag(h, current))
+	}
+	if h := r.Header.Get("If-None-Match"); h != "" && ok {
+		ok = current == "" || (h != "*" && !matchETag(h, current))
+	}
+
+	if !ok {
+		http.Error(w, "resource has been modified", http.StatusPreconditionFailed)
+	}
+	return ok
+}
+
+// matchETag returns true if the ETag is in the comma-separated list.
+func matchETag(list string, tag string) bool {
+	for _, t := range strings.Split(list, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(t), "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// writeError responds with the status for the error. The API signals
+// missing resources with 404 and revision conflicts with 409; other
+// errors are from the API, so are reported as a bad gateway.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	switch {
+	case isNotFound(err):
+		status = http.StatusNotFound
+	case wl.IsConflict(err):
+		status = http.StatusPreconditionFailed
+	}
+
+	http.Error(w, err.Error(), status)
+}
+
+func isNotFound(err error) bool {
+	return err == errNotFound || wl.IsNotFound(err)
+}
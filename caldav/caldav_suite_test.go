@@ -0,0 +1,13 @@
+package caldav_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCaldav(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Caldav Suite")
+}
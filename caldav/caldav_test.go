@@ -0,0 +1,414 @@
+package caldav_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/caldav"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+func newClient() *wltest.Client {
+	client := wltest.NewClient()
+	client.AddList(wl.List{ID: 1, Title: "inbox", Revision: 3})
+	client.AddList(wl.List{ID: 2, Title: "Work & Play", Revision: 8})
+	client.AddTask(wl.Task{ID: 10, ListID: 2, Title: "Write report", Revision: 4, DueDate: wl.NewDate(2016, time.January, 6)})
+	client.AddTask(wl.Task{ID: 11, ListID: 2, Title: "Done", Revision: 2, Completed: true})
+	client.AddTask(wl.Task{ID: 12, ListID: 1, Title: "Elsewhere", Revision: 1})
+	client.AddNote(wl.Note{ID: 50, TaskID: 10, Content: "Quarterly", Revision: 1})
+	return client
+}
+
+const todo = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Client//EN\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"UID:client-uid-1\r\n" +
+	"SUMMARY:Buy milk\r\n" +
+	"DUE;VALUE=DATE:20160110\r\n" +
+	"PRIORITY:1\r\n" +
+	"RRULE:FREQ=WEEKLY\r\n" +
+	"END:VTODO\r\n" +
+	"END:VCALENDAR\r\n"
+
+var _ = Describe("Server", func() {
+	var (
+		client *wltest.Client
+		server *caldav.Server
+	)
+
+	BeforeEach(func() {
+		client = newClient()
+		server = caldav.NewServer(client, caldav.Options{
+			Now: func() time.Time { return time.Date(2016, time.January, 5, 0, 0, 0, 0, time.UTC) },
+		})
+	})
+
+	do := func(method string, path string, body string, headers ...string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		return w
+	}
+
+	etagOf := func(path string) string {
+		w := do("GET", path, "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		return w.Header().Get("ETag")
+	}
+
+	task := func(taskID uint) wl.Task {
+		t, err := client.Task(taskID)
+		Expect(err).NotTo(HaveOccurred())
+		return t
+	}
+
+	tasks := func(listID uint) []wl.Task {
+		tasks, err := client.TasksForListID(listID)
+		Expect(err).NotTo(HaveOccurred())
+		return tasks
+	}
+
+	// escaped returns the ETag as it appears in XML.
+	escaped := func(etag string) string {
+		return strings.Replace(etag, `"`, "&#34;", -1)
+	}
+
+	It("advertises CalDAV support", func() {
+		w := do("OPTIONS", "/", "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("DAV")).To(ContainSubstring("calendar-access"))
+
+		w = do("GET", "/.well-known/caldav", "")
+		Expect(w.Code).To(Equal(http.StatusMovedPermanently))
+		Expect(w.Header().Get("Location")).To(Equal("/"))
+	})
+
+	Describe("PROPFIND", func() {
+		It("finds the calendar home of the principal", func() {
+			w := do("PROPFIND", "/principal/", `<?xml version="1.0"?>
+<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><c:calendar-home-set/><d:unknown/></d:prop>
+</d:propfind>`, "Depth", "0")
+
+			Expect(w.Code).To(Equal(http.StatusMultiStatus))
+			Expect(w.Body.String()).To(ContainSubstring(
+				"<d:propstat><d:prop><c:calendar-home-set><d:href>/calendars/</d:href></c:calendar-home-set></d:prop>" +
+					"<d:status>HTTP/1.1 200 OK</d:status></d:propstat>",
+			))
+			Expect(w.Body.String()).To(ContainSubstring(
+				"<d:propstat><d:prop><d:unknown/></d:prop><d:status>HTTP/1.1 404 Not Found</d:status></d:propstat>",
+			))
+		})
+
+		It("lists a calendar for each list", func() {
+			w := do("PROPFIND", "/calendars/", `<d:propfind xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/">
+  <d:prop><d:displayname/><d:resourcetype/><cs:getctag/></d:prop>
+</d:propfind>`, "Depth", "1")
+
+			Expect(w.Code).To(Equal(http.StatusMultiStatus))
+			body := w.Body.String()
+			Expect(body).To(ContainSubstring("<d:href>/calendars/1/</d:href>"))
+			Expect(body).To(ContainSubstring(
+				"<d:href>/calendars/2/</d:href><d:propstat><d:prop><d:displayname>Work &amp; Play</d:displayname>" +
+					"<d:resourcetype><d:collection/><c:calendar/></d:resourcetype><cs:getctag>&#34;8&#34;</cs:getctag>",
+			))
+		})
+
+		It("lists the tasks of a calendar with their ETags", func() {
+			w := do("PROPFIND", "/calendars/2/", "", "Depth", "1")
+
+			Expect(w.Code).To(Equal(http.StatusMultiStatus))
+			body := w.Body.String()
+			Expect(body).To(ContainSubstring(
+				"<d:href>/calendars/2/10.ics</d:href><d:propstat><d:prop><d:getcontenttype>",
+			))
+			Expect(body).To(ContainSubstring("<d:getetag>" + escaped(etagOf("/calendars/2/10.ics")) + "</d:getetag>"))
+			Expect(body).NotTo(ContainSubstring("11.ics"))
+			Expect(body).NotTo(ContainSubstring("calendar-data"))
+		})
+
+		It("includes completed tasks if configured", func() {
+			server = caldav.NewServer(client, caldav.Options{IncludeCompleted: true})
+
+			w := do("PROPFIND", "/calendars/2/", "", "Depth", "1")
+			Expect(w.Body.String()).To(ContainSubstring("<d:href>/calendars/2/11.ics</d:href>"))
+		})
+
+		It("returns 404 for tasks in other lists", func() {
+			w := do("PROPFIND", "/calendars/2/12.ics", "", "Depth", "0")
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("REPORT", func() {
+		It("returns the data of all todos for a calendar-query", func() {
+			w := do("REPORT", "/calendars/2/", `<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VTODO"/></c:comp-filter></c:filter>
+</c:calendar-query>`)
+
+			Expect(w.Code).To(Equal(http.StatusMultiStatus))
+			body := w.Body.String()
+			Expect(body).To(ContainSubstring("<d:href>/calendars/2/10.ics</d:href>"))
+			Expect(body).To(ContainSubstring("SUMMARY:Write report&#xD;&#xA;DESCRIPTION:Quarterly"))
+		})
+
+		It("returns no events for a calendar-query", func() {
+			w := do("REPORT", "/calendars/2/", `<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VEVENT"/></c:comp-filter></c:filter>
+</c:calendar-query>`)
+
+			Expect(w.Code).To(Equal(http.StatusMultiStatus))
+			Expect(w.Body.String()).NotTo(ContainSubstring("<d:response>"))
+		})
+
+		It("returns the todos for the hrefs of a calendar-multiget", func() {
+			w := do("REPORT", "/calendars/2/", `<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/></d:prop>
+  <d:href>/calendars/2/10.ics</d:href>
+  <d:href>http://localhost:5232/calendars/2/99.ics</d:href>
+</c:calendar-multiget>`)
+
+			Expect(w.Code).To(Equal(http.StatusMultiStatus))
+			body := w.Body.String()
+			Expect(body).To(ContainSubstring("<d:href>/calendars/2/10.ics</d:href><d:propstat><d:prop><d:getetag>" +
+				escaped(etagOf("/calendars/2/10.ics")) + "</d:getetag>"))
+			Expect(body).To(ContainSubstring("<d:href>/calendars/2/99.ics</d:href><d:status>HTTP/1.1 404 Not Found</d:status>"))
+		})
+
+		It("rejects other reports", func() {
+			w := do("REPORT", "/calendars/2/", `<d:sync-collection xmlns:d="DAV:"/>`)
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Describe("GET", func() {
+		It("returns the todo with its ETag", func() {
+			w := do("GET", "/calendars/2/10.ics", "")
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("ETag")).To(MatchRegexp(`^"[0-9a-f]+"$`))
+			Expect(w.Body.String()).To(ContainSubstring("UID:task-10@wunderlist\r\n"))
+			Expect(w.Body.String()).To(ContainSubstring("DUE;VALUE=DATE:20160106\r\n"))
+		})
+
+		It("changes the ETag when the task, its note or its reminders change", func() {
+			etags := []string{etagOf("/calendars/2/10.ics")}
+
+			_, err := client.UpdateTask(task(10))
+			Expect(err).NotTo(HaveOccurred())
+			etags = append(etags, etagOf("/calendars/2/10.ics"))
+
+			note, err := client.Note(50)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.UpdateNote(note)
+			Expect(err).NotTo(HaveOccurred())
+			etags = append(etags, etagOf("/calendars/2/10.ics"))
+
+			reminder, err := client.CreateReminder(time.Date(2016, time.January, 6, 9, 0, 0, 0, time.UTC), 10, "")
+			Expect(err).NotTo(HaveOccurred())
+			etags = append(etags, etagOf("/calendars/2/10.ics"))
+
+			_, err = client.UpdateReminder(reminder)
+			Expect(err).NotTo(HaveOccurred())
+			etags = append(etags, etagOf("/calendars/2/10.ics"))
+
+			seen := map[string]bool{}
+			for _, etag := range etags {
+				Expect(seen).NotTo(HaveKey(etag))
+				seen[etag] = true
+			}
+		})
+
+		It("returns the whole calendar for a collection", func() {
+			w := do("GET", "/calendars/2/", "")
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("ETag")).To(Equal(`"8"`))
+			Expect(w.Body.String()).To(ContainSubstring("X-WR-CALNAME:Work & Play\r\n"))
+		})
+
+		It("returns 404 for unknown paths", func() {
+			Expect(do("GET", "/calendars/2/unknown.ics", "").Code).To(Equal(http.StatusNotFound))
+			Expect(do("GET", "/elsewhere", "").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("PUT", func() {
+		It("creates a task, which keeps the href and UID of the client", func() {
+			w := do("PUT", "/calendars/2/client-uid-1.ics", todo, "If-None-Match", "*")
+
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			created := w.Header().Get("ETag")
+
+			Expect(tasks(2)).To(HaveLen(2))
+			Expect(tasks(2)[1]).To(Equal(wl.Task{
+				ID:              tasks(2)[1].ID,
+				ListID:          2,
+				Title:           "Buy milk",
+				DueDate:         wl.NewDate(2016, time.January, 10),
+				Starred:         true,
+				RecurrenceType:  wl.RecurrenceWeek,
+				RecurrenceCount: 1,
+				Revision:        1,
+			}))
+
+			w = do("GET", "/calendars/2/client-uid-1.ics", "")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("ETag")).To(Equal(created))
+			Expect(w.Body.String()).To(ContainSubstring("UID:client-uid-1\r\n"))
+
+			w = do("PROPFIND", "/calendars/2/", "", "Depth", "1")
+			Expect(w.Body.String()).To(ContainSubstring("<d:href>/calendars/2/client-uid-1.ics</d:href>"))
+		})
+
+		It("updates a task matching the ETag", func() {
+			update := strings.Replace(todo, "SUMMARY:Buy milk", "SUMMARY:Renamed\r\nSTATUS:COMPLETED", 1)
+
+			current := etagOf("/calendars/2/10.ics")
+
+			w := do("PUT", "/calendars/2/10.ics", update, "If-Match", current)
+			Expect(w.Code).To(Equal(http.StatusNoContent))
+			Expect(w.Header().Get("ETag")).NotTo(Equal(current))
+			Expect(w.Header().Get("ETag")).To(Equal(etagOf("/calendars/2/10.ics")))
+
+			Expect(task(10).Title).To(Equal("Renamed"))
+			Expect(task(10).Completed).To(BeTrue())
+			Expect(task(10).DueDate).To(Equal(wl.NewDate(2016, time.January, 10)))
+		})
+
+		It("replaces the note and reminders with the description and alarms", func() {
+			client.AddReminder(wl.Reminder{ID: 60, TaskID: 10, Date: time.Date(2016, time.January, 5, 9, 0, 0, 0, time.UTC)})
+			client.AddReminder(wl.Reminder{ID: 61, TaskID: 10, Date: time.Date(2016, time.January, 6, 9, 0, 0, 0, time.UTC)})
+			update := strings.Replace(todo, "END:VTODO", "DESCRIPTION:Annual\r\n"+
+				"BEGIN:VALARM\r\n"+
+				"ACTION:DISPLAY\r\n"+
+				"TRIGGER;VALUE=DATE-TIME:20160106T090000Z\r\n"+
+				"END:VALARM\r\n"+
+				"BEGIN:VALARM\r\n"+
+				"ACTION:DISPLAY\r\n"+
+				"TRIGGER;VALUE=DATE-TIME:20160107T090000Z\r\n"+
+				"END:VALARM\r\n"+
+				"END:VTODO", 1)
+
+			w := do("PUT", "/calendars/2/10.ics", update)
+			Expect(w.Code).To(Equal(http.StatusNoContent))
+			Expect(w.Header().Get("ETag")).To(Equal(etagOf("/calendars/2/10.ics")))
+
+			notes, err := client.NotesForTaskID(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notes).To(HaveLen(1))
+			Expect(notes[0].ID).To(Equal(uint(50)))
+			Expect(notes[0].Content).To(Equal("Annual"))
+
+			reminders, err := client.RemindersForTaskID(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reminders).To(HaveLen(2))
+			Expect(reminders[0].ID).To(Equal(uint(61)))
+			Expect(reminders[1].Date).To(Equal(time.Date(2016, time.January, 7, 9, 0, 0, 0, time.UTC)))
+			Expect(client.Calls("DeleteReminder")).To(Equal(1))
+
+			w = do("PUT", "/calendars/2/10.ics", todo)
+			Expect(w.Code).To(Equal(http.StatusNoContent))
+
+			notes, err = client.NotesForTaskID(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notes).To(BeEmpty())
+
+			reminders, err = client.RemindersForTaskID(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reminders).To(BeEmpty())
+		})
+
+		It("creates the note and reminders of a new task", func() {
+			create := strings.Replace(todo, "END:VTODO", "DESCRIPTION:Semi-skimmed\r\n"+
+				"BEGIN:VALARM\r\n"+
+				"ACTION:DISPLAY\r\n"+
+				"TRIGGER;VALUE=DATE-TIME:20160110T090000Z\r\n"+
+				"END:VALARM\r\n"+
+				"END:VTODO", 1)
+
+			w := do("PUT", "/calendars/2/client-uid-1.ics", create, "If-None-Match", "*")
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			Expect(w.Header().Get("ETag")).To(Equal(etagOf("/calendars/2/client-uid-1.ics")))
+
+			id := tasks(2)[1].ID
+			notes, err := client.NotesForTaskID(id)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notes).To(HaveLen(1))
+			Expect(notes[0].Content).To(Equal("Semi-skimmed"))
+
+			reminders, err := client.RemindersForTaskID(id)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reminders).To(HaveLen(1))
+			Expect(reminders[0].Date).To(Equal(time.Date(2016, time.January, 10, 9, 0, 0, 0, time.UTC)))
+		})
+
+		It("rejects updates to an old ETag", func() {
+			w := do("PUT", "/calendars/2/10.ics", todo, "If-Match", `"3"`)
+			Expect(w.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(task(10).Title).To(Equal("Write report"))
+
+			w = do("PUT", "/calendars/2/10.ics", todo, "If-None-Match", "*")
+			Expect(w.Code).To(Equal(http.StatusPreconditionFailed))
+		})
+
+		It("reports conflicts with the API as failed preconditions", func() {
+			client.Conflict("UpdateTask", 1)
+
+			w := do("PUT", "/calendars/2/10.ics", todo)
+			Expect(w.Code).To(Equal(http.StatusPreconditionFailed))
+		})
+
+		It("does not create a task if the existing task cannot be fetched", func() {
+			client.Fail("Task", errors.New("Get https://a.wunderlist.com/api/v1/tasks/14049: connection refused"))
+
+			w := do("PUT", "/calendars/2/14049.ics", todo)
+			Expect(w.Code).To(Equal(http.StatusBadGateway))
+			Expect(client.Calls("CreateTask")).To(BeZero())
+		})
+
+		It("rejects invalid calendars", func() {
+			Expect(do("PUT", "/calendars/2/new.ics", "nonsense").Code).To(Equal(http.StatusBadRequest))
+
+			empty := strings.Replace(todo, "SUMMARY:Buy milk\r\n", "", 1)
+			Expect(do("PUT", "/calendars/2/new.ics", empty).Code).To(Equal(http.StatusBadRequest))
+
+			Expect(do("PUT", "/calendars/2/", todo).Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("DELETE", func() {
+		It("deletes the task matching the ETag", func() {
+			Expect(do("DELETE", "/calendars/2/10.ics", "", "If-Match", `"3"`).Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(tasks(2)).To(HaveLen(1))
+
+			Expect(do("DELETE", "/calendars/2/10.ics", "", "If-Match", etagOf("/calendars/2/10.ics")).Code).To(Equal(http.StatusNoContent))
+			Expect(tasks(2)).To(BeEmpty())
+
+			Expect(do("DELETE", "/calendars/2/10.ics", "").Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("deletes tasks created by clients at their href", func() {
+			Expect(do("PUT", "/calendars/2/client-uid-1.ics", todo).Code).To(Equal(http.StatusCreated))
+
+			Expect(do("DELETE", "/calendars/2/client-uid-1.ics", "").Code).To(Equal(http.StatusNoContent))
+			Expect(tasks(2)).To(Equal([]wl.Task{task(10)}))
+
+			Expect(do("GET", "/calendars/2/client-uid-1.ics", "").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -0,0 +1,414 @@
+package caldav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/ical"
+)
+
+// XML namespaces of properties.
+const (
+	nsDAV            = "DAV:"
+	nsCalDAV         = "urn:ietf:params:xml:ns:caldav"
+	nsCalendarServer = "http://calendarserver.org/ns/"
+)
+
+// prefixes are the prefixes of the namespaces declared in responses.
+var prefixes = map[string]string{
+	nsDAV:            "d",
+	nsCalDAV:         "c",
+	nsCalendarServer: "cs",
+}
+
+var (
+	calendarDataName = xml.Name{Space: nsCalDAV, Local: "calendar-data"}
+	principalHref    = href(principalPath)
+)
+
+// props are the values of properties, as XML using the declared prefixes.
+type props map[xml.Name]string
+
+// propRequest is the properties requested by a PROPFIND or REPORT,
+// either all or those named.
+type propRequest struct {
+	all   bool
+	names []xml.Name
+}
+
+type anyElement struct {
+	XMLName xml.Name
+}
+
+type propElement struct {
+	Names []anyElement `xml:",any"`
+}
+
+type propfindBody struct {
+	XMLName xml.Name     `xml:"DAV: propfind"`
+	Prop    *propElement `xml:"DAV: prop"`
+}
+
+type compFilter struct {
+	Name    string       `xml:"name,attr"`
+	Filters []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type reportBody struct {
+	XMLName xml.Name
+	Prop    *propElement `xml:"DAV: prop"`
+	Hrefs   []string     `xml:"DAV: href"`
+	Filter  *compFilter  `xml:"urn:ietf:params:xml:ns:caldav filter>comp-filter"`
+}
+
+func (p *propElement) request() propRequest {
+	if p == nil {
+		return propRequest{all: true}
+	}
+
+	req := propRequest{}
+	for _, n := range p.Names {
+		req.names = append(req.names, n.XMLName)
+	}
+	return req
+}
+
+// response is a response in a multistatus, either with properties or,
+// if status is not zero, with only a status.
+type response struct {
+	href    string
+	status  int
+	found   []xml.Name
+	values  props
+	missing []xml.Name
+}
+
+// newResponse returns the response with the requested properties.
+// All properties other than calendar data are included for allprop.
+func newResponse(href string, values props, req propRequest) response {
+	r := response{href: href, values: values}
+
+	if req.all {
+		for name := range values {
+			if name != calendarDataName {
+				r.found = append(r.found, name)
+			}
+		}
+		sort.Sort(byName(r.found))
+		return r
+	}
+
+	for _, name := range req.names {
+		if _, ok := values[name]; ok {
+			r.found = append(r.found, name)
+		} else {
+			r.missing = append(r.missing, name)
+		}
+	}
+	return r
+}
+
+func (s *Server) propfind(w http.ResponseWriter, r *http.Request, t target) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req := propRequest{all: true}
+	if len(bytes.TrimSpace(body)) > 0 {
+		var b propfindBody
+		err = xml.Unmarshal(body, &b)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req = b.Prop.request()
+	}
+
+	// Infinite depth is treated as a depth of 1, as there are no deeper
+	// collections which clients would need to discover.
+	children := r.Header.Get("Depth") != "0"
+
+	var responses []response
+	switch t.kind {
+	case rootResource:
+		responses = append(responses, newResponse(t.href, collectionProps("wl"), req))
+		if children {
+			responses = append(responses,
+				newResponse(principalPath, principalProps(), req),
+				newResponse(homePath, collectionProps("Calendars"), req),
+			)
+		}
+
+	case principalResource:
+		responses = append(responses, newResponse(t.href, principalProps(), req))
+
+	case homeResource:
+		responses = append(responses, newResponse(t.href, collectionProps("Calendars"), req))
+		if children {
+			lists, err := s.client.Lists()
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			for _, l := range lists {
+				responses = append(responses, newResponse(calendarPath(l.ID), calendarProps(l), req))
+			}
+		}
+
+	case calendarResource:
+		if !children {
+			l, err := s.client.List(t.listID)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			responses = append(responses, newResponse(t.href, calendarProps(l), req))
+			break
+		}
+
+		l, resources, err := s.calendar(t.listID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		responses = append(responses, newResponse(t.href, calendarProps(l), req))
+		for _, r := range resources {
+			responses = append(responses, newResponse(r.href, todoProps(r, s.opts.Now()), req))
+		}
+
+	case taskResource:
+		e, err := s.entry(t)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		responses = append(responses, newResponse(t.href, todoProps(s.resource(t.listID, e), s.opts.Now()), req))
+	}
+
+	writeMultistatus(w, responses)
+}
+
+// report responds to a calendar-query with all todos in the calendar, or
+// none if only other components are requested, and to a calendar-multiget
+// with the todos for the hrefs.
+func (s *Server) report(w http.ResponseWriter, r *http.Request, t target) {
+	var b reportBody
+	err := xml.NewDecoder(r.Body).Decode(&b)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req := b.Prop.request()
+	responses := []response{}
+
+	switch b.XMLName {
+	case xml.Name{Space: nsCalDAV, Local: "calendar-query"}:
+		if !b.Filter.includesTodos() {
+			break
+		}
+
+		_, resources, err := s.calendar(t.listID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		for _, r := range resources {
+			responses = append(responses, newResponse(r.href, todoProps(r, s.opts.Now()), req))
+		}
+
+	case xml.Name{Space: nsCalDAV, Local: "calendar-multiget"}:
+		for _, h := range b.Hrefs {
+			u, err := url.Parse(h)
+			if err != nil {
+				responses = append(responses, response{href: h, status: http.StatusNotFound})
+				continue
+			}
+
+			ht, ok := parsePath(u.Path)
+			if !ok || ht.kind != taskResource || ht.listID != t.listID {
+				responses = append(responses, response{href: u.Path, status: http.StatusNotFound})
+				continue
+			}
+
+			e, err := s.entry(ht)
+			if err != nil {
+				if !isNotFound(err) {
+					writeError(w, err)
+					return
+				}
+				responses = append(responses, response{href: u.Path, status: http.StatusNotFound})
+				continue
+			}
+			responses = append(responses, newResponse(u.Path, todoProps(s.resource(ht.listID, e), s.opts.Now()), req))
+		}
+
+	default:
+		http.Error(w, fmt.Sprintf("report %s is not supported", b.XMLName.Local), http.StatusForbidden)
+		return
+	}
+
+	writeMultistatus(w, responses)
+}
+
+// includesTodos returns true unless the filter only matches calendars
+// without todos, i.e. calendars of other components.
+func (f *compFilter) includesTodos() bool {
+	if f == nil || len(f.Filters) == 0 {
+		return true
+	}
+
+	for _, c := range f.Filters {
+		if c.Name == "VTODO" {
+			return true
+		}
+	}
+	return false
+}
+
+func collectionProps(name string) props {
+	return props{
+		davName("resourcetype"):           "<d:collection/>",
+		davName("displayname"):            escapeXML(name),
+		davName("current-user-principal"): principalHref,
+	}
+}
+
+func principalProps() props {
+	return props{
+		davName("resourcetype"):                               "<d:principal/>",
+		davName("displayname"):                                "wl",
+		davName("current-user-principal"):                     principalHref,
+		davName("principal-URL"):                              principalHref,
+		xml.Name{Space: nsCalDAV, Local: "calendar-home-set"}: href(homePath),
+	}
+}
+
+func calendarProps(l wl.List) props {
+	return props{
+		davName("resourcetype"):           "<d:collection/><c:calendar/>",
+		davName("displayname"):            escapeXML(l.Title),
+		davName("current-user-principal"): principalHref,
+		davName("getetag"):                escapeXML(etag(l.Revision)),
+		davName("current-user-privilege-set"): "<d:privilege><d:read/></d:privilege>" +
+			"<d:privilege><d:write/></d:privilege>",
+		xml.Name{Space: nsCalDAV, Local: "supported-calendar-component-set"}: `<c:comp name="VTODO"/>`,
+		xml.Name{Space: nsCalendarServer, Local: "getctag"}:                  escapeXML(etag(l.Revision)),
+	}
+}
+
+func todoProps(r resource, stamp time.Time) props {
+	var buf bytes.Buffer
+	ical.Calendar{Todos: []ical.Todo{r.todo}}.Write(&buf, stamp)
+
+	return props{
+		davName("resourcetype"):   "",
+		davName("getetag"):        escapeXML(r.etag),
+		davName("getcontenttype"): todoContentType,
+		calendarDataName:          escapeXML(buf.String()),
+	}
+}
+
+func davName(local string) xml.Name {
+	return xml.Name{Space: nsDAV, Local: local}
+}
+
+// href returns the href element for a path.
+func href(p string) string {
+	u := url.URL{Path: p}
+	return "<d:href>" + escapeXML(u.EscapedPath()) + "</d:href>"
+}
+
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// writeMultistatus responds with 207 Multi-Status. Properties which were
+// found are in a propstat with 200 OK, and the rest in one with 404 Not Found.
+func writeMultistatus(w http.ResponseWriter, responses []response) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	fmt.Fprintf(&buf, `<d:multistatus xmlns:d="%s" xmlns:c="%s" xmlns:cs="%s">`, nsDAV, nsCalDAV, nsCalendarServer)
+
+	for _, r := range responses {
+		u := url.URL{Path: r.href}
+		buf.WriteString("<d:response>")
+		fmt.Fprintf(&buf, "<d:href>%s</d:href>", escapeXML(u.EscapedPath()))
+
+		if r.status != 0 {
+			writeStatus(&buf, r.status)
+		} else {
+			if len(r.found) > 0 {
+				writePropstat(&buf, r.found, r.values, http.StatusOK)
+			}
+			if len(r.missing) > 0 {
+				writePropstat(&buf, r.missing, nil, http.StatusNotFound)
+			}
+		}
+
+		buf.WriteString("</d:response>")
+	}
+
+	buf.WriteString("</d:multistatus>")
+
+	w.Header().Set("Content-Type", `application/xml; charset="utf-8"`)
+	w.WriteHeader(http.StatusMultiStatus)
+	w.Write(buf.Bytes())
+}
+
+func writePropstat(buf *bytes.Buffer, names []xml.Name, values props, status int) {
+	buf.WriteString("<d:propstat><d:prop>")
+	for _, name := range names {
+		writeElement(buf, name, values[name])
+	}
+	buf.WriteString("</d:prop>")
+	writeStatus(buf, status)
+	buf.WriteString("</d:propstat>")
+}
+
+func writeStatus(buf *bytes.Buffer, status int) {
+	fmt.Fprintf(buf, "<d:status>HTTP/1.1 %d %s</d:status>", status, http.StatusText(status))
+}
+
+// writeElement writes the element, declaring its namespace
+// if it is not one of the declared prefixes.
+func writeElement(buf *bytes.Buffer, name xml.Name, value string) {
+	start := name.Local
+	end := name.Local
+
+	if prefix, ok := prefixes[name.Space]; ok {
+		start = prefix + ":" + name.Local
+		end = start
+	} else if name.Space != "" {
+		start = fmt.Sprintf(`x:%s xmlns:x="%s"`, name.Local, escapeXML(name.Space))
+		end = "x:" + name.Local
+	}
+
+	if value == "" {
+		fmt.Fprintf(buf, "<%s/>", start)
+		return
+	}
+	fmt.Fprintf(buf, "<%s>%s</%s>", start, value, end)
+}
+
+type byName []xml.Name
+
+func (s byName) Len() int      { return len(s) }
+func (s byName) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byName) Less(i, j int) bool {
+	if s[i].Space != s[j].Space {
+		return s[i].Space < s[j].Space
+	}
+	return s[i].Local < s[j].Local
+}
@@ -16,6 +16,7 @@ type Client interface {
 	DeleteList(list List) error
 	DeleteAllLists() error
 	Inbox() (List, error)
+	ListTaskCount(listID uint) (ListTaskCount, error)
 
 	Notes() ([]Note, error)
 	NotesForListID(listID uint) ([]Note, error)
@@ -35,9 +36,9 @@ type Client interface {
 		listID uint,
 		assigneeID uint,
 		completed bool,
-		recurrenceType string,
+		recurrenceType RecurrenceType,
 		recurrenceCount uint,
-		dueDate time.Time,
+		dueDate Date,
 		starred bool,
 	) (Task, error)
 	UpdateTask(task Task) (Task, error)
@@ -64,7 +65,7 @@ type Client interface {
 	RemindersForTaskID(taskID uint) ([]Reminder, error)
 	Reminder(reminderID uint) (Reminder, error)
 	CreateReminder(
-		date string,
+		date time.Time,
 		taskID uint,
 		createdByDeviceUdid string,
 	) (Reminder, error)
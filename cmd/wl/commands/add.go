@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/robdimsdale/wl/quickadd"
+	"github.com/spf13/cobra"
+)
+
+const (
+	dryRunLongFlag = "dry-run"
+)
+
+var (
+	// Flags
+	dryRun bool
+
+	// Commands
+	cmdAdd = &cobra.Command{
+		Use:   "add <text>",
+		Short: "creates a task from a single line of text",
+		Long: `add creates a task from a single line of text, e.g.
+
+    wl add "Renew certs tomorrow 9am *starred #ops @bob !remind 8am"
+
+The title may end with a due date such as tomorrow, next friday or +3d.
+The text may also contain * to star the task, #list for the list title or ID (defaulting to the inbox),
+@assignee for the assignee's name, email or ID,
+and !remind followed by a date or time for a reminder.
+If the due date includes a time a reminder is set at that time, unless !remind is provided.
+Use double quotes to keep words in the title, e.g. 'Watch "Friday"'.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				fmt.Printf("text must be provided\n\n")
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			task, err := quickadd.Parse(strings.Join(args, " "), currentTime())
+			if err != nil {
+				fmt.Printf("error parsing text: %v\n\n", err)
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			if dryRun {
+				renderOutput(nil)(task, nil)
+				return
+			}
+
+			client := newClient(cmd)
+			renderOutput(client)(quickadd.Create(client, task))
+		},
+	}
+)
+
+func init() {
+	cmdAdd.Flags().BoolVar(&dryRun, dryRunLongFlag, false, "print the parsed fields without creating anything")
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/robdimsdale/wl/agenda"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags
+	agendaFormat string
+
+	// Commands
+	cmdAgenda = &cobra.Command{
+		Use:   "agenda",
+		Short: "gets today's agenda",
+		Long: `agenda aggregates uncompleted tasks across all lists into
+overdue, today, next 7 days, starred and assigned-to-me sections,
+followed by the reminders firing today.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			a, err := agenda.Build(newClient(cmd), currentTime())
+			if err != nil {
+				handleError(err)
+			}
+
+			err = agenda.Write(os.Stdout, a, agendaFormat)
+			if err != nil {
+				handleError(err)
+			}
+		},
+	}
+)
+
+func init() {
+	cmdAgenda.Flags().StringVar(&agendaFormat, formatLongFlag, agenda.FormatText, "output format: text, markdown or html")
+}
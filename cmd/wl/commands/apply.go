@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/robdimsdale/wl/apply"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	fileShortFlag = "f"
+	pruneLongFlag = "prune"
+	yesLongFlag   = "yes"
+	yesShortFlag  = "y"
+)
+
+var (
+	// Flags
+	applyPath  string
+	applyPrune bool
+	applyYes   bool
+
+	// Commands
+	cmdApply = &cobra.Command{
+		Use:   "apply",
+		Short: "makes the account match a YAML workspace file",
+		Long: `apply reads folders, lists, members, webhooks and seed tasks from a YAML file,
+prints the changes needed to make the account match it and, once confirmed,
+makes them. For example:
+
+  folders:
+  - title: Work
+    lists:
+    - title: Launch
+      members:
+      - email: jane@example.com
+      webhooks:
+      - url: https://example.com/hook
+      tasks:
+      - title: Write announcement
+        due: 2026-11-02
+        starred: true
+        note: Keep it short
+        subtasks: [Draft, Review]
+  lists:
+  - title: Errands
+
+Folders are matched by title, lists in folders by title within a folder of the
+same title, other lists by title, tasks by title within their list, members by
+email address or user_id and webhooks by URL. Existing lists are never moved
+between folders. Due dates, stars and notes are only changed if declared, and
+completed tasks are never changed.
+
+With --prune, members, webhooks and incomplete tasks of declared lists which
+are not declared are deleted. Nothing outside declared lists is deleted.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			if applyPath == "" {
+				fmt.Printf("--%s must be provided\n\n", fileLongFlag)
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			data, err := ioutil.ReadFile(applyPath)
+			if err != nil {
+				handleError(err)
+			}
+
+			var spec apply.Spec
+			err = yaml.Unmarshal(data, &spec)
+			if err != nil {
+				handleError(fmt.Errorf("failed to parse %s: %v", applyPath, err))
+			}
+
+			client := newClient(cmd)
+
+			plan, err := apply.NewPlan(client, spec, apply.Options{Prune: applyPrune})
+			if err != nil {
+				handleError(err)
+			}
+
+			err = apply.Write(os.Stdout, plan)
+			if err != nil {
+				handleError(err)
+			}
+
+			if dryRun || len(plan.Changes) == 0 {
+				return
+			}
+
+			if !applyYes && !confirm("Apply these changes? [y/N] ") {
+				fmt.Println("No changes applied.")
+				return
+			}
+
+			n, err := apply.Apply(client, plan)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%d of %d changes applied\n", n, len(plan.Changes))
+				handleError(err)
+			}
+			fmt.Printf("%d changes applied.\n", n)
+		},
+	}
+)
+
+// confirm prints the prompt and reports whether the answer read from stdin
+// is yes.
+func confirm(prompt string) bool {
+	fmt.Print(prompt)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+func init() {
+	cmdApply.Flags().StringVarP(&applyPath, fileLongFlag, fileShortFlag, "", "path of the YAML workspace file")
+	cmdApply.Flags().BoolVar(&applyPrune, pruneLongFlag, false, "delete undeclared members, webhooks and incomplete tasks of declared lists")
+	cmdApply.Flags().BoolVar(&dryRun, dryRunLongFlag, false, "print the changes without applying them")
+	cmdApply.Flags().BoolVarP(&applyYes, yesLongFlag, yesShortFlag, false, "apply the changes without asking for confirmation")
+}
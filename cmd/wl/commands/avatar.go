@@ -40,7 +40,8 @@ var (
 			}
 			userID := uint(userIDInt)
 
-			renderOutput(newClient(cmd).AvatarURL(
+			client := newClient(cmd)
+			renderOutput(client)(client.AvatarURL(
 				userID,
 				avatarSize,
 				avatarFallback,
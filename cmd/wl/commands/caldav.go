@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/robdimsdale/wl/caldav"
+	"github.com/spf13/cobra"
+)
+
+const (
+	addrLongFlag = "addr"
+
+	defaultCaldavAddr = "localhost:5232"
+)
+
+var (
+	// Flags
+	caldavAddr      string
+	caldavCompleted bool
+
+	// Commands
+	cmdCaldav = &cobra.Command{
+		Use:   "caldav",
+		Short: "serves lists to CalDAV clients",
+		Long: `caldav serves each list as a CalDAV calendar of VTODOs, so that calendar and
+task clients can read and edit tasks.
+        `,
+	}
+
+	cmdCaldavServe = &cobra.Command{
+		Use:   "serve",
+		Short: "runs a local CalDAV server",
+		Long: `serve runs a CalDAV server until interrupted. Point clients at
+http://<addr>/ with any username and password; the server has no authentication,
+so it listens on localhost only by default.
+
+Completing, renaming, starring and changing the due date or recurrence of a
+task in a client updates it, and creating or deleting a task in a client
+creates or deletes it. The ETag of a task changes with the task, its note and its
+reminders, so edits to a task which has changed since the client last fetched it
+are rejected.
+Notes and reminders are read-only.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			server := caldav.NewServer(newClient(cmd), caldav.Options{
+				IncludeCompleted: caldavCompleted,
+				Now:              currentTime,
+				Location:         location(),
+			})
+
+			fmt.Fprintf(os.Stderr, "serving CalDAV at http://%s/\n", caldavAddr)
+			err := http.ListenAndServe(caldavAddr, server)
+			if err != nil {
+				handleError(err)
+			}
+		},
+	}
+)
+
+func init() {
+	cmdCaldav.AddCommand(cmdCaldavServe)
+
+	cmdCaldavServe.Flags().StringVar(&caldavAddr, addrLongFlag, defaultCaldavAddr, "address to listen on")
+	cmdCaldavServe.Flags().BoolVar(&caldavCompleted, completedLongFlag, false, "include completed tasks")
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/dateparse"
+)
+
+// location returns the timezone provided via the timezone flag
+// or the WL_TIMEZONE environment variable, defaulting to the local timezone.
+func location() *time.Location {
+	if timezone == "" {
+		timezone = os.Getenv(timezoneEnvVariable)
+	}
+
+	if timezone == "" {
+		return time.Local
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		handleError(fmt.Errorf("invalid timezone %q: %v", timezone, err))
+	}
+	return loc
+}
+
+// currentTime returns the current time in the configured timezone.
+func currentTime() time.Time {
+	return time.Now().In(location())
+}
+
+// parseDay parses a date, such as 2016-01-05, tomorrow or +3d,
+// which must not include a time.
+func parseDay(input string) (time.Time, error) {
+	t, hasTime, err := dateparse.Parse(input, currentTime())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if hasTime {
+		return time.Time{}, fmt.Errorf("date %q must not include a time", input)
+	}
+	return t, nil
+}
+
+// parseDueDate parses a due date, which must not include a time.
+func parseDueDate(input string) (wl.Date, error) {
+	t, err := parseDay(input)
+	if err != nil {
+		return wl.Date{}, err
+	}
+	return wl.DateOf(t), nil
+}
+
+// parseDateTime parses a date with an optional time, such as tomorrow 9am.
+// Dates without a time are at dateparse.DefaultReminderHour.
+func parseDateTime(input string) (time.Time, error) {
+	return dateparse.ParseDateTime(input, currentTime())
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/robdimsdale/wl/export"
+	"github.com/spf13/cobra"
+)
+
+const (
+	fileLongFlag        = "file"
+	filesLongFlag       = "files"
+	concurrencyLongFlag = "concurrency"
+)
+
+var (
+	// Flags
+	exportPath   string
+	exportFormat string
+	includeFiles bool
+	concurrency  int
+
+	// Commands
+	cmdExport = &cobra.Command{
+		Use:   "export",
+		Short: "exports the whole account to an archive",
+		Long: `export writes the user, lists, folders, positions, tasks, subtasks, notes,
+comments, reminders, memberships, webhooks and file metadata to an archive.
+The archive is JSON, or tar.gz if the file ends in .tar.gz or .tgz,
+and is written to stdout if no file is provided.
+File contents are only included with the files flag, which requires tar.gz.
+Progress is written to stderr.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			format := exportFormat
+			if format == "" {
+				format = export.FormatForPath(exportPath)
+			}
+
+			if format != export.FormatJSON && format != export.FormatTarGz {
+				fmt.Printf("format must be %s or %s\n\n", export.FormatJSON, export.FormatTarGz)
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			if includeFiles && format != export.FormatTarGz {
+				fmt.Printf("--%s requires %s format\n\n", filesLongFlag, export.FormatTarGz)
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			a, err := export.Export(newClient(cmd), export.Options{
+				Concurrency:         concurrency,
+				IncludeFileContents: includeFiles,
+				Progress: func(p export.Progress) {
+					fmt.Fprintf(os.Stderr, "exported %s %d/%d %s\n", p.Stage, p.Done, p.Total, p.Item)
+				},
+			})
+			if err != nil {
+				handleError(err)
+			}
+
+			var w io.Writer = os.Stdout
+			if exportPath != "" {
+				f, err := os.Create(exportPath)
+				if err != nil {
+					handleError(err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			err = export.Write(w, a, format)
+			if err != nil {
+				handleError(err)
+			}
+
+			if exportPath != "" {
+				fmt.Fprintf(os.Stderr, "account exported successfully to %s\n", exportPath)
+			}
+		},
+	}
+)
+
+func init() {
+	cmdExport.Flags().StringVar(&exportPath, fileLongFlag, "", "path of the archive. Defaults to stdout")
+	cmdExport.Flags().StringVar(&exportFormat, formatLongFlag, "", "archive format: json or tar.gz. Defaults to the extension of the file")
+	cmdExport.Flags().BoolVar(&includeFiles, filesLongFlag, false, "include the contents of files")
+	cmdExport.Flags().IntVar(&concurrency, concurrencyLongFlag, export.DefaultConcurrency, "number of lists or files fetched at once")
+}
@@ -43,7 +43,8 @@ var (
 				os.Exit(2)
 			}
 
-			renderOutput(newClient(cmd).UploadFile(
+			client := newClient(cmd)
+			renderOutput(client)(client.UploadFile(
 				localFilePath,
 				remoteName,
 				contentType,
@@ -81,7 +82,8 @@ var (
 			}
 			taskID := uint(taskIDInt)
 
-			renderOutput(newClient(cmd).CreateFile(
+			client := newClient(cmd)
+			renderOutput(client)(client.CreateFile(
 				uploadID,
 				taskID,
 			))
@@ -108,7 +110,8 @@ var (
 			}
 			fileID := uint(fileIDInt)
 
-			renderOutput(newClient(cmd).File(
+			client := newClient(cmd)
+			renderOutput(client)(client.File(
 				fileID,
 			))
 		},
@@ -121,11 +124,14 @@ var (
         `,
 		Run: func(cmd *cobra.Command, args []string) {
 			if taskID != 0 {
-				renderOutput(newClient(cmd).FilesForTaskID(taskID))
+				client := newClient(cmd)
+				renderOutput(client)(client.FilesForTaskID(taskID))
 			} else if listID != 0 {
-				renderOutput(newClient(cmd).FilesForListID(listID))
+				client := newClient(cmd)
+				renderOutput(client)(client.FilesForListID(listID))
 			} else {
-				renderOutput(newClient(cmd).Files())
+				client := newClient(cmd)
+				renderOutput(client)(client.Files())
 			}
 		},
 	}
@@ -187,7 +193,8 @@ var (
 			}
 			fileID := uint(fileIDInt)
 
-			renderOutput(newClient(cmd).FilePreview(
+			client := newClient(cmd)
+			renderOutput(client)(client.FilePreview(
 				fileID,
 				filePreviewPlatform,
 				filePreviewSize,
@@ -198,6 +205,7 @@ var (
 
 func init() {
 	cmdFiles.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "filter by listID")
+	cmdFiles.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 	cmdFiles.Flags().UintVarP(&taskID, taskIDLongFlag, taskIDShortFlag, 0, "filter by taskID")
 	cmdFilePreview.Flags().StringVar(&filePreviewSize, filePreviewSizeLongFlag, "", "obtain preview for specific size")
 	cmdFilePreview.Flags().StringVar(&filePreviewPlatform, filePreviewPlatformLongFlag, "", "obtain preview for specific platform")
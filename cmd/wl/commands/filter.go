@@ -0,0 +1,247 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/filter"
+)
+
+const (
+	filterLongFlag  = "filter"
+	sortByLongFlag  = "sort-by"
+	groupByLongFlag = "group-by"
+
+	groupByList     = "list"
+	groupByFolder   = "folder"
+	groupByAssignee = "assignee"
+	groupByDueDate  = "due"
+)
+
+var (
+	// Flags
+	filterExpression string
+	sortBy           string
+	groupBy          string
+)
+
+// taskGroup is used to render tasks grouped by list, folder, assignee or due date.
+type taskGroup struct {
+	Group string    `json:"group" yaml:"group"`
+	Tasks []wl.Task `json:"tasks" yaml:"tasks"`
+}
+
+// filterEnv returns the environment in which filter expressions are evaluated.
+// The current user is only fetched if a filter expression is provided.
+func filterEnv(client wl.Client) (filter.Env, error) {
+	env := filter.Env{Now: currentTime()}
+	if filterExpression == "" {
+		return env, nil
+	}
+
+	user, err := client.User()
+	if err != nil {
+		return filter.Env{}, err
+	}
+	env.UserID = user.ID
+
+	return env, nil
+}
+
+func sortKeys() []string {
+	if sortBy == "" {
+		return nil
+	}
+
+	keys := strings.Split(sortBy, ",")
+	for i, k := range keys {
+		keys[i] = strings.TrimSpace(k)
+	}
+	return keys
+}
+
+// filterTasks applies the filter and sort-by flags to the tasks.
+func filterTasks(client wl.Client, tasks []wl.Task) ([]wl.Task, error) {
+	env, err := filterEnv(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if filterExpression != "" {
+		f, err := filter.Parse(filterExpression)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks, err = f.Tasks(tasks, env)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if keys := sortKeys(); keys != nil {
+		err = filter.SortTasks(tasks, keys, env)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tasks, nil
+}
+
+// filterSubtasks applies the filter and sort-by flags to the subtasks.
+func filterSubtasks(client wl.Client, subtasks []wl.Subtask) ([]wl.Subtask, error) {
+	env, err := filterEnv(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if filterExpression != "" {
+		f, err := filter.Parse(filterExpression)
+		if err != nil {
+			return nil, err
+		}
+
+		subtasks, err = f.Subtasks(subtasks, env)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if keys := sortKeys(); keys != nil {
+		err = filter.SortSubtasks(subtasks, keys, env)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return subtasks, nil
+}
+
+// filterReminders applies the filter and sort-by flags to the reminders.
+func filterReminders(client wl.Client, reminders []wl.Reminder) ([]wl.Reminder, error) {
+	env, err := filterEnv(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if filterExpression != "" {
+		f, err := filter.Parse(filterExpression)
+		if err != nil {
+			return nil, err
+		}
+
+		reminders, err = f.Reminders(reminders, env)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if keys := sortKeys(); keys != nil {
+		err = filter.SortReminders(reminders, keys, env)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return reminders, nil
+}
+
+// groupTasks groups the tasks by the group-by flag.
+// Groups are ordered by the first appearance of a task in each group,
+// so sorting the tasks beforehand also sorts the groups.
+func groupTasks(client wl.Client, tasks []wl.Task) ([]taskGroup, error) {
+	var groupName func(t wl.Task) string
+
+	switch groupBy {
+	case groupByList:
+		lists, err := client.Lists()
+		if err != nil {
+			return nil, err
+		}
+		titles := map[uint]string{}
+		for _, l := range lists {
+			titles[l.ID] = l.Title
+		}
+
+		groupName = func(t wl.Task) string {
+			if title, ok := titles[t.ListID]; ok {
+				return title
+			}
+			return fmt.Sprintf("%d", t.ListID)
+		}
+
+	case groupByFolder:
+		folders, err := client.Folders()
+		if err != nil {
+			return nil, err
+		}
+		titles := map[uint]string{}
+		for _, f := range folders {
+			for _, id := range f.ListIDs {
+				titles[id] = f.Title
+			}
+		}
+
+		groupName = func(t wl.Task) string {
+			if title, ok := titles[t.ListID]; ok {
+				return title
+			}
+			return "(no folder)"
+		}
+
+	case groupByAssignee:
+		users, err := client.Users()
+		if err != nil {
+			return nil, err
+		}
+		names := map[uint]string{}
+		for _, u := range users {
+			names[u.ID] = u.Name
+		}
+
+		groupName = func(t wl.Task) string {
+			if t.AssigneeID == 0 {
+				return "(unassigned)"
+			}
+			if name, ok := names[t.AssigneeID]; ok {
+				return name
+			}
+			return fmt.Sprintf("%d", t.AssigneeID)
+		}
+
+	case groupByDueDate:
+		groupName = func(t wl.Task) string {
+			if t.DueDate.IsZero() {
+				return "(no due date)"
+			}
+			return t.DueDate.String()
+		}
+
+	default:
+		return nil, fmt.Errorf(
+			"unrecognized group-by: %s - must be one of %s, %s, %s or %s",
+			groupBy,
+			groupByList,
+			groupByFolder,
+			groupByAssignee,
+			groupByDueDate,
+		)
+	}
+
+	groups := []taskGroup{}
+	indices := map[string]int{}
+	for _, t := range tasks {
+		name := groupName(t)
+		i, ok := indices[name]
+		if !ok {
+			i = len(groups)
+			indices[name] = i
+			groups = append(groups, taskGroup{Group: name, Tasks: []wl.Task{}})
+		}
+		groups[i].Tasks = append(groups[i].Tasks, t)
+	}
+
+	return groups, nil
+}
@@ -3,7 +3,6 @@ package commands
 import (
 	"fmt"
 	"os"
-	"strconv"
 
 	"github.com/robdimsdale/wl"
 	"github.com/spf13/cobra"
@@ -17,17 +16,19 @@ var (
 		Long: `folders gets the user's folders.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(newClient(cmd).Folders())
+			client := newClient(cmd)
+			renderOutput(client)(client.Folders())
 		},
 	}
 
 	cmdFolder = &cobra.Command{
 		Use:   "folder <folder-id>",
 		Short: "gets the folder for the provided folder id",
-		Long: `folder gets a folder specified by <folder-id>
+		Long: `folder gets a folder specified by <folder-id>, which may also be the folder title.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(folder(cmd, args))
+			client := newClient(cmd)
+			renderOutput(client)(folder(cmd, client, args))
 		},
 	}
 
@@ -48,7 +49,8 @@ var (
 				os.Exit(2)
 			}
 
-			renderOutput(newClient(cmd).CreateFolder(
+			client := newClient(cmd)
+			renderOutput(client)(client.CreateFolder(
 				title,
 				listIDsUints,
 			))
@@ -62,7 +64,8 @@ var (
 and updates fields with the provided flags.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			folder, err := folder(cmd, args)
+			client := newClient(cmd)
+			folder, err := folder(cmd, client, args)
 			if err != nil {
 				handleError(err)
 			}
@@ -81,24 +84,25 @@ and updates fields with the provided flags.
 				folder.ListIDs = listIDsUints
 			}
 
-			renderOutput(newClient(cmd).UpdateFolder(folder))
+			renderOutput(client)(client.UpdateFolder(folder))
 		},
 	}
 
 	cmdDeleteFolder = &cobra.Command{
 		Use:   "delete-folder <folder-id>",
 		Short: "deletes the folder for the provided folder id",
-		Long: `delete-folder deletes the folder specified by <folder-id>
+		Long: `delete-folder deletes the folder specified by <folder-id>, which may also be the folder title.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			folder, err := folder(cmd, args)
+			client := newClient(cmd)
+			folder, err := folder(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting folder: %v\n\n", err)
 				cmd.Usage()
 				os.Exit(2)
 			}
 
-			err = newClient(cmd).DeleteFolder(folder)
+			err = client.DeleteFolder(folder)
 			if err != nil {
 				handleError(err)
 			}
@@ -131,20 +135,6 @@ func init() {
 	cmdUpdateFolder.Flags().StringVar(&listIDs, listIDsLongFlag, "", "comma-separated list IDs (required)")
 }
 
-func folder(cmd *cobra.Command, args []string) (wl.Folder, error) {
-	if len(args) != 1 {
-		fmt.Printf("incorrect number of arguments provided\n\n")
-		cmd.Usage()
-		os.Exit(2)
-	}
-
-	idInt, err := strconv.Atoi(args[0])
-	if err != nil {
-		fmt.Printf("error parsing folderID: %v\n\n", err)
-		cmd.Usage()
-		os.Exit(2)
-	}
-	id := uint(idInt)
-
-	return newClient(cmd).Folder(id)
+func folder(cmd *cobra.Command, client wl.Client, args []string) (wl.Folder, error) {
+	return client.Folder(folderArg(cmd, client, args))
 }
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/robdimsdale/wl/htmlreport"
+	"github.com/robdimsdale/wl/tree"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags
+	htmlReportPath string
+
+	// Commands
+	cmdHTMLReport = &cobra.Command{
+		Use:   "html-report",
+		Short: "generates an HTML report of lists",
+		Long: `html-report writes a self-contained HTML page for the folder, or for all lists
+if no folder is provided, which can be published without any other files.
+
+Each list has a progress bar of its completed tasks and a table of its incomplete
+tasks, with overdue tasks highlighted, assignees shown with their avatars, and
+subtasks and notes in collapsible sections.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient(cmd)
+
+			var t tree.Tree
+			var err error
+			if folderID != 0 {
+				t, err = tree.LoadFolder(client, folderID, tree.Options{})
+			} else {
+				t, err = tree.LoadAll(client, tree.Options{})
+			}
+			if err != nil {
+				handleError(err)
+			}
+
+			r, err := htmlreport.Build(client, t, htmlreport.Options{Now: currentTime()})
+			if err != nil {
+				handleError(err)
+			}
+
+			var w io.Writer = os.Stdout
+			if htmlReportPath != "" {
+				f, err := os.Create(htmlReportPath)
+				if err != nil {
+					handleError(err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			err = htmlreport.Write(w, r)
+			if err != nil {
+				handleError(err)
+			}
+
+			if htmlReportPath != "" {
+				fmt.Fprintf(os.Stderr, "report of %d lists written successfully to %s\n", len(r.Lists), htmlReportPath)
+			}
+		},
+	}
+)
+
+func init() {
+	cmdHTMLReport.Flags().StringVar(&folderName, folderLongFlag, "", "title or ID of folder to report on. Defaults to all lists")
+	cmdHTMLReport.Flags().StringVar(&htmlReportPath, fileLongFlag, "", "path of the HTML file. Defaults to stdout")
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/robdimsdale/wl/ical"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultCalendarName = "Wunderlist"
+)
+
+var (
+	// Flags
+	icalPath      string
+	icalCompleted bool
+
+	// Commands
+	cmdIcal = &cobra.Command{
+		Use:   "ical",
+		Short: "exports tasks as an iCalendar file",
+		Long: `ical writes tasks as VTODOs in an RFC 5545 calendar, which calendar clients
+can import or subscribe to. The calendar contains the tasks in the list or folder,
+or in all lists if neither is provided. Run once per list or folder to create a
+calendar for each.
+
+Due dates, completion, notes, reminders, recurrence and stars are included.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			if listID != 0 && folderID != 0 {
+				fmt.Printf("only one of --%s and --%s may be provided\n\n", listIDLongFlag, folderLongFlag)
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			client := newClient(cmd)
+
+			name := defaultCalendarName
+			var listIDs []uint
+
+			switch {
+			case listID != 0:
+				l, err := client.List(listID)
+				if err != nil {
+					handleError(err)
+				}
+				name = l.Title
+				listIDs = []uint{l.ID}
+
+			case folderID != 0:
+				f, err := client.Folder(folderID)
+				if err != nil {
+					handleError(err)
+				}
+				name = f.Title
+				listIDs = f.ListIDs
+
+			default:
+				lists, err := client.Lists()
+				if err != nil {
+					handleError(err)
+				}
+				for _, l := range lists {
+					listIDs = append(listIDs, l.ID)
+				}
+			}
+
+			c, err := ical.NewCalendar(client, name, listIDs, icalCompleted)
+			if err != nil {
+				handleError(err)
+			}
+
+			var w io.Writer = os.Stdout
+			if icalPath != "" {
+				f, err := os.Create(icalPath)
+				if err != nil {
+					handleError(err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			err = c.Write(w, currentTime())
+			if err != nil {
+				handleError(err)
+			}
+
+			if icalPath != "" {
+				fmt.Fprintf(os.Stderr, "%d tasks exported successfully to %s\n", len(c.Todos), icalPath)
+			}
+		},
+	}
+)
+
+func init() {
+	cmdIcal.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "list to export")
+	cmdIcal.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
+	cmdIcal.Flags().StringVar(&folderName, folderLongFlag, "", "title or ID of folder to export")
+	cmdIcal.Flags().BoolVar(&icalCompleted, completedLongFlag, false, "include completed tasks")
+	cmdIcal.Flags().StringVar(&icalPath, fileLongFlag, "", "path of the calendar file. Defaults to stdout")
+}
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/robdimsdale/wl/importer"
+	"github.com/spf13/cobra"
+)
+
+const (
+	folderMapLongFlag = "folder-map"
+)
+
+var (
+	// Flags
+	importFormat string
+	folderMap    string
+
+	// Commands
+	cmdImport = &cobra.Command{
+		Use:   "import <file>...",
+		Short: "imports lists and tasks from another format",
+		Long: `import creates the folders, lists, tasks, subtasks, notes and reminders in
+each <file>. Supported formats are:
+
+  wunderlist-backup  a backup created with "Create Backup" in the Wunderlist apps
+  trello             a Trello board exported as JSON. The board becomes a folder,
+                     lists become lists, cards become tasks, checklist items
+                     become subtasks and descriptions become notes
+  todoist            a Todoist project exported as CSV. The project becomes a
+                     folder named after the file, and each section a list
+
+Folders can be renamed with --folder-map, e.g. --folder-map "Board A=Work,Board B="
+imports the lists of "Board A" into the folder "Work", and those of "Board B"
+without a folder.
+
+Lists with the same title as an existing list, ignoring case, are added to
+the existing list, and tasks with the same title as a task already in that
+list are skipped. Lists in a folder only match lists in a folder with the same
+title. The inbox is imported into the existing inbox.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				fmt.Printf("incorrect number of arguments provided\n\n")
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			if importFormat == "" {
+				fmt.Printf("--%s must be provided\n\n", formatLongFlag)
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			source, err := importer.SourceFor(importFormat)
+			if err != nil {
+				fmt.Printf("%v\n\n", err)
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			// Dates are read in the configured timezone.
+			switch s := source.(type) {
+			case importer.Trello:
+				s.Location = location()
+				source = s
+			case importer.Todoist:
+				s.Now = currentTime()
+				source = s
+			}
+
+			var mapping map[string]string
+			if folderMap != "" {
+				mapping, err = splitFolderMap(folderMap)
+				if err != nil {
+					fmt.Printf("error parsing %s: %v\n\n", folderMapLongFlag, err)
+					cmd.Usage()
+					os.Exit(2)
+				}
+			}
+
+			var data importer.Data
+			for _, path := range args {
+				d, err := readImportFile(source, path)
+				if err != nil {
+					handleError(err)
+				}
+				data.Lists = append(data.Lists, d.Lists...)
+			}
+
+			if mapping != nil {
+				data = importer.MapFolders(data, mapping)
+			}
+
+			client := newClient(cmd)
+
+			plan, err := importer.NewPlan(client, data)
+			if err != nil {
+				handleError(err)
+			}
+
+			if dryRun {
+				renderOutput(client)(plan, nil)
+				return
+			}
+
+			renderOutput(client)(importer.Apply(client, plan))
+		},
+	}
+)
+
+func init() {
+	cmdImport.Flags().StringVar(&importFormat, formatLongFlag, "", "format of the files: "+strings.Join(importer.Formats(), ", "))
+	cmdImport.Flags().StringVar(&folderMap, folderMapLongFlag, "", `comma-separated "from=to" folder titles. An empty "to" imports without a folder`)
+	cmdImport.Flags().BoolVar(&dryRun, dryRunLongFlag, false, "print the folders, lists and tasks which would be created without changing anything")
+}
+
+// readImportFile reads the file, named without its extension.
+func readImportFile(source importer.Source, path string) (importer.Data, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return importer.Data{}, err
+	}
+	defer f.Close()
+
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+
+	return source.Read(f, name)
+}
+
+// splitFolderMap parses comma-separated "from=to" pairs.
+func splitFolderMap(input string) (map[string]string, error) {
+	mapping := map[string]string{}
+	for i, pair := range strings.Split(input, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("expected from=to at index %d", i)
+		}
+		mapping[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return mapping, nil
+}
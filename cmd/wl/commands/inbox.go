@@ -11,7 +11,8 @@ var (
         It cannot be deleted.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(newClient(cmd).Inbox())
+			client := newClient(cmd)
+			renderOutput(client)(client.Inbox())
 		},
 	}
 )
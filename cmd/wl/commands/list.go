@@ -3,7 +3,6 @@ package commands
 import (
 	"fmt"
 	"os"
-	"strconv"
 
 	"github.com/robdimsdale/wl"
 	"github.com/spf13/cobra"
@@ -17,17 +16,40 @@ var (
 		Long: `lists gets the user's lists.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(newClient(cmd).Lists())
+			client := newClient(cmd)
+
+			lists, err := client.Lists()
+			if err != nil {
+				handleError(err)
+			}
+
+			if cmd.Flags().Changed(folderLongFlag) {
+				listIDs, err := inFolder(client)
+				if err != nil {
+					handleError(err)
+				}
+
+				var folderLists []wl.List
+				for _, l := range lists {
+					if listIDs[l.ID] {
+						folderLists = append(folderLists, l)
+					}
+				}
+				lists = folderLists
+			}
+
+			renderOutput(client)(lists, nil)
 		},
 	}
 
 	cmdList = &cobra.Command{
 		Use:   "list <list-id>",
 		Short: "gets the list for the provided list id",
-		Long: `list gets a list specified by <list-id>
+		Long: `list gets a list specified by <list-id>, which may also be the list title.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(list(cmd, args))
+			client := newClient(cmd)
+			renderOutput(client)(list(cmd, client, args))
 		},
 	}
 
@@ -45,7 +67,8 @@ var (
 
 			title := args[0]
 
-			renderOutput(newClient(cmd).CreateList(
+			client := newClient(cmd)
+			renderOutput(client)(client.CreateList(
 				title,
 			))
 		},
@@ -55,10 +78,12 @@ var (
 		Use:   "update-list <list-id> [flags]",
 		Short: "updates the list",
 		Long: `update-list obtains the current state of the list specified by <list-id>,
+which may also be the list title,
 and updates fields with the provided flags.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			list, err := list(cmd, args)
+			client := newClient(cmd)
+			list, err := list(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting list: %v\n\n", err)
 				cmd.Usage()
@@ -69,24 +94,25 @@ and updates fields with the provided flags.
 				list.Title = title
 			}
 
-			renderOutput(newClient(cmd).UpdateList(list))
+			renderOutput(client)(client.UpdateList(list))
 		},
 	}
 
 	cmdDeleteList = &cobra.Command{
 		Use:   "delete-list <list-id>",
 		Short: "deletes the list for the provided list id",
-		Long: `delete-list deletes the list specified by <list-id>
+		Long: `delete-list deletes the list specified by <list-id>, which may also be the list title.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			list, err := list(cmd, args)
+			client := newClient(cmd)
+			list, err := list(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting list: %v\n\n", err)
 				cmd.Usage()
 				os.Exit(2)
 			}
 
-			err = newClient(cmd).DeleteList(list)
+			err = client.DeleteList(list)
 			if err != nil {
 				handleError(err)
 			}
@@ -114,23 +140,11 @@ and updates fields with the provided flags.
 )
 
 func init() {
+	cmdLists.Flags().StringVar(&folderName, folderLongFlag, "", "filter by folder title or ID")
+
 	cmdUpdateList.Flags().StringVar(&title, titleLongFlag, "", "title of list")
 }
 
-func list(cmd *cobra.Command, args []string) (wl.List, error) {
-	if len(args) != 1 {
-		fmt.Printf("incorrect number of arguments provided\n\n")
-		cmd.Usage()
-		os.Exit(2)
-	}
-
-	idInt, err := strconv.Atoi(args[0])
-	if err != nil {
-		fmt.Printf("error parsing list ID: %v\n\n", err)
-		cmd.Usage()
-		os.Exit(2)
-	}
-	id := uint(idInt)
-
-	return newClient(cmd).List(id)
+func list(cmd *cobra.Command, client wl.Client, args []string) (wl.List, error) {
+	return client.List(listArg(cmd, client, args))
 }
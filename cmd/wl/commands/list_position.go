@@ -17,7 +17,8 @@ var (
 		Long: `list-positions gets the positions of the user's lists.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(newClient(cmd).ListPositions())
+			client := newClient(cmd)
+			renderOutput(client)(client.ListPositions())
 		},
 	}
 
@@ -27,7 +28,8 @@ var (
 		Long: `list-position gets a list-position specified by <list-position-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(listPosition(cmd, args))
+			client := newClient(cmd)
+			renderOutput(client)(listPosition(cmd, client, args))
 		},
 	}
 
@@ -38,7 +40,8 @@ var (
 and updates fields with the provided flags.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			listPosition, err := listPosition(cmd, args)
+			client := newClient(cmd)
+			listPosition, err := listPosition(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting list position: %v\n\n", err)
 				cmd.Usage()
@@ -55,7 +58,7 @@ and updates fields with the provided flags.
 				listPosition.Values = listIDsUints
 			}
 
-			renderOutput(newClient(cmd).UpdateListPosition(listPosition))
+			renderOutput(client)(client.UpdateListPosition(listPosition))
 		},
 	}
 )
@@ -64,7 +67,7 @@ func init() {
 	cmdUpdateListPosition.Flags().StringVar(&listIDs, listIDsLongFlag, "", "comma-separated list IDs (required)")
 }
 
-func listPosition(cmd *cobra.Command, args []string) (wl.Position, error) {
+func listPosition(cmd *cobra.Command, client wl.Client, args []string) (wl.Position, error) {
 	if len(args) != 1 {
 		fmt.Printf("incorrect number of arguments provided\n\n")
 		cmd.Usage()
@@ -79,5 +82,5 @@ func listPosition(cmd *cobra.Command, args []string) (wl.Position, error) {
 	}
 	id := uint(idInt)
 
-	return newClient(cmd).ListPosition(id)
+	return client.ListPosition(id)
 }
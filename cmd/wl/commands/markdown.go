@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/robdimsdale/wl/tree"
+	"github.com/spf13/cobra"
+)
+
+const (
+	commentsLongFlag = "comments"
+)
+
+var (
+	// Flags
+	markdownPath      string
+	markdownCompleted bool
+	markdownComments  bool
+
+	// Commands
+	cmdExportMarkdown = &cobra.Command{
+		Use:   "export-markdown",
+		Short: "exports lists as Markdown",
+		Long: `export-markdown renders the list or folder, or all lists if neither is
+provided, as Markdown for pasting into documents and wikis.
+
+Each list has a heading, and its tasks are a task list in position order with
+due dates and assignees inline. Notes and comments are quoted below their task,
+followed by its subtasks as a nested task list.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			if listID != 0 && folderID != 0 {
+				fmt.Printf("only one of --%s and --%s may be provided\n\n", listIDLongFlag, folderLongFlag)
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			client := newClient(cmd)
+			opts := tree.Options{Completed: markdownCompleted, Comments: markdownComments}
+
+			var t tree.Tree
+			var err error
+			switch {
+			case listID != 0:
+				t, err = tree.Load(client, []uint{listID}, opts)
+			case folderID != 0:
+				t, err = tree.LoadFolder(client, folderID, opts)
+			default:
+				t, err = tree.LoadAll(client, opts)
+			}
+			if err != nil {
+				handleError(err)
+			}
+
+			var w io.Writer = os.Stdout
+			if markdownPath != "" {
+				f, err := os.Create(markdownPath)
+				if err != nil {
+					handleError(err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			err = tree.WriteMarkdown(w, t, location())
+			if err != nil {
+				handleError(err)
+			}
+
+			if markdownPath != "" {
+				fmt.Fprintf(os.Stderr, "%d lists exported successfully to %s\n", len(t.Lists), markdownPath)
+			}
+		},
+	}
+)
+
+func init() {
+	cmdExportMarkdown.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "list to export")
+	cmdExportMarkdown.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
+	cmdExportMarkdown.Flags().StringVar(&folderName, folderLongFlag, "", "title or ID of folder to export")
+	cmdExportMarkdown.Flags().BoolVar(&markdownCompleted, completedLongFlag, false, "include completed tasks")
+	cmdExportMarkdown.Flags().BoolVar(&markdownComments, commentsLongFlag, false, "include task comments")
+	cmdExportMarkdown.Flags().StringVar(&markdownPath, fileLongFlag, "", "path of the Markdown file. Defaults to stdout")
+}
@@ -34,9 +34,11 @@ var (
 			// not just non-completed ones.
 
 			if listID == 0 {
-				renderOutput(newClient(cmd).Memberships())
+				client := newClient(cmd)
+				renderOutput(client)(client.Memberships())
 			} else {
-				renderOutput(newClient(cmd).MembershipsForListID(listID))
+				client := newClient(cmd)
+				renderOutput(client)(client.MembershipsForListID(listID))
 			}
 		},
 	}
@@ -47,7 +49,8 @@ var (
 		Long: `membership gets a membership specified by <membership-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(membership(cmd, args))
+			client := newClient(cmd)
+			renderOutput(client)(membership(cmd, client, args))
 		},
 	}
 
@@ -59,13 +62,15 @@ User must be identified by providing either ` + userIDLongFlag + ` or ` + emailA
         `,
 		Run: func(cmd *cobra.Command, args []string) {
 			if cmd.Flags().Changed(userIDLongFlag) {
-				renderOutput(newClient(cmd).AddMemberToListViaUserID(
+				client := newClient(cmd)
+				renderOutput(client)(client.AddMemberToListViaUserID(
 					userID,
 					listID,
 					muted,
 				))
 			} else if cmd.Flags().Changed(emailAddressLongFlag) {
-				renderOutput(newClient(cmd).AddMemberToListViaEmailAddress(
+				client := newClient(cmd)
+				renderOutput(client)(client.AddMemberToListViaEmailAddress(
 					emailAddress,
 					listID,
 					muted,
@@ -83,14 +88,15 @@ User must be identified by providing either ` + userIDLongFlag + ` or ` + emailA
 		Long: `accept-member rejects the invite for the membership specified by <membership-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			membership, err := membership(cmd, args)
+			client := newClient(cmd)
+			membership, err := membership(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting membership: %v\n\n", err)
 				cmd.Usage()
 				os.Exit(2)
 			}
 
-			renderOutput(newClient(cmd).AcceptMember(membership))
+			renderOutput(client)(client.AcceptMember(membership))
 		},
 	}
 
@@ -100,14 +106,15 @@ User must be identified by providing either ` + userIDLongFlag + ` or ` + emailA
 		Long: `remove-membership removes membership specified by <membership-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			membership, err := membership(cmd, args)
+			client := newClient(cmd)
+			membership, err := membership(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting membership: %v\n\n", err)
 				cmd.Usage()
 				os.Exit(2)
 			}
 
-			err = newClient(cmd).RemoveMemberFromList(membership)
+			err = client.RemoveMemberFromList(membership)
 			if err != nil {
 				handleError(err)
 			}
@@ -122,14 +129,15 @@ User must be identified by providing either ` + userIDLongFlag + ` or ` + emailA
 		Long: `reject-membership rejects the invite for the membership specified by <membership-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			membership, err := membership(cmd, args)
+			client := newClient(cmd)
+			membership, err := membership(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting membership: %v\n\n", err)
 				cmd.Usage()
 				os.Exit(2)
 			}
 
-			err = newClient(cmd).RejectInvite(membership)
+			err = client.RejectInvite(membership)
 			if err != nil {
 				handleError(err)
 			}
@@ -141,14 +149,16 @@ User must be identified by providing either ` + userIDLongFlag + ` or ` + emailA
 
 func init() {
 	cmdMemberships.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "filter by listID")
+	cmdMemberships.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 
 	cmdInviteMember.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "list to which membership will belong")
+	cmdInviteMember.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 	cmdInviteMember.Flags().UintVar(&userID, userIDLongFlag, 0, "identify user by userID")
 	cmdInviteMember.Flags().StringVar(&emailAddress, emailAddressLongFlag, "", "identify user by emailAddress")
 	cmdInviteMember.Flags().BoolVar(&muted, mutedLongFlag, false, "user is muted by default")
 }
 
-func membership(cmd *cobra.Command, args []string) (wl.Membership, error) {
+func membership(cmd *cobra.Command, client wl.Client, args []string) (wl.Membership, error) {
 	if len(args) != 1 {
 		fmt.Printf("incorrect number of arguments provided\n\n")
 		cmd.Usage()
@@ -163,5 +173,5 @@ func membership(cmd *cobra.Command, args []string) (wl.Membership, error) {
 	}
 	id := uint(idInt)
 
-	return newClient(cmd).Membership(id)
+	return client.Membership(id)
 }
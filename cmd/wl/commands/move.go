@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/robdimsdale/wl/position"
+	"github.com/spf13/cobra"
+)
+
+const (
+	beforeLongFlag = "before"
+	afterLongFlag  = "after"
+	topLongFlag    = "top"
+	bottomLongFlag = "bottom"
+)
+
+var (
+	// Flags
+	beforeID uint
+	afterID  uint
+	toTop    bool
+	toBottom bool
+
+	// Commands
+	cmdMoveTask = &cobra.Command{
+		Use:   "move-task <task-id> [flags]",
+		Short: "moves the task within its list, or to another list",
+		Long: `move-task moves the task specified by <task-id> to the position
+specified by exactly one of --before, --after, --top or --bottom.
+If --listID is provided the task is moved to that list, at the top
+unless otherwise specified.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			id := moveArg(cmd, args)
+
+			if cmd.Flags().Changed(listIDLongFlag) {
+				placement, err := parsePlacement(cmd, true)
+				if err != nil {
+					moveUsage(cmd, err)
+				}
+				client := newClient(cmd)
+				renderOutput(client)(position.NewMover(client).MoveTaskToList(id, listID, placement))
+				return
+			}
+
+			placement, err := parsePlacement(cmd, false)
+			if err != nil {
+				moveUsage(cmd, err)
+			}
+			client := newClient(cmd)
+			renderOutput(client)(position.NewMover(client).MoveTask(id, placement))
+		},
+	}
+
+	cmdMoveList = &cobra.Command{
+		Use:   "move-list <list-id> [flags]",
+		Short: "moves the list",
+		Long: `move-list moves the list specified by <list-id> to the position
+specified by exactly one of --before, --after, --top or --bottom.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			id := moveArg(cmd, args)
+
+			placement, err := parsePlacement(cmd, false)
+			if err != nil {
+				moveUsage(cmd, err)
+			}
+			client := newClient(cmd)
+			renderOutput(client)(position.NewMover(client).MoveList(id, placement))
+		},
+	}
+
+	cmdMoveSubtask = &cobra.Command{
+		Use:   "move-subtask <subtask-id> [flags]",
+		Short: "moves the subtask within its task",
+		Long: `move-subtask moves the subtask specified by <subtask-id> to the position
+specified by exactly one of --before, --after, --top or --bottom.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			id := moveArg(cmd, args)
+
+			placement, err := parsePlacement(cmd, false)
+			if err != nil {
+				moveUsage(cmd, err)
+			}
+			client := newClient(cmd)
+			renderOutput(client)(position.NewMover(client).MoveSubtask(id, placement))
+		},
+	}
+)
+
+func init() {
+	for _, c := range []*cobra.Command{cmdMoveTask, cmdMoveList, cmdMoveSubtask} {
+		c.Flags().UintVar(&beforeID, beforeLongFlag, 0, "id of the item to move before")
+		c.Flags().UintVar(&afterID, afterLongFlag, 0, "id of the item to move after")
+		c.Flags().BoolVar(&toTop, topLongFlag, false, "move to the top")
+		c.Flags().BoolVar(&toBottom, bottomLongFlag, false, "move to the bottom")
+	}
+
+	cmdMoveTask.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "id of list to move the task to")
+	cmdMoveTask.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
+}
+
+func moveArg(cmd *cobra.Command, args []string) uint {
+	if len(args) != 1 {
+		fmt.Printf("incorrect number of arguments provided\n\n")
+		cmd.Usage()
+		os.Exit(2)
+	}
+
+	idInt, err := strconv.Atoi(args[0])
+	if err != nil {
+		fmt.Printf("error parsing ID: %v\n\n", err)
+		cmd.Usage()
+		os.Exit(2)
+	}
+	return uint(idInt)
+}
+
+func moveUsage(cmd *cobra.Command, err error) {
+	fmt.Printf("%v\n\n", err)
+	cmd.Usage()
+	os.Exit(2)
+}
+
+// parsePlacement returns the placement specified by the flags.
+// If none are specified, the top is returned if defaultToTop is true.
+func parsePlacement(cmd *cobra.Command, defaultToTop bool) (position.Placement, error) {
+	placements := []position.Placement{}
+
+	if cmd.Flags().Changed(beforeLongFlag) {
+		placements = append(placements, position.Before(beforeID))
+	}
+	if cmd.Flags().Changed(afterLongFlag) {
+		placements = append(placements, position.After(afterID))
+	}
+	if toTop {
+		placements = append(placements, position.Top())
+	}
+	if toBottom {
+		placements = append(placements, position.Bottom())
+	}
+
+	switch len(placements) {
+	case 1:
+		return placements[0], nil
+	case 0:
+		if defaultToTop {
+			return position.Top(), nil
+		}
+	}
+
+	return position.Placement{}, errors.New("exactly one of --before, --after, --top or --bottom must be provided")
+}
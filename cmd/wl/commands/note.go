@@ -25,11 +25,14 @@ var (
         `,
 		Run: func(cmd *cobra.Command, args []string) {
 			if taskID != 0 {
-				renderOutput(newClient(cmd).NotesForTaskID(taskID))
+				client := newClient(cmd)
+				renderOutput(client)(client.NotesForTaskID(taskID))
 			} else if listID != 0 {
-				renderOutput(newClient(cmd).NotesForListID(listID))
+				client := newClient(cmd)
+				renderOutput(client)(client.NotesForListID(listID))
 			} else {
-				renderOutput(newClient(cmd).Notes())
+				client := newClient(cmd)
+				renderOutput(client)(client.Notes())
 			}
 		},
 	}
@@ -40,7 +43,8 @@ var (
 		Long: `note gets a note specified by <note-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(note(cmd, args))
+			client := newClient(cmd)
+			renderOutput(client)(note(cmd, client, args))
 		},
 	}
 
@@ -50,7 +54,8 @@ var (
 		Long: `create-note creates a note with the specified args
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(newClient(cmd).CreateNote(
+			client := newClient(cmd)
+			renderOutput(client)(client.CreateNote(
 				content,
 				taskID,
 			))
@@ -64,7 +69,8 @@ var (
 and updates fields with the provided flags.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			note, err := note(cmd, args)
+			client := newClient(cmd)
+			note, err := note(cmd, client, args)
 			if err != nil {
 				handleError(err)
 			}
@@ -73,7 +79,7 @@ and updates fields with the provided flags.
 				note.Content = content
 			}
 
-			renderOutput(newClient(cmd).UpdateNote(note))
+			renderOutput(client)(client.UpdateNote(note))
 		},
 	}
 
@@ -83,14 +89,15 @@ and updates fields with the provided flags.
 		Long: `delete-note deletes the note specified by <note-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			note, err := note(cmd, args)
+			client := newClient(cmd)
+			note, err := note(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting note: %v\n\n", err)
 				cmd.Usage()
 				os.Exit(2)
 			}
 
-			err = newClient(cmd).DeleteNote(note)
+			err = client.DeleteNote(note)
 			if err != nil {
 				handleError(err)
 			}
@@ -103,6 +110,7 @@ and updates fields with the provided flags.
 func init() {
 	cmdNotes.Flags().UintVarP(&taskID, taskIDLongFlag, taskIDShortFlag, 0, "filter by taskID")
 	cmdNotes.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "filter by listID")
+	cmdNotes.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 
 	cmdCreateNote.Flags().UintVarP(&taskID, taskIDLongFlag, taskIDShortFlag, 0, "id of task to which note belongs")
 	cmdCreateNote.Flags().StringVar(&content, contentLongFlag, "", "note content")
@@ -110,7 +118,7 @@ func init() {
 	cmdUpdateNote.Flags().StringVar(&content, contentLongFlag, "", "note content")
 }
 
-func note(cmd *cobra.Command, args []string) (wl.Note, error) {
+func note(cmd *cobra.Command, client wl.Client, args []string) (wl.Note, error) {
 	if len(args) != 1 {
 		fmt.Printf("incorrect number of arguments provided\n\n")
 		cmd.Usage()
@@ -125,5 +133,5 @@ func note(cmd *cobra.Command, args []string) (wl.Note, error) {
 	}
 	id := uint(idInt)
 
-	return newClient(cmd).Note(id)
+	return client.Note(id)
 }
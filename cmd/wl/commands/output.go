@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/table"
+)
+
+const (
+	outputYAML     = "yaml"
+	outputJSON     = "json"
+	outputJSONL    = "jsonl"
+	outputTable    = "table"
+	outputCSV      = "csv"
+	outputIDs      = "ids"
+	outputTemplate = "template="
+
+	defaultTerminalWidth = 120
+)
+
+// write renders the output in the format specified by the output flag.
+// In table format, list and user IDs are resolved to names with the client,
+// unless it is nil.
+func write(w io.Writer, output interface{}, client wl.Client) error {
+	format := outputFormat
+	if format == "" {
+		format = outputYAML
+		if useJSON {
+			format = outputJSON
+		}
+	}
+
+	switch {
+	case format == outputYAML:
+		data, err := yaml.Marshal(output)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+
+	case format == outputJSON:
+		data, err := marshalJSON(output)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+
+	case format == outputJSONL:
+		for _, item := range table.Items(output) {
+			data, err := marshalJSON(item)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case format == outputTable:
+		t, err := table.New(output, columnsFlag(true))
+		if err != nil {
+			return err
+		}
+		return table.Write(w, t, table.Options{
+			Width:  terminalWidth(),
+			Now:    currentTime(),
+			Client: client,
+		})
+
+	case format == outputCSV:
+		t, err := table.New(output, columnsFlag(false))
+		if err != nil {
+			return err
+		}
+		return table.WriteCSV(w, t, location())
+
+	case format == outputIDs:
+		t, err := table.New(output, []string{})
+		if err != nil {
+			return err
+		}
+		return table.WriteIDs(w, t)
+
+	case strings.HasPrefix(format, outputTemplate):
+		tmpl, err := template.New("output").Parse(strings.TrimPrefix(format, outputTemplate))
+		if err != nil {
+			return err
+		}
+		for _, item := range table.Items(output) {
+			if err := tmpl.Execute(w, item); err != nil {
+				return err
+			}
+			fmt.Fprintln(w)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf(
+			"unrecognized output format: %s - must be one of %s",
+			format,
+			strings.Join([]string{outputYAML, outputJSON, outputJSONL, outputTable, outputCSV, outputIDs, outputTemplate + "<go-template>"}, ", "),
+		)
+	}
+}
+
+func marshalJSON(output interface{}) ([]byte, error) {
+	data, err := json.Marshal(output)
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, '\n')
+
+	// The JSON package escapes & which we do not want.
+	// It also escapes < and > but those are not present in URLs
+	return bytes.Replace(data, []byte("\\u0026"), []byte("&"), -1), nil
+}
+
+// columnsFlag returns the columns provided via --columns, or nil if the
+// flag is not provided and default columns should be used.
+func columnsFlag(useDefaults bool) []string {
+	if columns == "" {
+		if useDefaults {
+			return nil
+		}
+		return []string{}
+	}
+
+	split := strings.Split(columns, ",")
+	for i, c := range split {
+		split[i] = strings.TrimSpace(c)
+	}
+	return split
+}
+
+// terminalWidth returns the width provided by the COLUMNS environment variable,
+// or a default if it is not set.
+func terminalWidth() int {
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return defaultTerminalWidth
+}
@@ -24,18 +24,22 @@ var (
 		Long: `reminders gets the user's reminders.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			// Currently sending completed=false is the same as not sending completed
-			// Checking for whether the flag has changed protects us from potentially
-			// breaking changes, i.e. if the reminders endpoint decides to return all tasks,
-			// not just non-completed ones.
+			client := newClient(cmd)
 
+			var reminders []wl.Reminder
+			var err error
 			if taskID != 0 {
-				renderOutput(newClient(cmd).RemindersForTaskID(taskID))
+				reminders, err = client.RemindersForTaskID(taskID)
 			} else if listID != 0 {
-				renderOutput(newClient(cmd).RemindersForListID(listID))
+				reminders, err = client.RemindersForListID(listID)
 			} else {
-				renderOutput(newClient(cmd).Reminders())
+				reminders, err = client.Reminders()
 			}
+			if err != nil {
+				handleError(err)
+			}
+
+			renderOutput(client)(filterReminders(client, reminders))
 		},
 	}
 
@@ -45,7 +49,8 @@ var (
 		Long: `reminder gets a reminder specified by <reminder-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(reminder(cmd, args))
+			client := newClient(cmd)
+			renderOutput(client)(reminder(cmd, client, args))
 		},
 	}
 
@@ -55,8 +60,14 @@ var (
 		Long: `create-reminder creates a reminder with the specified args
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(newClient(cmd).CreateReminder(
-				date,
+			reminderDate, err := parseDateTime(date)
+			if err != nil {
+				handleError(err)
+			}
+
+			client := newClient(cmd)
+			renderOutput(client)(client.CreateReminder(
+				reminderDate,
 				taskID,
 				"",
 			))
@@ -70,16 +81,20 @@ var (
 and updates fields with the provided flags.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			reminder, err := reminder(cmd, args)
+			client := newClient(cmd)
+			reminder, err := reminder(cmd, client, args)
 			if err != nil {
 				handleError(err)
 			}
 
 			if cmd.Flags().Changed(dateLongFlag) {
-				reminder.Date = date
+				reminder.Date, err = parseDateTime(date)
+				if err != nil {
+					handleError(err)
+				}
 			}
 
-			renderOutput(newClient(cmd).UpdateReminder(reminder))
+			renderOutput(client)(client.UpdateReminder(reminder))
 		},
 	}
 
@@ -89,14 +104,15 @@ and updates fields with the provided flags.
 		Long: `delete-reminder deletes the reminder specified by <reminder-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			reminder, err := reminder(cmd, args)
+			client := newClient(cmd)
+			reminder, err := reminder(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting reminder: %v\n\n", err)
 				cmd.Usage()
 				os.Exit(2)
 			}
 
-			err = newClient(cmd).DeleteReminder(reminder)
+			err = client.DeleteReminder(reminder)
 			if err != nil {
 				handleError(err)
 			}
@@ -108,15 +124,18 @@ and updates fields with the provided flags.
 
 func init() {
 	cmdReminders.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "filter by listID")
+	cmdReminders.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 	cmdReminders.Flags().UintVarP(&taskID, taskIDLongFlag, taskIDShortFlag, 0, "filter by taskID")
+	cmdReminders.Flags().StringVar(&filterExpression, filterLongFlag, "", "filter expression, e.g. 'date < today+1d'")
+	cmdReminders.Flags().StringVar(&sortBy, sortByLongFlag, "", "comma-separated fields to sort by, prefixed with - for descending order")
 
 	cmdCreateReminder.Flags().UintVarP(&taskID, taskIDLongFlag, taskIDShortFlag, 0, "id of task to which reminder belongs")
-	cmdCreateReminder.Flags().StringVar(&date, dateLongFlag, "", "reminder date")
+	cmdCreateReminder.Flags().StringVar(&date, dateLongFlag, "", "reminder date and time, e.g. tomorrow 9am, in 2 hours or 2016-01-05T09:00:00Z. Defaults to 9am.")
 
-	cmdUpdateReminder.Flags().StringVar(&date, dateLongFlag, "", "reminder date")
+	cmdUpdateReminder.Flags().StringVar(&date, dateLongFlag, "", "reminder date and time, e.g. tomorrow 9am, in 2 hours or 2016-01-05T09:00:00Z. Defaults to 9am.")
 }
 
-func reminder(cmd *cobra.Command, args []string) (wl.Reminder, error) {
+func reminder(cmd *cobra.Command, client wl.Client, args []string) (wl.Reminder, error) {
 	if len(args) != 1 {
 		fmt.Printf("incorrect number of arguments provided\n\n")
 		cmd.Usage()
@@ -131,5 +150,5 @@ func reminder(cmd *cobra.Command, args []string) (wl.Reminder, error) {
 	}
 	id := uint(idInt)
 
-	return newClient(cmd).Reminder(id)
+	return client.Reminder(id)
 }
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/robdimsdale/wl/report"
+	"github.com/spf13/cobra"
+)
+
+const (
+	fromLongFlag   = "from"
+	toLongFlag     = "to"
+	formatLongFlag = "format"
+
+	defaultReportDays = 7
+)
+
+var (
+	// Flags
+	from         string
+	to           string
+	reportFormat string
+
+	// Commands
+	cmdReport = &cobra.Command{
+		Use:   "report",
+		Short: "reports task throughput over a date range",
+		Long: `report computes tasks created and completed per user and per list,
+the average time from creation to completion, and the number of overdue tasks.
+Dates are e.g. 2016-01-05, yesterday or -30d, and both --from and --to are inclusive.
+Defaults to the last seven days, including today.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			end, err := parseDay("today")
+			if err != nil {
+				handleError(err)
+			}
+
+			if cmd.Flags().Changed(toLongFlag) {
+				end, err = parseDay(to)
+				if err != nil {
+					handleError(err)
+				}
+			}
+
+			start := end.AddDate(0, 0, 1-defaultReportDays)
+			if cmd.Flags().Changed(fromLongFlag) {
+				start, err = parseDay(from)
+				if err != nil {
+					handleError(err)
+				}
+			}
+
+			r, err := report.Generate(newClient(cmd), start, end.AddDate(0, 0, 1))
+			if err != nil {
+				handleError(err)
+			}
+
+			err = report.Write(os.Stdout, r, reportFormat)
+			if err != nil {
+				handleError(err)
+			}
+		},
+	}
+)
+
+func init() {
+	cmdReport.Flags().StringVar(&from, fromLongFlag, "", "first day of the report")
+	cmdReport.Flags().StringVar(&to, toLongFlag, "", "last day of the report")
+	cmdReport.Flags().StringVar(&reportFormat, formatLongFlag, report.FormatTable, "output format: table, csv or json")
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/resolve"
+	"github.com/spf13/cobra"
+)
+
+const (
+	listLongFlag     = "list"
+	folderLongFlag   = "folder"
+	assigneeLongFlag = "assignee"
+)
+
+var (
+	// Flags
+	listName     string
+	folderName   string
+	assigneeName string
+
+	// folderID is resolved from the folder flag.
+	folderID uint
+)
+
+// resolveNames resolves the list, folder and assignee flags to IDs.
+// The list and assignee flags set the corresponding ID flags,
+// so commands only need to check the ID flags.
+func resolveNames(cmd *cobra.Command, args []string) {
+	flags := cmd.Flags()
+
+	if !flags.Changed(listLongFlag) &&
+		!flags.Changed(folderLongFlag) &&
+		!flags.Changed(assigneeLongFlag) {
+		return
+	}
+
+	for flag, idFlag := range map[string]string{
+		listLongFlag:     listIDLongFlag,
+		assigneeLongFlag: assigneeIDLongFlag,
+	} {
+		if flags.Changed(flag) && flags.Changed(idFlag) {
+			fmt.Printf("only one of --%s and --%s may be provided\n\n", flag, idFlag)
+			cmd.Usage()
+			os.Exit(2)
+		}
+	}
+
+	resolver := resolve.NewResolver(newClient(cmd))
+
+	if flags.Changed(listLongFlag) {
+		id, err := resolver.ListID(listName)
+		if err != nil {
+			handleError(err)
+		}
+		flags.Set(listIDLongFlag, fmt.Sprintf("%d", id))
+	}
+
+	if flags.Changed(assigneeLongFlag) {
+		id, err := resolver.UserID(assigneeName)
+		if err != nil {
+			handleError(err)
+		}
+		flags.Set(assigneeIDLongFlag, fmt.Sprintf("%d", id))
+	}
+
+	if flags.Changed(folderLongFlag) {
+		id, err := resolver.FolderID(folderName)
+		if err != nil {
+			handleError(err)
+		}
+		folderID = id
+	}
+}
+
+// listArg returns the ID of the list specified by the only argument,
+// which is either an ID or a list title.
+func listArg(cmd *cobra.Command, client wl.Client, args []string) uint {
+	if len(args) != 1 {
+		fmt.Printf("incorrect number of arguments provided\n\n")
+		cmd.Usage()
+		os.Exit(2)
+	}
+
+	id, err := resolve.NewResolver(client).ListID(args[0])
+	if err != nil {
+		fmt.Printf("error resolving list: %v\n\n", err)
+		cmd.Usage()
+		os.Exit(2)
+	}
+	return id
+}
+
+// folderArg returns the ID of the folder specified by the only argument,
+// which is either an ID or a folder title.
+func folderArg(cmd *cobra.Command, client wl.Client, args []string) uint {
+	if len(args) != 1 {
+		fmt.Printf("incorrect number of arguments provided\n\n")
+		cmd.Usage()
+		os.Exit(2)
+	}
+
+	id, err := resolve.NewResolver(client).FolderID(args[0])
+	if err != nil {
+		fmt.Printf("error resolving folder: %v\n\n", err)
+		cmd.Usage()
+		os.Exit(2)
+	}
+	return id
+}
+
+// inFolder returns the IDs of the lists in the folder specified by the folder flag.
+func inFolder(client wl.Client) (map[uint]bool, error) {
+	folder, err := client.Folder(folderID)
+	if err != nil {
+		return nil, err
+	}
+
+	listIDs := make(map[uint]bool, len(folder.ListIDs))
+	for _, id := range folder.ListIDs {
+		listIDs[id] = true
+	}
+	return listIDs, nil
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/robdimsdale/wl/export"
+	"github.com/robdimsdale/wl/restore"
+	"github.com/spf13/cobra"
+)
+
+const (
+	journalLongFlag = "journal"
+)
+
+var (
+	// Flags
+	journalPath string
+
+	// Commands
+	cmdRestore = &cobra.Command{
+		Use:   "restore <archive>",
+		Short: "restores an archive created by export",
+		Long: `restore recreates the folders, lists, tasks, subtasks, notes, reminders,
+comments, files and positions in <archive>, which may be JSON or tar.gz.
+The account does not need to be empty; restored objects are added to it,
+and the archive's inbox is restored into the existing inbox.
+
+Progress is recorded in a journal, <archive>.journal by default. If a restore fails,
+run it again with the same journal to continue where it stopped.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Printf("incorrect number of arguments provided\n\n")
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			var selectedListIDs []uint
+			if listIDs != "" {
+				var err error
+				selectedListIDs, err = splitStringToUints(listIDs)
+				if err != nil {
+					fmt.Printf("error parsing listIDs: %v\n\n", err)
+					cmd.Usage()
+					os.Exit(2)
+				}
+			}
+
+			f, err := os.Open(args[0])
+			if err != nil {
+				handleError(err)
+			}
+			defer f.Close()
+
+			a, err := export.Read(f)
+			if err != nil {
+				handleError(err)
+			}
+
+			path := journalPath
+			if path == "" {
+				path = args[0] + ".journal"
+			}
+
+			client := newClient(cmd)
+			renderOutput(client)(restore.Restore(client, a, restore.Options{
+				ListIDs:     selectedListIDs,
+				JournalPath: path,
+				DryRun:      dryRun,
+				Progress: func(s restore.Step) {
+					if !dryRun {
+						fmt.Fprintf(os.Stderr, "%s %s %d -> %d\n", s.Action, s.Kind, s.OldID, s.NewID)
+					}
+				},
+			}))
+		},
+	}
+)
+
+func init() {
+	cmdRestore.Flags().BoolVar(&dryRun, dryRunLongFlag, false, "print the steps which would be taken without changing anything")
+	cmdRestore.Flags().StringVar(&listIDs, listIDsLongFlag, "", "comma-separated IDs of the lists in the archive to restore. Defaults to all lists")
+	cmdRestore.Flags().StringVar(&journalPath, journalLongFlag, "", "path of the progress journal. Defaults to <archive>.journal")
+}
@@ -10,7 +10,8 @@ var (
         Root is the top of the list,task etc hierarchy'.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(newClient(cmd).Root())
+			client := newClient(cmd)
+			renderOutput(client)(client.Root())
 		},
 	}
 )
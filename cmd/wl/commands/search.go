@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/robdimsdale/wl/search"
+	"github.com/spf13/cobra"
+)
+
+const (
+	searchIndexEnvVariable = "WL_SEARCH_INDEX"
+
+	indexLongFlag   = "index"
+	offlineLongFlag = "offline"
+	rebuildLongFlag = "rebuild"
+)
+
+var (
+	// Flags
+	indexPath string
+	offline   bool
+	rebuild   bool
+
+	// Commands
+	cmdSearch = &cobra.Command{
+		Use:   "search <query>",
+		Short: "searches tasks, subtasks, notes and comments",
+		Long: `search finds tasks, subtasks, notes and task comments containing every word of <query>.
+Words match case-insensitively, and match any word they are a prefix of.
+
+Searches use an index saved in ~/.wl/search-index.json, or the file provided
+via the index flag or the WL_SEARCH_INDEX environment variable.
+The first search builds the index. Later searches only fetch lists which have changed.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				fmt.Printf("query must be provided\n\n")
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			path := searchIndexPath()
+
+			index := search.NewIndex()
+			if !rebuild {
+				var err error
+				index, err = search.Load(path)
+				if err != nil {
+					handleError(err)
+				}
+			}
+
+			if !offline {
+				fetched, err := index.Sync(newClient(cmd))
+				if err != nil {
+					handleError(err)
+				}
+
+				if fetched > 0 || rebuild {
+					err = index.Save(path)
+					if err != nil {
+						handleError(err)
+					}
+				}
+			}
+
+			renderOutput(nil)(index.Search(strings.Join(args, " ")), nil)
+		},
+	}
+)
+
+func init() {
+	cmdSearch.Flags().StringVar(&indexPath, indexLongFlag, "", "path of the search index")
+	cmdSearch.Flags().BoolVar(&offline, offlineLongFlag, false, "search the saved index without checking for changes")
+	cmdSearch.Flags().BoolVar(&rebuild, rebuildLongFlag, false, "discard the saved index and build it again")
+}
+
+// searchIndexPath returns the path of the search index provided via the index flag
+// or the WL_SEARCH_INDEX environment variable, defaulting to ~/.wl/search-index.json.
+func searchIndexPath() string {
+	if indexPath != "" {
+		return indexPath
+	}
+
+	if path := os.Getenv(searchIndexEnvVariable); path != "" {
+		return path
+	}
+
+	home := os.Getenv("HOME")
+	if home == "" {
+		handleError(fmt.Errorf("HOME is not set - provide the search index path via --%s or %s", indexLongFlag, searchIndexEnvVariable))
+	}
+	return filepath.Join(home, ".wl", "search-index.json")
+}
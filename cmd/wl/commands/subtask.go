@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/position"
 	"github.com/spf13/cobra"
 )
 
@@ -17,30 +18,21 @@ var (
 		Long: `subtasks gets the user's subtasks.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			// Currently sending completed=false is the same as not sending completed
-			// Checking for whether the flag has changed protects us from potentially
-			// breaking changes, i.e. if the subtasks endpoint decides to return all tasks,
-			// not just non-completed ones.
-
-			if taskID != 0 {
-				if cmd.Flags().Changed(completedLongFlag) {
-					renderOutput(newClient(cmd).CompletedSubtasksForTaskID(taskID, completed))
-				} else {
-					renderOutput(newClient(cmd).SubtasksForTaskID(taskID))
-				}
-			} else if listID != 0 {
-				if cmd.Flags().Changed(completedLongFlag) {
-					renderOutput(newClient(cmd).CompletedSubtasksForListID(listID, completed))
-				} else {
-					renderOutput(newClient(cmd).SubtasksForListID(listID))
-				}
-			} else {
-				if cmd.Flags().Changed(completedLongFlag) {
-					renderOutput(newClient(cmd).CompletedSubtasks(completed))
-				} else {
-					renderOutput(newClient(cmd).Subtasks())
+			client := newClient(cmd)
+
+			subtasks, err := fetchSubtasks(cmd, client)
+			if err != nil {
+				handleError(err)
+			}
+
+			if ordered {
+				subtasks, err = orderSubtasks(client, subtasks)
+				if err != nil {
+					handleError(err)
 				}
 			}
+
+			renderOutput(client)(filterSubtasks(client, subtasks))
 		},
 	}
 
@@ -50,7 +42,8 @@ var (
 		Long: `subtask gets a subtask specified by <subtask-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(subtask(cmd, args))
+			client := newClient(cmd)
+			renderOutput(client)(subtask(cmd, client, args))
 		},
 	}
 
@@ -60,7 +53,8 @@ var (
 		Long: `create-subtask creates a subtask with the specified args
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(newClient(cmd).CreateSubtask(
+			client := newClient(cmd)
+			renderOutput(client)(client.CreateSubtask(
 				title,
 				taskID,
 				completed,
@@ -75,7 +69,8 @@ var (
 and updates fields with the provided flags.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			subtask, err := subtask(cmd, args)
+			client := newClient(cmd)
+			subtask, err := subtask(cmd, client, args)
 			if err != nil {
 				handleError(err)
 			}
@@ -88,7 +83,7 @@ and updates fields with the provided flags.
 				subtask.Completed = completed
 			}
 
-			renderOutput(newClient(cmd).UpdateSubtask(subtask))
+			renderOutput(client)(client.UpdateSubtask(subtask))
 		},
 	}
 
@@ -98,14 +93,15 @@ and updates fields with the provided flags.
 		Long: `delete-subtask deletes the subtask specified by <subtask-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			subtask, err := subtask(cmd, args)
+			client := newClient(cmd)
+			subtask, err := subtask(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting subtask: %v\n\n", err)
 				cmd.Usage()
 				os.Exit(2)
 			}
 
-			err = newClient(cmd).DeleteSubtask(subtask)
+			err = client.DeleteSubtask(subtask)
 			if err != nil {
 				handleError(err)
 			}
@@ -117,8 +113,12 @@ and updates fields with the provided flags.
 
 func init() {
 	cmdSubtasks.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "filter by listID")
+	cmdSubtasks.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 	cmdSubtasks.Flags().UintVarP(&taskID, taskIDLongFlag, taskIDShortFlag, 0, "filter by taskID")
 	cmdSubtasks.Flags().BoolVar(&completed, completedLongFlag, false, "filter for completed tasks")
+	cmdSubtasks.Flags().BoolVar(&ordered, orderedLongFlag, false, "order subtasks by their positions, as in the apps")
+	cmdSubtasks.Flags().StringVar(&filterExpression, filterLongFlag, "", `filter expression, e.g. 'completed && title ~ "deploy"'`)
+	cmdSubtasks.Flags().StringVar(&sortBy, sortByLongFlag, "", "comma-separated fields to sort by, prefixed with - for descending order")
 
 	cmdCreateSubtask.Flags().UintVarP(&taskID, taskIDLongFlag, taskIDShortFlag, 0, "id of task to which subtask belongs")
 	cmdCreateSubtask.Flags().BoolVar(&completed, completedLongFlag, false, "whether subtask is completed")
@@ -128,7 +128,53 @@ func init() {
 	cmdUpdateSubtask.Flags().StringVar(&title, titleLongFlag, "", "subtask title")
 }
 
-func subtask(cmd *cobra.Command, args []string) (wl.Subtask, error) {
+// fetchSubtasks returns the subtasks specified by the taskID, listID and completed flags.
+func fetchSubtasks(cmd *cobra.Command, client wl.Client) ([]wl.Subtask, error) {
+	// Currently sending completed=false is the same as not sending completed
+	// Checking for whether the flag has changed protects us from potentially
+	// breaking changes, i.e. if the subtasks endpoint decides to return all tasks,
+	// not just non-completed ones.
+
+	if taskID != 0 {
+		if cmd.Flags().Changed(completedLongFlag) {
+			return client.CompletedSubtasksForTaskID(taskID, completed)
+		}
+		return client.SubtasksForTaskID(taskID)
+	}
+
+	if listID != 0 {
+		if cmd.Flags().Changed(completedLongFlag) {
+			return client.CompletedSubtasksForListID(listID, completed)
+		}
+		return client.SubtasksForListID(listID)
+	}
+
+	if cmd.Flags().Changed(completedLongFlag) {
+		return client.CompletedSubtasks(completed)
+	}
+	return client.Subtasks()
+}
+
+// orderSubtasks orders the subtasks by the positions
+// specified by the taskID or listID flags.
+func orderSubtasks(client wl.Client, subtasks []wl.Subtask) ([]wl.Subtask, error) {
+	var positions []wl.Position
+	var err error
+	if taskID != 0 {
+		positions, err = client.SubtaskPositionsForTaskID(taskID)
+	} else if listID != 0 {
+		positions, err = client.SubtaskPositionsForListID(listID)
+	} else {
+		positions, err = client.SubtaskPositions()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return position.OrderSubtasks(subtasks, positions), nil
+}
+
+func subtask(cmd *cobra.Command, client wl.Client, args []string) (wl.Subtask, error) {
 	if len(args) != 1 {
 		fmt.Printf("incorrect number of arguments provided\n\n")
 		cmd.Usage()
@@ -143,5 +189,5 @@ func subtask(cmd *cobra.Command, args []string) (wl.Subtask, error) {
 	}
 	id := uint(idInt)
 
-	return newClient(cmd).Subtask(id)
+	return client.Subtask(id)
 }
@@ -25,11 +25,14 @@ var (
         `,
 		Run: func(cmd *cobra.Command, args []string) {
 			if taskID != 0 {
-				renderOutput(newClient(cmd).SubtaskPositionsForTaskID(taskID))
+				client := newClient(cmd)
+				renderOutput(client)(client.SubtaskPositionsForTaskID(taskID))
 			} else if listID != 0 {
-				renderOutput(newClient(cmd).SubtaskPositionsForListID(listID))
+				client := newClient(cmd)
+				renderOutput(client)(client.SubtaskPositionsForListID(listID))
 			} else {
-				renderOutput(newClient(cmd).SubtaskPositions())
+				client := newClient(cmd)
+				renderOutput(client)(client.SubtaskPositions())
 			}
 		},
 	}
@@ -40,7 +43,8 @@ var (
 		Long: `subtask-position gets a subtask-position specified by <subtask-position-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(subtaskPosition(cmd, args))
+			client := newClient(cmd)
+			renderOutput(client)(subtaskPosition(cmd, client, args))
 		},
 	}
 
@@ -51,7 +55,8 @@ var (
 and updates fields with the provided flags.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			subtaskPosition, err := subtaskPosition(cmd, args)
+			client := newClient(cmd)
+			subtaskPosition, err := subtaskPosition(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting sub task position: %v\n\n", err)
 				cmd.Usage()
@@ -68,7 +73,7 @@ and updates fields with the provided flags.
 				subtaskPosition.Values = subtaskIDsInts
 			}
 
-			renderOutput(newClient(cmd).UpdateSubtaskPosition(subtaskPosition))
+			renderOutput(client)(client.UpdateSubtaskPosition(subtaskPosition))
 		},
 	}
 )
@@ -76,11 +81,12 @@ and updates fields with the provided flags.
 func init() {
 	cmdSubtaskPositions.Flags().UintVarP(&taskID, taskIDLongFlag, taskIDShortFlag, 0, "filter by taskID")
 	cmdSubtaskPositions.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "filter by listID")
+	cmdSubtaskPositions.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 
 	cmdUpdateSubtaskPosition.Flags().StringVar(&subtaskIDs, subtaskIDsLongFlag, "", "comma-separated subtask IDs (required)")
 }
 
-func subtaskPosition(cmd *cobra.Command, args []string) (wl.Position, error) {
+func subtaskPosition(cmd *cobra.Command, client wl.Client, args []string) (wl.Position, error) {
 	if len(args) != 1 {
 		fmt.Printf("incorrect number of arguments provided\n\n")
 		cmd.Usage()
@@ -95,5 +101,5 @@ func subtaskPosition(cmd *cobra.Command, args []string) (wl.Position, error) {
 	}
 	id := uint(idInt)
 
-	return newClient(cmd).SubtaskPosition(id)
+	return client.SubtaskPosition(id)
 }
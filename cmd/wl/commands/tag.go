@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/tags"
+	"github.com/spf13/cobra"
+)
+
+const (
+	tagLongFlag = "tag"
+)
+
+var (
+	// Flags
+	tag string
+
+	// Commands
+	cmdTags = &cobra.Command{
+		Use:   "tags",
+		Short: "gets all hashtags with their counts",
+		Long: `tags gets the hashtags, e.g. #ops, used in task titles, subtasks and notes,
+with the number of times each is used and the lists in which they are used.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient(cmd)
+
+			tasks, err := tasksForList(client)
+			if err != nil {
+				handleError(err)
+			}
+
+			index, err := tagIndex(client, tasks)
+			if err != nil {
+				handleError(err)
+			}
+
+			renderOutput(client)(index.Counts(), nil)
+		},
+	}
+
+	cmdRetag = &cobra.Command{
+		Use:   "retag <old-tag> <new-tag>",
+		Short: "renames a hashtag in task titles",
+		Long: `retag replaces <old-tag> with <new-tag> in the titles of tasks,
+e.g. wl retag '#old' '#new'. Subtasks and notes are not changed.
+If a task is modified concurrently it is fetched again and the rename is retried.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				fmt.Printf("incorrect number of arguments provided\n\n")
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			client := newClient(cmd)
+
+			tasks, err := tasksForList(client)
+			if err != nil {
+				handleError(err)
+			}
+
+			renderOutput(client)(tags.NewRetagger(client).Retag(tasks, args[0], args[1]))
+		},
+	}
+)
+
+func init() {
+	cmdTags.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "filter by listID")
+	cmdTags.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
+
+	cmdRetag.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "only retag tasks in list with listID")
+	cmdRetag.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
+}
+
+// tasksForList returns the tasks in the list specified by the listID flag,
+// or all tasks if it is not provided.
+func tasksForList(client wl.Client) ([]wl.Task, error) {
+	if listID == 0 {
+		return client.Tasks()
+	}
+	return client.TasksForListID(listID)
+}
+
+// tagIndex returns an index of the tags in the tasks and their subtasks and notes,
+// fetching the subtasks and notes of the list specified by the listID flag.
+func tagIndex(client wl.Client, tasks []wl.Task) (*tags.Index, error) {
+	var subtasks []wl.Subtask
+	var notes []wl.Note
+	var err error
+
+	if listID == 0 {
+		subtasks, err = client.Subtasks()
+	} else {
+		subtasks, err = client.SubtasksForListID(listID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if listID == 0 {
+		notes, err = client.Notes()
+	} else {
+		notes, err = client.NotesForListID(listID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tags.NewIndex(tasks, subtasks, notes), nil
+}
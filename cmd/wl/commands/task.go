@@ -5,9 +5,10 @@ import (
 	"os"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/position"
+	"github.com/robdimsdale/wl/smartlists"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +18,8 @@ const (
 	recurrenceCountLongFlag = "recurrenceCount"
 	dueDateLongFlag         = "dueDate"
 	starredLongFlag         = "starred"
+	smartLongFlag           = "smart"
+	orderedLongFlag         = "ordered"
 )
 
 var (
@@ -26,32 +29,65 @@ var (
 	recurrenceCount uint
 	dueDate         string
 	starred         bool
+	smart           string
+	ordered         bool
 
 	// Commands
 	cmdTasks = &cobra.Command{
 		Use:   "tasks",
 		Short: "gets all tasks",
 		Long: `tasks gets the user's tasks.
+Tasks in smart lists are always ordered by their positions.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			// Currently sending completed=false is the same as not sending completed
-			// Checking for whether the flag has changed protects us from potentially
-			// breaking changes, i.e. if the tasks endpoint decides to return all tasks,
-			// not just non-completed ones.
-
-			if listID == 0 {
-				if cmd.Flags().Changed(completedLongFlag) {
-					renderOutput(newClient(cmd).CompletedTasks(completed))
-				} else {
-					renderOutput(newClient(cmd).Tasks())
+			client := newClient(cmd)
+
+			tasks, err := fetchTasks(cmd, client)
+			if err != nil {
+				handleError(err)
+			}
+
+			if cmd.Flags().Changed(folderLongFlag) {
+				listIDs, err := inFolder(client)
+				if err != nil {
+					handleError(err)
 				}
-			} else {
-				if cmd.Flags().Changed(completedLongFlag) {
-					renderOutput(newClient(cmd).CompletedTasksForListID(listID, completed))
-				} else {
-					renderOutput(newClient(cmd).TasksForListID(listID))
+
+				var folderTasks []wl.Task
+				for _, t := range tasks {
+					if listIDs[t.ListID] {
+						folderTasks = append(folderTasks, t)
+					}
+				}
+				tasks = folderTasks
+			}
+
+			if tag != "" {
+				index, err := tagIndex(client, tasks)
+				if err != nil {
+					handleError(err)
+				}
+				tasks = index.Tasks(tasks, tag)
+			}
+
+			if ordered && !cmd.Flags().Changed(smartLongFlag) {
+				tasks, err = orderTasks(client, tasks)
+				if err != nil {
+					handleError(err)
 				}
 			}
+
+			tasks, err = filterTasks(client, tasks)
+			if err != nil {
+				handleError(err)
+			}
+
+			if groupBy != "" {
+				renderOutput(client)(groupTasks(client, tasks))
+				return
+			}
+
+			renderOutput(client)(tasks, nil)
 		},
 	}
 
@@ -61,7 +97,8 @@ var (
 		Long: `task gets a task specified by <task-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(task(cmd, args))
+			client := newClient(cmd)
+			renderOutput(client)(task(cmd, client, args))
 		},
 	}
 
@@ -71,17 +108,24 @@ var (
 		Long: `create-task creates a task with the specified args
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			parsedDueDate, err := parseDueDate(dueDate)
-			if err != nil {
-				handleError(err)
+			var parsedDueDate wl.Date
+			if cmd.Flags().Changed(dueDateLongFlag) {
+				var err error
+				parsedDueDate, err = parseDueDate(dueDate)
+				if err != nil {
+					handleError(err)
+				}
 			}
 
-			renderOutput(newClient(cmd).CreateTask(
+			parsedRecurrenceType := parseRecurrence(cmd, recurrenceType, recurrenceCount)
+
+			client := newClient(cmd)
+			renderOutput(client)(client.CreateTask(
 				title,
 				listID,
 				assigneeID,
 				completed,
-				recurrenceType,
+				parsedRecurrenceType,
 				recurrenceCount,
 				parsedDueDate,
 				starred,
@@ -96,7 +140,7 @@ var (
 and updates fields with the provided flags.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			var parsedDueDate time.Time
+			var parsedDueDate wl.Date
 			if cmd.Flags().Changed(dueDateLongFlag) {
 				var err error
 				parsedDueDate, err = parseDueDate(dueDate)
@@ -105,7 +149,8 @@ and updates fields with the provided flags.
 				}
 			}
 
-			task, err := task(cmd, args)
+			client := newClient(cmd)
+			task, err := task(cmd, client, args)
 			if err != nil {
 				handleError(err)
 			}
@@ -126,14 +171,14 @@ and updates fields with the provided flags.
 				task.Completed = completed
 			}
 
-			if cmd.Flags().Changed(recurrenceTypeLongFlag) {
-				task.RecurrenceType = recurrenceType
-			}
-
 			if cmd.Flags().Changed(recurrenceCountLongFlag) {
 				task.RecurrenceCount = recurrenceCount
 			}
 
+			if cmd.Flags().Changed(recurrenceTypeLongFlag) {
+				task.RecurrenceType = parseRecurrence(cmd, recurrenceType, task.RecurrenceCount)
+			}
+
 			if cmd.Flags().Changed(dueDateLongFlag) {
 				task.DueDate = parsedDueDate
 			}
@@ -142,7 +187,7 @@ and updates fields with the provided flags.
 				task.Starred = starred
 			}
 
-			renderOutput(newClient(cmd).UpdateTask(task))
+			renderOutput(client)(client.UpdateTask(task))
 		},
 	}
 
@@ -152,14 +197,15 @@ and updates fields with the provided flags.
 		Long: `delete-task deletes the task specified by <task-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			task, err := task(cmd, args)
+			client := newClient(cmd)
+			task, err := task(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting task: %v\n\n", err)
 				cmd.Usage()
 				os.Exit(2)
 			}
 
-			err = newClient(cmd).DeleteTask(task)
+			err = client.DeleteTask(task)
 			if err != nil {
 				handleError(err)
 			}
@@ -185,28 +231,40 @@ and updates fields with the provided flags.
 
 func init() {
 	cmdTasks.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "filter by listID")
+	cmdTasks.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
+	cmdTasks.Flags().StringVar(&folderName, folderLongFlag, "", "filter by folder title or ID")
 	cmdTasks.Flags().BoolVar(&completed, completedLongFlag, false, "filter for completed tasks")
+	cmdTasks.Flags().StringVar(&smart, smartLongFlag, "", "smart list: "+strings.Join(smartlists.Names(), ", "))
+	cmdTasks.Flags().StringVar(&tag, tagLongFlag, "", "filter by hashtag in the title, subtasks or notes, e.g. ops")
+	cmdTasks.Flags().BoolVar(&ordered, orderedLongFlag, false, "order tasks by their positions, as in the apps")
+	cmdTasks.Flags().StringVar(&filterExpression, filterLongFlag, "", `filter expression, e.g. 'starred && due <= today+3d && assignee == me'`)
+	cmdTasks.Flags().StringVar(&sortBy, sortByLongFlag, "", "comma-separated fields to sort by, prefixed with - for descending order")
+	cmdTasks.Flags().StringVar(&groupBy, groupByLongFlag, "", "group by list, folder, assignee or due")
 
 	cmdCreateTask.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "id of list to which task will belong")
+	cmdCreateTask.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 	cmdCreateTask.Flags().StringVar(&title, titleLongFlag, "", "title of task")
 	cmdCreateTask.Flags().UintVar(&assigneeID, assigneeIDLongFlag, 0, "id of task assignee")
+	cmdCreateTask.Flags().StringVar(&assigneeName, assigneeLongFlag, "", "name or email of task assignee, or me, instead of assingeeID")
 	cmdCreateTask.Flags().BoolVar(&completed, completedLongFlag, false, "whether task is completed")
-	cmdCreateTask.Flags().StringVar(&recurrenceType, recurrenceTypeLongFlag, "", "recurrence type")
-	cmdCreateTask.Flags().UintVar(&recurrenceCount, recurrenceCountLongFlag, 0, "id of task assignee")
-	cmdCreateTask.Flags().StringVar(&dueDate, dueDateLongFlag, "", "due date of task")
+	cmdCreateTask.Flags().StringVar(&recurrenceType, recurrenceTypeLongFlag, "", "recurrence type: day, week, month or year")
+	cmdCreateTask.Flags().UintVar(&recurrenceCount, recurrenceCountLongFlag, 0, "recurrence count, e.g. 2 to recur every 2 weeks")
+	cmdCreateTask.Flags().StringVar(&dueDate, dueDateLongFlag, "", "due date of task, e.g. 2016-01-05, tomorrow, next friday, +3d or eom")
 	cmdCreateTask.Flags().BoolVar(&starred, starredLongFlag, false, "whether task is starred")
 
 	cmdUpdateTask.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "id of list to which task will belong")
+	cmdUpdateTask.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 	cmdUpdateTask.Flags().StringVar(&title, titleLongFlag, "", "title of task")
 	cmdUpdateTask.Flags().UintVar(&assigneeID, assigneeIDLongFlag, 0, "id of task assignee")
+	cmdUpdateTask.Flags().StringVar(&assigneeName, assigneeLongFlag, "", "name or email of task assignee, or me, instead of assingeeID")
 	cmdUpdateTask.Flags().BoolVar(&completed, completedLongFlag, false, "whether task is completed")
-	cmdUpdateTask.Flags().StringVar(&recurrenceType, recurrenceTypeLongFlag, "", "recurrence type")
-	cmdUpdateTask.Flags().UintVar(&recurrenceCount, recurrenceCountLongFlag, 0, "id of task assignee")
-	cmdUpdateTask.Flags().StringVar(&dueDate, dueDateLongFlag, "", "due date of task")
+	cmdUpdateTask.Flags().StringVar(&recurrenceType, recurrenceTypeLongFlag, "", "recurrence type: day, week, month or year")
+	cmdUpdateTask.Flags().UintVar(&recurrenceCount, recurrenceCountLongFlag, 0, "recurrence count, e.g. 2 to recur every 2 weeks")
+	cmdUpdateTask.Flags().StringVar(&dueDate, dueDateLongFlag, "", "due date of task, e.g. 2016-01-05, tomorrow, next friday, +3d or eom")
 	cmdUpdateTask.Flags().BoolVar(&starred, starredLongFlag, false, "whether task is starred")
 }
 
-func task(cmd *cobra.Command, args []string) (wl.Task, error) {
+func task(cmd *cobra.Command, client wl.Client, args []string) (wl.Task, error) {
 	if len(args) != 1 {
 		fmt.Printf("incorrect number of arguments provided\n\n")
 		cmd.Usage()
@@ -221,35 +279,92 @@ func task(cmd *cobra.Command, args []string) (wl.Task, error) {
 	}
 	id := uint(idInt)
 
-	return newClient(cmd).Task(id)
+	return client.Task(id)
+}
+
+// fetchTasks returns the tasks specified by the listID, completed and smart flags.
+func fetchTasks(cmd *cobra.Command, client wl.Client) ([]wl.Task, error) {
+	if cmd.Flags().Changed(smartLongFlag) {
+		return smartTasks(client)
+	}
+
+	// Currently sending completed=false is the same as not sending completed
+	// Checking for whether the flag has changed protects us from potentially
+	// breaking changes, i.e. if the tasks endpoint decides to return all tasks,
+	// not just non-completed ones.
+
+	if listID == 0 {
+		if cmd.Flags().Changed(completedLongFlag) {
+			return client.CompletedTasks(completed)
+		}
+		return client.Tasks()
+	}
+
+	if cmd.Flags().Changed(completedLongFlag) {
+		return client.CompletedTasksForListID(listID, completed)
+	}
+	return client.TasksForListID(listID)
 }
 
-func parseDueDate(dueDate string) (time.Time, error) {
-	splitDate := strings.Split(dueDate, "-")
-	if len(splitDate) < 3 {
-		return time.Now(), fmt.Errorf("Failed to parse dueDate into expected YYYY-MM-DD format: %s", dueDate)
+// orderTasks orders the tasks by their positions. If the listID flag is provided
+// only the positions of that list are fetched, otherwise tasks are also ordered
+// by the positions of their lists.
+func orderTasks(client wl.Client, tasks []wl.Task) ([]wl.Task, error) {
+	if listID != 0 {
+		positions, err := client.TaskPositionsForListID(listID)
+		if err != nil {
+			return nil, err
+		}
+		return position.OrderTasks(tasks, positions), nil
 	}
 
-	year, err := strconv.Atoi(splitDate[0])
+	lists, err := client.Lists()
 	if err != nil {
-		return time.Now(), err
+		return nil, err
 	}
 
-	monthInt, err := strconv.Atoi(splitDate[1])
+	listPositions, err := client.ListPositions()
 	if err != nil {
-		return time.Now(), err
+		return nil, err
 	}
-	month := time.Month(monthInt)
 
-	day, err := strconv.Atoi(splitDate[2])
+	taskPositions, err := client.TaskPositions()
 	if err != nil {
-		return time.Now(), err
+		return nil, err
 	}
 
-	hour := 0
-	minute := 0
-	second := 0
-	nano := 0
+	return position.OrderTasksByList(tasks, lists, listPositions, taskPositions), nil
+}
+
+// smartTasks returns the tasks in the smart list specified by the smart flag,
+// optionally filtered by listID.
+func smartTasks(client wl.Client) ([]wl.Task, error) {
+	tasks, err := smartlists.Build(client, smart, currentTime())
+	if err != nil || listID == 0 {
+		return tasks, err
+	}
 
-	return time.Date(year, month, day, hour, minute, second, nano, time.Local), nil
+	filtered := []wl.Task{}
+	for _, t := range tasks {
+		if t.ListID == listID {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered, nil
+}
+
+// parseRecurrence parses the recurrence type, printing usage and exiting
+// if it is invalid or does not agree with the recurrence count.
+func parseRecurrence(cmd *cobra.Command, recurrenceType string, recurrenceCount uint) wl.RecurrenceType {
+	r, err := wl.ParseRecurrenceType(recurrenceType)
+	if err == nil {
+		err = wl.Recurrence{Type: r, Count: recurrenceCount}.Validate()
+	}
+
+	if err != nil {
+		fmt.Printf("%v\n\n", err)
+		cmd.Usage()
+		os.Exit(2)
+	}
+	return r
 }
@@ -30,11 +30,14 @@ var (
 			// not just non-completed ones.
 
 			if taskID != 0 {
-				renderOutput(newClient(cmd).TaskCommentsForTaskID(taskID))
+				client := newClient(cmd)
+				renderOutput(client)(client.TaskCommentsForTaskID(taskID))
 			} else if listID != 0 {
-				renderOutput(newClient(cmd).TaskCommentsForListID(listID))
+				client := newClient(cmd)
+				renderOutput(client)(client.TaskCommentsForListID(listID))
 			} else {
-				renderOutput(newClient(cmd).TaskComments())
+				client := newClient(cmd)
+				renderOutput(client)(client.TaskComments())
 			}
 		},
 	}
@@ -45,7 +48,8 @@ var (
 		Long: `task-comment gets a task-comment specified by <task-comment-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(taskComment(cmd, args))
+			client := newClient(cmd)
+			renderOutput(client)(taskComment(cmd, client, args))
 		},
 	}
 
@@ -55,7 +59,8 @@ var (
 		Long: `create-task-comment creates a task-comment with the specified args
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(newClient(cmd).CreateTaskComment(
+			client := newClient(cmd)
+			renderOutput(client)(client.CreateTaskComment(
 				text,
 				taskID,
 			))
@@ -68,14 +73,15 @@ var (
 		Long: `delete-task-comment deletes the task-comment specified by <task-comment-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			taskComment, err := taskComment(cmd, args)
+			client := newClient(cmd)
+			taskComment, err := taskComment(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting task-comment: %v\n\n", err)
 				cmd.Usage()
 				os.Exit(2)
 			}
 
-			err = newClient(cmd).DeleteTaskComment(taskComment)
+			err = client.DeleteTaskComment(taskComment)
 			if err != nil {
 				handleError(err)
 			}
@@ -87,13 +93,14 @@ var (
 
 func init() {
 	cmdTaskComments.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "filter by listID")
+	cmdTaskComments.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 	cmdTaskComments.Flags().UintVarP(&taskID, taskIDLongFlag, taskIDShortFlag, 0, "filter by taskID")
 
 	cmdCreateTaskComment.Flags().UintVarP(&taskID, taskIDLongFlag, taskIDShortFlag, 0, "id of task to which task-comment belongs")
 	cmdCreateTaskComment.Flags().StringVar(&text, textLongFlag, "", "task-comment text")
 }
 
-func taskComment(cmd *cobra.Command, args []string) (wl.TaskComment, error) {
+func taskComment(cmd *cobra.Command, client wl.Client, args []string) (wl.TaskComment, error) {
 	if len(args) != 1 {
 		fmt.Printf("incorrect number of arguments provided\n\n")
 		cmd.Usage()
@@ -108,5 +115,5 @@ func taskComment(cmd *cobra.Command, args []string) (wl.TaskComment, error) {
 	}
 	id := uint(idInt)
 
-	return newClient(cmd).TaskComment(id)
+	return client.TaskComment(id)
 }
@@ -25,9 +25,11 @@ var (
         `,
 		Run: func(cmd *cobra.Command, args []string) {
 			if listID == 0 {
-				renderOutput(newClient(cmd).TaskPositions())
+				client := newClient(cmd)
+				renderOutput(client)(client.TaskPositions())
 			} else {
-				renderOutput(newClient(cmd).TaskPositionsForListID(listID))
+				client := newClient(cmd)
+				renderOutput(client)(client.TaskPositionsForListID(listID))
 			}
 		},
 	}
@@ -38,7 +40,8 @@ var (
 		Long: `task-position gets a task-position specified by <task-position-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(taskPosition(cmd, args))
+			client := newClient(cmd)
+			renderOutput(client)(taskPosition(cmd, client, args))
 		},
 	}
 
@@ -49,7 +52,8 @@ var (
 and updates fields with the provided flags.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			taskPosition, err := taskPosition(cmd, args)
+			client := newClient(cmd)
+			taskPosition, err := taskPosition(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting task position: %v\n\n", err)
 				cmd.Usage()
@@ -66,18 +70,19 @@ and updates fields with the provided flags.
 				taskPosition.Values = taskIDsUints
 			}
 
-			renderOutput(newClient(cmd).UpdateTaskPosition(taskPosition))
+			renderOutput(client)(client.UpdateTaskPosition(taskPosition))
 		},
 	}
 )
 
 func init() {
 	cmdTaskPositions.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "filter by listID")
+	cmdTaskPositions.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 
 	cmdUpdateTaskPosition.Flags().StringVar(&taskIDs, taskIDsLongFlag, "", "comma-separated task IDs (required)")
 }
 
-func taskPosition(cmd *cobra.Command, args []string) (wl.Position, error) {
+func taskPosition(cmd *cobra.Command, client wl.Client, args []string) (wl.Position, error) {
 	if len(args) != 1 {
 		fmt.Printf("incorrect number of arguments provided\n\n")
 		cmd.Usage()
@@ -92,5 +97,5 @@ func taskPosition(cmd *cobra.Command, args []string) (wl.Position, error) {
 	}
 	id := uint(idInt)
 
-	return newClient(cmd).TaskPosition(id)
+	return client.TaskPosition(id)
 }
@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/template"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	startLongFlag = "start"
+)
+
+var (
+	// Flags
+	templateStart string
+	templatePath  string
+
+	// Commands
+	cmdTemplate = &cobra.Command{
+		Use:   "template",
+		Short: "creates lists from YAML templates",
+		Long: `template creates lists from YAML templates, whose due dates and reminders
+are relative to a start date, and captures templates from existing lists.
+For example:
+
+  title: Onboarding
+  tasks:
+  - title: Set up laptop
+    due: start+2d
+    starred: true
+    note: Ask IT for an account first
+    subtasks: [Email, VPN]
+    reminders: [start+1d 09:00]
+  - title: Meet the team
+    due: start+1w
+
+Offsets are start, start+Nd, start-Nd or start+Nw, and reminders are an
+offset followed by a time of day.
+        `,
+	}
+
+	cmdTemplateApply = &cobra.Command{
+		Use:   "apply <file>",
+		Short: "creates a list from a template",
+		Long: `apply creates a list from the template in <file>, with due dates and
+reminders relative to --start, which defaults to today. The list is titled
+--title, or the title of the template, and is added to --folder if provided.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Printf("incorrect number of arguments provided\n\n")
+				cmd.Usage()
+				os.Exit(2)
+			}
+
+			start := wl.DateOf(currentTime())
+			if templateStart != "" {
+				var err error
+				start, err = parseDueDate(templateStart)
+				if err != nil {
+					fmt.Printf("error parsing %s: %v\n\n", startLongFlag, err)
+					cmd.Usage()
+					os.Exit(2)
+				}
+			}
+
+			data, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				handleError(err)
+			}
+
+			var t template.Template
+			err = yaml.Unmarshal(data, &t)
+			if err != nil {
+				handleError(fmt.Errorf("failed to parse %s: %v", args[0], err))
+			}
+
+			client := newClient(cmd)
+			renderOutput(client)(template.Instantiate(client, t, template.Options{
+				Title:    title,
+				Start:    start,
+				FolderID: folderID,
+				Location: location(),
+			}))
+		},
+	}
+
+	cmdTemplateCapture = &cobra.Command{
+		Use:   "capture <list-id>",
+		Short: "creates a template from a list",
+		Long: `capture writes a template of the incomplete tasks in the list specified by
+<list-id>, which may also be a list title. Due dates and reminders become
+offsets from --start, which defaults to the earliest of them.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient(cmd)
+			id := listArg(cmd, client, args)
+
+			var start wl.Date
+			if templateStart != "" {
+				var err error
+				start, err = parseDueDate(templateStart)
+				if err != nil {
+					fmt.Printf("error parsing %s: %v\n\n", startLongFlag, err)
+					cmd.Usage()
+					os.Exit(2)
+				}
+			}
+
+			t, err := template.Capture(client, id, template.CaptureOptions{
+				Start: start,
+				Now:   currentTime(),
+			})
+			if err != nil {
+				handleError(err)
+			}
+
+			data, err := yaml.Marshal(t)
+			if err != nil {
+				handleError(err)
+			}
+
+			if templatePath == "" {
+				os.Stdout.Write(data)
+				return
+			}
+
+			err = ioutil.WriteFile(templatePath, data, 0644)
+			if err != nil {
+				handleError(err)
+			}
+			fmt.Fprintf(os.Stderr, "template of %d tasks written to %s\n", len(t.Tasks), templatePath)
+		},
+	}
+)
+
+func init() {
+	cmdTemplate.AddCommand(cmdTemplateApply)
+	cmdTemplate.AddCommand(cmdTemplateCapture)
+
+	cmdTemplateApply.Flags().StringVar(&templateStart, startLongFlag, "", "date offsets are relative to. Defaults to today")
+	cmdTemplateApply.Flags().StringVar(&title, titleLongFlag, "", "title of the list. Defaults to the title of the template")
+	cmdTemplateApply.Flags().StringVar(&folderName, folderLongFlag, "", "title or ID of folder to add the list to")
+
+	cmdTemplateCapture.Flags().StringVar(&templateStart, startLongFlag, "", "date offsets are relative to. Defaults to the earliest date in the list")
+	cmdTemplateCapture.Flags().StringVar(&templatePath, fileLongFlag, "", "path of the template file. Defaults to stdout")
+}
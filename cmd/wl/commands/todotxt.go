@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/robdimsdale/wl/todotxt"
+	"github.com/spf13/cobra"
+)
+
+const (
+	stateLongFlag = "state"
+)
+
+var (
+	// Flags
+	todotxtPath      string
+	todotxtStatePath string
+
+	// Commands
+	cmdTodotxt = &cobra.Command{
+		Use:   "todotxt",
+		Short: "exports and syncs todo.txt files",
+		Long: `todotxt exports tasks as todo.txt lines and syncs edits to them back.
+Starred tasks have priority (A), lists are +projects, assignees are @contexts,
+due dates are due: tags and completed tasks start with x.
+Each line has a wl: tag with the ID of its task.
+        `,
+	}
+
+	cmdTodotxtExport = &cobra.Command{
+		Use:   "export",
+		Short: "exports incomplete tasks as todo.txt lines",
+		Long: `export writes the incomplete tasks in the list, or in all lists if no list is
+provided, as todo.txt lines to stdout or to a file. When writing to a file,
+the exported lines are also recorded in a state file, <file>.state by default,
+so that sync can tell which lines have been edited or removed.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient(cmd)
+
+			listIDs := []uint{listID}
+			if listID == 0 {
+				lists, err := client.Lists()
+				if err != nil {
+					handleError(err)
+				}
+
+				listIDs = nil
+				for _, l := range lists {
+					listIDs = append(listIDs, l.ID)
+				}
+			}
+
+			lines, state, err := todotxt.Export(client, listIDs, location())
+			if err != nil {
+				handleError(err)
+			}
+
+			if todotxtPath == "" {
+				err = todotxt.Write(os.Stdout, lines)
+				if err != nil {
+					handleError(err)
+				}
+				return
+			}
+
+			err = todotxt.WriteFile(todotxtPath, lines)
+			if err != nil {
+				handleError(err)
+			}
+
+			err = state.Save(todotxtState(todotxtPath))
+			if err != nil {
+				handleError(err)
+			}
+
+			fmt.Fprintf(os.Stderr, "%d tasks exported successfully to %s\n", len(lines), todotxtPath)
+		},
+	}
+
+	cmdTodotxtSync = &cobra.Command{
+		Use:   "sync <file>",
+		Short: "syncs a todo.txt file with Wunderlist",
+		Long: `sync applies the edits made to <file> since it was exported or last synced,
+and then rewrites it with the current tasks.
+
+New lines create tasks, in the list of their first matching +project or else in
+the provided list, which defaults to the inbox. Edited lines update their task.
+Unedited lines are updated with any changes made in Wunderlist, and tasks created
+in Wunderlist are added. Tasks whose lines have been removed are deleted, unless
+the lines were completed, as completed lines are often archived to done.txt.
+
+Edits are detected using the state file, <file>.state by default. Without one,
+every line is treated as edited and nothing is deleted or added.
+        `,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Printf("incorrect number of arguments provided\n\n")
+				cmd.Usage()
+				os.Exit(2)
+			}
+			path := args[0]
+
+			f, err := os.Open(path)
+			if err != nil {
+				handleError(err)
+			}
+			lines, err := todotxt.Read(f)
+			f.Close()
+			if err != nil {
+				handleError(err)
+			}
+
+			statePath := todotxtState(path)
+			state, err := todotxt.LoadState(statePath)
+			if err != nil {
+				handleError(err)
+			}
+
+			client := newClient(cmd)
+			result, err := todotxt.Sync(client, lines, state, todotxt.Options{
+				ListID:   listID,
+				DryRun:   dryRun,
+				Location: location(),
+			})
+
+			// Write what was synced even after an error, so that created
+			// tasks are not created again by the next sync.
+			if !dryRun && result.Lines != nil {
+				writeErr := todotxt.WriteFile(path, result.Lines)
+				if writeErr == nil {
+					writeErr = result.State.Save(statePath)
+				}
+				if err == nil {
+					err = writeErr
+				}
+			}
+
+			renderOutput(client)(result.Changes, err)
+		},
+	}
+)
+
+func init() {
+	cmdTodotxt.AddCommand(cmdTodotxtExport)
+	cmdTodotxt.AddCommand(cmdTodotxtSync)
+
+	cmdTodotxtExport.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "list to export. Defaults to all lists")
+	cmdTodotxtExport.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
+	cmdTodotxtExport.Flags().StringVar(&todotxtPath, fileLongFlag, "", "path of the todo.txt file. Defaults to stdout")
+	cmdTodotxtExport.Flags().StringVar(&todotxtStatePath, stateLongFlag, "", "path of the state file. Defaults to <file>.state")
+
+	cmdTodotxtSync.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "list for new lines without a matching project. Defaults to the inbox")
+	cmdTodotxtSync.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
+	cmdTodotxtSync.Flags().StringVar(&todotxtStatePath, stateLongFlag, "", "path of the state file. Defaults to <file>.state")
+	cmdTodotxtSync.Flags().BoolVar(&dryRun, dryRunLongFlag, false, "print the changes which would be made without changing anything")
+}
+
+func todotxtState(path string) string {
+	if todotxtStatePath != "" {
+		return todotxtStatePath
+	}
+	return path + ".state"
+}
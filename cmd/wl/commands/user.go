@@ -17,7 +17,8 @@ var (
 		Long: `user gets the logged-in user's information.
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(newClient(cmd).User())
+			client := newClient(cmd)
+			renderOutput(client)(client.User())
 		},
 	}
 
@@ -28,9 +29,11 @@ var (
         `,
 		Run: func(cmd *cobra.Command, args []string) {
 			if listID != 0 {
-				renderOutput(newClient(cmd).UsersForListID(listID))
+				client := newClient(cmd)
+				renderOutput(client)(client.UsersForListID(listID))
 			} else {
-				renderOutput(newClient(cmd).Users())
+				client := newClient(cmd)
+				renderOutput(client)(client.Users())
 			}
 		},
 	}
@@ -51,12 +54,13 @@ and updates fields with the provided flags.
 			if name != "" {
 				user.Name = name
 			}
-			renderOutput(client.UpdateUser(user))
+			renderOutput(client)(client.UpdateUser(user))
 		},
 	}
 )
 
 func init() {
 	cmdUsers.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "filter by listID")
+	cmdUsers.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 	cmdUpdateUser.Flags().StringVar(&name, nameLongFlag, "", "name")
 }
@@ -30,9 +30,11 @@ var (
 			// not just non-completed ones.
 
 			if listID == 0 {
-				renderOutput(newClient(cmd).Webhooks())
+				client := newClient(cmd)
+				renderOutput(client)(client.Webhooks())
 			} else {
-				renderOutput(newClient(cmd).WebhooksForListID(listID))
+				client := newClient(cmd)
+				renderOutput(client)(client.WebhooksForListID(listID))
 			}
 		},
 	}
@@ -43,7 +45,8 @@ var (
 		Long: `webhook gets a webhook specified by <webhook-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(webhook(cmd, args))
+			client := newClient(cmd)
+			renderOutput(client)(webhook(cmd, client, args))
 		},
 	}
 
@@ -53,7 +56,8 @@ var (
 		Long: `create-webhook creates a webhook with the specified args
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			renderOutput(newClient(cmd).CreateWebhook(
+			client := newClient(cmd)
+			renderOutput(client)(client.CreateWebhook(
 				listID,
 				url,
 				"generic",
@@ -68,14 +72,15 @@ var (
 		Long: `delete-webhook deletes the webhook specified by <webhook-id>
         `,
 		Run: func(cmd *cobra.Command, args []string) {
-			webhook, err := webhook(cmd, args)
+			client := newClient(cmd)
+			webhook, err := webhook(cmd, client, args)
 			if err != nil {
 				fmt.Printf("error getting webhook: %v\n\n", err)
 				cmd.Usage()
 				os.Exit(2)
 			}
 
-			err = newClient(cmd).DeleteWebhook(webhook)
+			err = client.DeleteWebhook(webhook)
 			if err != nil {
 				handleError(err)
 			}
@@ -87,12 +92,14 @@ var (
 
 func init() {
 	cmdWebhooks.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "filter by listID")
+	cmdWebhooks.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 
 	cmdCreateWebhook.Flags().UintVarP(&listID, listIDLongFlag, listIDShortFlag, 0, "id of list to which webhook will belong")
+	cmdCreateWebhook.Flags().StringVar(&listName, listLongFlag, "", "title of list, instead of listID")
 	cmdCreateWebhook.Flags().StringVar(&url, urlLongFlag, "", "url of webhook")
 }
 
-func webhook(cmd *cobra.Command, args []string) (wl.Webhook, error) {
+func webhook(cmd *cobra.Command, client wl.Client, args []string) (wl.Webhook, error) {
 	if len(args) != 1 {
 		fmt.Printf("incorrect number of arguments provided\n\n")
 		cmd.Usage()
@@ -107,5 +114,5 @@ func webhook(cmd *cobra.Command, args []string) (wl.Webhook, error) {
 	}
 	id := uint(idInt)
 
-	return newClient(cmd).Webhook(id)
+	return client.Webhook(id)
 }
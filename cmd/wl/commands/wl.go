@@ -1,16 +1,12 @@
 package commands
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
 
-	"gopkg.in/yaml.v2"
-
 	"github.com/robdimsdale/wl"
 	"github.com/robdimsdale/wl/logger"
 	"github.com/robdimsdale/wl/oauth"
@@ -21,6 +17,7 @@ const (
 	// Global flags
 	accessTokenEnvVariable = "WL_ACCESS_TOKEN"
 	clientIDEnvVariable    = "WL_CLIENT_ID"
+	timezoneEnvVariable    = "WL_TIMEZONE"
 
 	accessTokenLongFlag = "accessToken"
 	clientIDLongFlag    = "clientID"
@@ -31,6 +28,13 @@ const (
 	useJSONLongFlag  = "useJSON"
 	useJSONShortFlag = "j"
 
+	outputLongFlag  = "output"
+	outputShortFlag = "o"
+
+	columnsLongFlag = "columns"
+
+	timezoneLongFlag = "timezone"
+
 	// Shared, non-global flags
 	listIDLongFlag  = "listID"
 	listIDShortFlag = "l"
@@ -47,10 +51,13 @@ const (
 
 var (
 	// Global flags
-	accessToken string
-	clientID    string
-	verbose     bool
-	useJSON     bool
+	accessToken  string
+	clientID     string
+	verbose      bool
+	useJSON      bool
+	outputFormat string
+	columns      string
+	timezone     string
 
 	// Non-global, shared flags
 	taskID    uint
@@ -60,7 +67,12 @@ var (
 	listIDs   string
 
 	// WLCmd is the root command. All other commands are subcommands of it.
-	WLCmd = &cobra.Command{Use: "wl"}
+	// Names provided via the list, folder and assignee flags
+	// are resolved to IDs before any subcommand runs.
+	WLCmd = &cobra.Command{
+		Use:              "wl",
+		PersistentPreRun: resolveNames,
+	}
 )
 
 // Execute adds all child commands to the root command WLCmd,
@@ -78,6 +90,11 @@ func init() {
 	WLCmd.PersistentFlags().StringVarP(&clientID, clientIDLongFlag, "", "", `Wunderlist client ID. 
                      Required, but can be provided via WL_CLIENT_ID environment variable instead.`)
 	WLCmd.PersistentFlags().BoolVarP(&useJSON, useJSONLongFlag, useJSONShortFlag, false, "render output as JSON instead of YAML.")
+	WLCmd.PersistentFlags().StringVarP(&outputFormat, outputLongFlag, outputShortFlag, "", `output format: yaml (default), json, jsonl, table, csv, ids
+                      	or template=<go-template>, which is executed for each item.`)
+	WLCmd.PersistentFlags().StringVarP(&timezone, timezoneLongFlag, "", "", `timezone for dates, e.g. Europe/London. Defaults to the local timezone.
+                      	Can be provided via WL_TIMEZONE environment variable instead.`)
+	WLCmd.PersistentFlags().StringVarP(&columns, columnsLongFlag, "", "", "comma-separated columns to render in table and csv output formats.")
 }
 
 func addCommands() {
@@ -100,9 +117,12 @@ func addCommands() {
 	WLCmd.AddCommand(cmdTasks)
 	WLCmd.AddCommand(cmdTask)
 	WLCmd.AddCommand(cmdCreateTask)
+	WLCmd.AddCommand(cmdAdd)
 	WLCmd.AddCommand(cmdUpdateTask)
 	WLCmd.AddCommand(cmdDeleteTask)
 	WLCmd.AddCommand(cmdDeleteAllTasks)
+	WLCmd.AddCommand(cmdTags)
+	WLCmd.AddCommand(cmdRetag)
 
 	WLCmd.AddCommand(cmdUploadFile)
 	WLCmd.AddCommand(cmdCreateFile)
@@ -162,6 +182,24 @@ func addCommands() {
 	WLCmd.AddCommand(cmdSubtaskPositions)
 	WLCmd.AddCommand(cmdSubtaskPosition)
 	WLCmd.AddCommand(cmdUpdateSubtaskPosition)
+
+	WLCmd.AddCommand(cmdMoveTask)
+	WLCmd.AddCommand(cmdMoveList)
+	WLCmd.AddCommand(cmdMoveSubtask)
+
+	WLCmd.AddCommand(cmdReport)
+	WLCmd.AddCommand(cmdAgenda)
+	WLCmd.AddCommand(cmdSearch)
+	WLCmd.AddCommand(cmdExport)
+	WLCmd.AddCommand(cmdRestore)
+	WLCmd.AddCommand(cmdImport)
+	WLCmd.AddCommand(cmdTodotxt)
+	WLCmd.AddCommand(cmdIcal)
+	WLCmd.AddCommand(cmdCaldav)
+	WLCmd.AddCommand(cmdExportMarkdown)
+	WLCmd.AddCommand(cmdHTMLReport)
+	WLCmd.AddCommand(cmdApply)
+	WLCmd.AddCommand(cmdTemplate)
 }
 
 func newClient(cmd *cobra.Command) wl.Client {
@@ -202,29 +240,21 @@ func handleError(err error) {
 	os.Exit(1)
 }
 
-func renderOutput(output interface{}, err error) {
-	if err != nil {
-		handleError(err)
-	}
-
-	var data []byte
-	if useJSON {
-		data, err = json.Marshal(output)
-		data = append(data, '\n')
-	} else {
-		data, err = yaml.Marshal(output)
-	}
+// renderOutput returns a function which renders the output, or exits if
+// there is an error. In table format, list and user IDs are resolved to
+// names with the client, unless it is nil.
+func renderOutput(client wl.Client) func(output interface{}, err error) {
+	return func(output interface{}, err error) {
+		if err != nil {
+			handleError(err)
+		}
 
-	if err != nil {
-		fmt.Printf("exiting - failed to render output - error: %v\n", err)
-		os.Exit(1)
+		err = write(os.Stdout, output, client)
+		if err != nil {
+			fmt.Printf("exiting - failed to render output - error: %v\n", err)
+			os.Exit(1)
+		}
 	}
-
-	// The JSON package escapes & which we do not want.
-	// It also escapes < and > but those are not present in URLs
-	data = bytes.Replace(data, []byte("\\u0026"), []byte("&"), -1)
-
-	fmt.Printf("%s", string(data))
 }
 
 func splitStringToUints(input string) ([]uint, error) {
@@ -0,0 +1,135 @@
+package wl
+
+import (
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Date is a calendar date without a time of day or location,
+// such as the due date of a task.
+// It marshals as YYYY-MM-DD. The zero Date marshals as an empty string.
+type Date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// NewDate returns the Date for the provided year, month and day.
+// Values outside their usual ranges are normalized, as with time.Date.
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns the Date of t in t's location.
+// The zero time returns the zero Date.
+func DateOf(t time.Time) Date {
+	if t.IsZero() {
+		return Date{}
+	}
+	year, month, day := t.Date()
+	return Date{year: year, month: month, day: day}
+}
+
+// ParseDate parses a date in YYYY-MM-DD format.
+// For compatibility with previous output, RFC3339 timestamps are also
+// accepted, using the date as written. An empty string returns the zero Date.
+func ParseDate(s string) (Date, error) {
+	if s == "" {
+		return Date{}, nil
+	}
+
+	if len(s) > len(dateLayout) && s[len(dateLayout)] == 'T' {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return Date{}, fmt.Errorf("failed to parse date %q: %v", s, err)
+		}
+		return DateOf(t), nil
+	}
+
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("failed to parse date %q: expected YYYY-MM-DD format", s)
+	}
+	return DateOf(t), nil
+}
+
+// IsZero reports whether d is the zero Date, i.e. no date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Year returns the year of d.
+func (d Date) Year() int {
+	return d.year
+}
+
+// Month returns the month of d.
+func (d Date) Month() time.Month {
+	return d.month
+}
+
+// Day returns the day of the month of d.
+func (d Date) Day() int {
+	return d.day
+}
+
+// Weekday returns the day of the week of d.
+func (d Date) Weekday() time.Weekday {
+	return d.In(time.UTC).Weekday()
+}
+
+// In returns midnight at the start of d in the provided location.
+// The zero Date returns the zero time.
+func (d Date) In(loc *time.Location) time.Time {
+	if d.IsZero() {
+		return time.Time{}
+	}
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns d plus n days.
+func (d Date) AddDays(n int) Date {
+	return NewDate(d.year, d.month, d.day+n)
+}
+
+// Before reports whether d is before other.
+func (d Date) Before(other Date) bool {
+	if d.year != other.year {
+		return d.year < other.year
+	}
+	if d.month != other.month {
+		return d.month < other.month
+	}
+	return d.day < other.day
+}
+
+// After reports whether d is after other.
+func (d Date) After(other Date) bool {
+	return other.Before(d)
+}
+
+// String returns d in YYYY-MM-DD format, or an empty string for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.year, d.month, d.day)
+}
+
+// MarshalText implements encoding.TextMarshaler,
+// which is used for both JSON and YAML.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,109 @@
+package wl_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("Date", func() {
+	It("normalizes out of range values", func() {
+		Expect(wl.NewDate(2016, time.February, 30)).To(Equal(wl.NewDate(2016, time.March, 1)))
+	})
+
+	It("returns the date of a time in its own location", func() {
+		t := time.Date(2016, time.January, 5, 2, 0, 0, 0, time.UTC)
+
+		Expect(wl.DateOf(t)).To(Equal(wl.NewDate(2016, time.January, 5)))
+		Expect(wl.DateOf(t.In(time.FixedZone("test", -8*60*60)))).To(Equal(wl.NewDate(2016, time.January, 4)))
+	})
+
+	It("treats the zero time as the zero date", func() {
+		Expect(wl.DateOf(time.Time{}).IsZero()).To(BeTrue())
+		Expect(wl.Date{}.In(time.UTC).IsZero()).To(BeTrue())
+	})
+
+	It("returns midnight in the provided location", func() {
+		loc := time.FixedZone("test", 10*60*60)
+		Expect(wl.NewDate(2016, time.January, 5).In(loc)).To(Equal(time.Date(2016, time.January, 5, 0, 0, 0, 0, loc)))
+	})
+
+	It("compares dates", func() {
+		d := wl.NewDate(2016, time.January, 5)
+
+		Expect(d.Before(d.AddDays(1))).To(BeTrue())
+		Expect(d.Before(wl.NewDate(2015, time.December, 31))).To(BeFalse())
+		Expect(d.After(d.AddDays(-1))).To(BeTrue())
+		Expect(d.Before(d)).To(BeFalse())
+		Expect(d.AddDays(27)).To(Equal(wl.NewDate(2016, time.February, 1)))
+		Expect(d.Weekday()).To(Equal(time.Tuesday))
+	})
+
+	Describe("ParseDate", func() {
+		It("parses YYYY-MM-DD", func() {
+			d, err := wl.ParseDate("2016-01-05")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d).To(Equal(wl.NewDate(2016, time.January, 5)))
+		})
+
+		It("parses an empty string as the zero date", func() {
+			d, err := wl.ParseDate("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.IsZero()).To(BeTrue())
+		})
+
+		It("parses timestamps using the date as written", func() {
+			d, err := wl.ParseDate("2016-01-05T00:00:00-08:00")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d).To(Equal(wl.NewDate(2016, time.January, 5)))
+
+			d, err = wl.ParseDate("0001-01-01T00:00:00Z")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.IsZero()).To(BeTrue())
+		})
+
+		It("returns an error for invalid dates", func() {
+			_, err := wl.ParseDate("2016-02-30")
+			Expect(err).To(HaveOccurred())
+
+			_, err = wl.ParseDate("5 Jan 2016")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("marshalling", func() {
+		type dated struct {
+			DueDate wl.Date `json:"due_date" yaml:"due_date"`
+		}
+
+		It("marshals JSON as YYYY-MM-DD", func() {
+			b, err := json.Marshal(dated{DueDate: wl.NewDate(2016, time.January, 5)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(`{"due_date":"2016-01-05"}`))
+
+			b, err = json.Marshal(dated{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(`{"due_date":""}`))
+		})
+
+		It("unmarshals JSON", func() {
+			var d dated
+			Expect(json.Unmarshal([]byte(`{"due_date":"2016-01-05"}`), &d)).To(Succeed())
+			Expect(d.DueDate).To(Equal(wl.NewDate(2016, time.January, 5)))
+
+			d = dated{}
+			Expect(json.Unmarshal([]byte(`{"due_date":null}`), &d)).To(Succeed())
+			Expect(d.DueDate.IsZero()).To(BeTrue())
+		})
+
+		It("marshals YAML as YYYY-MM-DD", func() {
+			b, err := yaml.Marshal(dated{DueDate: wl.NewDate(2016, time.January, 5)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("due_date: 2016-01-05\n"))
+		})
+	})
+})
@@ -0,0 +1,450 @@
+// Package dateparse parses natural-language and relative dates,
+// such as "tomorrow 9am", "next friday", "+3d", "in 2 weeks" and "eom".
+//
+// Inputs are interpreted relative to a reference time, in that time's location.
+package dateparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultReminderHour is the hour of times returned by ParseDateTime
+	// for inputs which do not include a time of day.
+	DefaultReminderHour = 9
+)
+
+var (
+	weekdays = map[string]time.Weekday{
+		"sunday":    time.Sunday,
+		"sun":       time.Sunday,
+		"monday":    time.Monday,
+		"mon":       time.Monday,
+		"tuesday":   time.Tuesday,
+		"tue":       time.Tuesday,
+		"tues":      time.Tuesday,
+		"wednesday": time.Wednesday,
+		"wed":       time.Wednesday,
+		"thursday":  time.Thursday,
+		"thu":       time.Thursday,
+		"thur":      time.Thursday,
+		"thurs":     time.Thursday,
+		"friday":    time.Friday,
+		"fri":       time.Friday,
+		"saturday":  time.Saturday,
+		"sat":       time.Saturday,
+	}
+
+	months = map[string]time.Month{
+		"january":   time.January,
+		"jan":       time.January,
+		"february":  time.February,
+		"feb":       time.February,
+		"march":     time.March,
+		"mar":       time.March,
+		"april":     time.April,
+		"apr":       time.April,
+		"may":       time.May,
+		"june":      time.June,
+		"jun":       time.June,
+		"july":      time.July,
+		"jul":       time.July,
+		"august":    time.August,
+		"aug":       time.August,
+		"september": time.September,
+		"sep":       time.September,
+		"sept":      time.September,
+		"october":   time.October,
+		"oct":       time.October,
+		"november":  time.November,
+		"nov":       time.November,
+		"december":  time.December,
+		"dec":       time.December,
+	}
+
+	units = map[string]string{
+		"d":       "d",
+		"day":     "d",
+		"days":    "d",
+		"w":       "w",
+		"wk":      "w",
+		"wks":     "w",
+		"week":    "w",
+		"weeks":   "w",
+		"m":       "m",
+		"mo":      "m",
+		"month":   "m",
+		"months":  "m",
+		"y":       "y",
+		"yr":      "y",
+		"yrs":     "y",
+		"year":    "y",
+		"years":   "y",
+		"h":       "h",
+		"hr":      "h",
+		"hrs":     "h",
+		"hour":    "h",
+		"hours":   "h",
+		"min":     "min",
+		"mins":    "min",
+		"minute":  "min",
+		"minutes": "min",
+	}
+)
+
+// Parse parses input relative to now.
+//
+// The returned time is in now's location. If the input does not
+// specify a time of day the returned time is at midnight and
+// hasTime is false.
+//
+// Supported inputs are:
+//
+//	2016-01-05, today, tomorrow, yesterday, now
+//	friday, next friday, next week, next month, next year
+//	jan 5, 5 jan
+//	+3d, -1w, +2m, +1y, +4h, +30min
+//	in 3 days, in 2 weeks, in a month, in 4 hours
+//	eow, eom, eoy (end of week, month and year)
+//
+// optionally followed by a time such as 9am, 9:30pm, 21:00, noon or midnight,
+// which may be preceded by "at". A time on its own means today.
+//
+// Timestamps such as 2016-01-05T09:00:00Z, the format used by the API,
+// are also supported and are exact.
+func Parse(input string, now time.Time) (t time.Time, hasTime bool, err error) {
+	fields := strings.Fields(strings.ToLower(input))
+	if len(fields) == 0 {
+		return time.Time{}, false, fmt.Errorf("date must not be empty")
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, time.RFC3339} {
+		if t, err := time.Parse(layout, strings.TrimSpace(input)); err == nil {
+			return t.In(now.Location()), true, nil
+		}
+	}
+
+	dateFields, hour, minute, hasTime, ok := splitTime(fields)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("cannot parse time in %q", input)
+	}
+
+	today := midnight(now)
+
+	var day time.Time
+	if len(dateFields) == 0 {
+		day = today
+	} else {
+		var exact bool
+		day, exact, ok = parseDate(dateFields, now, today)
+		if !ok {
+			return time.Time{}, false, fmt.Errorf("cannot parse date %q", input)
+		}
+
+		if exact {
+			if hasTime {
+				return time.Time{}, false, fmt.Errorf("cannot combine %q with a time", strings.Join(dateFields, " "))
+			}
+			return day, true, nil
+		}
+	}
+
+	if !hasTime {
+		return day, false, nil
+	}
+
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location()), true, nil
+}
+
+// splitTime removes a trailing time of day from fields.
+// ok is false if fields end with something which looks like an invalid time.
+func splitTime(fields []string) (dateFields []string, hour int, minute int, hasTime bool, ok bool) {
+	n := len(fields)
+
+	last := fields[n-1]
+	if (last == "am" || last == "pm") && n > 1 {
+		last = fields[n-2] + last
+		n--
+	}
+
+	hour, minute, hasTime, ok = parseTime(last)
+	if !hasTime {
+		return fields, 0, 0, false, true
+	}
+	if !ok {
+		return nil, 0, 0, false, false
+	}
+
+	n--
+	if n > 0 && fields[n-1] == "at" {
+		n--
+	}
+
+	return fields[:n], hour, minute, true, true
+}
+
+// parseTime parses a time of day.
+// isTime is false if s does not look like a time at all.
+func parseTime(s string) (hour int, minute int, isTime bool, ok bool) {
+	switch s {
+	case "noon":
+		return 12, 0, true, true
+	case "midnight":
+		return 0, 0, true, true
+	}
+
+	suffix := ""
+	if strings.HasSuffix(s, "am") || strings.HasSuffix(s, "pm") {
+		suffix = s[len(s)-2:]
+		s = s[:len(s)-2]
+	}
+
+	hourPart, minutePart := s, ""
+	if i := strings.Index(s, ":"); i >= 0 {
+		hourPart, minutePart = s[:i], s[i+1:]
+	} else if suffix == "" {
+		// A bare number is a day of the month, not a time.
+		return 0, 0, false, false
+	}
+
+	hour, err := strconv.Atoi(hourPart)
+	if err != nil || hourPart == "" {
+		return 0, 0, false, false
+	}
+
+	if minutePart != "" {
+		if len(minutePart) != 2 {
+			return 0, 0, true, false
+		}
+		minute, err = strconv.Atoi(minutePart)
+		if err != nil || minute > 59 {
+			return 0, 0, true, false
+		}
+	}
+
+	switch suffix {
+	case "":
+		if hour > 23 {
+			return 0, 0, true, false
+		}
+	default:
+		if hour < 1 || hour > 12 {
+			return 0, 0, true, false
+		}
+		hour = hour % 12
+		if suffix == "pm" {
+			hour += 12
+		}
+	}
+
+	return hour, minute, true, true
+}
+
+// parseDate parses the date part of the input.
+// exact is true if the date includes a time of day, e.g. "now" or "+2h".
+func parseDate(fields []string, now time.Time, today time.Time) (day time.Time, exact bool, ok bool) {
+	switch len(fields) {
+	case 1:
+		return parseWord(fields[0], now, today)
+
+	case 2:
+		if fields[0] == "next" {
+			if wd, found := weekdays[fields[1]]; found {
+				return nextWeekday(today, wd, false), false, true
+			}
+			if u, found := units[fields[1]]; found && (u == "w" || u == "m" || u == "y") {
+				return offset(now, today, 1, u)
+			}
+			return time.Time{}, false, false
+		}
+
+		if m, found := months[fields[0]]; found {
+			return monthDay(today, m, fields[1])
+		}
+		if m, found := months[fields[1]]; found {
+			return monthDay(today, m, fields[0])
+		}
+
+	case 3:
+		if fields[0] != "in" {
+			return time.Time{}, false, false
+		}
+
+		var n int
+		switch fields[1] {
+		case "a", "an":
+			n = 1
+		default:
+			var err error
+			n, err = strconv.Atoi(fields[1])
+			if err != nil || n < 0 {
+				return time.Time{}, false, false
+			}
+		}
+
+		u, found := units[fields[2]]
+		if !found {
+			return time.Time{}, false, false
+		}
+		return offset(now, today, n, u)
+	}
+
+	return time.Time{}, false, false
+}
+
+// parseWord parses single-word dates.
+func parseWord(word string, now time.Time, today time.Time) (day time.Time, exact bool, ok bool) {
+	switch word {
+	case "now":
+		return now, true, true
+	case "today", "tod":
+		return today, false, true
+	case "tomorrow", "tmr", "tmrw":
+		return today.AddDate(0, 0, 1), false, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), false, true
+	case "eow":
+		return nextWeekday(today, time.Sunday, true), false, true
+	case "eom":
+		return time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location()), false, true
+	case "eoy":
+		return time.Date(today.Year(), time.December, 31, 0, 0, 0, 0, today.Location()), false, true
+	}
+
+	if wd, found := weekdays[word]; found {
+		return nextWeekday(today, wd, true), false, true
+	}
+
+	if strings.HasPrefix(word, "+") || strings.HasPrefix(word, "-") {
+		return parseOffset(word, now, today)
+	}
+
+	d, err := time.ParseInLocation("2006-01-02", word, today.Location())
+	if err != nil {
+		return time.Time{}, false, false
+	}
+	return d, false, true
+}
+
+// parseOffset parses offsets such as +3d and -1w.
+func parseOffset(word string, now time.Time, today time.Time) (day time.Time, exact bool, ok bool) {
+	i := 1
+	for i < len(word) && word[i] >= '0' && word[i] <= '9' {
+		i++
+	}
+
+	n, err := strconv.Atoi(word[1:i])
+	if err != nil {
+		return time.Time{}, false, false
+	}
+	if word[0] == '-' {
+		n = -n
+	}
+
+	u, found := units[word[i:]]
+	if !found {
+		return time.Time{}, false, false
+	}
+	return offset(now, today, n, u)
+}
+
+// offset returns the date n units after today,
+// or the time n hours or minutes after now.
+func offset(now time.Time, today time.Time, n int, unit string) (day time.Time, exact bool, ok bool) {
+	switch unit {
+	case "d":
+		return today.AddDate(0, 0, n), false, true
+	case "w":
+		return today.AddDate(0, 0, 7*n), false, true
+	case "m":
+		return addMonths(today, n), false, true
+	case "y":
+		return addMonths(today, 12*n), false, true
+	case "h":
+		return now.Add(time.Duration(n) * time.Hour), true, true
+	case "min":
+		return now.Add(time.Duration(n) * time.Minute), true, true
+	}
+	return time.Time{}, false, false
+}
+
+// monthDay returns the next occurrence of the day in the month,
+// which is today or later.
+func monthDay(today time.Time, m time.Month, dayOfMonth string) (day time.Time, exact bool, ok bool) {
+	d, err := strconv.Atoi(strings.TrimRight(dayOfMonth, "stndrh"))
+	if err != nil || d < 1 || d > 31 {
+		return time.Time{}, false, false
+	}
+
+	for year := today.Year(); year <= today.Year()+8; year++ {
+		day = time.Date(year, m, d, 0, 0, 0, 0, today.Location())
+		if day.Month() != m {
+			// e.g. feb 29 in a non-leap year
+			continue
+		}
+		if !day.Before(today) {
+			return day, false, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// nextWeekday returns the next day which is the weekday.
+// If includeToday is true and today is the weekday, today is returned.
+func nextWeekday(today time.Time, wd time.Weekday, includeToday bool) time.Time {
+	days := (int(wd) - int(today.Weekday()) + 7) % 7
+	if days == 0 && !includeToday {
+		days = 7
+	}
+	return today.AddDate(0, 0, days)
+}
+
+// addMonths adds n months to t, clamping to the end of the month,
+// so that one month after January 31st is the last day of February.
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+
+	d := t.Day()
+	if d > last {
+		d = last
+	}
+	return time.Date(first.Year(), first.Month(), d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// ParseDateTime parses input like Parse, for times such as reminders.
+// If the input does not specify a time of day the returned time is at
+// DefaultReminderHour.
+func ParseDateTime(input string, now time.Time) (time.Time, error) {
+	t, hasTime, err := Parse(input, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !hasTime {
+		t = time.Date(t.Year(), t.Month(), t.Day(), DefaultReminderHour, 0, 0, 0, t.Location())
+	}
+	return t, nil
+}
+
+// ParseTimeOfDay parses a time of day on its own, such as 9am, 9:30 pm,
+// 21:00, noon or at midnight.
+func ParseTimeOfDay(input string) (hour int, minute int, err error) {
+	fields := strings.Fields(strings.ToLower(input))
+	if len(fields) == 0 {
+		return 0, 0, fmt.Errorf("time must not be empty")
+	}
+
+	dateFields, hour, minute, hasTime, ok := splitTime(fields)
+	if !ok || !hasTime || len(dateFields) > 0 {
+		return 0, 0, fmt.Errorf("cannot parse time %q", input)
+	}
+	return hour, minute, nil
+}
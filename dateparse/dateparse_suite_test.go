@@ -0,0 +1,13 @@
+package dateparse_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestDateparse(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Dateparse Suite")
+}
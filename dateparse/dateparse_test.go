@@ -0,0 +1,227 @@
+package dateparse_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl/dateparse"
+)
+
+var _ = Describe("Parse", func() {
+	var (
+		// Wednesday afternoon, west of UTC
+		zone = time.FixedZone("test", -8*60*60)
+		now  = time.Date(2016, time.January, 6, 15, 4, 5, 0, zone)
+	)
+
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, zone)
+	}
+
+	at := func(year int, month time.Month, d int, hour int, minute int) time.Time {
+		return time.Date(year, month, d, hour, minute, 0, 0, zone)
+	}
+
+	table.DescribeTable("dates",
+		func(input string, expected time.Time) {
+			t, hasTime, err := dateparse.Parse(input, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hasTime).To(BeFalse())
+			Expect(t).To(Equal(expected))
+		},
+		table.Entry("ISO date", "2016-02-29", day(2016, time.February, 29)),
+		table.Entry("today", "today", day(2016, time.January, 6)),
+		table.Entry("tod", "tod", day(2016, time.January, 6)),
+		table.Entry("tomorrow", "tomorrow", day(2016, time.January, 7)),
+		table.Entry("tmr", "tmr", day(2016, time.January, 7)),
+		table.Entry("yesterday", "yesterday", day(2016, time.January, 5)),
+		table.Entry("upper case", "TOMORROW", day(2016, time.January, 7)),
+		table.Entry("surrounding whitespace", "  today ", day(2016, time.January, 6)),
+		table.Entry("weekday later this week", "friday", day(2016, time.January, 8)),
+		table.Entry("weekday abbreviation", "fri", day(2016, time.January, 8)),
+		table.Entry("weekday earlier in the week", "monday", day(2016, time.January, 11)),
+		table.Entry("weekday which is today", "wednesday", day(2016, time.January, 6)),
+		table.Entry("next weekday", "next friday", day(2016, time.January, 8)),
+		table.Entry("next weekday which is today", "next wed", day(2016, time.January, 13)),
+		table.Entry("next week", "next week", day(2016, time.January, 13)),
+		table.Entry("next month", "next month", day(2016, time.February, 6)),
+		table.Entry("next year", "next year", day(2017, time.January, 6)),
+		table.Entry("days offset", "+3d", day(2016, time.January, 9)),
+		table.Entry("negative days offset", "-7d", day(2015, time.December, 30)),
+		table.Entry("weeks offset", "+2w", day(2016, time.January, 20)),
+		table.Entry("months offset", "+1m", day(2016, time.February, 6)),
+		table.Entry("years offset", "+1y", day(2017, time.January, 6)),
+		table.Entry("offset with unit word", "+10days", day(2016, time.January, 16)),
+		table.Entry("in days", "in 3 days", day(2016, time.January, 9)),
+		table.Entry("in one day", "in 1 day", day(2016, time.January, 7)),
+		table.Entry("in weeks", "in 2 weeks", day(2016, time.January, 20)),
+		table.Entry("in a week", "in a week", day(2016, time.January, 13)),
+		table.Entry("in months", "in 3 months", day(2016, time.April, 6)),
+		table.Entry("in a year", "in a year", day(2017, time.January, 6)),
+		table.Entry("end of week", "eow", day(2016, time.January, 10)),
+		table.Entry("end of month", "eom", day(2016, time.January, 31)),
+		table.Entry("end of year", "eoy", day(2016, time.December, 31)),
+		table.Entry("month and day", "jan 20", day(2016, time.January, 20)),
+		table.Entry("day and month", "20 january", day(2016, time.January, 20)),
+		table.Entry("ordinal day", "feb 1st", day(2016, time.February, 1)),
+		table.Entry("month and day in the past", "jan 5", day(2017, time.January, 5)),
+		table.Entry("month and day which is today", "jan 6", day(2016, time.January, 6)),
+		table.Entry("leap day", "feb 29", day(2016, time.February, 29)),
+	)
+
+	table.DescribeTable("dates and times",
+		func(input string, expected time.Time) {
+			t, hasTime, err := dateparse.Parse(input, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hasTime).To(BeTrue())
+			Expect(t).To(Equal(expected))
+		},
+		table.Entry("now", "now", now),
+		table.Entry("timestamp", "2016-01-05T09:00:00Z", at(2016, time.January, 5, 1, 0)),
+		table.Entry("timestamp with offset", "2016-01-05T09:00:00+01:00", at(2016, time.January, 5, 0, 0)),
+		table.Entry("API timestamp", "1970-08-30T08:29:46.203Z", time.Date(1970, time.August, 30, 0, 29, 46, 203000000, zone)),
+		table.Entry("time alone", "9am", at(2016, time.January, 6, 9, 0)),
+		table.Entry("afternoon time alone", "5pm", at(2016, time.January, 6, 17, 0)),
+		table.Entry("date and time", "tomorrow 9am", at(2016, time.January, 7, 9, 0)),
+		table.Entry("separate meridiem", "tomorrow 9 am", at(2016, time.January, 7, 9, 0)),
+		table.Entry("hours and minutes", "tomorrow 9:30pm", at(2016, time.January, 7, 21, 30)),
+		table.Entry("24 hour time", "tomorrow 21:15", at(2016, time.January, 7, 21, 15)),
+		table.Entry("at", "friday at 8am", at(2016, time.January, 8, 8, 0)),
+		table.Entry("noon", "tomorrow noon", at(2016, time.January, 7, 12, 0)),
+		table.Entry("midnight", "tomorrow midnight", at(2016, time.January, 7, 0, 0)),
+		table.Entry("12am", "today 12am", at(2016, time.January, 6, 0, 0)),
+		table.Entry("12pm", "today 12pm", at(2016, time.January, 6, 12, 0)),
+		table.Entry("ISO date and time", "2016-03-01 07:00", at(2016, time.March, 1, 7, 0)),
+		table.Entry("offset and time", "+2d 10am", at(2016, time.January, 8, 10, 0)),
+		table.Entry("in weeks and time", "in 2 weeks at 6pm", at(2016, time.January, 20, 18, 0)),
+		table.Entry("hours offset", "+2h", now.Add(2*time.Hour)),
+		table.Entry("in minutes", "in 30 minutes", now.Add(30*time.Minute)),
+		table.Entry("in an hour", "in an hour", now.Add(time.Hour)),
+	)
+
+	table.DescribeTable("invalid input",
+		func(input string) {
+			_, _, err := dateparse.Parse(input, now)
+			Expect(err).To(HaveOccurred())
+		},
+		table.Entry("empty", ""),
+		table.Entry("whitespace", "   "),
+		table.Entry("unknown word", "someday"),
+		table.Entry("invalid ISO date", "2016-02-30"),
+		table.Entry("trailing garbage after date", "2016-01-05T10:00"),
+		table.Entry("offset without unit", "+3"),
+		table.Entry("offset without number", "+d"),
+		table.Entry("unknown unit", "+3q"),
+		table.Entry("in without unit", "in 3"),
+		table.Entry("in with unknown unit", "in 3 fortnights"),
+		table.Entry("negative in", "in -3 days"),
+		table.Entry("next unknown", "next tuesdays"),
+		table.Entry("next day", "next day"),
+		table.Entry("invalid hour", "tomorrow 25:00"),
+		table.Entry("invalid 12 hour time", "tomorrow 13pm"),
+		table.Entry("invalid minutes", "tomorrow 9:60"),
+		table.Entry("single digit minutes", "tomorrow 9:5"),
+		table.Entry("day out of range", "jan 32"),
+		table.Entry("meridiem alone", "pm"),
+		table.Entry("time combined with an exact offset", "+2h 9am"),
+		table.Entry("too many words", "a week from now"),
+	)
+
+	It("uses the location of the reference time", func() {
+		utcNow := now.UTC()
+
+		t, _, err := dateparse.Parse("today", utcNow)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t).To(Equal(time.Date(2016, time.January, 6, 0, 0, 0, 0, time.UTC)))
+
+		t, _, err = dateparse.Parse("today", now.In(time.FixedZone("east", 10*60*60)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.Day()).To(Equal(7))
+	})
+
+	It("clamps month offsets to the end of the month", func() {
+		endOfJanuary := time.Date(2016, time.January, 31, 12, 0, 0, 0, zone)
+
+		t, _, err := dateparse.Parse("+1m", endOfJanuary)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t).To(Equal(day(2016, time.February, 29)))
+
+		t, _, err = dateparse.Parse("in 1 year", time.Date(2016, time.February, 29, 0, 0, 0, 0, zone))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t).To(Equal(day(2017, time.February, 28)))
+	})
+
+	It("finds the next occurrence of a leap day", func() {
+		t, _, err := dateparse.Parse("feb 29", time.Date(2016, time.March, 1, 0, 0, 0, 0, zone))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t).To(Equal(day(2020, time.February, 29)))
+	})
+
+	It("keeps today's date across a DST change", func() {
+		ny, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			Skip("time zone database not available")
+		}
+
+		t, _, err := dateparse.Parse("tomorrow 9am", time.Date(2016, time.March, 12, 22, 0, 0, 0, ny))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t).To(Equal(time.Date(2016, time.March, 13, 9, 0, 0, 0, ny)))
+	})
+})
+
+var _ = Describe("ParseDateTime", func() {
+	var now = time.Date(2016, time.January, 6, 15, 4, 5, 0, time.UTC)
+
+	It("returns the time, if there is one", func() {
+		t, err := dateparse.ParseDateTime("tomorrow 5pm", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t).To(Equal(time.Date(2016, time.January, 7, 17, 0, 0, 0, time.UTC)))
+	})
+
+	It("returns timestamps exactly", func() {
+		t, err := dateparse.ParseDateTime("1970-08-30T08:29:46.203Z", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t).To(Equal(time.Date(1970, time.August, 30, 8, 29, 46, 203000000, time.UTC)))
+	})
+
+	It("defaults to the reminder hour", func() {
+		t, err := dateparse.ParseDateTime("tomorrow", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t).To(Equal(time.Date(2016, time.January, 7, dateparse.DefaultReminderHour, 0, 0, 0, time.UTC)))
+	})
+
+	It("returns an error for invalid input", func() {
+		_, err := dateparse.ParseDateTime("soon", now)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ParseTimeOfDay", func() {
+	table.DescribeTable("valid input",
+		func(input string, hour int, minute int) {
+			h, m, err := dateparse.ParseTimeOfDay(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(h).To(Equal(hour))
+			Expect(m).To(Equal(minute))
+		},
+		table.Entry("12 hour time", "8am", 8, 0),
+		table.Entry("separate meridiem", "9:30 PM", 21, 30),
+		table.Entry("24 hour time", "21:00", 21, 0),
+		table.Entry("noon", "noon", 12, 0),
+		table.Entry("preceded by at", "at midnight", 0, 0),
+	)
+
+	table.DescribeTable("invalid input",
+		func(input string) {
+			_, _, err := dateparse.ParseTimeOfDay(input)
+			Expect(err).To(HaveOccurred())
+		},
+		table.Entry("empty", ""),
+		table.Entry("date", "tomorrow"),
+		table.Entry("date and time", "tomorrow 9am"),
+		table.Entry("invalid time", "25:00"),
+		table.Entry("bare number", "9"),
+	)
+})
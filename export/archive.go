@@ -0,0 +1,237 @@
+package export
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robdimsdale/wl"
+)
+
+const (
+	// SchemaVersion is the version of the archive schema written by this package.
+	// It is incremented whenever the schema changes incompatibly.
+	SchemaVersion = 1
+
+	// FormatJSON writes the archive as a single JSON document.
+	// File contents cannot be included.
+	FormatJSON = "json"
+
+	// FormatTarGz writes the archive as a gzipped tar file containing
+	// archive.json and, optionally, the contents of each file.
+	FormatTarGz = "tar.gz"
+
+	archiveFileName = "archive.json"
+	filesDir        = "files"
+)
+
+// Archive is a snapshot of an account.
+//
+// Objects are as returned by the API, and reference each other by their
+// original IDs, e.g. Task.ListID, Folder.ListIDs and Position.Values.
+// The positions are the list positions of the user, and the task and
+// subtask positions of each list.
+type Archive struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+
+	User             wl.User          `json:"user"`
+	Lists            []wl.List        `json:"lists"`
+	Folders          []wl.Folder      `json:"folders"`
+	ListPositions    []wl.Position    `json:"list_positions"`
+	TaskPositions    []wl.Position    `json:"task_positions"`
+	SubtaskPositions []wl.Position    `json:"subtask_positions"`
+	Tasks            []wl.Task        `json:"tasks"`
+	Subtasks         []wl.Subtask     `json:"subtasks"`
+	Notes            []wl.Note        `json:"notes"`
+	TaskComments     []wl.TaskComment `json:"task_comments"`
+	Reminders        []wl.Reminder    `json:"reminders"`
+	Memberships      []wl.Membership  `json:"memberships"`
+	Webhooks         []wl.Webhook     `json:"webhooks"`
+	Files            []wl.File        `json:"files"`
+
+	// FileContents contains the contents of files, by file ID.
+	// It is only populated if file contents were exported, and is stored
+	// in tar.gz archives as files/<file-id>.
+	FileContents map[uint][]byte `json:"-"`
+}
+
+// Write writes the archive to the writer in the provided format.
+func Write(w io.Writer, a Archive, format string) error {
+	switch format {
+	case FormatJSON:
+		return WriteJSON(w, a)
+	case FormatTarGz:
+		return WriteTarGz(w, a)
+	default:
+		return fmt.Errorf("unrecognized format: %s", format)
+	}
+}
+
+// FormatForPath returns the format implied by the extension of the path,
+// defaulting to FormatJSON.
+func FormatForPath(p string) string {
+	if strings.HasSuffix(p, ".tar.gz") || strings.HasSuffix(p, ".tgz") {
+		return FormatTarGz
+	}
+	return FormatJSON
+}
+
+// WriteJSON writes the archive as JSON.
+// It returns an error if the archive contains file contents.
+func WriteJSON(w io.Writer, a Archive) error {
+	if len(a.FileContents) > 0 {
+		return fmt.Errorf("file contents can only be written in %s format", FormatTarGz)
+	}
+
+	b, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// WriteTarGz writes the archive as a gzipped tar file.
+func WriteTarGz(w io.Writer, a Archive) error {
+	b, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	err = writeTarEntry(tw, archiveFileName, b, a.ExportedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range a.Files {
+		contents, ok := a.FileContents[f.ID]
+		if !ok {
+			continue
+		}
+
+		name := path.Join(filesDir, strconv.FormatUint(uint64(f.ID), 10))
+		err = writeTarEntry(tw, name, contents, a.ExportedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeTarEntry(tw *tar.Writer, name string, contents []byte, modTime time.Time) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(contents)),
+		ModTime: modTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tw.Write(contents)
+	return err
+}
+
+// Read reads an archive in either format, detecting gzipped archives
+// by their contents. It returns an error if the archive was written
+// with a newer or unknown schema version.
+func Read(r io.Reader) (Archive, error) {
+	br := bufio.NewReader(r)
+
+	magic, err := br.Peek(2)
+	if err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		return readTarGz(br)
+	}
+
+	var a Archive
+	err = json.NewDecoder(br).Decode(&a)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to read archive: %v", err)
+	}
+
+	return a, checkVersion(a)
+}
+
+func readTarGz(r io.Reader) (Archive, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return Archive{}, err
+	}
+	defer gr.Close()
+
+	var a Archive
+	found := false
+	contents := map[uint][]byte{}
+
+	tr := tar.NewReader(gr)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Archive{}, fmt.Errorf("failed to read archive: %v", err)
+		}
+
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return Archive{}, fmt.Errorf("failed to read archive: %v", err)
+		}
+
+		switch {
+		case h.Name == archiveFileName:
+			err = json.Unmarshal(b, &a)
+			if err != nil {
+				return Archive{}, fmt.Errorf("failed to read %s: %v", archiveFileName, err)
+			}
+			found = true
+
+		case path.Dir(h.Name) == filesDir:
+			id, err := strconv.ParseUint(path.Base(h.Name), 10, 0)
+			if err != nil {
+				return Archive{}, fmt.Errorf("unexpected file in archive: %s", h.Name)
+			}
+			contents[uint(id)] = b
+		}
+	}
+
+	if !found {
+		return Archive{}, fmt.Errorf("archive does not contain %s", archiveFileName)
+	}
+
+	if len(contents) > 0 {
+		a.FileContents = contents
+	}
+	return a, checkVersion(a)
+}
+
+func checkVersion(a Archive) error {
+	if a.Version < 1 || a.Version > SchemaVersion {
+		return fmt.Errorf(
+			"unsupported archive version %d - expected at most %d",
+			a.Version,
+			SchemaVersion,
+		)
+	}
+	return nil
+}
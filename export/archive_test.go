@@ -0,0 +1,92 @@
+package export_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/export"
+)
+
+var _ = Describe("Archive", func() {
+	var a export.Archive
+
+	BeforeEach(func() {
+		a = export.Archive{
+			Version:    export.SchemaVersion,
+			ExportedAt: time.Date(2016, time.January, 5, 10, 0, 0, 0, time.UTC),
+			User:       wl.User{ID: 1, Name: "Alice"},
+			Lists:      []wl.List{{ID: 10, Title: "inbox"}},
+			Tasks: []wl.Task{{
+				ID:             11,
+				ListID:         10,
+				Title:          "Renew certs",
+				DueDate:        wl.NewDate(2016, time.January, 6),
+				RecurrenceType: wl.RecurrenceWeek,
+			}},
+			Reminders: []wl.Reminder{{ID: 12, TaskID: 11, Date: time.Date(2016, time.January, 6, 9, 0, 0, 0, time.UTC)}},
+			Files:     []wl.File{{ID: 13, TaskID: 11, FileName: "cert.pem"}},
+		}
+	})
+
+	It("round-trips through JSON", func() {
+		var buf bytes.Buffer
+		err := export.Write(&buf, a, export.FormatJSON)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring(`"version": 1`))
+		Expect(buf.String()).To(ContainSubstring(`"due_date": "2016-01-06"`))
+
+		read, err := export.Read(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(read).To(Equal(a))
+	})
+
+	It("round-trips through tar.gz, including file contents", func() {
+		a.FileContents = map[uint][]byte{13: []byte("-----BEGIN CERTIFICATE-----")}
+
+		var buf bytes.Buffer
+		err := export.Write(&buf, a, export.FormatTarGz)
+		Expect(err).NotTo(HaveOccurred())
+
+		read, err := export.Read(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(read).To(Equal(a))
+	})
+
+	It("does not write file contents as JSON", func() {
+		a.FileContents = map[uint][]byte{13: []byte("contents")}
+
+		err := export.Write(&bytes.Buffer{}, a, export.FormatJSON)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects unknown formats", func() {
+		err := export.Write(&bytes.Buffer{}, a, "zip")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects archives with unsupported versions", func() {
+		a.Version = export.SchemaVersion + 1
+
+		var buf bytes.Buffer
+		err := export.Write(&buf, a, export.FormatJSON)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = export.Read(&buf)
+		Expect(err).To(MatchError(ContainSubstring("unsupported archive version")))
+	})
+
+	It("rejects invalid archives", func() {
+		_, err := export.Read(bytes.NewBufferString("not an archive"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("infers formats from paths", func() {
+		Expect(export.FormatForPath("backup.tar.gz")).To(Equal(export.FormatTarGz))
+		Expect(export.FormatForPath("backup.tgz")).To(Equal(export.FormatTarGz))
+		Expect(export.FormatForPath("backup.json")).To(Equal(export.FormatJSON))
+		Expect(export.FormatForPath("-")).To(Equal(export.FormatJSON))
+	})
+})
@@ -0,0 +1,334 @@
+// Package export exports a whole account to a versioned archive.
+//
+// An archive is either a single JSON document, or a gzipped tar file
+// containing that document as archive.json and the contents of each file
+// as files/<file-id>. The JSON document is an object with the keys:
+//
+//	version            the schema version, currently 1
+//	exported_at        the time of the export, in RFC3339 format
+//	user               the user, as returned by the user endpoint
+//	lists, folders     all lists and folders
+//	list_positions     the positions of the lists
+//	task_positions     the positions of the tasks in each list
+//	subtask_positions  the positions of the subtasks in each list
+//	tasks, subtasks    all tasks and subtasks, both completed and not
+//	notes, task_comments, reminders, memberships, webhooks, files
+//	                   all of each, in all lists
+//
+// Each object has the same fields as in the wl package, e.g. a task has
+// the fields of wl.Task. Objects keep their original IDs.
+//
+// Readers should reject archives with a version newer than they support.
+// Fields may be added without changing the version.
+package export
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/robdimsdale/wl"
+)
+
+const (
+	// DefaultConcurrency is the number of lists or files fetched at once.
+	DefaultConcurrency = 4
+)
+
+// Stages of an export, reported via Progress.
+const (
+	StageAccount = "account"
+	StageLists   = "lists"
+	StageFiles   = "files"
+)
+
+// Progress describes how far an export has got.
+// Item is the title of the list or the name of the file just fetched.
+type Progress struct {
+	Stage string
+	Done  int
+	Total int
+	Item  string
+}
+
+// Options configure an export.
+type Options struct {
+	// Concurrency is the number of lists or files fetched at once.
+	// It defaults to DefaultConcurrency.
+	Concurrency int
+
+	// IncludeFileContents downloads the contents of each file.
+	IncludeFileContents bool
+
+	// Download fetches the contents of a file from its URL.
+	// It defaults to an HTTP GET.
+	Download func(url string) (io.ReadCloser, error)
+
+	// Progress is called after each step of the export.
+	// Calls are not concurrent.
+	Progress func(Progress)
+}
+
+// listData is everything exported for a single list.
+type listData struct {
+	taskPositions    []wl.Position
+	subtaskPositions []wl.Position
+	tasks            []wl.Task
+	subtasks         []wl.Subtask
+	notes            []wl.Note
+	taskComments     []wl.TaskComment
+	reminders        []wl.Reminder
+	memberships      []wl.Membership
+	webhooks         []wl.Webhook
+	files            []wl.File
+}
+
+// Export fetches the whole account.
+func Export(client wl.Client, opts Options) (Archive, error) {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.Download == nil {
+		opts.Download = download
+	}
+	if opts.Progress == nil {
+		opts.Progress = func(Progress) {}
+	}
+
+	a := Archive{
+		Version:    SchemaVersion,
+		ExportedAt: time.Now().UTC(),
+	}
+
+	var err error
+	a.User, err = client.User()
+	if err != nil {
+		return Archive{}, err
+	}
+
+	a.Lists, err = client.Lists()
+	if err != nil {
+		return Archive{}, err
+	}
+
+	a.Folders, err = client.Folders()
+	if err != nil {
+		return Archive{}, err
+	}
+
+	a.ListPositions, err = client.ListPositions()
+	if err != nil {
+		return Archive{}, err
+	}
+	opts.Progress(Progress{Stage: StageAccount, Done: 1, Total: 1})
+
+	data := make([]listData, len(a.Lists))
+	err = forEach(len(a.Lists), opts.Concurrency,
+		func(i int) error {
+			var err error
+			data[i], err = fetchList(client, a.Lists[i].ID)
+			if err != nil {
+				return fmt.Errorf("failed to export list %d: %v", a.Lists[i].ID, err)
+			}
+			return nil
+		},
+		func(i int, done int) {
+			opts.Progress(Progress{Stage: StageLists, Done: done, Total: len(a.Lists), Item: a.Lists[i].Title})
+		},
+	)
+	if err != nil {
+		return Archive{}, err
+	}
+
+	for _, d := range data {
+		a.TaskPositions = append(a.TaskPositions, d.taskPositions...)
+		a.SubtaskPositions = append(a.SubtaskPositions, d.subtaskPositions...)
+		a.Tasks = append(a.Tasks, d.tasks...)
+		a.Subtasks = append(a.Subtasks, d.subtasks...)
+		a.Notes = append(a.Notes, d.notes...)
+		a.TaskComments = append(a.TaskComments, d.taskComments...)
+		a.Reminders = append(a.Reminders, d.reminders...)
+		a.Memberships = append(a.Memberships, d.memberships...)
+		a.Webhooks = append(a.Webhooks, d.webhooks...)
+		a.Files = append(a.Files, d.files...)
+	}
+
+	if !opts.IncludeFileContents || len(a.Files) == 0 {
+		return a, nil
+	}
+
+	contents := make([][]byte, len(a.Files))
+	err = forEach(len(a.Files), opts.Concurrency,
+		func(i int) error {
+			var err error
+			contents[i], err = fetchFile(opts.Download, a.Files[i])
+			return err
+		},
+		func(i int, done int) {
+			opts.Progress(Progress{Stage: StageFiles, Done: done, Total: len(a.Files), Item: a.Files[i].FileName})
+		},
+	)
+	if err != nil {
+		return Archive{}, err
+	}
+
+	a.FileContents = make(map[uint][]byte, len(a.Files))
+	for i, f := range a.Files {
+		a.FileContents[f.ID] = contents[i]
+	}
+
+	return a, nil
+}
+
+// fetchList fetches everything in the list.
+func fetchList(client wl.Client, listID uint) (listData, error) {
+	var d listData
+	var err error
+
+	d.taskPositions, err = client.TaskPositionsForListID(listID)
+	if err != nil {
+		return listData{}, err
+	}
+
+	d.subtaskPositions, err = client.SubtaskPositionsForListID(listID)
+	if err != nil {
+		return listData{}, err
+	}
+
+	for _, completed := range []bool{false, true} {
+		tasks, err := client.CompletedTasksForListID(listID, completed)
+		if err != nil {
+			return listData{}, err
+		}
+		d.tasks = append(d.tasks, tasks...)
+
+		subtasks, err := client.CompletedSubtasksForListID(listID, completed)
+		if err != nil {
+			return listData{}, err
+		}
+		d.subtasks = append(d.subtasks, subtasks...)
+	}
+
+	d.notes, err = client.NotesForListID(listID)
+	if err != nil {
+		return listData{}, err
+	}
+
+	d.taskComments, err = client.TaskCommentsForListID(listID)
+	if err != nil {
+		return listData{}, err
+	}
+
+	d.reminders, err = client.RemindersForListID(listID)
+	if err != nil {
+		return listData{}, err
+	}
+
+	d.memberships, err = client.MembershipsForListID(listID)
+	if err != nil {
+		return listData{}, err
+	}
+
+	d.webhooks, err = client.WebhooksForListID(listID)
+	if err != nil {
+		return listData{}, err
+	}
+
+	d.files, err = client.FilesForListID(listID)
+	if err != nil {
+		return listData{}, err
+	}
+
+	return d, nil
+}
+
+func fetchFile(dl func(url string) (io.ReadCloser, error), f wl.File) ([]byte, error) {
+	rc, err := dl(f.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file %d: %v", f.ID, err)
+	}
+	defer rc.Close()
+
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file %d: %v", f.ID, err)
+	}
+	return b, nil
+}
+
+func download(url string) (io.ReadCloser, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+	}
+	return resp.Body, nil
+}
+
+// forEach calls work for each index from 0 to n-1, with at most concurrency
+// calls at once. done is called after each successful call, from a single
+// goroutine, with the number of calls completed so far. The first error is
+// returned, after which no more work is started.
+func forEach(n int, concurrency int, work func(i int) error, done func(i int, done int)) error {
+	type result struct {
+		i   int
+		err error
+	}
+
+	indexes := make(chan int)
+	results := make(chan result)
+	stop := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results <- result{i: i, err: work(i)}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(indexes)
+		for i := 0; i < n; i++ {
+			select {
+			case indexes <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var firstErr error
+	completed := 0
+	for r := range results {
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+				close(stop)
+			}
+			continue
+		}
+
+		if firstErr == nil {
+			completed++
+			done(r.i, completed)
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,178 @@
+package export_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/export"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+// newClient returns an account where each list has a task, a completed
+// task and one of everything else.
+func newClient() *wltest.Client {
+	client := wltest.NewClient()
+	client.SetUser(wl.User{ID: 1, Name: "Alice"})
+
+	for _, list := range []wl.List{{ID: 10, Title: "inbox"}, {ID: 20, Title: "Work"}, {ID: 30, Title: "Home"}} {
+		id := list.ID
+		client.AddList(list)
+		client.AddTask(wl.Task{ID: id + 1, ListID: id})
+		client.AddTask(wl.Task{ID: id + 2, ListID: id, Completed: true})
+		client.SetTaskPosition(id, id+1, id+2)
+		client.AddSubtask(wl.Subtask{ID: id + 3, TaskID: id + 1})
+		client.SetSubtaskPosition(id+1, id+3)
+		client.AddNote(wl.Note{ID: id + 4, TaskID: id + 1})
+		client.AddTaskComment(wl.TaskComment{ID: id + 5, TaskID: id + 1})
+		client.AddReminder(wl.Reminder{ID: id + 6, TaskID: id + 1})
+		client.AddMembership(wl.Membership{ID: id + 7, ListID: id})
+		client.AddWebhook(wl.Webhook{ID: id + 8, ListID: id})
+		client.AddFile(wl.File{ID: id + 9, ListID: id, TaskID: id + 1, FileName: "file.txt", URL: "https://example.com/file"}, nil)
+	}
+	client.AddFolder(wl.Folder{ID: 2, ListIDs: []uint{10}})
+	client.SetListPosition(10, 20)
+	return client
+}
+
+var _ = Describe("Export", func() {
+	var (
+		client   *wltest.Client
+		progress []export.Progress
+		opts     export.Options
+	)
+
+	BeforeEach(func() {
+		client = newClient()
+
+		progress = nil
+		opts = export.Options{
+			Concurrency: 2,
+			Progress: func(p export.Progress) {
+				progress = append(progress, p)
+			},
+		}
+	})
+
+	It("exports everything in every list, in list order", func() {
+		a, err := export.Export(client, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(a.Version).To(Equal(export.SchemaVersion))
+		Expect(a.ExportedAt).NotTo(BeZero())
+		Expect(a.User.Name).To(Equal("Alice"))
+		Expect(a.Lists).To(HaveLen(3))
+		Expect(a.Folders).To(HaveLen(1))
+		Expect(a.ListPositions).To(HaveLen(1))
+
+		Expect(a.Tasks).To(Equal([]wl.Task{
+			{ID: 11, ListID: 10},
+			{ID: 12, ListID: 10, Completed: true},
+			{ID: 21, ListID: 20},
+			{ID: 22, ListID: 20, Completed: true},
+			{ID: 31, ListID: 30},
+			{ID: 32, ListID: 30, Completed: true},
+		}))
+		Expect(a.TaskPositions).To(HaveLen(3))
+		Expect(a.SubtaskPositions).To(HaveLen(6))
+		Expect(a.Subtasks).To(HaveLen(3))
+		Expect(a.Notes).To(HaveLen(3))
+		Expect(a.TaskComments).To(HaveLen(3))
+		Expect(a.Reminders).To(HaveLen(3))
+		Expect(a.Memberships).To(HaveLen(3))
+		Expect(a.Webhooks).To(HaveLen(3))
+		Expect(a.Files).To(HaveLen(3))
+		Expect(a.FileContents).To(BeNil())
+	})
+
+	It("fetches at most the provided number of lists at once", func() {
+		var (
+			mutex       sync.Mutex
+			inFlight    int
+			maxInFlight int
+		)
+		client.Before("TaskPositionsForListID", func(args ...interface{}) error {
+			mutex.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mutex.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mutex.Lock()
+			inFlight--
+			mutex.Unlock()
+			return nil
+		})
+
+		_, err := export.Export(client, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(maxInFlight).To(BeNumerically(">", 0))
+		Expect(maxInFlight).To(BeNumerically("<=", 2))
+	})
+
+	It("reports progress", func() {
+		_, err := export.Export(client, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(progress).To(HaveLen(4))
+		Expect(progress[0]).To(Equal(export.Progress{Stage: export.StageAccount, Done: 1, Total: 1}))
+		for i, p := range progress[1:] {
+			Expect(p.Stage).To(Equal(export.StageLists))
+			Expect(p.Done).To(Equal(i + 1))
+			Expect(p.Total).To(Equal(3))
+		}
+	})
+
+	It("returns an error if a list cannot be exported", func() {
+		client.Before("TaskPositionsForListID", func(args ...interface{}) error {
+			if args[0].(uint) == 20 {
+				return errors.New("list error")
+			}
+			return nil
+		})
+
+		_, err := export.Export(client, opts)
+		Expect(err).To(MatchError(ContainSubstring("list error")))
+	})
+
+	Context("when including file contents", func() {
+		BeforeEach(func() {
+			opts.IncludeFileContents = true
+			opts.Download = func(url string) (io.ReadCloser, error) {
+				return ioutil.NopCloser(bytes.NewBufferString("contents of " + url)), nil
+			}
+		})
+
+		It("downloads each file", func() {
+			a, err := export.Export(client, opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(a.FileContents).To(Equal(map[uint][]byte{
+				19: []byte("contents of https://example.com/file"),
+				29: []byte("contents of https://example.com/file"),
+				39: []byte("contents of https://example.com/file"),
+			}))
+			Expect(progress).To(HaveLen(7))
+			Expect(progress[6].Stage).To(Equal(export.StageFiles))
+		})
+
+		It("returns an error if a file cannot be downloaded", func() {
+			opts.Download = func(url string) (io.ReadCloser, error) {
+				return nil, errors.New("download error")
+			}
+
+			_, err := export.Export(client, opts)
+			Expect(err).To(MatchError(ContainSubstring("download error")))
+		})
+	})
+})
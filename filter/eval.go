@@ -0,0 +1,194 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// node is an element of the expression tree.
+type node interface {
+	eval(fields Fields, env Env) (value, error)
+}
+
+type literalNode struct {
+	v value
+}
+
+func (n literalNode) eval(fields Fields, env Env) (value, error) {
+	return n.v, nil
+}
+
+type fieldNode struct {
+	name string
+}
+
+func (n fieldNode) eval(fields Fields, env Env) (value, error) {
+	v, ok := fields[n.name]
+	if !ok {
+		return value{}, fmt.Errorf("unknown field: %s", n.name)
+	}
+	return valueOf(v)
+}
+
+type envNode struct {
+	name string
+}
+
+func (n envNode) eval(fields Fields, env Env) (value, error) {
+	switch n.name {
+	case "today":
+		now := env.Now.In(env.location())
+		return dateValue(now.Year(), now.Month(), now.Day()), nil
+	case "now":
+		return value{kind: kindTime, t: env.Now}, nil
+	case "me":
+		return value{kind: kindNumber, n: float64(env.UserID)}, nil
+	default:
+		return value{}, fmt.Errorf("unknown identifier: %s", n.name)
+	}
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n notNode) eval(fields Fields, env Env) (value, error) {
+	v, err := n.operand.eval(fields, env)
+	if err != nil {
+		return value{}, err
+	}
+	return value{kind: kindBool, b: !v.truthy()}, nil
+}
+
+type andNode struct {
+	left  node
+	right node
+}
+
+func (n andNode) eval(fields Fields, env Env) (value, error) {
+	l, err := n.left.eval(fields, env)
+	if err != nil {
+		return value{}, err
+	}
+	if !l.truthy() {
+		return value{kind: kindBool, b: false}, nil
+	}
+
+	r, err := n.right.eval(fields, env)
+	if err != nil {
+		return value{}, err
+	}
+	return value{kind: kindBool, b: r.truthy()}, nil
+}
+
+type orNode struct {
+	left  node
+	right node
+}
+
+func (n orNode) eval(fields Fields, env Env) (value, error) {
+	l, err := n.left.eval(fields, env)
+	if err != nil {
+		return value{}, err
+	}
+	if l.truthy() {
+		return value{kind: kindBool, b: true}, nil
+	}
+
+	r, err := n.right.eval(fields, env)
+	if err != nil {
+		return value{}, err
+	}
+	return value{kind: kindBool, b: r.truthy()}, nil
+}
+
+type offsetNode struct {
+	operand node
+	d       duration
+	negate  bool
+}
+
+func (n offsetNode) eval(fields Fields, env Env) (value, error) {
+	v, err := n.operand.eval(fields, env)
+	if err != nil {
+		return value{}, err
+	}
+	if v.kind == kindNull {
+		return v, nil
+	}
+	return v.add(n.d, n.negate)
+}
+
+type comparisonNode struct {
+	op    string
+	left  node
+	right node
+}
+
+func (n comparisonNode) eval(fields Fields, env Env) (value, error) {
+	l, err := n.left.eval(fields, env)
+	if err != nil {
+		return value{}, err
+	}
+
+	r, err := n.right.eval(fields, env)
+	if err != nil {
+		return value{}, err
+	}
+
+	if l.kind == kindNull || r.kind == kindNull {
+		bothNull := l.kind == r.kind
+		switch n.op {
+		case "==":
+			return value{kind: kindBool, b: bothNull}, nil
+		case "!=":
+			return value{kind: kindBool, b: !bothNull}, nil
+		default:
+			return value{kind: kindBool, b: false}, nil
+		}
+	}
+
+	c, err := compare(l, r, env.location())
+	if err != nil {
+		return value{}, err
+	}
+
+	var result bool
+	switch n.op {
+	case "==":
+		result = c == 0
+	case "!=":
+		result = c != 0
+	case "<":
+		result = c < 0
+	case "<=":
+		result = c <= 0
+	case ">":
+		result = c > 0
+	case ">=":
+		result = c >= 0
+	}
+	return value{kind: kindBool, b: result}, nil
+}
+
+type matchNode struct {
+	operand node
+	re      *regexp.Regexp
+	negate  bool
+}
+
+func (n matchNode) eval(fields Fields, env Env) (value, error) {
+	v, err := n.operand.eval(fields, env)
+	if err != nil {
+		return value{}, err
+	}
+
+	switch v.kind {
+	case kindNull:
+		return value{kind: kindBool, b: n.negate}, nil
+	case kindString:
+		return value{kind: kindBool, b: n.re.MatchString(v.s) != n.negate}, nil
+	default:
+		return value{}, fmt.Errorf("cannot match %s against a regular expression", v.kind)
+	}
+}
@@ -0,0 +1,67 @@
+package filter
+
+import (
+	"github.com/robdimsdale/wl"
+)
+
+// TaskFields returns the fields of a task which may be referred to
+// in expressions: id, title, list, assignee, assigner, creator, created,
+// due, starred, completed, completed_at, completed_by, recurrence,
+// recurrence_count and revision.
+func TaskFields(t wl.Task) Fields {
+	return Fields{
+		"id":               t.ID,
+		"title":            t.Title,
+		"list":             t.ListID,
+		"assignee":         optionalID(t.AssigneeID),
+		"assigner":         optionalID(t.AssignerID),
+		"creator":          optionalID(t.CreatedByID),
+		"created":          t.CreatedAt,
+		"due":              t.DueDate,
+		"starred":          t.Starred,
+		"completed":        t.Completed,
+		"completed_at":     t.CompletedAt,
+		"completed_by":     optionalID(t.CompletedByID),
+		"recurrence":       string(t.RecurrenceType),
+		"recurrence_count": t.RecurrenceCount,
+		"revision":         t.Revision,
+	}
+}
+
+// SubtaskFields returns the fields of a subtask which may be referred to
+// in expressions: id, task, title, creator, created, completed,
+// completed_at, completed_by and revision.
+func SubtaskFields(s wl.Subtask) Fields {
+	return Fields{
+		"id":           s.ID,
+		"task":         s.TaskID,
+		"title":        s.Title,
+		"creator":      optionalID(s.CreatedByID),
+		"created":      s.CreatedAt,
+		"completed":    s.Completed,
+		"completed_at": s.CompletedAt,
+		"completed_by": optionalID(s.CompletedByID),
+		"revision":     s.Revision,
+	}
+}
+
+// ReminderFields returns the fields of a reminder which may be referred to
+// in expressions: id, task, date, created, updated and revision.
+func ReminderFields(r wl.Reminder) Fields {
+	return Fields{
+		"id":       r.ID,
+		"task":     r.TaskID,
+		"date":     r.Date,
+		"created":  r.CreatedAt,
+		"updated":  r.UpdatedAt,
+		"revision": r.Revision,
+	}
+}
+
+// optionalID returns nil for a zero ID, so that it is treated as unset.
+func optionalID(id uint) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
@@ -0,0 +1,85 @@
+/*
+Package filter provides an expression language for filtering, sorting and
+grouping tasks, subtasks and reminders.
+
+Expressions combine comparisons of fields with && (and), || (or) and ! (not),
+and parentheses for grouping, e.g.
+
+	starred && due <= today+3d && assignee == me && title ~ "deploy"
+
+Comparison operators are ==, !=, <, <=, >, >=, ~ (matches a case-insensitive
+regular expression) and !~ (does not match). A field on its own is true if
+it is set, e.g. 'starred' or 'due'. Comparisons against an unset field are
+false, except for !=.
+
+Literals may be strings ("deploy" or 'deploy'), numbers (42), booleans
+(true, false), dates (2016-01-04) or durations, which may be added to or
+subtracted from times: 3h, 3d, 2w, 1m or 1y. The identifiers 'today' and
+'now' refer to the current day and time, and 'me' refers to the ID of the
+current user, all as provided via Env.
+*/
+package filter
+
+import (
+	"time"
+)
+
+// Env provides the context in which an expression is evaluated.
+type Env struct {
+	// Now is the current time. Its location determines the current day,
+	// and the calendar day of instants when compared with dates.
+	Now time.Time
+
+	// UserID is the ID of the current user, referred to by 'me'.
+	UserID uint
+}
+
+func (e Env) location() *time.Location {
+	if e.Now.IsZero() {
+		return time.UTC
+	}
+	return e.Now.Location()
+}
+
+// Fields maps field names to their values. Supported value types are
+// nil, bool, string, int, uint, float64, time.Time and wl.Date.
+// A wl.Date is compared with instants by calendar day in the location of the Env.
+type Fields map[string]interface{}
+
+// Filter is a parsed expression.
+type Filter struct {
+	expression string
+	root       node
+}
+
+// Parse parses the expression into a Filter.
+func Parse(expression string) (*Filter, error) {
+	tokens, err := lex(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Filter{expression: expression, root: root}, nil
+}
+
+// String returns the expression from which the Filter was parsed.
+func (f *Filter) String() string {
+	return f.expression
+}
+
+// Match evaluates the expression against the fields. It returns an error
+// if the expression refers to a field which is not present, or compares
+// values of incompatible types.
+func (f *Filter) Match(fields Fields, env Env) (bool, error) {
+	v, err := f.root.eval(fields, env)
+	if err != nil {
+		return false, err
+	}
+	return v.truthy(), nil
+}
@@ -0,0 +1,148 @@
+package filter_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/filter"
+)
+
+var _ = Describe("Filter", func() {
+	var (
+		env  filter.Env
+		task wl.Task
+	)
+
+	BeforeEach(func() {
+		env = filter.Env{
+			// Late in the evening west of UTC, when it is already
+			// the next day in UTC
+			Now:    time.Date(2016, time.January, 4, 22, 0, 0, 0, time.FixedZone("test", -8*60*60)),
+			UserID: 10,
+		}
+
+		task = wl.Task{
+			ID:         1234,
+			Title:      "Deploy the new release",
+			ListID:     5,
+			AssigneeID: 10,
+			Starred:    true,
+			DueDate:    wl.NewDate(2016, time.January, 6),
+			CreatedAt:  time.Date(2016, time.January, 5, 5, 0, 0, 0, time.UTC),
+		}
+	})
+
+	table.DescribeTable("matching tasks",
+		func(expression string, expected bool) {
+			f, err := filter.Parse(expression)
+			Expect(err).NotTo(HaveOccurred())
+
+			matched, err := f.Match(filter.TaskFields(task), env)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matched).To(Equal(expected))
+		},
+		table.Entry("boolean field", "starred", true),
+		table.Entry("negated boolean field", "!starred", false),
+		table.Entry("unset field", "completed_at", false),
+		table.Entry("set date field", "due", true),
+		table.Entry("number equality", "list == 5", true),
+		table.Entry("number inequality", "list != 5", false),
+		table.Entry("number ordering", "id > 1000 && id < 2000", true),
+		table.Entry("string equality", `title == "Deploy the new release"`, true),
+		table.Entry("single-quoted string", `title == 'Deploy the new release'`, true),
+		table.Entry("case-insensitive match", `title ~ "deploy"`, true),
+		table.Entry("regular expression match", `title ~ "^deploy.*release$"`, true),
+		table.Entry("negated match", `title !~ "deploy"`, false),
+		table.Entry("me", "assignee == me", true),
+		table.Entry("unset field equality with null", "assigner == null", true),
+		table.Entry("unset field comparison", "completed_at < now", false),
+		table.Entry("due today", "due == today", false),
+		table.Entry("due within offset", "due <= today+3d", true),
+		table.Entry("due before offset", "due < today+2d", false),
+		table.Entry("subtracted offset", "due-2d == today", true),
+		table.Entry("week offset", "due < today+1w", true),
+		table.Entry("date literal", "due == 2016-01-06", true),
+		table.Entry("instant compared with date in local time", "created == 2016-01-04", true),
+		table.Entry("instant compared with instant", "created < now+2h", true),
+		table.Entry("and", "starred && list == 6", false),
+		table.Entry("or", "starred || list == 6", true),
+		table.Entry("precedence of && over ||", "list == 6 && starred || completed", false),
+		table.Entry("parentheses", "!(list == 6 || completed)", true),
+		table.Entry("example from the docs", `starred && due <= today+3d && assignee == me && title ~ "deploy"`, true),
+	)
+
+	table.DescribeTable("parse errors",
+		func(expression string) {
+			_, err := filter.Parse(expression)
+			Expect(err).To(HaveOccurred())
+		},
+		table.Entry("empty expression", ""),
+		table.Entry("unterminated string", `title == "deploy`),
+		table.Entry("unbalanced parentheses", "(starred"),
+		table.Entry("trailing tokens", "starred completed"),
+		table.Entry("missing operand", "starred &&"),
+		table.Entry("unexpected character", "starred # completed"),
+		table.Entry("match against non-string", "title ~ 5"),
+		table.Entry("invalid regular expression", `title ~ "("`),
+		table.Entry("offset without duration", "due < today+3"),
+	)
+
+	table.DescribeTable("evaluation errors",
+		func(expression string) {
+			f, err := filter.Parse(expression)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = f.Match(filter.TaskFields(task), env)
+			Expect(err).To(HaveOccurred())
+		},
+		table.Entry("unknown field", "priority == 1"),
+		table.Entry("incompatible types", `list == "5"`),
+		table.Entry("match against a number", `list ~ "5"`),
+		table.Entry("hours added to a date", "due < today+3h"),
+	)
+
+	It("returns the original expression", func() {
+		f, err := filter.Parse("starred &&  completed")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.String()).To(Equal("starred &&  completed"))
+	})
+
+	Describe("filtering collections", func() {
+		var f *filter.Filter
+
+		BeforeEach(func() {
+			var err error
+			f, err = filter.Parse("completed")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("filters tasks", func() {
+			tasks, err := f.Tasks([]wl.Task{{ID: 1}, {ID: 2, Completed: true}}, env)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tasks).To(Equal([]wl.Task{{ID: 2, Completed: true}}))
+		})
+
+		It("filters subtasks", func() {
+			subtasks, err := f.Subtasks([]wl.Subtask{{ID: 1, Completed: true}, {ID: 2}}, env)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subtasks).To(Equal([]wl.Subtask{{ID: 1, Completed: true}}))
+		})
+
+		It("filters reminders", func() {
+			f, err := filter.Parse("date < today+1d && task == 7")
+			Expect(err).NotTo(HaveOccurred())
+
+			reminders, err := f.Reminders([]wl.Reminder{
+				{ID: 1, TaskID: 7, Date: time.Date(2016, time.January, 5, 7, 0, 0, 0, time.UTC)},
+				{ID: 2, TaskID: 7, Date: time.Date(2016, time.January, 5, 9, 0, 0, 0, time.UTC)},
+				{ID: 3, TaskID: 8, Date: time.Date(2016, time.January, 5, 7, 0, 0, 0, time.UTC)},
+			}, env)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reminders).To(HaveLen(1))
+			Expect(reminders[0].ID).To(Equal(uint(1)))
+		})
+	})
+})
@@ -0,0 +1,156 @@
+package filter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenDate
+	tokenDuration
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	typ tokenType
+	val string
+	pos int
+}
+
+func (t token) String() string {
+	if t.typ == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q at position %d", t.val, t.pos)
+}
+
+// operators are ordered so that longer operators are matched first.
+var operators = []string{
+	"&&", "||", "==", "!=", "<=", ">=", "!~",
+	"<", ">", "~", "!", "+", "-",
+}
+
+// lex splits the input into tokens.
+func lex(input string) ([]token, error) {
+	tokens := []token{}
+
+	i := 0
+	for i < len(input) {
+		c := rune(input[i])
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '(':
+			tokens = append(tokens, token{typ: tokenLeftParen, val: "(", pos: i})
+			i++
+
+		case c == ')':
+			tokens = append(tokens, token{typ: tokenRightParen, val: ")", pos: i})
+			i++
+
+		case c == '"' || c == '\'':
+			s, n, err := lexString(input[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%v at position %d", err, i)
+			}
+			tokens = append(tokens, token{typ: tokenString, val: s, pos: i})
+			i += n
+
+		case unicode.IsDigit(c):
+			t, n := lexNumeric(input[i:])
+			t.pos = i
+			tokens = append(tokens, t)
+			i += n
+
+		case c == '_' || unicode.IsLetter(c):
+			start := i
+			for i < len(input) && (input[i] == '_' ||
+				unicode.IsLetter(rune(input[i])) || unicode.IsDigit(rune(input[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{typ: tokenIdent, val: input[start:i], pos: start})
+
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(input[i:], op) {
+					tokens = append(tokens, token{typ: tokenOperator, val: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+		}
+	}
+
+	tokens = append(tokens, token{typ: tokenEOF, pos: len(input)})
+	return tokens, nil
+}
+
+// lexString reads a quoted string, returning its unescaped value
+// and the number of bytes consumed.
+func lexString(input string) (string, int, error) {
+	quote := input[0]
+	var b bytes.Buffer
+
+	for i := 1; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			if i+1 < len(input) {
+				i++
+				b.WriteByte(input[i])
+			}
+		case quote:
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(input[i])
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+// lexNumeric reads a number (42), a date (2016-01-04)
+// or a duration (3d) from the start of the input.
+func lexNumeric(input string) (token, int) {
+	digits := func(s string) int {
+		n := 0
+		for n < len(s) && unicode.IsDigit(rune(s[n])) {
+			n++
+		}
+		return n
+	}
+
+	n := digits(input)
+
+	// YYYY-MM-DD
+	if n == 4 && len(input) >= 10 &&
+		input[4] == '-' && digits(input[5:]) == 2 &&
+		input[7] == '-' && digits(input[8:]) == 2 {
+		return token{typ: tokenDate, val: input[:10]}, 10
+	}
+
+	if n < len(input) && strings.ContainsRune(durationUnits, rune(input[n])) {
+		end := n + 1
+		if end == len(input) || !unicode.IsLetter(rune(input[end])) {
+			return token{typ: tokenDuration, val: input[:end]}, end
+		}
+	}
+
+	return token{typ: tokenNumber, val: input[:n]}, n
+}
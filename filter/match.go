@@ -0,0 +1,48 @@
+package filter
+
+import "github.com/robdimsdale/wl"
+
+// Tasks returns the tasks matching the filter, preserving their order.
+func (f *Filter) Tasks(tasks []wl.Task, env Env) ([]wl.Task, error) {
+	matched := []wl.Task{}
+	for _, t := range tasks {
+		ok, err := f.Match(TaskFields(t), env)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, t)
+		}
+	}
+	return matched, nil
+}
+
+// Subtasks returns the subtasks matching the filter, preserving their order.
+func (f *Filter) Subtasks(subtasks []wl.Subtask, env Env) ([]wl.Subtask, error) {
+	matched := []wl.Subtask{}
+	for _, s := range subtasks {
+		ok, err := f.Match(SubtaskFields(s), env)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, s)
+		}
+	}
+	return matched, nil
+}
+
+// Reminders returns the reminders matching the filter, preserving their order.
+func (f *Filter) Reminders(reminders []wl.Reminder, env Env) ([]wl.Reminder, error) {
+	matched := []wl.Reminder{}
+	for _, r := range reminders {
+		ok, err := f.Match(ReminderFields(r), env)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, r)
+		}
+	}
+	return matched, nil
+}
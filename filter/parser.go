@@ -0,0 +1,219 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// parser is a recursive-descent parser for the grammar:
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | comparison
+//	comparison = sum [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" | "~" | "!~" ) sum ]
+//	sum        = primary { ( "+" | "-" ) duration }
+//	primary    = "(" or ")" | identifier | string | number | date | duration
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) acceptOperator(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.typ != tokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.val == op {
+			p.next()
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) parse() (node, error) {
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.typ != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s", t)
+	}
+	return n, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.acceptOperator("||"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.acceptOperator("&&"); !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if _, ok := p.acceptOperator("!"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	opToken := p.peek()
+	op, ok := p.acceptOperator("==", "!=", "<", "<=", ">", ">=", "~", "!~")
+	if !ok {
+		return left, nil
+	}
+
+	if op == "~" || op == "!~" {
+		t := p.next()
+		if t.typ != tokenString {
+			return nil, fmt.Errorf("expected string after %s, got %s", opToken, t)
+		}
+		re, err := regexp.Compile("(?i)" + t.val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %s: %v", t, err)
+		}
+		return matchNode{operand: left, re: re, negate: op == "!~"}, nil
+	}
+
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	return comparisonNode{op: op, left: left, right: right}, nil
+}
+
+func (p *parser) parseSum() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.acceptOperator("+", "-")
+		if !ok {
+			return left, nil
+		}
+
+		t := p.next()
+		if t.typ != tokenDuration {
+			return nil, fmt.Errorf("expected duration after %s, got %s", op, t)
+		}
+		d, err := parseDuration(t.val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %s: %v", t, err)
+		}
+
+		left = offsetNode{operand: left, d: d, negate: op == "-"}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+
+	switch t.typ {
+	case tokenLeftParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.typ != tokenRightParen {
+			return nil, fmt.Errorf("expected ) but got %s", closing)
+		}
+		return n, nil
+
+	case tokenIdent:
+		switch t.val {
+		case "true":
+			return literalNode{value{kind: kindBool, b: true}}, nil
+		case "false":
+			return literalNode{value{kind: kindBool, b: false}}, nil
+		case "null":
+			return literalNode{value{kind: kindNull}}, nil
+		case "today", "now", "me":
+			return envNode{name: t.val}, nil
+		default:
+			return fieldNode{name: t.val}, nil
+		}
+
+	case tokenString:
+		return literalNode{value{kind: kindString, s: t.val}}, nil
+
+	case tokenNumber:
+		n, err := strconv.ParseFloat(t.val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s: %v", t, err)
+		}
+		return literalNode{value{kind: kindNumber, n: n}}, nil
+
+	case tokenDate:
+		d, err := time.Parse("2006-01-02", t.val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %s: %v", t, err)
+		}
+		return literalNode{dateValue(d.Year(), d.Month(), d.Day())}, nil
+
+	case tokenDuration:
+		d, err := parseDuration(t.val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %s: %v", t, err)
+		}
+		return literalNode{value{kind: kindDuration, d: d}}, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected %s", t)
+	}
+}
@@ -0,0 +1,136 @@
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/robdimsdale/wl"
+)
+
+// Less reports whether a sorts before b according to the keys.
+// Each key is a field name, optionally prefixed with '-' for descending order.
+// Later keys are only considered if a and b are equal for earlier keys.
+// Unset fields sort last, regardless of order.
+func Less(a Fields, b Fields, keys []string, env Env) bool {
+	for _, key := range keys {
+		name := strings.TrimPrefix(key, "-")
+		descending := name != key
+
+		av, aErr := valueOf(a[name])
+		bv, bErr := valueOf(b[name])
+		if aErr != nil || bErr != nil {
+			continue
+		}
+
+		if av.kind == kindNull || bv.kind == kindNull {
+			if av.kind == bv.kind {
+				continue
+			}
+			return bv.kind == kindNull
+		}
+
+		c, err := compare(av, bv, env.location())
+		if err != nil || c == 0 {
+			continue
+		}
+
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	}
+	return false
+}
+
+// ValidateKeys returns an error if any of the keys, with any '-' prefix
+// removed, is not present in the fields.
+func ValidateKeys(keys []string, fields Fields) error {
+	for _, key := range keys {
+		name := strings.TrimPrefix(key, "-")
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("unknown field: %s", name)
+		}
+	}
+	return nil
+}
+
+// SortTasks sorts the tasks in place according to the keys.
+// See Less for the format of keys.
+func SortTasks(tasks []wl.Task, keys []string, env Env) error {
+	if err := ValidateKeys(keys, TaskFields(wl.Task{})); err != nil {
+		return err
+	}
+
+	fields := make([]Fields, len(tasks))
+	for i, t := range tasks {
+		fields[i] = TaskFields(t)
+	}
+
+	sort.Stable(sorter{
+		fields: fields,
+		keys:   keys,
+		env:    env,
+		swap:   func(i, j int) { tasks[i], tasks[j] = tasks[j], tasks[i] },
+	})
+	return nil
+}
+
+// SortSubtasks sorts the subtasks in place according to the keys.
+// See Less for the format of keys.
+func SortSubtasks(subtasks []wl.Subtask, keys []string, env Env) error {
+	if err := ValidateKeys(keys, SubtaskFields(wl.Subtask{})); err != nil {
+		return err
+	}
+
+	fields := make([]Fields, len(subtasks))
+	for i, s := range subtasks {
+		fields[i] = SubtaskFields(s)
+	}
+
+	sort.Stable(sorter{
+		fields: fields,
+		keys:   keys,
+		env:    env,
+		swap:   func(i, j int) { subtasks[i], subtasks[j] = subtasks[j], subtasks[i] },
+	})
+	return nil
+}
+
+// SortReminders sorts the reminders in place according to the keys.
+// See Less for the format of keys.
+func SortReminders(reminders []wl.Reminder, keys []string, env Env) error {
+	if err := ValidateKeys(keys, ReminderFields(wl.Reminder{})); err != nil {
+		return err
+	}
+
+	fields := make([]Fields, len(reminders))
+	for i, r := range reminders {
+		fields[i] = ReminderFields(r)
+	}
+
+	sort.Stable(sorter{
+		fields: fields,
+		keys:   keys,
+		env:    env,
+		swap:   func(i, j int) { reminders[i], reminders[j] = reminders[j], reminders[i] },
+	})
+	return nil
+}
+
+// sorter sorts precomputed fields alongside the items they were derived from.
+type sorter struct {
+	fields []Fields
+	keys   []string
+	env    Env
+	swap   func(i, j int)
+}
+
+func (s sorter) Len() int { return len(s.fields) }
+func (s sorter) Swap(i, j int) {
+	s.fields[i], s.fields[j] = s.fields[j], s.fields[i]
+	s.swap(i, j)
+}
+func (s sorter) Less(i, j int) bool {
+	return Less(s.fields[i], s.fields[j], s.keys, s.env)
+}
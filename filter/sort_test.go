@@ -0,0 +1,80 @@
+package filter_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/filter"
+)
+
+var _ = Describe("Sorting", func() {
+	var (
+		env   filter.Env
+		tasks []wl.Task
+	)
+
+	ids := func(tasks []wl.Task) []uint {
+		ids := []uint{}
+		for _, t := range tasks {
+			ids = append(ids, t.ID)
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		env = filter.Env{Now: time.Date(2016, time.January, 4, 12, 0, 0, 0, time.UTC)}
+
+		tasks = []wl.Task{
+			{ID: 1, Title: "b", DueDate: wl.NewDate(2016, time.January, 6)},
+			{ID: 2, Title: "a"},
+			{ID: 3, Title: "c", DueDate: wl.NewDate(2016, time.January, 5)},
+			{ID: 4, Title: "a", DueDate: wl.NewDate(2016, time.January, 6)},
+		}
+	})
+
+	It("sorts by a single key, with unset fields last", func() {
+		err := filter.SortTasks(tasks, []string{"due"}, env)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids(tasks)).To(Equal([]uint{3, 1, 4, 2}))
+	})
+
+	It("sorts by multiple keys", func() {
+		err := filter.SortTasks(tasks, []string{"due", "title"}, env)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids(tasks)).To(Equal([]uint{3, 4, 1, 2}))
+	})
+
+	It("sorts in descending order, with unset fields still last", func() {
+		err := filter.SortTasks(tasks, []string{"-due", "-id"}, env)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids(tasks)).To(Equal([]uint{4, 1, 3, 2}))
+	})
+
+	It("sorts subtasks", func() {
+		subtasks := []wl.Subtask{{ID: 1, Title: "b"}, {ID: 2, Title: "a"}}
+
+		err := filter.SortSubtasks(subtasks, []string{"title"}, env)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subtasks[0].ID).To(Equal(uint(2)))
+	})
+
+	It("sorts reminders", func() {
+		reminders := []wl.Reminder{
+			{ID: 1, Date: time.Date(2016, time.January, 5, 9, 0, 0, 0, time.UTC)},
+			{ID: 2, Date: time.Date(2016, time.January, 5, 8, 0, 0, 0, time.UTC)},
+		}
+
+		err := filter.SortReminders(reminders, []string{"date"}, env)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reminders[0].ID).To(Equal(uint(2)))
+	})
+
+	Context("when a key is not a known field", func() {
+		It("returns an error", func() {
+			err := filter.SortTasks(tasks, []string{"priority"}, env)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
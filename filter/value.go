@@ -0,0 +1,223 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/robdimsdale/wl"
+)
+
+type kind int
+
+const (
+	kindNull kind = iota
+	kindBool
+	kindNumber
+	kindString
+	kindTime
+	kindDuration
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindBool:
+		return "boolean"
+	case kindNumber:
+		return "number"
+	case kindString:
+		return "string"
+	case kindTime:
+		return "time"
+	case kindDuration:
+		return "duration"
+	default:
+		return "null"
+	}
+}
+
+// value is the result of evaluating an operand.
+type value struct {
+	kind kind
+
+	b bool
+	n float64
+	s string
+
+	// t is either an instant, or midnight UTC of a calendar date
+	// when dateOnly is true.
+	t        time.Time
+	dateOnly bool
+
+	d duration
+}
+
+// duration is a calendar-aware offset, e.g. 3d or 1m.
+type duration struct {
+	amount int
+	unit   byte
+}
+
+const durationUnits = "hdwmy"
+
+func parseDuration(s string) (duration, error) {
+	amount, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil {
+		return duration{}, err
+	}
+	return duration{amount: amount, unit: s[len(s)-1]}, nil
+}
+
+func dateValue(year int, month time.Month, day int) value {
+	return value{
+		kind:     kindTime,
+		t:        time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+		dateOnly: true,
+	}
+}
+
+// valueOf converts a field value to a value.
+func valueOf(v interface{}) (value, error) {
+	switch v := v.(type) {
+	case nil:
+		return value{kind: kindNull}, nil
+	case bool:
+		return value{kind: kindBool, b: v}, nil
+	case string:
+		return value{kind: kindString, s: v}, nil
+	case int:
+		return value{kind: kindNumber, n: float64(v)}, nil
+	case uint:
+		return value{kind: kindNumber, n: float64(v)}, nil
+	case float64:
+		return value{kind: kindNumber, n: v}, nil
+	case time.Time:
+		if v.IsZero() {
+			return value{kind: kindNull}, nil
+		}
+		return value{kind: kindTime, t: v}, nil
+	case wl.Date:
+		if v.IsZero() {
+			return value{kind: kindNull}, nil
+		}
+		return dateValue(v.Year(), v.Month(), v.Day()), nil
+	default:
+		return value{}, fmt.Errorf("unsupported field type %T", v)
+	}
+}
+
+// truthy returns whether the value is considered true when used
+// as a condition on its own, e.g. 'starred' or 'due'.
+func (v value) truthy() bool {
+	switch v.kind {
+	case kindBool:
+		return v.b
+	case kindNumber:
+		return v.n != 0
+	case kindString:
+		return v.s != ""
+	case kindTime:
+		return !v.t.IsZero()
+	case kindDuration:
+		return v.d.amount != 0
+	default:
+		return false
+	}
+}
+
+// add offsets a time value by the duration, subtracting if negate is true.
+func (v value) add(d duration, negate bool) (value, error) {
+	if v.kind != kindTime {
+		return value{}, fmt.Errorf("cannot add duration to %s", v.kind)
+	}
+
+	amount := d.amount
+	if negate {
+		amount = -amount
+	}
+
+	switch d.unit {
+	case 'h':
+		if v.dateOnly {
+			return value{}, fmt.Errorf("cannot add hours to a date")
+		}
+		v.t = v.t.Add(time.Duration(amount) * time.Hour)
+	case 'd':
+		v.t = v.t.AddDate(0, 0, amount)
+	case 'w':
+		v.t = v.t.AddDate(0, 0, 7*amount)
+	case 'm':
+		v.t = v.t.AddDate(0, amount, 0)
+	case 'y':
+		v.t = v.t.AddDate(amount, 0, 0)
+	}
+	return v, nil
+}
+
+// compare returns -1, 0 or 1. Values of different kinds cannot be compared,
+// with the exception of null, which is unequal to everything but null.
+// Dates are compared with instants by the calendar day of the instant in loc.
+func compare(a value, b value, loc *time.Location) (int, error) {
+	if a.kind != b.kind {
+		return 0, fmt.Errorf("cannot compare %s with %s", a.kind, b.kind)
+	}
+
+	switch a.kind {
+	case kindNull:
+		return 0, nil
+	case kindBool:
+		if a.b == b.b {
+			return 0, nil
+		}
+		if !a.b {
+			return -1, nil
+		}
+		return 1, nil
+	case kindNumber:
+		return compareFloats(a.n, b.n), nil
+	case kindString:
+		switch {
+		case a.s < b.s:
+			return -1, nil
+		case a.s > b.s:
+			return 1, nil
+		default:
+			return 0, nil
+		}
+	case kindTime:
+		at, bt := a.t, b.t
+		if a.dateOnly || b.dateOnly {
+			at, bt = calendarDay(a, loc), calendarDay(b, loc)
+		}
+		switch {
+		case at.Before(bt):
+			return -1, nil
+		case at.After(bt):
+			return 1, nil
+		default:
+			return 0, nil
+		}
+	default:
+		return 0, fmt.Errorf("cannot compare %s values", a.kind)
+	}
+}
+
+func compareFloats(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// calendarDay returns midnight UTC of the calendar day of the value.
+func calendarDay(v value, loc *time.Location) time.Time {
+	if v.dateOnly {
+		return v.t
+	}
+	t := v.t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,180 @@
+/*
+Package htmlreport renders lists as a self-contained HTML report, showing the
+progress of each list and its incomplete tasks. The report has no external
+assets, so avatars are embedded as data URIs.
+*/
+package htmlreport
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/tree"
+)
+
+const (
+	// DefaultAvatarSize is the size of avatars in pixels.
+	DefaultAvatarSize = 32
+
+	defaultTitle = "All lists"
+)
+
+// Options configure a report.
+type Options struct {
+	// Now is when the report is generated.
+	// Tasks due before its date are overdue.
+	Now time.Time
+
+	// AvatarSize is the size of avatars in pixels.
+	// It defaults to DefaultAvatarSize.
+	AvatarSize int
+
+	// Download fetches an avatar from its URL.
+	// It defaults to an HTTP GET.
+	Download func(url string) (io.ReadCloser, error)
+}
+
+// Task is an incomplete task in a report.
+type Task struct {
+	tree.Task
+	Overdue bool
+}
+
+// List is a list in a report, with its task counts and incomplete tasks.
+type List struct {
+	wl.List
+	Count   wl.ListTaskCount
+	Overdue int
+	Tasks   []Task
+}
+
+// Total returns the number of tasks in the list.
+func (l List) Total() uint {
+	return l.Count.CompletedCount + l.Count.UncompletedCount
+}
+
+// Percent returns the percentage of tasks in the list which are completed,
+// rounded down, or 0 if it has no tasks.
+func (l List) Percent() uint {
+	if l.Total() == 0 {
+		return 0
+	}
+	return 100 * l.Count.CompletedCount / l.Total()
+}
+
+// Report is a report on lists.
+type Report struct {
+	Title     string
+	Generated time.Time
+	Lists     []List
+
+	// AvatarSize is the size of avatars in pixels.
+	AvatarSize int
+
+	// Avatars are the avatars of assignees as data URIs, by user ID.
+	Avatars map[uint]string
+}
+
+// Build returns the report for the incomplete tasks in the tree, fetching
+// the task counts of each list and the avatar of each assignee. Assignees
+// whose avatars cannot be fetched are shown without them, so that a
+// missing avatar does not prevent the report.
+func Build(client wl.Client, t tree.Tree, opts Options) (Report, error) {
+	if opts.AvatarSize <= 0 {
+		opts.AvatarSize = DefaultAvatarSize
+	}
+	if opts.Download == nil {
+		opts.Download = download
+	}
+
+	r := Report{
+		Title:      t.Title,
+		Generated:  opts.Now,
+		Lists:      []List{},
+		AvatarSize: opts.AvatarSize,
+		Avatars:    map[uint]string{},
+	}
+	if r.Title == "" {
+		r.Title = defaultTitle
+	}
+
+	today := wl.DateOf(opts.Now)
+	fetched := map[uint]bool{}
+
+	for _, l := range t.Lists {
+		count, err := client.ListTaskCount(l.ID)
+		if err != nil {
+			return Report{}, err
+		}
+
+		list := List{List: l.List, Count: count, Tasks: []Task{}}
+		for _, task := range l.Tasks {
+			if task.Completed {
+				continue
+			}
+
+			overdue := !task.DueDate.IsZero() && task.DueDate.Before(today)
+			if overdue {
+				list.Overdue++
+			}
+			list.Tasks = append(list.Tasks, Task{Task: task, Overdue: overdue})
+
+			id := task.Assignee.ID
+			if id == 0 || fetched[id] {
+				continue
+			}
+			fetched[id] = true
+
+			if avatar, err := fetchAvatar(client, opts, id); err == nil {
+				r.Avatars[id] = avatar
+			}
+		}
+
+		r.Lists = append(r.Lists, list)
+	}
+
+	return r, nil
+}
+
+// fetchAvatar returns the avatar of the user as a data URI.
+func fetchAvatar(client wl.Client, opts Options, userID uint) (string, error) {
+	url, err := client.AvatarURL(userID, opts.AvatarSize, true)
+	if err != nil {
+		return "", err
+	}
+
+	rc, err := opts.Download(url)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"data:%s;base64,%s",
+		http.DetectContentType(b),
+		base64.StdEncoding.EncodeToString(b),
+	), nil
+}
+
+func download(url string) (io.ReadCloser, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+	}
+	return resp.Body, nil
+}
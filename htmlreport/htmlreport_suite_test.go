@@ -0,0 +1,13 @@
+package htmlreport_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestHtmlreport(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Htmlreport Suite")
+}
@@ -0,0 +1,143 @@
+package htmlreport_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/htmlreport"
+	"github.com/robdimsdale/wl/tree"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+// png is the start of a PNG file, enough for its content type to be detected.
+var png = []byte("\x89PNG\x0D\x0A\x1A\x0A")
+
+func download(url string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(png)), nil
+}
+
+var _ = Describe("htmlreport", func() {
+	var (
+		client      *wltest.Client
+		avatarSizes []int
+		t           tree.Tree
+		opts        htmlreport.Options
+	)
+
+	BeforeEach(func() {
+		client = wltest.NewClient()
+		client.AddList(wl.List{ID: 2, Title: "Work"})
+		client.AddList(wl.List{ID: 3, Title: "Empty"})
+		client.AddTask(wl.Task{ListID: 2, Title: "Late <report>"})
+		client.AddTask(wl.Task{ListID: 2, Title: "Due today"})
+		client.AddTask(wl.Task{ListID: 2, Title: "Done", Completed: true})
+
+		avatarSizes = nil
+		client.Before("AvatarURL", func(args ...interface{}) error {
+			avatarSizes = append(avatarSizes, args[1].(int))
+			if args[0].(uint) == 8 {
+				return errors.New("Unexpected response code 500 - expected 302")
+			}
+			return nil
+		})
+
+		jane := wl.User{ID: 7, Name: "Jane"}
+		t = tree.Tree{
+			Title: "Projects",
+			Lists: []tree.List{
+				{
+					List: wl.List{ID: 2, Title: "Work"},
+					Tasks: []tree.Task{
+						{
+							Task:     wl.Task{ID: 10, Title: "Late <report>", DueDate: wl.NewDate(2016, time.January, 4), Starred: true},
+							Assignee: jane,
+							Note:     "Some notes",
+							Subtasks: []wl.Subtask{{Title: "Draft", Completed: true}, {Title: "Review"}},
+						},
+						{Task: wl.Task{ID: 11, Title: "Due today", DueDate: wl.NewDate(2016, time.January, 5)}, Assignee: jane},
+						{Task: wl.Task{ID: 12, Title: "Unknown avatar"}, Assignee: wl.User{ID: 8, Name: "Bob"}},
+						{Task: wl.Task{ID: 13, Title: "Done", Completed: true}},
+					},
+				},
+				{List: wl.List{ID: 3, Title: "Empty"}},
+			},
+		}
+
+		opts = htmlreport.Options{
+			Now:      time.Date(2016, time.January, 5, 9, 0, 0, 0, time.UTC),
+			Download: download,
+		}
+	})
+
+	Describe("Build", func() {
+		It("counts tasks, flags overdue tasks and embeds avatars", func() {
+			r, err := htmlreport.Build(client, t, opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Title).To(Equal("Projects"))
+			Expect(r.Lists).To(HaveLen(2))
+
+			work := r.Lists[0]
+			Expect(work.Total()).To(Equal(uint(3)))
+			Expect(work.Percent()).To(Equal(uint(33)))
+			Expect(work.Overdue).To(Equal(1))
+			Expect(work.Tasks).To(HaveLen(3))
+			Expect(work.Tasks[0].Overdue).To(BeTrue())
+			Expect(work.Tasks[1].Overdue).To(BeFalse())
+
+			Expect(r.Avatars).To(Equal(map[uint]string{7: "data:image/png;base64,iVBORw0KGgo="}))
+			Expect(avatarSizes).To(Equal([]int{htmlreport.DefaultAvatarSize, htmlreport.DefaultAvatarSize}))
+		})
+
+		It("defaults the title for trees which are not folders", func() {
+			t.Title = ""
+
+			r, err := htmlreport.Build(client, t, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Title).To(Equal("All lists"))
+		})
+
+		It("returns errors fetching task counts", func() {
+			t.Lists[1].ID = 99
+
+			_, err := htmlreport.Build(client, t, opts)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Write", func() {
+		It("renders a self-contained document", func() {
+			r, err := htmlreport.Build(client, t, opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			var buf bytes.Buffer
+			Expect(htmlreport.Write(&buf, r)).To(Succeed())
+			html := buf.String()
+
+			Expect(html).To(ContainSubstring("<title>Projects</title>"))
+			Expect(html).To(ContainSubstring(`<div class="bar" style="width: 33%"></div>`))
+			Expect(html).To(ContainSubstring(`1 of 3 completed (33%), <span class="overdue">1 overdue</span>`))
+			Expect(html).To(ContainSubstring(`<tr class="overdue">` + "\n" + `<td>&#9733; Late &lt;report&gt;`))
+			Expect(html).To(ContainSubstring("<details><summary>2 subtasks, note</summary>"))
+			Expect(html).To(ContainSubstring(`<li class="completed">&#9745; Draft</li>`))
+			Expect(html).To(ContainSubstring(`<img src="data:image/png;base64,iVBORw0KGgo=" width="32" height="32" alt="">Jane`))
+			Expect(html).To(ContainSubstring(`<td class="assignee">Bob</td>`))
+			Expect(html).To(ContainSubstring("<em>No tasks</em>"))
+			Expect(html).NotTo(ContainSubstring("Done"))
+
+			r.Lists[0].Tasks[0].Subtasks = r.Lists[0].Tasks[0].Subtasks[:1]
+			r.Lists[0].Tasks[0].Note = ""
+			buf.Reset()
+			Expect(htmlreport.Write(&buf, r)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("<details><summary>1 subtask</summary>"))
+
+			Expect(html).NotTo(ContainSubstring("http"))
+		})
+	})
+})
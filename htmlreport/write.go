@@ -0,0 +1,75 @@
+package htmlreport
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+)
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	// dataURL marks an avatar as safe to use as an image source.
+	// Avatars are only ever data URIs built by Build.
+	"dataURL": func(s string) template.URL {
+		return template.URL(s)
+	},
+	"subtasks": func(n int) string {
+		if n == 1 {
+			return "1 subtask"
+		}
+		return fmt.Sprintf("%d subtasks", n)
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 60em; color: #222; }
+h2 { margin-bottom: 0.3em; }
+.generated, .summary { color: #666; }
+.progress { background: #eee; border-radius: 4px; height: 0.8em; overflow: hidden; }
+.progress .bar { background: #2b88d9; height: 100%; }
+table { border-collapse: collapse; width: 100%; margin-top: 1em; }
+th, td { border-bottom: 1px solid #ddd; padding: 0.4em; text-align: left; vertical-align: top; }
+tr.overdue td { background: #fdecea; }
+tr.overdue .due, .summary .overdue { color: #c62828; font-weight: bold; }
+.assignee img { border-radius: 50%; vertical-align: middle; margin-right: 0.3em; }
+details summary { color: #666; cursor: pointer; }
+.note { white-space: pre-wrap; margin: 0.3em 0; }
+.subtasks { list-style: none; margin: 0.3em 0; padding-left: 1em; }
+.subtasks .completed { color: #999; text-decoration: line-through; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="generated">Generated {{.Generated.Format "Monday, 2 January 2006 15:04"}}</p>
+{{range .Lists}}<section>
+<h2>{{.Title}}</h2>
+<div class="progress"><div class="bar" style="width: {{.Percent}}%"></div></div>
+<p class="summary">{{.Count.CompletedCount}} of {{.Total}} completed ({{.Percent}}%){{if .Overdue}}, <span class="overdue">{{.Overdue}} overdue</span>{{end}}</p>
+{{if .Tasks}}<table>
+<thead><tr><th>Task</th><th>Due</th><th>Assignee</th></tr></thead>
+<tbody>
+{{range .Tasks}}<tr{{if .Overdue}} class="overdue"{{end}}>
+<td>{{if .Starred}}&#9733; {{end}}{{.Title}}{{if or .Note .Subtasks}}
+<details><summary>{{if .Subtasks}}{{subtasks (len .Subtasks)}}{{if .Note}}, {{end}}{{end}}{{if .Note}}note{{end}}</summary>
+{{if .Note}}<div class="note">{{.Note}}</div>
+{{end}}{{if .Subtasks}}<ul class="subtasks">
+{{range .Subtasks}}<li{{if .Completed}} class="completed"{{end}}>{{if .Completed}}&#9745;{{else}}&#9744;{{end}} {{.Title}}</li>
+{{end}}</ul>
+{{end}}</details>{{end}}</td>
+<td class="due">{{if not .DueDate.IsZero}}{{.DueDate}}{{end}}</td>
+<td class="assignee">{{if .Assignee.Name}}{{with index $.Avatars .Assignee.ID}}<img src="{{dataURL .}}" width="{{$.AvatarSize}}" height="{{$.AvatarSize}}" alt="">{{end}}{{.Assignee.Name}}{{end}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+{{else}}<p><em>No tasks</em></p>
+{{end}}</section>
+{{end}}</body>
+</html>
+`))
+
+// Write renders the report as a standalone HTML document.
+func Write(w io.Writer, r Report) error {
+	return htmlTemplate.Execute(w, r)
+}
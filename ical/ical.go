@@ -0,0 +1,188 @@
+// Package ical converts between tasks and iCalendar VTODO components, as
+// described in RFC 5545, so that calendar clients can subscribe to them.
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/robdimsdale/wl"
+)
+
+const (
+	// ProdID identifies this package as the producer of calendars.
+	ProdID = "-//robdimsdale//wl//EN"
+
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+
+	// maxLineLength is the maximum length of a content line in octets,
+	// excluding the line break.
+	maxLineLength = 75
+)
+
+// Todo is a VTODO component. HasPriority is set by Parse if the
+// PRIORITY property is present, and Recurrence is zero if the RRULE
+// property is not, as clients may omit properties they do not support.
+type Todo struct {
+	UID         string
+	Summary     string
+	Description string
+	Due         wl.Date
+	Completed   bool
+	CompletedAt time.Time
+	Created     time.Time
+	Priority    int
+	HasPriority bool
+	Recurrence  wl.Recurrence
+	Alarms      []time.Time
+	Sequence    uint
+}
+
+// Calendar is a VCALENDAR containing todos.
+type Calendar struct {
+	Name  string
+	Todos []Todo
+}
+
+// Write writes the calendar. stamp is the DTSTAMP of each todo,
+// i.e. when the calendar was created.
+func (c Calendar) Write(w io.Writer, stamp time.Time) error {
+	var buf bytes.Buffer
+
+	writeLine(&buf, "BEGIN", "VCALENDAR")
+	writeLine(&buf, "VERSION", "2.0")
+	writeLine(&buf, "PRODID", ProdID)
+	writeLine(&buf, "CALSCALE", "GREGORIAN")
+	if c.Name != "" {
+		writeLine(&buf, "X-WR-CALNAME", escape(c.Name))
+	}
+
+	for _, t := range c.Todos {
+		t.write(&buf, stamp)
+	}
+
+	writeLine(&buf, "END", "VCALENDAR")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (t Todo) write(buf *bytes.Buffer, stamp time.Time) {
+	writeLine(buf, "BEGIN", "VTODO")
+	writeLine(buf, "UID", escape(t.UID))
+	writeLine(buf, "DTSTAMP", formatTime(stamp))
+	if !t.Created.IsZero() {
+		writeLine(buf, "CREATED", formatTime(t.Created))
+	}
+	if t.Sequence > 0 {
+		writeLine(buf, "SEQUENCE", fmt.Sprintf("%d", t.Sequence))
+	}
+	writeLine(buf, "SUMMARY", escape(t.Summary))
+	if t.Description != "" {
+		writeLine(buf, "DESCRIPTION", escape(t.Description))
+	}
+	// A recurrence is anchored to the due date, which is its DTSTART.
+	rule := RRule(t.Recurrence)
+	if !t.Due.IsZero() {
+		if rule != "" {
+			writeLine(buf, "DTSTART;VALUE=DATE", formatDate(t.Due))
+		}
+		writeLine(buf, "DUE;VALUE=DATE", formatDate(t.Due))
+	}
+	if t.Priority > 0 {
+		writeLine(buf, "PRIORITY", fmt.Sprintf("%d", t.Priority))
+	}
+
+	if t.Completed {
+		writeLine(buf, "STATUS", "COMPLETED")
+		if !t.CompletedAt.IsZero() {
+			writeLine(buf, "COMPLETED", formatTime(t.CompletedAt))
+		}
+	} else {
+		writeLine(buf, "STATUS", "NEEDS-ACTION")
+	}
+
+	if rule != "" && !t.Due.IsZero() {
+		writeLine(buf, "RRULE", rule)
+	}
+
+	for _, a := range t.Alarms {
+		writeLine(buf, "BEGIN", "VALARM")
+		writeLine(buf, "ACTION", "DISPLAY")
+		writeLine(buf, "DESCRIPTION", escape(t.Summary))
+		writeLine(buf, "TRIGGER;VALUE=DATE-TIME", formatTime(a))
+		writeLine(buf, "END", "VALARM")
+	}
+
+	writeLine(buf, "END", "VTODO")
+}
+
+// RRule returns the RRULE value for the recurrence,
+// or the empty string if it does not recur.
+func RRule(r wl.Recurrence) string {
+	var freq string
+	switch r.Type {
+	case wl.RecurrenceDay:
+		freq = "DAILY"
+	case wl.RecurrenceWeek:
+		freq = "WEEKLY"
+	case wl.RecurrenceMonth:
+		freq = "MONTHLY"
+	case wl.RecurrenceYear:
+		freq = "YEARLY"
+	default:
+		return ""
+	}
+
+	if r.Count <= 1 {
+		return "FREQ=" + freq
+	}
+	return fmt.Sprintf("FREQ=%s;INTERVAL=%d", freq, r.Count)
+}
+
+func formatDate(d wl.Date) string {
+	return d.In(time.UTC).Format(dateLayout)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeLine writes a content line, folded so that no line is longer than
+// 75 octets without splitting a UTF-8 character.
+func writeLine(buf *bytes.Buffer, name string, value string) {
+	line := name + ":" + value
+
+	limit := maxLineLength
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+		buf.WriteString(line[:i])
+		buf.WriteString("\r\n ")
+		line = line[i:]
+
+		// Continuation lines start with a space.
+		limit = maxLineLength - 1
+	}
+
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,13 @@
+package ical_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestIcal(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ical Suite")
+}
@@ -0,0 +1,132 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/ical"
+)
+
+var _ = Describe("Calendar", func() {
+	var stamp time.Time
+
+	BeforeEach(func() {
+		stamp = time.Date(2016, time.January, 5, 10, 0, 0, 0, time.UTC)
+	})
+
+	It("writes todos", func() {
+		c := ical.Calendar{
+			Name: "Work, mostly",
+			Todos: []ical.Todo{
+				{
+					UID:         "task-1@wunderlist",
+					Summary:     "Write report; then send",
+					Description: "Line one\nLine two",
+					Due:         wl.NewDate(2016, time.January, 6),
+					Created:     time.Date(2016, time.January, 1, 9, 0, 0, 0, time.UTC),
+					Priority:    1,
+					Recurrence:  wl.Recurrence{Type: wl.RecurrenceWeek, Count: 2},
+					Alarms:      []time.Time{time.Date(2016, time.January, 6, 9, 0, 0, 0, time.UTC)},
+					Sequence:    3,
+				},
+				{
+					UID:         "task-2@wunderlist",
+					Summary:     "Done",
+					Completed:   true,
+					CompletedAt: time.Date(2016, time.January, 4, 8, 0, 0, 0, time.UTC),
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		Expect(c.Write(&buf, stamp)).To(Succeed())
+
+		Expect(buf.String()).To(Equal(strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//robdimsdale//wl//EN",
+			"CALSCALE:GREGORIAN",
+			`X-WR-CALNAME:Work\, mostly`,
+			"BEGIN:VTODO",
+			"UID:task-1@wunderlist",
+			"DTSTAMP:20160105T100000Z",
+			"CREATED:20160101T090000Z",
+			"SEQUENCE:3",
+			`SUMMARY:Write report\; then send`,
+			`DESCRIPTION:Line one\nLine two`,
+			"DTSTART;VALUE=DATE:20160106",
+			"DUE;VALUE=DATE:20160106",
+			"PRIORITY:1",
+			"STATUS:NEEDS-ACTION",
+			"RRULE:FREQ=WEEKLY;INTERVAL=2",
+			"BEGIN:VALARM",
+			"ACTION:DISPLAY",
+			`DESCRIPTION:Write report\; then send`,
+			"TRIGGER;VALUE=DATE-TIME:20160106T090000Z",
+			"END:VALARM",
+			"END:VTODO",
+			"BEGIN:VTODO",
+			"UID:task-2@wunderlist",
+			"DTSTAMP:20160105T100000Z",
+			"SUMMARY:Done",
+			"STATUS:COMPLETED",
+			"COMPLETED:20160104T080000Z",
+			"END:VTODO",
+			"END:VCALENDAR",
+			"",
+		}, "\r\n")))
+	})
+
+	It("only writes a recurrence with a due date to start from", func() {
+		c := ical.Calendar{Todos: []ical.Todo{{
+			UID:        "u",
+			Summary:    "No due date",
+			Recurrence: wl.Recurrence{Type: wl.RecurrenceDay, Count: 1},
+		}}}
+
+		var buf bytes.Buffer
+		Expect(c.Write(&buf, stamp)).To(Succeed())
+		Expect(buf.String()).NotTo(ContainSubstring("RRULE"))
+		Expect(buf.String()).NotTo(ContainSubstring("DTSTART"))
+	})
+
+	It("folds long lines without splitting characters", func() {
+		c := ical.Calendar{Todos: []ical.Todo{{UID: "u", Summary: strings.Repeat("é", 100)}}}
+
+		var buf bytes.Buffer
+		Expect(c.Write(&buf, stamp)).To(Succeed())
+
+		var summary []string
+		inSummary := false
+		for _, line := range strings.Split(buf.String(), "\r\n") {
+			Expect(len(line)).To(BeNumerically("<=", 75))
+
+			if strings.HasPrefix(line, "SUMMARY:") {
+				inSummary = true
+				summary = append(summary, line)
+				continue
+			}
+			if inSummary && strings.HasPrefix(line, " ") {
+				summary = append(summary, line[1:])
+				continue
+			}
+			inSummary = false
+		}
+
+		Expect(len(summary)).To(BeNumerically(">", 1))
+		Expect(strings.Join(summary, "")).To(Equal("SUMMARY:" + strings.Repeat("é", 100)))
+	})
+
+	Describe("RRule", func() {
+		It("converts recurrences", func() {
+			Expect(ical.RRule(wl.Recurrence{})).To(BeEmpty())
+			Expect(ical.RRule(wl.Recurrence{Type: wl.RecurrenceDay, Count: 1})).To(Equal("FREQ=DAILY"))
+			Expect(ical.RRule(wl.Recurrence{Type: wl.RecurrenceMonth, Count: 3})).To(Equal("FREQ=MONTHLY;INTERVAL=3"))
+			Expect(ical.RRule(wl.Recurrence{Type: wl.RecurrenceYear, Count: 1})).To(Equal("FREQ=YEARLY"))
+		})
+	})
+})
@@ -0,0 +1,297 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robdimsdale/wl"
+)
+
+// maxContentLength is the maximum length of an unfolded content line.
+const maxContentLength = 1 << 20
+
+// property is a parsed content line.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse parses the VTODOs in a calendar. Unknown properties and components,
+// such as VEVENT and VTIMEZONE, are ignored, as are alarms with a trigger
+// relative to the todo. Floating times are in the location, as are the
+// dates of due times in UTC. It returns an error if a line cannot be parsed
+// or a recurrence rule is not supported.
+func Parse(r io.Reader, loc *time.Location) (Calendar, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return Calendar{}, err
+	}
+
+	var c Calendar
+	var todo *Todo
+	var components []string
+
+	for _, line := range lines {
+		p, err := parseLine(line)
+		if err != nil {
+			return Calendar{}, err
+		}
+
+		switch p.name {
+		case "BEGIN":
+			component := strings.ToUpper(p.value)
+			components = append(components, component)
+			if component == "VTODO" && len(components) == 2 {
+				todo = &Todo{}
+			}
+			continue
+
+		case "END":
+			if len(components) == 0 || components[len(components)-1] != strings.ToUpper(p.value) {
+				return Calendar{}, fmt.Errorf("unexpected END:%s", p.value)
+			}
+			if todo != nil && len(components) == 2 {
+				c.Todos = append(c.Todos, *todo)
+				todo = nil
+			}
+			components = components[:len(components)-1]
+			continue
+		}
+
+		switch {
+		case len(components) == 1 && components[0] == "VCALENDAR":
+			if p.name == "X-WR-CALNAME" {
+				c.Name = unescape(p.value)
+			}
+
+		case todo != nil && len(components) == 2:
+			err = todo.set(p, loc)
+
+		case todo != nil && len(components) == 3 && components[2] == "VALARM":
+			if p.name == "TRIGGER" && strings.EqualFold(p.params["VALUE"], "DATE-TIME") {
+				var t time.Time
+				t, err = parseTime(p, loc)
+				todo.Alarms = append(todo.Alarms, t)
+			}
+		}
+		if err != nil {
+			return Calendar{}, err
+		}
+	}
+
+	if len(components) > 0 {
+		return Calendar{}, fmt.Errorf("missing END:%s", components[len(components)-1])
+	}
+
+	return c, nil
+}
+
+func (t *Todo) set(p property, loc *time.Location) error {
+	var err error
+
+	switch p.name {
+	case "UID":
+		t.UID = unescape(p.value)
+	case "SUMMARY":
+		t.Summary = unescape(p.value)
+	case "DESCRIPTION":
+		t.Description = unescape(p.value)
+	case "DUE":
+		t.Due, err = parseDate(p, loc)
+	case "STATUS":
+		t.Completed = strings.EqualFold(p.value, "COMPLETED")
+	case "COMPLETED":
+		t.Completed = true
+		t.CompletedAt, err = parseTime(p, loc)
+	case "CREATED":
+		t.Created, err = parseTime(p, loc)
+	case "PRIORITY":
+		t.Priority, err = strconv.Atoi(p.value)
+		t.HasPriority = true
+	case "SEQUENCE":
+		var n uint64
+		n, err = strconv.ParseUint(p.value, 10, 0)
+		t.Sequence = uint(n)
+	case "RRULE":
+		t.Recurrence, err = ParseRRule(p.value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", p.name, err)
+	}
+	return nil
+}
+
+// ParseRRule parses the frequency and interval of a recurrence rule.
+// Other parts of the rule, such as BYDAY and COUNT, are ignored.
+func ParseRRule(rule string) (wl.Recurrence, error) {
+	r := wl.Recurrence{Count: 1}
+
+	for _, part := range strings.Split(rule, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return wl.Recurrence{}, fmt.Errorf("invalid rule part %q", part)
+		}
+
+		switch strings.ToUpper(kv[0]) {
+		case "FREQ":
+			switch strings.ToUpper(kv[1]) {
+			case "DAILY":
+				r.Type = wl.RecurrenceDay
+			case "WEEKLY":
+				r.Type = wl.RecurrenceWeek
+			case "MONTHLY":
+				r.Type = wl.RecurrenceMonth
+			case "YEARLY":
+				r.Type = wl.RecurrenceYear
+			default:
+				return wl.Recurrence{}, fmt.Errorf("unsupported frequency %s", kv[1])
+			}
+		case "INTERVAL":
+			n, err := strconv.ParseUint(kv[1], 10, 0)
+			if err != nil || n == 0 {
+				return wl.Recurrence{}, fmt.Errorf("invalid interval %s", kv[1])
+			}
+			r.Count = uint(n)
+		}
+	}
+
+	if r.Type == wl.RecurrenceNone {
+		return wl.Recurrence{}, fmt.Errorf("missing frequency")
+	}
+	return r, nil
+}
+
+// unfold reads the content lines, joining folded lines.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), maxContentLength)
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		if line == "" {
+			continue
+		}
+
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseLine parses a content line of the form NAME;PARAM=VALUE:VALUE,
+// where parameter values may be quoted.
+func parseLine(line string) (property, error) {
+	var parts []string
+	start := 0
+	quoted := false
+
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				parts = append(parts, line[start:i])
+				start = i + 1
+			}
+		case ':':
+			if !quoted {
+				parts = append(parts, line[start:i])
+
+				p := property{
+					name:   strings.ToUpper(parts[0]),
+					params: map[string]string{},
+					value:  line[i+1:],
+				}
+				for _, param := range parts[1:] {
+					kv := strings.SplitN(param, "=", 2)
+					if len(kv) == 2 {
+						p.params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+					}
+				}
+				return p, nil
+			}
+		}
+	}
+
+	return property{}, fmt.Errorf("invalid content line %q", line)
+}
+
+// parseTime parses a DATE-TIME value, in UTC, in the time zone of its
+// TZID parameter, or otherwise in the location.
+func parseTime(p property, loc *time.Location) (time.Time, error) {
+	if strings.HasSuffix(p.value, "Z") {
+		return time.Parse(dateTimeLayout, p.value)
+	}
+
+	if tzid, ok := p.params["TZID"]; ok {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	if len(p.value) == len(dateLayout) {
+		return time.ParseInLocation(dateLayout, p.value, loc)
+	}
+	return time.ParseInLocation("20060102T150405", p.value, loc)
+}
+
+// parseDate parses a DATE or DATE-TIME value as a date. Times in UTC are
+// converted to the location first; other times use the date as written.
+func parseDate(p property, loc *time.Location) (wl.Date, error) {
+	if len(p.value) < len(dateLayout) {
+		return wl.Date{}, fmt.Errorf("invalid date %q", p.value)
+	}
+
+	if strings.HasSuffix(p.value, "Z") {
+		t, err := time.Parse(dateTimeLayout, p.value)
+		if err != nil {
+			return wl.Date{}, err
+		}
+		return wl.DateOf(t.In(loc)), nil
+	}
+
+	t, err := time.Parse(dateLayout, p.value[:len(dateLayout)])
+	if err != nil {
+		return wl.Date{}, err
+	}
+	return wl.DateOf(t), nil
+}
+
+// unescape unescapes a TEXT value.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/ical"
+)
+
+var _ = Describe("Parse", func() {
+	It("round trips written calendars", func() {
+		c := ical.Calendar{
+			Name: "Work, mostly",
+			Todos: []ical.Todo{
+				{
+					UID:         "task-1@wunderlist",
+					Summary:     strings.Repeat("Long; summary, ", 10),
+					Description: "Line one\nLine two \\ backslash",
+					Due:         wl.NewDate(2016, time.January, 6),
+					Created:     time.Date(2016, time.January, 1, 9, 0, 0, 0, time.UTC),
+					Priority:    1,
+					HasPriority: true,
+					Recurrence:  wl.Recurrence{Type: wl.RecurrenceWeek, Count: 2},
+					Alarms:      []time.Time{time.Date(2016, time.January, 6, 9, 0, 0, 0, time.UTC)},
+					Sequence:    3,
+				},
+				{
+					UID:         "task-2@wunderlist",
+					Summary:     "Done",
+					Completed:   true,
+					CompletedAt: time.Date(2016, time.January, 4, 8, 0, 0, 0, time.UTC),
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		Expect(c.Write(&buf, time.Now())).To(Succeed())
+
+		parsed, err := ical.Parse(&buf, time.UTC)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(c))
+	})
+
+	It("parses todos written by other clients", func() {
+		input := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//Other//EN",
+			"BEGIN:VTIMEZONE",
+			"TZID:Europe/London",
+			"BEGIN:STANDARD",
+			"DTSTART:19701025T020000",
+			"END:STANDARD",
+			"END:VTIMEZONE",
+			"BEGIN:VEVENT",
+			"UID:event",
+			"SUMMARY:Ignored",
+			"END:VEVENT",
+			"BEGIN:VTODO",
+			"UID:abc-123",
+			"SUMMARY:Buy",
+			"  milk",
+			`DESCRIPTION;LANGUAGE="en:GB":Semi\;colon`,
+			"DUE;TZID=Europe/London:20160106T230000",
+			"PRIORITY:5",
+			"RRULE:FREQ=MONTHLY;BYMONTHDAY=6;COUNT=3",
+			"STATUS:NEEDS-ACTION",
+			"BEGIN:VALARM",
+			"TRIGGER:-PT15M",
+			"ACTION:DISPLAY",
+			"END:VALARM",
+			"END:VTODO",
+			"END:VCALENDAR",
+		}, "\n")
+
+		c, err := ical.Parse(strings.NewReader(input), time.UTC)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(c.Todos).To(Equal([]ical.Todo{{
+			UID:         "abc-123",
+			Summary:     "Buy milk",
+			Description: "Semi;colon",
+			Due:         wl.NewDate(2016, time.January, 6),
+			Priority:    5,
+			HasPriority: true,
+			Recurrence:  wl.Recurrence{Type: wl.RecurrenceMonth, Count: 1},
+		}}))
+	})
+
+	It("uses the location for floating times and the dates of UTC due times", func() {
+		loc := time.FixedZone("test", -8*60*60)
+		input := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VTODO",
+			"SUMMARY:Buy milk",
+			"DUE:20160106T030000Z",
+			"CREATED:20160105T120000",
+			"BEGIN:VALARM",
+			"TRIGGER;VALUE=DATE-TIME:20160105T090000",
+			"END:VALARM",
+			"END:VTODO",
+			"END:VCALENDAR",
+		}, "\n")
+
+		c, err := ical.Parse(strings.NewReader(input), loc)
+		Expect(err).NotTo(HaveOccurred())
+
+		todo := c.Todos[0]
+		Expect(todo.Due).To(Equal(wl.NewDate(2016, time.January, 5)))
+		Expect(todo.Created).To(Equal(time.Date(2016, time.January, 5, 12, 0, 0, 0, loc)))
+		Expect(todo.Alarms).To(Equal([]time.Time{time.Date(2016, time.January, 5, 9, 0, 0, 0, loc)}))
+	})
+
+	It("returns an error for unsupported recurrences", func() {
+		input := "BEGIN:VCALENDAR\nBEGIN:VTODO\nRRULE:FREQ=HOURLY\nEND:VTODO\nEND:VCALENDAR\n"
+
+		_, err := ical.Parse(strings.NewReader(input), time.UTC)
+		Expect(err).To(MatchError("invalid RRULE: unsupported frequency HOURLY"))
+	})
+
+	It("returns an error for mismatched components", func() {
+		_, err := ical.Parse(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VTODO\nEND:VCALENDAR\n"), time.UTC)
+		Expect(err).To(MatchError("unexpected END:VCALENDAR"))
+
+		_, err = ical.Parse(strings.NewReader("BEGIN:VCALENDAR\n"), time.UTC)
+		Expect(err).To(MatchError("missing END:VCALENDAR"))
+	})
+
+	It("returns an error for invalid lines", func() {
+		_, err := ical.Parse(strings.NewReader("BEGIN:VCALENDAR\nnonsense\nEND:VCALENDAR\n"), time.UTC)
+		Expect(err).To(MatchError(`invalid content line "nonsense"`))
+	})
+})
@@ -0,0 +1,230 @@
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/position"
+)
+
+const (
+	uidSuffix = "@wunderlist"
+
+	// starredPriority is the priority of starred tasks, the highest.
+	starredPriority = 1
+
+	// lowestHighPriority is the lowest priority which is considered high,
+	// and so stars a task.
+	lowestHighPriority = 4
+)
+
+// UID returns the UID of the todo for the task.
+func UID(taskID uint) string {
+	return fmt.Sprintf("task-%d%s", taskID, uidSuffix)
+}
+
+// TaskID returns the ID of the task from the UID of its todo.
+func TaskID(uid string) (uint, bool) {
+	if !strings.HasPrefix(uid, "task-") || !strings.HasSuffix(uid, uidSuffix) {
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(uid, "task-"), uidSuffix), 10, 0)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// NewTodo returns the todo for the task, with its note as the
+// description and an alarm for each reminder.
+func NewTodo(t wl.Task, note string, reminders []wl.Reminder) Todo {
+	todo := Todo{
+		UID:         UID(t.ID),
+		Summary:     t.Title,
+		Description: note,
+		Due:         t.DueDate,
+		Completed:   t.Completed,
+		CompletedAt: t.CompletedAt,
+		Created:     t.CreatedAt,
+		Recurrence:  t.Recurrence(),
+		Sequence:    t.Revision,
+	}
+
+	if t.Starred {
+		todo.Priority = starredPriority
+	}
+
+	for _, r := range reminders {
+		todo.Alarms = append(todo.Alarms, r.Date)
+	}
+
+	return todo
+}
+
+// ApplyTodo returns the task updated with the summary, completion, due date
+// and recurrence of the todo. High priorities, from 1 to 4, star the task.
+// The star is only changed if the todo has a priority, and the recurrence
+// only if it has one, so that clients which do not send them back do not
+// unstar the task or stop it recurring. The description and alarms are
+// applied by UpdateDetails.
+func ApplyTodo(todo Todo, t wl.Task) wl.Task {
+	t.Title = todo.Summary
+	t.Completed = todo.Completed
+	t.DueDate = todo.Due
+	if todo.HasPriority {
+		t.Starred = todo.Priority >= starredPriority && todo.Priority <= lowestHighPriority
+	}
+	if !todo.Recurrence.IsZero() {
+		t.RecurrenceType = todo.Recurrence.Type
+		t.RecurrenceCount = todo.Recurrence.Count
+	}
+	return t
+}
+
+// Entry is a task with its note and reminders, which together make a todo.
+type Entry struct {
+	Task      wl.Task
+	Note      wl.Note
+	Reminders []wl.Reminder
+}
+
+// Todo returns the todo for the entry.
+func (e Entry) Todo() Todo {
+	return NewTodo(e.Task, e.Note.Content, e.Reminders)
+}
+
+// UpdateDetails replaces the note of the entry with the description of the
+// todo, and its reminders with the alarms, returning the updated entry.
+// The note is deleted if the description is empty. Reminders at the time of
+// an alarm are kept.
+func UpdateDetails(client wl.Client, e Entry, todo Todo) (Entry, error) {
+	switch {
+	case todo.Description == e.Note.Content:
+	case todo.Description == "":
+		if err := client.DeleteNote(e.Note); err != nil {
+			return e, err
+		}
+		e.Note = wl.Note{}
+	case e.Note.ID != 0:
+		n := e.Note
+		n.Content = todo.Description
+		n, err := client.UpdateNote(n)
+		if err != nil {
+			return e, err
+		}
+		e.Note = n
+	default:
+		n, err := client.CreateNote(todo.Description, e.Task.ID)
+		if err != nil {
+			return e, err
+		}
+		e.Note = n
+	}
+
+	alarms := append([]time.Time{}, todo.Alarms...)
+	reminders := []wl.Reminder{}
+	for _, r := range e.Reminders {
+		i := indexOfTime(alarms, r.Date)
+		if i >= 0 {
+			alarms = append(alarms[:i], alarms[i+1:]...)
+			reminders = append(reminders, r)
+			continue
+		}
+
+		if err := client.DeleteReminder(r); err != nil {
+			return e, err
+		}
+	}
+	e.Reminders = reminders
+
+	for _, a := range alarms {
+		r, err := client.CreateReminder(a, e.Task.ID, "")
+		if err != nil {
+			return e, err
+		}
+		e.Reminders = append(e.Reminders, r)
+	}
+
+	return e, nil
+}
+
+func indexOfTime(times []time.Time, t time.Time) int {
+	for i, u := range times {
+		if u.Equal(t) {
+			return i
+		}
+	}
+	return -1
+}
+
+// NewCalendar returns a calendar with the tasks in the lists, in order of
+// the lists and then their positions. Completed tasks are only included if
+// completed is true.
+func NewCalendar(client wl.Client, name string, listIDs []uint, completed bool) (Calendar, error) {
+	entries, err := Entries(client, listIDs, completed)
+	if err != nil {
+		return Calendar{}, err
+	}
+
+	c := Calendar{Name: name, Todos: []Todo{}}
+	for _, e := range entries {
+		c.Todos = append(c.Todos, e.Todo())
+	}
+	return c, nil
+}
+
+// Entries returns the tasks in the lists with their notes and reminders,
+// in order of the lists and then their positions. Completed tasks are only
+// included if completed is true.
+func Entries(client wl.Client, listIDs []uint, completed bool) ([]Entry, error) {
+	entries := []Entry{}
+
+	for _, listID := range listIDs {
+		tasks, err := position.TasksForListID(client, listID)
+		if err != nil {
+			return nil, err
+		}
+
+		if completed {
+			completedTasks, err := client.CompletedTasksForListID(listID, true)
+			if err != nil {
+				return nil, err
+			}
+			tasks = append(tasks, completedTasks...)
+		}
+
+		notes, err := client.NotesForListID(listID)
+		if err != nil {
+			return nil, err
+		}
+
+		reminders, err := client.RemindersForListID(listID)
+		if err != nil {
+			return nil, err
+		}
+
+		notesByTask := map[uint]wl.Note{}
+		for _, n := range notes {
+			notesByTask[n.TaskID] = n
+		}
+
+		remindersByTask := map[uint][]wl.Reminder{}
+		for _, r := range reminders {
+			remindersByTask[r.TaskID] = append(remindersByTask[r.TaskID], r)
+		}
+
+		for _, t := range tasks {
+			entries = append(entries, Entry{
+				Task:      t,
+				Note:      notesByTask[t.ID],
+				Reminders: remindersByTask[t.ID],
+			})
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,145 @@
+package ical_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/ical"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+var _ = Describe("Tasks", func() {
+	Describe("UID and TaskID", func() {
+		It("round trip", func() {
+			Expect(ical.UID(12)).To(Equal("task-12@wunderlist"))
+
+			id, ok := ical.TaskID("task-12@wunderlist")
+			Expect(ok).To(BeTrue())
+			Expect(id).To(Equal(uint(12)))
+
+			_, ok = ical.TaskID("some-other-uid")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("NewTodo", func() {
+		It("maps the task, note and reminders", func() {
+			due := wl.NewDate(2016, time.January, 6)
+			remind := time.Date(2016, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+			todo := ical.NewTodo(
+				wl.Task{
+					ID:              12,
+					Title:           "Task",
+					Starred:         true,
+					DueDate:         due,
+					RecurrenceType:  wl.RecurrenceMonth,
+					RecurrenceCount: 1,
+					Revision:        4,
+				},
+				"Note",
+				[]wl.Reminder{{Date: remind}},
+			)
+
+			Expect(todo).To(Equal(ical.Todo{
+				UID:         "task-12@wunderlist",
+				Summary:     "Task",
+				Description: "Note",
+				Due:         due,
+				Priority:    1,
+				Recurrence:  wl.Recurrence{Type: wl.RecurrenceMonth, Count: 1},
+				Alarms:      []time.Time{remind},
+				Sequence:    4,
+			}))
+		})
+	})
+
+	Describe("ApplyTodo", func() {
+		It("updates the task from the todo", func() {
+			due := wl.NewDate(2016, time.January, 6)
+
+			task := ical.ApplyTodo(
+				ical.Todo{
+					Summary:     "Renamed",
+					Description: "Ignored",
+					Completed:   true,
+					Due:         due,
+					Priority:    3,
+					HasPriority: true,
+					Recurrence:  wl.Recurrence{Type: wl.RecurrenceWeek, Count: 2},
+				},
+				wl.Task{ID: 12, ListID: 5, Title: "Task", Revision: 4},
+			)
+
+			Expect(task).To(Equal(wl.Task{
+				ID:              12,
+				ListID:          5,
+				Title:           "Renamed",
+				Completed:       true,
+				DueDate:         due,
+				Starred:         true,
+				RecurrenceType:  wl.RecurrenceWeek,
+				RecurrenceCount: 2,
+				Revision:        4,
+			}))
+		})
+
+		It("only stars tasks with a high priority", func() {
+			for priority, starred := range map[int]bool{0: false, 1: true, 4: true, 5: false, 9: false} {
+				task := ical.ApplyTodo(ical.Todo{Priority: priority, HasPriority: true}, wl.Task{Starred: true})
+				Expect(task.Starred).To(Equal(starred))
+			}
+		})
+
+		It("does not change the star or recurrence if the todo has no priority or recurrence", func() {
+			task := ical.ApplyTodo(
+				ical.Todo{Summary: "Renamed"},
+				wl.Task{Title: "Task", Starred: true, RecurrenceType: wl.RecurrenceWeek, RecurrenceCount: 2},
+			)
+
+			Expect(task).To(Equal(wl.Task{
+				Title:           "Renamed",
+				Starred:         true,
+				RecurrenceType:  wl.RecurrenceWeek,
+				RecurrenceCount: 2,
+			}))
+		})
+	})
+
+	Describe("NewCalendar", func() {
+		var client *wltest.Client
+
+		BeforeEach(func() {
+			client = wltest.NewClient()
+			client.AddList(wl.List{ID: 5, Title: "Work"})
+			client.AddTask(wl.Task{ID: 1, ListID: 5, Title: "Second"})
+			client.AddTask(wl.Task{ID: 2, ListID: 5, Title: "Completed", Completed: true})
+			client.AddTask(wl.Task{ID: 3, ListID: 5, Title: "First"})
+			client.SetTaskPosition(5, 3, 1)
+			client.AddNote(wl.Note{TaskID: 3, Content: "A note"})
+			client.AddReminder(wl.Reminder{TaskID: 1})
+			client.AddReminder(wl.Reminder{TaskID: 1})
+		})
+
+		It("includes incomplete tasks in order", func() {
+			c, err := ical.NewCalendar(client, "Work", []uint{5}, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Name).To(Equal("Work"))
+			Expect(c.Todos).To(HaveLen(2))
+			Expect(c.Todos[0].Summary).To(Equal("First"))
+			Expect(c.Todos[0].Description).To(Equal("A note"))
+			Expect(c.Todos[1].Alarms).To(HaveLen(2))
+		})
+
+		It("includes completed tasks if requested", func() {
+			c, err := ical.NewCalendar(client, "Work", []uint{5}, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Todos).To(HaveLen(3))
+			Expect(c.Todos[2].Completed).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,354 @@
+// Package importer imports lists and tasks from other formats.
+//
+// Each format is read by a Source into Data, which is independent of the
+// format. Data is then planned against the account, matching existing
+// folders and lists by title so that importing the same data twice does not
+// duplicate it, and the plan is applied by creating everything through a
+// wl.Client.
+package importer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/position"
+)
+
+// Data is the content to import.
+type Data struct {
+	Lists []List `json:"lists" yaml:"lists"`
+}
+
+// List is a list to import. Tasks are in the order they should appear.
+// Tasks in the inbox are imported into the existing inbox.
+// Folder is the title of the folder containing the list, if any.
+type List struct {
+	Title  string `json:"title" yaml:"title"`
+	Folder string `json:"folder" yaml:"folder"`
+	Inbox  bool   `json:"inbox" yaml:"inbox"`
+	Tasks  []Task `json:"tasks" yaml:"tasks"`
+}
+
+// Task is a task to import. Subtasks are in the order they should appear.
+type Task struct {
+	Title      string        `json:"title" yaml:"title"`
+	Completed  bool          `json:"completed" yaml:"completed"`
+	Starred    bool          `json:"starred" yaml:"starred"`
+	DueDate    wl.Date       `json:"due_date" yaml:"due_date"`
+	Recurrence wl.Recurrence `json:"recurrence" yaml:"recurrence"`
+	Note       string        `json:"note" yaml:"note"`
+	Subtasks   []Subtask     `json:"subtasks" yaml:"subtasks"`
+	Reminders  []time.Time   `json:"reminders" yaml:"reminders"`
+}
+
+// Subtask is a subtask to import.
+type Subtask struct {
+	Title     string `json:"title" yaml:"title"`
+	Completed bool   `json:"completed" yaml:"completed"`
+}
+
+// Plan describes what an import will create.
+type Plan struct {
+	Folders []PlannedFolder `json:"folders" yaml:"folders"`
+	Lists   []PlannedList   `json:"lists" yaml:"lists"`
+}
+
+// PlannedFolder is a folder to create, or an existing folder with the same
+// title to add created lists to.
+type PlannedFolder struct {
+	Title      string `json:"title" yaml:"title"`
+	ExistingID uint   `json:"existing_id" yaml:"existing_id"`
+}
+
+// PlannedList is a list to create, or an existing list with the same title
+// to add tasks to. Tasks with the same title as a task in an existing list
+// are skipped. Folder is the title of the folder to add a created list to.
+type PlannedList struct {
+	Title        string   `json:"title" yaml:"title"`
+	Folder       string   `json:"folder" yaml:"folder"`
+	ExistingID   uint     `json:"existing_id" yaml:"existing_id"`
+	Tasks        []Task   `json:"tasks" yaml:"tasks"`
+	SkippedTasks []string `json:"skipped_tasks" yaml:"skipped_tasks"`
+}
+
+// Result counts what an import created.
+type Result struct {
+	FoldersCreated int `json:"folders_created" yaml:"folders_created"`
+	FoldersReused  int `json:"folders_reused" yaml:"folders_reused"`
+	ListsCreated   int `json:"lists_created" yaml:"lists_created"`
+	ListsReused    int `json:"lists_reused" yaml:"lists_reused"`
+	TasksCreated   int `json:"tasks_created" yaml:"tasks_created"`
+	TasksSkipped   int `json:"tasks_skipped" yaml:"tasks_skipped"`
+	Subtasks       int `json:"subtasks" yaml:"subtasks"`
+	Notes          int `json:"notes" yaml:"notes"`
+	Reminders      int `json:"reminders" yaml:"reminders"`
+}
+
+// NewPlan plans the import of the data, matching folders and lists
+// case-insensitively by title against those in the account.
+// Lists without a folder match any list with the same title, and lists in
+// a folder only match lists with the same title in a folder with the same title.
+func NewPlan(client wl.Client, data Data) (Plan, error) {
+	lists, err := client.Lists()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	existing := map[string]wl.List{}
+	var inbox wl.List
+	for _, l := range lists {
+		existing[listKey("", l.Title)] = l
+		if l.ListType == "inbox" {
+			inbox = l
+		}
+	}
+
+	existingFolders := map[string]wl.Folder{}
+	if hasFolders(data) {
+		folders, err := client.Folders()
+		if err != nil {
+			return Plan{}, err
+		}
+
+		byID := map[uint]wl.List{}
+		for _, l := range lists {
+			byID[l.ID] = l
+		}
+
+		for _, f := range folders {
+			existingFolders[strings.ToLower(f.Title)] = f
+			for _, id := range f.ListIDs {
+				if l, ok := byID[id]; ok {
+					existing[listKey(f.Title, l.Title)] = l
+				}
+			}
+		}
+	}
+
+	plan := Plan{Folders: []PlannedFolder{}, Lists: []PlannedList{}}
+	planned := map[string]int{}
+	plannedFolders := map[string]bool{}
+	existingTasks := map[int]map[string]bool{}
+
+	for _, l := range data.Lists {
+		key := listKey(l.Folder, l.Title)
+		if l.Inbox && inbox.ID != 0 {
+			key = listKey("", inbox.Title)
+		}
+
+		// Lists with the same folder and title in the data are merged.
+		i, ok := planned[key]
+		if !ok {
+			i = len(plan.Lists)
+			planned[key] = i
+
+			pl := PlannedList{Title: l.Title, Folder: l.Folder}
+			if e, ok := existing[key]; ok {
+				pl = PlannedList{Title: e.Title, ExistingID: e.ID}
+				existingTasks[i], err = taskTitles(client, e.ID)
+				if err != nil {
+					return Plan{}, err
+				}
+			}
+			plan.Lists = append(plan.Lists, pl)
+
+			folder := strings.ToLower(pl.Folder)
+			if pl.Folder != "" && !plannedFolders[folder] {
+				plannedFolders[folder] = true
+				plan.Folders = append(plan.Folders, PlannedFolder{
+					Title:      pl.Folder,
+					ExistingID: existingFolders[folder].ID,
+				})
+			}
+		}
+
+		pl := &plan.Lists[i]
+		for _, t := range l.Tasks {
+			if existingTasks[i][strings.ToLower(t.Title)] {
+				pl.SkippedTasks = append(pl.SkippedTasks, t.Title)
+				continue
+			}
+			pl.Tasks = append(pl.Tasks, t)
+		}
+	}
+
+	return plan, nil
+}
+
+func listKey(folder string, title string) string {
+	return strings.ToLower(folder) + "\x00" + strings.ToLower(title)
+}
+
+func hasFolders(data Data) bool {
+	for _, l := range data.Lists {
+		if l.Folder != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// MapFolders returns the data with the folders of its lists renamed as per
+// the mapping, which is from original to new folder title. Original titles
+// are matched case-insensitively, and mapping a folder to the empty string
+// imports its lists without a folder.
+func MapFolders(data Data, mapping map[string]string) Data {
+	lower := make(map[string]string, len(mapping))
+	for from, to := range mapping {
+		lower[strings.ToLower(from)] = to
+	}
+
+	mapped := Data{Lists: make([]List, len(data.Lists))}
+	for i, l := range data.Lists {
+		if to, ok := lower[strings.ToLower(l.Folder)]; ok && l.Folder != "" {
+			l.Folder = to
+		}
+		mapped.Lists[i] = l
+	}
+	return mapped
+}
+
+// taskTitles returns the lowercased titles of all tasks in the list,
+// completed or not.
+func taskTitles(client wl.Client, listID uint) (map[string]bool, error) {
+	titles := map[string]bool{}
+	for _, completed := range []bool{false, true} {
+		tasks, err := client.CompletedTasksForListID(listID, completed)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tasks {
+			titles[strings.ToLower(t.Title)] = true
+		}
+	}
+	return titles, nil
+}
+
+// Apply creates the folders, lists, tasks, subtasks, notes and reminders in
+// the plan, placing imported tasks and subtasks before existing ones in their
+// original order.
+func Apply(client wl.Client, plan Plan) (Result, error) {
+	var result Result
+
+	folderLists := map[string][]uint{}
+
+	for _, pl := range plan.Lists {
+		listID := pl.ExistingID
+		if listID == 0 {
+			l, err := client.CreateList(pl.Title)
+			if err != nil {
+				return result, err
+			}
+			listID = l.ID
+			result.ListsCreated++
+
+			if pl.Folder != "" {
+				folder := strings.ToLower(pl.Folder)
+				folderLists[folder] = append(folderLists[folder], listID)
+			}
+		} else {
+			result.ListsReused++
+		}
+		result.TasksSkipped += len(pl.SkippedTasks)
+
+		var taskIDs []uint
+		for _, t := range pl.Tasks {
+			id, err := createTask(client, listID, t, &result)
+			if err != nil {
+				return result, err
+			}
+			taskIDs = append(taskIDs, id)
+		}
+
+		if len(taskIDs) > 0 {
+			_, err := position.NewMover(client).MoveTasksToTop(listID, taskIDs)
+			if err != nil {
+				return result, err
+			}
+		}
+	}
+
+	for _, pf := range plan.Folders {
+		listIDs := folderLists[strings.ToLower(pf.Title)]
+		if len(listIDs) == 0 {
+			continue
+		}
+
+		if pf.ExistingID == 0 {
+			_, err := client.CreateFolder(pf.Title, listIDs)
+			if err != nil {
+				return result, err
+			}
+			result.FoldersCreated++
+			continue
+		}
+
+		f, err := client.Folder(pf.ExistingID)
+		if err != nil {
+			return result, err
+		}
+		f.ListIDs = append(f.ListIDs, listIDs...)
+
+		_, err = client.UpdateFolder(f)
+		if err != nil {
+			return result, err
+		}
+		result.FoldersReused++
+	}
+
+	return result, nil
+}
+
+// createTask creates the task and its subtasks, note and reminders.
+func createTask(client wl.Client, listID uint, t Task, result *Result) (uint, error) {
+	task, err := client.CreateTask(
+		t.Title,
+		listID,
+		0,
+		t.Completed,
+		t.Recurrence.Type,
+		t.Recurrence.Count,
+		t.DueDate,
+		t.Starred,
+	)
+	if err != nil {
+		return 0, err
+	}
+	result.TasksCreated++
+
+	var subtaskIDs []uint
+	for _, s := range t.Subtasks {
+		subtask, err := client.CreateSubtask(s.Title, task.ID, s.Completed)
+		if err != nil {
+			return 0, err
+		}
+		subtaskIDs = append(subtaskIDs, subtask.ID)
+		result.Subtasks++
+	}
+
+	if len(subtaskIDs) > 0 {
+		_, err = position.NewMover(client).MoveSubtasksToTop(task.ID, subtaskIDs)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if t.Note != "" {
+		_, err = client.CreateNote(t.Note, task.ID)
+		if err != nil {
+			return 0, err
+		}
+		result.Notes++
+	}
+
+	for _, r := range t.Reminders {
+		_, err = client.CreateReminder(r, task.ID, "")
+		if err != nil {
+			return 0, err
+		}
+		result.Reminders++
+	}
+
+	return task.ID, nil
+}
@@ -0,0 +1,342 @@
+package importer_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/importer"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+func newClient() *wltest.Client {
+	client := wltest.NewClient()
+	client.AddList(wl.List{ID: 1, Title: "inbox", ListType: "inbox"})
+	client.AddList(wl.List{ID: 2, Title: "Work"})
+	client.AddTask(wl.Task{ID: 3, ListID: 2, Title: "Existing"})
+	client.AddTask(wl.Task{ID: 4, ListID: 2, Title: "Done", Completed: true})
+	client.SetTaskPosition(2, 3)
+	return client
+}
+
+var _ = Describe("Importer", func() {
+	var (
+		client *wltest.Client
+		data   importer.Data
+	)
+
+	lists := func() []wl.List {
+		lists, err := client.Lists()
+		Expect(err).NotTo(HaveOccurred())
+		return lists
+	}
+
+	folders := func() []wl.Folder {
+		folders, err := client.Folders()
+		Expect(err).NotTo(HaveOccurred())
+		return folders
+	}
+
+	tasks := func() []wl.Task {
+		incomplete, err := client.CompletedTasks(false)
+		Expect(err).NotTo(HaveOccurred())
+		completed, err := client.CompletedTasks(true)
+		Expect(err).NotTo(HaveOccurred())
+		return append(incomplete, completed...)
+	}
+
+	taskTitled := func(title string) wl.Task {
+		for _, t := range tasks() {
+			if t.Title == title {
+				return t
+			}
+		}
+		Fail("no task titled " + title)
+		return wl.Task{}
+	}
+
+	subtaskTitled := func(title string) wl.Subtask {
+		for _, completed := range []bool{false, true} {
+			subtasks, err := client.CompletedSubtasks(completed)
+			Expect(err).NotTo(HaveOccurred())
+			for _, s := range subtasks {
+				if s.Title == title {
+					return s
+				}
+			}
+		}
+		Fail("no subtask titled " + title)
+		return wl.Subtask{}
+	}
+
+	taskPosition := func(listID uint) []uint {
+		positions, err := client.TaskPositionsForListID(listID)
+		Expect(err).NotTo(HaveOccurred())
+		return positions[0].Values
+	}
+
+	BeforeEach(func() {
+		client = newClient()
+		data = importer.Data{
+			Lists: []importer.List{
+				{
+					Title: "Inbox",
+					Inbox: true,
+					Tasks: []importer.Task{{Title: "Call home"}},
+				},
+				{
+					Title: "work",
+					Tasks: []importer.Task{
+						{Title: "existing"},
+						{Title: "DONE"},
+						{Title: "Write report", Starred: true},
+					},
+				},
+				{
+					Title: "Home",
+					Tasks: []importer.Task{
+						{
+							Title:      "Paint fence",
+							DueDate:    wl.NewDate(2016, time.January, 6),
+							Recurrence: wl.Recurrence{Type: wl.RecurrenceWeek, Count: 2},
+							Note:       "white",
+							Subtasks: []importer.Subtask{
+								{Title: "Buy paint"},
+								{Title: "Sand", Completed: true},
+							},
+							Reminders: []time.Time{time.Date(2016, time.January, 6, 9, 0, 0, 0, time.UTC)},
+						},
+						{Title: "Mow lawn", Completed: true},
+					},
+				},
+				{
+					Title: "home",
+					Tasks: []importer.Task{{Title: "Clean gutters"}},
+				},
+			},
+		}
+	})
+
+	Describe("NewPlan", func() {
+		It("matches lists by title, skipping existing tasks", func() {
+			plan, err := importer.NewPlan(client, data)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.Lists).To(HaveLen(3))
+
+			Expect(plan.Lists[0].Title).To(Equal("inbox"))
+			Expect(plan.Lists[0].ExistingID).To(Equal(uint(1)))
+			Expect(plan.Lists[0].Tasks).To(HaveLen(1))
+
+			Expect(plan.Lists[1].Title).To(Equal("Work"))
+			Expect(plan.Lists[1].ExistingID).To(Equal(uint(2)))
+			Expect(plan.Lists[1].SkippedTasks).To(Equal([]string{"existing", "DONE"}))
+			Expect(plan.Lists[1].Tasks).To(HaveLen(1))
+			Expect(plan.Lists[1].Tasks[0].Title).To(Equal("Write report"))
+		})
+
+		It("merges lists with the same title", func() {
+			plan, err := importer.NewPlan(client, data)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.Lists[2].Title).To(Equal("Home"))
+			Expect(plan.Lists[2].ExistingID).To(Equal(uint(0)))
+			Expect(plan.Lists[2].Tasks).To(HaveLen(3))
+			Expect(plan.Lists[2].Tasks[2].Title).To(Equal("Clean gutters"))
+		})
+
+		It("does not create anything", func() {
+			_, err := importer.NewPlan(client, data)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(lists()).To(HaveLen(2))
+			Expect(tasks()).To(HaveLen(2))
+		})
+	})
+
+	Describe("Apply", func() {
+		var plan importer.Plan
+
+		BeforeEach(func() {
+			var err error
+			plan, err = importer.NewPlan(client, data)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the lists, tasks, subtasks, notes and reminders", func() {
+			result, err := importer.Apply(client, plan)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result).To(Equal(importer.Result{
+				ListsCreated: 1,
+				ListsReused:  2,
+				TasksCreated: 5,
+				TasksSkipped: 2,
+				Subtasks:     2,
+				Notes:        1,
+				Reminders:    1,
+			}))
+
+			home := lists()[2]
+			Expect(home.Title).To(Equal("Home"))
+
+			fence := taskTitled("Paint fence")
+			Expect(fence.ListID).To(Equal(home.ID))
+			Expect(fence.DueDate).To(Equal(wl.NewDate(2016, time.January, 6)))
+			Expect(fence.Recurrence()).To(Equal(wl.Recurrence{Type: wl.RecurrenceWeek, Count: 2}))
+
+			notes, err := client.Notes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notes).To(HaveLen(1))
+			Expect(notes[0].TaskID).To(Equal(fence.ID))
+
+			reminders, err := client.Reminders()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reminders[0].TaskID).To(Equal(fence.ID))
+
+			Expect(subtaskTitled("Buy paint").TaskID).To(Equal(fence.ID))
+			Expect(subtaskTitled("Sand").Completed).To(BeTrue())
+
+			Expect(taskTitled("Mow lawn").Completed).To(BeTrue())
+			Expect(taskTitled("Write report").Starred).To(BeTrue())
+			Expect(taskTitled("Call home").ListID).To(Equal(uint(1)))
+		})
+
+		It("places imported tasks and subtasks first in their original order", func() {
+			_, err := importer.Apply(client, plan)
+			Expect(err).NotTo(HaveOccurred())
+
+			report := taskTitled("Write report")
+			Expect(taskPosition(2)).To(Equal([]uint{report.ID, 3}))
+
+			home := lists()[2]
+			Expect(taskPosition(home.ID)).To(Equal([]uint{
+				taskTitled("Paint fence").ID,
+				taskTitled("Mow lawn").ID,
+				taskTitled("Clean gutters").ID,
+			}))
+
+			fence := taskTitled("Paint fence")
+			positions, err := client.SubtaskPositionsForTaskID(fence.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(positions[0].Values).To(Equal([]uint{
+				subtaskTitled("Buy paint").ID,
+				subtaskTitled("Sand").ID,
+			}))
+		})
+
+		It("retries position updates which conflict with concurrent changes", func() {
+			conflicts := 1
+			client.Before("UpdateTaskPosition", func(args ...interface{}) error {
+				if conflicts == 0 {
+					return nil
+				}
+				conflicts--
+				listID := args[0].(wl.Position).ID
+				client.SetTaskPosition(listID, append(taskPosition(listID), 9)...)
+				return wl.StatusError{StatusCode: http.StatusConflict, Expected: http.StatusOK}
+			})
+
+			_, err := importer.Apply(client, plan)
+			Expect(err).NotTo(HaveOccurred())
+
+			call := taskTitled("Call home")
+			Expect(taskPosition(1)).To(Equal([]uint{call.ID, 9}))
+		})
+
+		It("returns what was created before an error", func() {
+			client.Fail("CreateTask", errors.New("some error"))
+
+			result, err := importer.Apply(client, plan)
+			Expect(err).To(MatchError("some error"))
+			Expect(result.ListsReused).To(Equal(1))
+			Expect(result.TasksCreated).To(Equal(0))
+		})
+	})
+
+	Context("when lists are in folders", func() {
+		BeforeEach(func() {
+			client.AddList(wl.List{ID: 5, Title: "To Do"})
+			client.AddList(wl.List{ID: 6, Title: "Doing"})
+			client.AddFolder(wl.Folder{ID: 7, Title: "Board A", ListIDs: []uint{5}})
+
+			data = importer.Data{
+				Lists: []importer.List{
+					{Title: "to do", Folder: "board a", Tasks: []importer.Task{{Title: "One"}}},
+					{Title: "Doing", Folder: "Board A", Tasks: []importer.Task{{Title: "Two"}}},
+					{Title: "To Do", Folder: "Board B", Tasks: []importer.Task{{Title: "Three"}}},
+					{Title: "Work", Tasks: []importer.Task{{Title: "Four"}}},
+				},
+			}
+		})
+
+		It("only matches lists in a folder with the same title", func() {
+			plan, err := importer.NewPlan(client, data)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.Folders).To(Equal([]importer.PlannedFolder{
+				{Title: "Board A", ExistingID: 7},
+				{Title: "Board B"},
+			}))
+
+			Expect(plan.Lists).To(HaveLen(4))
+			Expect(plan.Lists[0].ExistingID).To(Equal(uint(5)))
+			Expect(plan.Lists[1].ExistingID).To(Equal(uint(0)))
+			Expect(plan.Lists[1].Folder).To(Equal("Board A"))
+			Expect(plan.Lists[2].ExistingID).To(Equal(uint(0)))
+			Expect(plan.Lists[2].Folder).To(Equal("Board B"))
+			Expect(plan.Lists[3].ExistingID).To(Equal(uint(2)))
+		})
+
+		It("adds created lists to existing or created folders", func() {
+			plan, err := importer.NewPlan(client, data)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := importer.Apply(client, plan)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.FoldersCreated).To(Equal(1))
+			Expect(result.FoldersReused).To(Equal(1))
+			Expect(result.ListsCreated).To(Equal(2))
+
+			l := lists()
+			doing := l[len(l)-2]
+			toDo := l[len(l)-1]
+			Expect(doing.Title).To(Equal("Doing"))
+			Expect(toDo.Title).To(Equal("To Do"))
+
+			f := folders()
+			Expect(f).To(HaveLen(2))
+			Expect(f[0].ListIDs).To(Equal([]uint{5, doing.ID}))
+			Expect(f[1].Title).To(Equal("Board B"))
+			Expect(f[1].ListIDs).To(Equal([]uint{toDo.ID}))
+		})
+	})
+
+	Describe("MapFolders", func() {
+		It("renames folders case-insensitively, removing those mapped to nothing", func() {
+			data := importer.Data{
+				Lists: []importer.List{
+					{Title: "A", Folder: "Board A"},
+					{Title: "B", Folder: "Board B"},
+					{Title: "C", Folder: "Board C"},
+					{Title: "D"},
+				},
+			}
+
+			mapped := importer.MapFolders(data, map[string]string{
+				"board a": "Work",
+				"Board B": "",
+				"":        "Other",
+			})
+
+			Expect(mapped.Lists[0].Folder).To(Equal("Work"))
+			Expect(mapped.Lists[1].Folder).To(BeEmpty())
+			Expect(mapped.Lists[2].Folder).To(Equal("Board C"))
+			Expect(mapped.Lists[3].Folder).To(BeEmpty())
+			Expect(data.Lists[0].Folder).To(Equal("Board A"))
+		})
+	})
+})
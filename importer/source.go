@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Source reads the data to import from a file in a particular format.
+// The name is that of the file without its extension, and is used by
+// formats which do not record the name of what was exported.
+type Source interface {
+	Read(r io.Reader, name string) (Data, error)
+}
+
+var (
+	sourcesMutex sync.RWMutex
+	sources      = map[string]Source{
+		FormatWunderlistBackup: WunderlistBackup{},
+		FormatTrello:           Trello{},
+		FormatTodoist:          Todoist{},
+	}
+)
+
+// Register makes the source available to read the format, e.g. from the init
+// function of the package which provides it. It panics if the source is nil
+// or the format already has a source.
+func Register(format string, s Source) {
+	sourcesMutex.Lock()
+	defer sourcesMutex.Unlock()
+
+	if s == nil {
+		panic("importer: Register source is nil")
+	}
+	if _, ok := sources[format]; ok {
+		panic("importer: Register called twice for format " + format)
+	}
+	sources[format] = s
+}
+
+// Formats returns the names of the supported formats, sorted.
+func Formats() []string {
+	sourcesMutex.RLock()
+	defer sourcesMutex.RUnlock()
+
+	formats := make([]string, 0, len(sources))
+	for f := range sources {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// SourceFor returns the source which reads the provided format.
+func SourceFor(format string) (Source, error) {
+	sourcesMutex.RLock()
+	defer sourcesMutex.RUnlock()
+
+	s, ok := sources[format]
+	if !ok {
+		return nil, fmt.Errorf("unrecognized format: %s", format)
+	}
+	return s, nil
+}
+
+// Read reads the data in the provided format.
+func Read(r io.Reader, name string, format string) (Data, error) {
+	s, err := SourceFor(format)
+	if err != nil {
+		return Data{}, err
+	}
+	return s.Read(r, name)
+}
@@ -0,0 +1,46 @@
+package importer_test
+
+import (
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl/importer"
+)
+
+type testSource struct{}
+
+func (testSource) Read(r io.Reader, name string) (importer.Data, error) {
+	return importer.Data{}, nil
+}
+
+func init() {
+	importer.Register("test", testSource{})
+}
+
+var _ = Describe("Sources", func() {
+	It("lists the supported formats", func() {
+		Expect(importer.Formats()).To(Equal([]string{"test", "todoist", "trello", "wunderlist-backup"}))
+	})
+
+	It("returns registered sources", func() {
+		s, err := importer.SourceFor("test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s).To(Equal(testSource{}))
+	})
+
+	It("panics if a format is registered twice", func() {
+		Expect(func() { importer.Register(importer.FormatTrello, importer.Trello{}) }).To(Panic())
+	})
+
+	It("returns the source for a format", func() {
+		s, err := importer.SourceFor(importer.FormatTrello)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s).To(Equal(importer.Trello{}))
+	})
+
+	It("returns an error for an unrecognized format", func() {
+		_, err := importer.SourceFor("other")
+		Expect(err).To(MatchError("unrecognized format: other"))
+	})
+})
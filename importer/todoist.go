@@ -0,0 +1,173 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/dateparse"
+)
+
+const (
+	// FormatTodoist is the CSV export of a Todoist project.
+	FormatTodoist = "todoist"
+)
+
+// Todoist reads CSV exports of Todoist projects.
+//
+// The project becomes a folder, named after the file. Tasks before the first
+// section go into a list with the same name, and each section becomes a list.
+// Indented tasks become subtasks of the task above them, and comments and
+// descriptions become its note. Priority 1 tasks are starred.
+type Todoist struct {
+	// Now is the time relative to which dates such as "tomorrow" are parsed.
+	// It defaults to the current time.
+	Now time.Time
+}
+
+// Read reads a Todoist project export.
+//
+// Dates which cannot be parsed are ignored. Recurring dates are imported
+// if they are of the form "every [n] day|week|month|year", due today.
+func (t Todoist) Read(r io.Reader, name string) (Data, error) {
+	now := t.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	// Skip any byte order mark, which would otherwise be part of the header.
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(3); err == nil && string(bom) == "\xef\xbb\xbf" {
+		br.Discard(3)
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return Data{}, fmt.Errorf("failed to read todoist csv: %v", err)
+	}
+
+	columns := map[string]int{}
+	for i, h := range header {
+		columns[strings.ToUpper(strings.TrimSpace(h))] = i
+	}
+	if _, ok := columns["TYPE"]; !ok {
+		return Data{}, fmt.Errorf("failed to read todoist csv: no TYPE column")
+	}
+	if _, ok := columns["CONTENT"]; !ok {
+		return Data{}, fmt.Errorf("failed to read todoist csv: no CONTENT column")
+	}
+
+	lists := []List{{Title: name, Folder: name, Tasks: []Task{}}}
+	var task *Task
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Data{}, fmt.Errorf("failed to read todoist csv: %v", err)
+		}
+
+		field := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		switch strings.ToLower(field("TYPE")) {
+		case "section":
+			lists = append(lists, List{Title: field("CONTENT"), Folder: name, Tasks: []Task{}})
+			task = nil
+
+		case "task":
+			indent, _ := strconv.Atoi(field("INDENT"))
+			if indent > 1 && task != nil {
+				task.Subtasks = append(task.Subtasks, Subtask{Title: field("CONTENT")})
+				continue
+			}
+
+			l := &lists[len(lists)-1]
+			l.Tasks = append(l.Tasks, Task{
+				Title:   field("CONTENT"),
+				Starred: field("PRIORITY") == "1",
+				Note:    field("DESCRIPTION"),
+			})
+			task = &l.Tasks[len(l.Tasks)-1]
+			task.DueDate, task.Recurrence = todoistDate(field("DATE"), now)
+
+		case "note":
+			if task == nil {
+				continue
+			}
+			if task.Note != "" {
+				task.Note += "\n\n"
+			}
+			task.Note += field("CONTENT")
+		}
+	}
+
+	// Only keep the list for the project itself if it is needed.
+	if len(lists) > 1 && len(lists[0].Tasks) == 0 {
+		lists = lists[1:]
+	}
+
+	return Data{Lists: lists}, nil
+}
+
+// todoistDate parses the due date of a task.
+func todoistDate(s string, now time.Time) (wl.Date, wl.Recurrence) {
+	if s == "" {
+		return wl.Date{}, wl.Recurrence{}
+	}
+
+	fields := strings.Fields(strings.ToLower(s))
+	if fields[0] == "every" {
+		r, ok := todoistRecurrence(fields[1:])
+		if !ok {
+			return wl.Date{}, wl.Recurrence{}
+		}
+		return wl.DateOf(now), r
+	}
+
+	t, _, err := dateparse.Parse(s, now)
+	if err != nil {
+		return wl.Date{}, wl.Recurrence{}
+	}
+	return wl.DateOf(t), wl.Recurrence{}
+}
+
+// todoistRecurrence parses the fields after "every" in a recurring date.
+func todoistRecurrence(fields []string) (wl.Recurrence, bool) {
+	r := wl.Recurrence{Count: 1}
+
+	if len(fields) == 2 {
+		n, err := strconv.ParseUint(fields[0], 10, 0)
+		if err != nil || n == 0 {
+			return wl.Recurrence{}, false
+		}
+		r.Count = uint(n)
+		fields = fields[1:]
+	}
+
+	if len(fields) != 1 {
+		return wl.Recurrence{}, false
+	}
+
+	t, err := wl.ParseRecurrenceType(fields[0])
+	if err != nil || t == wl.RecurrenceNone {
+		return wl.Recurrence{}, false
+	}
+	r.Type = t
+	return r, true
+}
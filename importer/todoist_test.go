@@ -0,0 +1,85 @@
+package importer_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/importer"
+)
+
+const todoistProject = "\xef\xbb\xbfTYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE\n" +
+	"task,Unsectioned,,4,1,Someone (1),,,en,UTC\n" +
+	",,,,,,,,,\n" +
+	"section,Week one,,,,,,,,\n" +
+	"task,Book venue,Call first,1,1,Someone (1),,2016-01-08,en,UTC\n" +
+	"task,Compare prices,,4,2,Someone (1),,,en,UTC\n" +
+	"note,\"Ask about parking, too\",,,,Someone (1),,,en,UTC\n" +
+	"task,Water plants,,4,1,Someone (1),,every 2 weeks,en,UTC\n" +
+	"task,Odd date,,4,1,Someone (1),,every other tuesday,en,UTC\n" +
+	"section,Week two,,,,,,,,\n" +
+	"task,Send invites,,4,1,Someone (1),,tomorrow,en,UTC\n"
+
+var _ = Describe("Todoist", func() {
+	var source importer.Todoist
+
+	BeforeEach(func() {
+		source = importer.Todoist{Now: time.Date(2016, time.January, 5, 10, 0, 0, 0, time.UTC)}
+	})
+
+	It("reads sections as lists in a folder named after the project", func() {
+		data, err := source.Read(strings.NewReader(todoistProject), "Party")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(data.Lists).To(HaveLen(3))
+		Expect(data.Lists[0].Title).To(Equal("Party"))
+		Expect(data.Lists[1].Title).To(Equal("Week one"))
+		Expect(data.Lists[2].Title).To(Equal("Week two"))
+		for _, l := range data.Lists {
+			Expect(l.Folder).To(Equal("Party"))
+		}
+
+		Expect(data.Lists[0].Tasks).To(HaveLen(1))
+		Expect(data.Lists[0].Tasks[0].Title).To(Equal("Unsectioned"))
+	})
+
+	It("reads tasks, subtasks, notes, priorities and dates", func() {
+		data, err := source.Read(strings.NewReader(todoistProject), "Party")
+		Expect(err).NotTo(HaveOccurred())
+
+		tasks := data.Lists[1].Tasks
+		Expect(tasks).To(HaveLen(3))
+
+		Expect(tasks[0].Title).To(Equal("Book venue"))
+		Expect(tasks[0].Starred).To(BeTrue())
+		Expect(tasks[0].DueDate).To(Equal(wl.NewDate(2016, time.January, 8)))
+		Expect(tasks[0].Subtasks).To(Equal([]importer.Subtask{{Title: "Compare prices"}}))
+		Expect(tasks[0].Note).To(Equal("Call first\n\nAsk about parking, too"))
+
+		Expect(tasks[1].Starred).To(BeFalse())
+		Expect(tasks[1].DueDate).To(Equal(wl.NewDate(2016, time.January, 5)))
+		Expect(tasks[1].Recurrence).To(Equal(wl.Recurrence{Type: wl.RecurrenceWeek, Count: 2}))
+
+		Expect(tasks[2].DueDate.IsZero()).To(BeTrue())
+		Expect(tasks[2].Recurrence.IsZero()).To(BeTrue())
+
+		Expect(data.Lists[2].Tasks[0].DueDate).To(Equal(wl.NewDate(2016, time.January, 6)))
+	})
+
+	It("omits the project list if it has no tasks and there are sections", func() {
+		csv := "TYPE,CONTENT\nsection,Only\ntask,Thing\n"
+
+		data, err := source.Read(strings.NewReader(csv), "Project")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(data.Lists).To(HaveLen(1))
+		Expect(data.Lists[0].Title).To(Equal("Only"))
+	})
+
+	It("returns an error if required columns are missing", func() {
+		_, err := source.Read(strings.NewReader("CONTENT\nThing\n"), "Project")
+		Expect(err).To(MatchError("failed to read todoist csv: no TYPE column"))
+	})
+})
@@ -0,0 +1,152 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/robdimsdale/wl"
+)
+
+const (
+	// FormatTrello is the JSON export of a Trello board.
+	FormatTrello = "trello"
+)
+
+// Trello reads JSON exports of Trello boards.
+//
+// The board becomes a folder, each list a list, each card a task, the items
+// of a card's checklists its subtasks and its description its note.
+// Archived lists are skipped, and archived cards are imported as completed.
+type Trello struct {
+	// Location is the time zone in which due dates are converted to dates.
+	// It defaults to the local time zone.
+	Location *time.Location
+}
+
+type trelloBoard struct {
+	Name       string            `json:"name"`
+	Lists      []trelloList      `json:"lists"`
+	Cards      []trelloCard      `json:"cards"`
+	Checklists []trelloChecklist `json:"checklists"`
+}
+
+type trelloList struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+type trelloCard struct {
+	ID          string     `json:"id"`
+	IDList      string     `json:"idList"`
+	Name        string     `json:"name"`
+	Desc        string     `json:"desc"`
+	Closed      bool       `json:"closed"`
+	Due         *time.Time `json:"due"`
+	DueComplete bool       `json:"dueComplete"`
+	Pos         float64    `json:"pos"`
+}
+
+type trelloChecklist struct {
+	IDCard     string            `json:"idCard"`
+	Pos        float64           `json:"pos"`
+	CheckItems []trelloCheckItem `json:"checkItems"`
+}
+
+type trelloCheckItem struct {
+	Name  string  `json:"name"`
+	State string  `json:"state"`
+	Pos   float64 `json:"pos"`
+}
+
+// Read reads a Trello board export. The name is used if the board has none.
+func (t Trello) Read(r io.Reader, name string) (Data, error) {
+	loc := t.Location
+	if loc == nil {
+		loc = time.Local
+	}
+
+	var b trelloBoard
+	err := json.NewDecoder(r).Decode(&b)
+	if err != nil {
+		return Data{}, fmt.Errorf("failed to read trello board: %v", err)
+	}
+
+	if b.Name == "" {
+		b.Name = name
+	}
+
+	sort.Stable(byTrelloListPos(b.Lists))
+	sort.Stable(byTrelloCardPos(b.Cards))
+	sort.Stable(byTrelloChecklistPos(b.Checklists))
+
+	subtasks := map[string][]Subtask{}
+	for _, c := range b.Checklists {
+		items := append([]trelloCheckItem{}, c.CheckItems...)
+		sort.Stable(byTrelloCheckItemPos(items))
+
+		for _, i := range items {
+			subtasks[c.IDCard] = append(subtasks[c.IDCard], Subtask{
+				Title:     i.Name,
+				Completed: i.State == "complete",
+			})
+		}
+	}
+
+	tasks := map[string][]Task{}
+	for _, c := range b.Cards {
+		task := Task{
+			Title:     c.Name,
+			Completed: c.Closed || c.DueComplete,
+			Note:      c.Desc,
+			Subtasks:  subtasks[c.ID],
+		}
+		if c.Due != nil {
+			task.DueDate = wl.DateOf(c.Due.In(loc))
+		}
+		tasks[c.IDList] = append(tasks[c.IDList], task)
+	}
+
+	data := Data{Lists: []List{}}
+	for _, l := range b.Lists {
+		if l.Closed {
+			continue
+		}
+
+		list := List{Title: l.Name, Folder: b.Name, Tasks: tasks[l.ID]}
+		if list.Tasks == nil {
+			list.Tasks = []Task{}
+		}
+		data.Lists = append(data.Lists, list)
+	}
+
+	return data, nil
+}
+
+type byTrelloListPos []trelloList
+
+func (l byTrelloListPos) Len() int           { return len(l) }
+func (l byTrelloListPos) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
+func (l byTrelloListPos) Less(i, j int) bool { return l[i].Pos < l[j].Pos }
+
+type byTrelloCardPos []trelloCard
+
+func (c byTrelloCardPos) Len() int           { return len(c) }
+func (c byTrelloCardPos) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c byTrelloCardPos) Less(i, j int) bool { return c[i].Pos < c[j].Pos }
+
+type byTrelloChecklistPos []trelloChecklist
+
+func (c byTrelloChecklistPos) Len() int           { return len(c) }
+func (c byTrelloChecklistPos) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c byTrelloChecklistPos) Less(i, j int) bool { return c[i].Pos < c[j].Pos }
+
+type byTrelloCheckItemPos []trelloCheckItem
+
+func (c byTrelloCheckItemPos) Len() int           { return len(c) }
+func (c byTrelloCheckItemPos) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c byTrelloCheckItemPos) Less(i, j int) bool { return c[i].Pos < c[j].Pos }
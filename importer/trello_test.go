@@ -0,0 +1,80 @@
+package importer_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/importer"
+)
+
+const trelloBoard = `{
+  "name": "Launch",
+  "lists": [
+    {"id": "l2", "name": "Doing", "closed": false, "pos": 2048},
+    {"id": "l1", "name": "To Do", "closed": false, "pos": 1024},
+    {"id": "l3", "name": "Old", "closed": true, "pos": 4096}
+  ],
+  "cards": [
+    {"id": "c2", "idList": "l1", "name": "Second", "pos": 200, "due": null},
+    {"id": "c1", "idList": "l1", "name": "First", "desc": "Details", "pos": 100, "due": "2016-01-06T12:00:00.000Z"},
+    {"id": "c3", "idList": "l2", "name": "Archived", "closed": true, "pos": 100},
+    {"id": "c4", "idList": "l2", "name": "Due done", "dueComplete": true, "pos": 200},
+    {"id": "c5", "idList": "l3", "name": "In old list", "pos": 100}
+  ],
+  "checklists": [
+    {"idCard": "c1", "pos": 2, "checkItems": [{"name": "Later", "state": "incomplete", "pos": 1}]},
+    {"idCard": "c1", "pos": 1, "checkItems": [
+      {"name": "B", "state": "complete", "pos": 2},
+      {"name": "A", "state": "incomplete", "pos": 1}
+    ]}
+  ]
+}`
+
+var _ = Describe("Trello", func() {
+	It("reads lists, cards and checklists in order", func() {
+		source := importer.Trello{Location: time.FixedZone("test", 13*60*60)}
+		data, err := source.Read(strings.NewReader(trelloBoard), "file")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(data.Lists).To(HaveLen(2))
+
+		toDo := data.Lists[0]
+		Expect(toDo.Title).To(Equal("To Do"))
+		Expect(toDo.Folder).To(Equal("Launch"))
+		Expect(toDo.Tasks).To(HaveLen(2))
+
+		first := toDo.Tasks[0]
+		Expect(first.Title).To(Equal("First"))
+		Expect(first.Note).To(Equal("Details"))
+		Expect(first.DueDate).To(Equal(wl.NewDate(2016, time.January, 7)))
+		Expect(first.Subtasks).To(Equal([]importer.Subtask{
+			{Title: "A"},
+			{Title: "B", Completed: true},
+			{Title: "Later"},
+		}))
+
+		Expect(toDo.Tasks[1].Title).To(Equal("Second"))
+		Expect(toDo.Tasks[1].DueDate.IsZero()).To(BeTrue())
+
+		doing := data.Lists[1]
+		Expect(doing.Title).To(Equal("Doing"))
+		Expect(doing.Tasks[0].Completed).To(BeTrue())
+		Expect(doing.Tasks[1].Completed).To(BeTrue())
+	})
+
+	It("uses the name if the board has none", func() {
+		data, err := importer.Trello{}.Read(strings.NewReader(`{"lists": [{"id": "l1", "name": "A"}]}`), "file")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(data.Lists[0].Folder).To(Equal("file"))
+		Expect(data.Lists[0].Tasks).To(BeEmpty())
+	})
+
+	It("returns an error for invalid JSON", func() {
+		_, err := importer.Trello{}.Read(strings.NewReader("["), "file")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/position"
+)
+
+const (
+	// FormatWunderlistBackup is the JSON file produced by the
+	// "Create Backup" feature of the Wunderlist apps.
+	FormatWunderlistBackup = "wunderlist-backup"
+)
+
+// WunderlistBackup reads backups created by the Wunderlist apps.
+type WunderlistBackup struct{}
+
+// Read reads a Wunderlist backup. The name is not used.
+func (WunderlistBackup) Read(r io.Reader, name string) (Data, error) {
+	return ReadWunderlistBackup(r)
+}
+
+// wunderlistBackup is the structure of a Wunderlist backup. Objects have
+// the same fields as returned by the API.
+type wunderlistBackup struct {
+	User     uint   `json:"user"`
+	Exported string `json:"exported"`
+	Data     struct {
+		Lists            []wl.List        `json:"lists"`
+		Tasks            []wl.Task        `json:"tasks"`
+		Subtasks         []wl.Subtask     `json:"subtasks"`
+		Notes            []wl.Note        `json:"notes"`
+		Reminders        []wl.Reminder    `json:"reminders"`
+		TaskPositions    []backupPosition `json:"task_positions"`
+		SubtaskPositions []backupPosition `json:"subtask_positions"`
+	} `json:"data"`
+}
+
+// backupPosition is a position which also records its list or task.
+// Older backups omit these, in which case the ID of the position is the
+// ID of its list or task.
+type backupPosition struct {
+	wl.Position
+	ListID uint `json:"list_id"`
+	TaskID uint `json:"task_id"`
+}
+
+// ReadWunderlistBackup reads a Wunderlist backup. Tasks and subtasks are
+// ordered by their positions in the backup, and the inbox is first.
+// Assignees are not imported, as they refer to users of the original account.
+func ReadWunderlistBackup(r io.Reader) (Data, error) {
+	var b wunderlistBackup
+	err := json.NewDecoder(r).Decode(&b)
+	if err != nil {
+		return Data{}, fmt.Errorf("failed to read wunderlist backup: %v", err)
+	}
+
+	taskPositions := map[uint][]wl.Position{}
+	for _, p := range b.Data.TaskPositions {
+		id := p.ListID
+		if id == 0 {
+			id = p.ID
+		}
+		taskPositions[id] = append(taskPositions[id], p.Position)
+	}
+
+	subtaskPositions := map[uint][]wl.Position{}
+	for _, p := range b.Data.SubtaskPositions {
+		id := p.TaskID
+		if id == 0 {
+			id = p.ID
+		}
+		subtaskPositions[id] = append(subtaskPositions[id], p.Position)
+	}
+
+	tasksByList := map[uint][]wl.Task{}
+	for _, t := range b.Data.Tasks {
+		tasksByList[t.ListID] = append(tasksByList[t.ListID], t)
+	}
+
+	subtasksByTask := map[uint][]wl.Subtask{}
+	for _, s := range b.Data.Subtasks {
+		subtasksByTask[s.TaskID] = append(subtasksByTask[s.TaskID], s)
+	}
+
+	notes := map[uint]string{}
+	for _, n := range b.Data.Notes {
+		if n.Content != "" {
+			notes[n.TaskID] = n.Content
+		}
+	}
+
+	reminders := map[uint][]wl.Reminder{}
+	for _, r := range b.Data.Reminders {
+		reminders[r.TaskID] = append(reminders[r.TaskID], r)
+	}
+
+	data := Data{Lists: []List{}}
+	for _, l := range position.OrderLists(b.Data.Lists, nil) {
+		list := List{
+			Title: l.Title,
+			Inbox: l.ListType == "inbox",
+			Tasks: []Task{},
+		}
+
+		for _, t := range position.OrderTasks(tasksByList[l.ID], taskPositions[l.ID]) {
+			list.Tasks = append(list.Tasks, backupTask(
+				t,
+				position.OrderSubtasks(subtasksByTask[t.ID], subtaskPositions[t.ID]),
+				notes[t.ID],
+				reminders[t.ID],
+			))
+		}
+
+		data.Lists = append(data.Lists, list)
+	}
+
+	return data, nil
+}
+
+func backupTask(t wl.Task, subtasks []wl.Subtask, note string, reminders []wl.Reminder) Task {
+	task := Task{
+		Title:     t.Title,
+		Completed: t.Completed,
+		Starred:   t.Starred,
+		DueDate:   t.DueDate,
+		Note:      note,
+	}
+
+	// Invalid recurrences would be rejected when creating the task.
+	if r := t.Recurrence(); r.Validate() == nil {
+		task.Recurrence = r
+	}
+
+	for _, s := range subtasks {
+		task.Subtasks = append(task.Subtasks, Subtask{Title: s.Title, Completed: s.Completed})
+	}
+
+	for _, r := range reminders {
+		task.Reminders = append(task.Reminders, r.Date)
+	}
+
+	return task
+}
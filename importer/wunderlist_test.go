@@ -0,0 +1,98 @@
+package importer_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/importer"
+)
+
+const backup = `{
+  "user": 99,
+  "exported": "2016-01-05T10:00:00.000Z",
+  "data": {
+    "lists": [
+      {"id": 20, "title": "Work", "list_type": "list"},
+      {"id": 10, "title": "inbox", "list_type": "inbox"}
+    ],
+    "tasks": [
+      {"id": 100, "list_id": 20, "title": "First", "starred": true, "due_date": "2016-01-06", "recurrence_type": "week", "recurrence_count": 1},
+      {"id": 101, "list_id": 20, "title": "Second", "completed": true, "assignee_id": 99},
+      {"id": 102, "list_id": 20, "title": "Third", "recurrence_type": "fortnight", "recurrence_count": 1},
+      {"id": 103, "list_id": 10, "title": "Inbox task"}
+    ],
+    "subtasks": [
+      {"id": 200, "task_id": 100, "title": "Sub A"},
+      {"id": 201, "task_id": 100, "title": "Sub B", "completed": true}
+    ],
+    "notes": [
+      {"id": 300, "task_id": 100, "content": "Some note"},
+      {"id": 301, "task_id": 101, "content": ""}
+    ],
+    "reminders": [
+      {"id": 400, "task_id": 100, "date": "2016-01-06T09:00:00.000Z"}
+    ],
+    "task_positions": [
+      {"id": 20, "list_id": 20, "values": [102, 100]}
+    ],
+    "subtask_positions": [
+      {"id": 100, "values": [201, 200]}
+    ]
+  }
+}`
+
+var _ = Describe("ReadWunderlistBackup", func() {
+	It("reads lists, tasks, subtasks, notes and reminders in order", func() {
+		data, err := importer.Read(strings.NewReader(backup), "backup", importer.FormatWunderlistBackup)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(data.Lists).To(HaveLen(2))
+
+		Expect(data.Lists[0].Title).To(Equal("inbox"))
+		Expect(data.Lists[0].Inbox).To(BeTrue())
+		Expect(data.Lists[0].Tasks).To(HaveLen(1))
+
+		work := data.Lists[1]
+		Expect(work.Title).To(Equal("Work"))
+		Expect(work.Inbox).To(BeFalse())
+		Expect(work.Tasks).To(HaveLen(3))
+		Expect(work.Tasks[0].Title).To(Equal("Third"))
+		Expect(work.Tasks[1].Title).To(Equal("First"))
+		Expect(work.Tasks[2].Title).To(Equal("Second"))
+
+		first := work.Tasks[1]
+		Expect(first.Starred).To(BeTrue())
+		Expect(first.DueDate).To(Equal(wl.NewDate(2016, time.January, 6)))
+		Expect(first.Recurrence).To(Equal(wl.Recurrence{Type: wl.RecurrenceWeek, Count: 1}))
+		Expect(first.Note).To(Equal("Some note"))
+		Expect(first.Subtasks).To(Equal([]importer.Subtask{
+			{Title: "Sub B", Completed: true},
+			{Title: "Sub A"},
+		}))
+		Expect(first.Reminders).To(HaveLen(1))
+		Expect(first.Reminders[0].Equal(time.Date(2016, time.January, 6, 9, 0, 0, 0, time.UTC))).To(BeTrue())
+
+		Expect(work.Tasks[2].Completed).To(BeTrue())
+		Expect(work.Tasks[2].Note).To(BeEmpty())
+	})
+
+	It("drops invalid recurrences", func() {
+		data, err := importer.ReadWunderlistBackup(strings.NewReader(backup))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(data.Lists[1].Tasks[0].Recurrence.IsZero()).To(BeTrue())
+	})
+
+	It("returns an error for invalid JSON", func() {
+		_, err := importer.ReadWunderlistBackup(strings.NewReader("{"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns an error for an unrecognized format", func() {
+		_, err := importer.Read(strings.NewReader(backup), "backup", "other")
+		Expect(err).To(MatchError("unrecognized format: other"))
+	})
+})
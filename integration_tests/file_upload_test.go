@@ -6,7 +6,6 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
-	"time"
 
 	"github.com/nu7hatch/gouuid"
 	. "github.com/onsi/ginkgo"
@@ -57,7 +56,7 @@ var _ = Describe("basic upload and file functionality", func() {
 				false,
 				"",
 				0,
-				time.Date(1971, 12, 31, 0, 0, 0, 0, time.UTC),
+				wl.NewDate(1971, 12, 31),
 				false,
 			)
 			return err
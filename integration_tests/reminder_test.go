@@ -40,7 +40,7 @@ var _ = Describe("basic reminder functionality", func() {
 				false,
 				"",
 				0,
-				time.Date(1971, 12, 31, 0, 0, 0, 0, time.UTC),
+				wl.NewDate(1971, 12, 31),
 				false,
 			)
 			return err
@@ -82,7 +82,7 @@ var _ = Describe("basic reminder functionality", func() {
 	It("can perform reminder CRUD", func() {
 		By("Creating reminder")
 		var reminder wl.Reminder
-		reminderDate := "1970-08-30T08:29:46.203Z"
+		reminderDate := time.Date(1970, time.August, 30, 8, 29, 46, 203000000, time.UTC)
 		createdByDeviceUdid := ""
 		Eventually(func() error {
 			reminder, err = client.CreateReminder(
@@ -114,7 +114,7 @@ var _ = Describe("basic reminder functionality", func() {
 		}).Should(BeTrue())
 
 		By("Updating reminder")
-		reminder.Date = "1971-08-30T08:29:46.203Z"
+		reminder.Date = time.Date(1971, time.August, 30, 8, 29, 46, 203000000, time.UTC)
 		var r wl.Reminder
 		Eventually(func() error {
 			r, err = client.UpdateReminder(reminder)
@@ -2,7 +2,6 @@ package wl_integration_test
 
 import (
 	"errors"
-	"time"
 
 	"github.com/nu7hatch/gouuid"
 	. "github.com/onsi/ginkgo"
@@ -40,7 +39,7 @@ var _ = Describe("basic subtask position functionality", func() {
 				false,
 				"",
 				0,
-				time.Date(1971, 12, 31, 0, 0, 0, 0, time.UTC),
+				wl.NewDate(1971, 12, 31),
 				false,
 			)
 			return err
@@ -1,8 +1,6 @@
 package wl_integration_test
 
 import (
-	"time"
-
 	"github.com/nu7hatch/gouuid"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -39,7 +37,7 @@ var _ = Describe("basic task comment functionality", func() {
 				false,
 				"",
 				0,
-				time.Date(1971, 12, 31, 0, 0, 0, 0, time.UTC),
+				wl.NewDate(1971, 12, 31),
 				false,
 			)
 			return err
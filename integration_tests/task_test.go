@@ -1,8 +1,6 @@
 package wl_integration_test
 
 import (
-	"time"
-
 	"github.com/nu7hatch/gouuid"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -40,7 +38,7 @@ var _ = Describe("basic task functionality", func() {
 				false,
 				"",
 				0,
-				time.Date(1971, 12, 31, 0, 0, 0, 0, time.UTC),
+				wl.NewDate(1971, 12, 31),
 				false,
 			)
 			return err
@@ -226,7 +224,7 @@ var _ = Describe("basic task functionality", func() {
 		By("Ensuring properties are set")
 		Expect(taskAgain.Starred).Should(BeTrue())
 		Expect(taskAgain.Completed).Should(BeTrue())
-		Expect(taskAgain.RecurrenceType).Should(Equal("week"))
+		Expect(taskAgain.RecurrenceType).Should(Equal(wl.RecurrenceWeek))
 		Expect(taskAgain.RecurrenceCount).Should(Equal(uint(2)))
 
 		By("Resetting properties")
@@ -245,13 +243,13 @@ var _ = Describe("basic task functionality", func() {
 		By("Verifying properties are reset")
 		Expect(taskAgain.Starred).Should(BeFalse())
 		Expect(taskAgain.Completed).Should(BeFalse())
-		Expect(taskAgain.RecurrenceType).Should(Equal(""))
+		Expect(taskAgain.RecurrenceType).Should(Equal(wl.RecurrenceNone))
 		Expect(taskAgain.RecurrenceCount).Should(Equal(uint(0)))
 	})
 
 	It("can update the due date", func() {
 		By("Setting properties")
-		firstDate := time.Date(1968, 1, 2, 0, 0, 0, 0, time.UTC)
+		firstDate := wl.NewDate(1968, 1, 2)
 		newTask.DueDate = firstDate
 
 		By("Updating task")
@@ -266,7 +264,7 @@ var _ = Describe("basic task functionality", func() {
 		Expect(newTask.DueDate).Should(Equal(firstDate))
 
 		By("Updating properties")
-		newDate := time.Date(1972, 2, 3, 0, 0, 0, 0, time.UTC)
+		newDate := wl.NewDate(1972, 2, 3)
 		newTask.DueDate = newDate
 
 		By("Updating task")
@@ -280,7 +278,7 @@ var _ = Describe("basic task functionality", func() {
 		Expect(newTask.DueDate).Should(Equal(newDate))
 
 		By("Removing due date")
-		newTask.DueDate = time.Time{}
+		newTask.DueDate = wl.Date{}
 
 		By("Updating task")
 		Eventually(func() error {
@@ -290,7 +288,7 @@ var _ = Describe("basic task functionality", func() {
 		newTask = t
 
 		By("Verifying due date is removed")
-		Expect(newTask.DueDate).Should(Equal(time.Time{}))
+		Expect(newTask.DueDate).Should(Equal(wl.Date{}))
 	})
 
 	It("can perform subtask CRUD", func() {
@@ -3,6 +3,8 @@ package oauth
 import (
 	"fmt"
 	"net/http"
+
+	"github.com/robdimsdale/wl"
 )
 
 // AvatarURL returns the URL of the user associated with userID.
@@ -52,7 +54,7 @@ func (c oauthClient) AvatarURL(userID uint, size int, fallback bool) (string, er
 
 	if fallback {
 		if resp.StatusCode != http.StatusFound {
-			return "", fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusFound)
+			return "", wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusFound}
 		}
 	} else {
 		if resp.StatusCode == http.StatusNoContent {
@@ -83,7 +83,7 @@ func (c oauthClient) FilesForListID(listID uint) ([]wl.File, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	files := []wl.File{}
@@ -116,7 +116,7 @@ func (c oauthClient) FilesForTaskID(taskID uint) ([]wl.File, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	files := []wl.File{}
@@ -150,7 +150,7 @@ func (c oauthClient) File(fileID uint) (wl.File, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.File{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.File{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	task := wl.File{}
@@ -189,7 +189,7 @@ func (c oauthClient) CreateFile(uploadID uint, taskID uint) (wl.File, error) {
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return wl.File{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusCreated)
+		return wl.File{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusCreated}
 	}
 
 	file := wl.File{}
@@ -220,7 +220,7 @@ func (c oauthClient) DestroyFile(file wl.File) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusNoContent)
+		return wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusNoContent}
 	}
 
 	return nil
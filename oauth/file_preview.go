@@ -54,7 +54,7 @@ func (c oauthClient) FilePreview(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.FilePreview{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.FilePreview{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	task := wl.FilePreview{}
@@ -27,7 +27,7 @@ func (c oauthClient) Folders() ([]wl.Folder, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	folders := []wl.Folder{}
@@ -80,7 +80,7 @@ func (c oauthClient) CreateFolder(
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return wl.Folder{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusCreated)
+		return wl.Folder{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusCreated}
 	}
 
 	folder := wl.Folder{}
@@ -114,7 +114,7 @@ func (c oauthClient) Folder(folderID uint) (wl.Folder, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Folder{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Folder{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	folder := wl.Folder{}
@@ -149,7 +149,7 @@ func (c oauthClient) UpdateFolder(folder wl.Folder) (wl.Folder, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Folder{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Folder{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	returnedFolder := wl.Folder{}
@@ -180,7 +180,7 @@ func (c oauthClient) DeleteFolder(folder wl.Folder) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusNoContent)
+		return wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusNoContent}
 	}
 
 	return nil
@@ -204,7 +204,7 @@ func (c oauthClient) FolderRevisions() ([]wl.FolderRevision, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	folders := []wl.FolderRevision{}
@@ -27,7 +27,7 @@ func (c oauthClient) Lists() ([]wl.List, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	lists := []wl.List{}
@@ -57,7 +57,7 @@ func (c oauthClient) List(listID uint) (wl.List, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.List{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.List{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	list := wl.List{}
@@ -88,7 +88,7 @@ func (c oauthClient) CreateList(title string) (wl.List, error) {
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return wl.List{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusCreated)
+		return wl.List{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusCreated}
 	}
 
 	list := wl.List{}
@@ -123,7 +123,7 @@ func (c oauthClient) UpdateList(list wl.List) (wl.List, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.List{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.List{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	returnedList := wl.List{}
@@ -154,7 +154,7 @@ func (c oauthClient) DeleteList(list wl.List) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusNoContent)
+		return wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusNoContent}
 	}
 
 	return nil
@@ -216,3 +216,34 @@ func (c oauthClient) Inbox() (wl.List, error) {
 
 	return wl.List{}, errors.New("Inbox not found")
 }
+
+// ListTaskCount returns the number of completed and uncompleted tasks
+// in the list for the corresponding listID.
+func (c oauthClient) ListTaskCount(listID uint) (wl.ListTaskCount, error) {
+	url := fmt.Sprintf(
+		"%s/lists/tasks_count?list_id=%d",
+		c.apiURL,
+		listID,
+	)
+
+	req, err := c.newGetRequest(url)
+	if err != nil {
+		return wl.ListTaskCount{}, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return wl.ListTaskCount{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return wl.ListTaskCount{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
+	}
+
+	count := wl.ListTaskCount{}
+	err = json.NewDecoder(resp.Body).Decode(&count)
+	if err != nil {
+		return wl.ListTaskCount{}, err
+	}
+	return count, nil
+}
@@ -27,7 +27,7 @@ func (c oauthClient) ListPositions() ([]wl.Position, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	listPositions := []wl.Position{}
@@ -57,7 +57,7 @@ func (c oauthClient) ListPosition(listPositionID uint) (wl.Position, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Position{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Position{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	listPosition := wl.Position{}
@@ -93,7 +93,7 @@ func (c oauthClient) UpdateListPosition(listPosition wl.Position) (wl.Position,
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Position{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Position{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	returnedListPosition := wl.Position{}
@@ -717,4 +717,122 @@ var _ = Describe("client - List operations", func() {
 			})
 		})
 	})
+
+	Describe("getting list task count", func() {
+		var listID uint
+
+		BeforeEach(func() {
+			listID = 1234
+		})
+
+		It("performs GET requests with correct headers to /lists/tasks_count", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/lists/tasks_count", "list_id=1234"),
+					ghttp.VerifyHeader(http.Header{
+						"X-Access-Token": []string{dummyAccessToken},
+						"X-Client-ID":    []string{dummyClientID},
+					}),
+				),
+			)
+
+			client.ListTaskCount(listID)
+
+			Expect(server.ReceivedRequests()).Should(HaveLen(1))
+		})
+
+		Context("when the request is valid", func() {
+			It("returns successfully", func() {
+				expectedCount := wl.ListTaskCount{
+					ID:               listID,
+					CompletedCount:   3,
+					UncompletedCount: 5,
+				}
+
+				// Marshal and unmarshal to ensure exact object is returned
+				// - this avoids odd behavior with the time fields
+				expectedBody, err := json.Marshal(expectedCount)
+				Expect(err).NotTo(HaveOccurred())
+				err = json.Unmarshal(expectedBody, &expectedCount)
+				Expect(err).NotTo(HaveOccurred())
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.RespondWith(http.StatusOK, expectedBody),
+					),
+				)
+
+				count, err := client.ListTaskCount(listID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(count).To(Equal(expectedCount))
+			})
+		})
+
+		Context("when creating request fails with error", func() {
+			BeforeEach(func() {
+				client = oauth.NewClient("", "", "", testLogger)
+			})
+
+			It("forwards the error", func() {
+				_, err := client.ListTaskCount(listID)
+
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when executing request fails with error", func() {
+			BeforeEach(func() {
+				client = oauth.NewClient("", "", "http://not-a-real-url.com", testLogger)
+			})
+
+			It("forwards the error", func() {
+				_, err := client.ListTaskCount(listID)
+
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when response status code is unexpected", func() {
+			It("returns an error", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+
+				_, err := client.ListTaskCount(listID)
+
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when response body is nil", func() {
+			It("returns an error", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+				)
+
+				_, err := client.ListTaskCount(listID)
+
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when unmarshalling json response returns an error", func() {
+			It("returns an error", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.RespondWith(http.StatusOK, "invalid json response"),
+					),
+				)
+
+				_, err := client.ListTaskCount(listID)
+
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
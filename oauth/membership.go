@@ -27,7 +27,7 @@ func (c oauthClient) Memberships() ([]wl.Membership, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	memberships := []wl.Membership{}
@@ -62,7 +62,7 @@ func (c oauthClient) Membership(membershipID uint) (wl.Membership, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Membership{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Membership{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	membership := wl.Membership{}
@@ -98,7 +98,7 @@ func (c oauthClient) MembershipsForListID(listID uint) ([]wl.Membership, error)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	memberships := []wl.Membership{}
@@ -142,7 +142,7 @@ func (c oauthClient) AddMemberToListViaUserID(userID uint, listID uint, muted bo
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return wl.Membership{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusCreated)
+		return wl.Membership{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusCreated}
 	}
 
 	membership := wl.Membership{}
@@ -186,7 +186,7 @@ func (c oauthClient) AddMemberToListViaEmailAddress(emailAddress string, listID
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return wl.Membership{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusCreated)
+		return wl.Membership{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusCreated}
 	}
 
 	membership := wl.Membership{}
@@ -224,7 +224,7 @@ func (c oauthClient) AcceptMember(membership wl.Membership) (wl.Membership, erro
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Membership{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Membership{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	returnedMembership := wl.Membership{}
@@ -256,7 +256,7 @@ func (c oauthClient) RejectInvite(membership wl.Membership) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusNoContent)
+		return wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusNoContent}
 	}
 
 	return nil
@@ -282,7 +282,7 @@ func (c oauthClient) RemoveMemberFromList(membership wl.Membership) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusNoContent)
+		return wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusNoContent}
 	}
 
 	return nil
@@ -84,7 +84,7 @@ func (c oauthClient) NotesForListID(listID uint) ([]wl.Note, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	notes := []wl.Note{}
@@ -118,7 +118,7 @@ func (c oauthClient) NotesForTaskID(taskID uint) ([]wl.Note, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	notes := []wl.Note{}
@@ -152,7 +152,7 @@ func (c oauthClient) Note(noteID uint) (wl.Note, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Note{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Note{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	note := wl.Note{}
@@ -185,7 +185,7 @@ func (c oauthClient) CreateNote(content string, taskID uint) (wl.Note, error) {
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return wl.Note{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusCreated)
+		return wl.Note{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusCreated}
 	}
 
 	note := wl.Note{}
@@ -221,7 +221,7 @@ func (c oauthClient) UpdateNote(note wl.Note) (wl.Note, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Note{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Note{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	returnedNote := wl.Note{}
@@ -252,7 +252,7 @@ func (c oauthClient) DeleteNote(note wl.Note) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusNoContent)
+		return wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusNoContent}
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package oauth
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
@@ -36,7 +37,11 @@ func NewClient(
 	}
 }
 
-func (c oauthClient) validateRecurrence(recurrenceType string, recurrenceCount uint) error {
+func (c oauthClient) validateRecurrence(recurrenceType wl.RecurrenceType, recurrenceCount uint) error {
+	if !recurrenceType.Valid() {
+		return fmt.Errorf("recurrenceType must be one of %v - got %q", wl.RecurrenceTypes(), recurrenceType)
+	}
+
 	if recurrenceType == "" && recurrenceCount > 0 {
 		return errors.New("recurrenceCount must be zero if provided recurrenceType is not provided")
 	}
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/robdimsdale/wl"
 )
 
+// reminderDateLayout is the format of reminder dates in the API.
+const reminderDateLayout = "2006-01-02T15:04:05.000Z07:00"
+
 // Reminders gets all reminders for all lists.
 func (c oauthClient) Reminders() ([]wl.Reminder, error) {
 	lists, err := c.Lists()
@@ -85,7 +89,7 @@ func (c oauthClient) RemindersForListID(listID uint) ([]wl.Reminder, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	reminders := []wl.Reminder{}
@@ -120,7 +124,7 @@ func (c oauthClient) RemindersForTaskID(taskID uint) ([]wl.Reminder, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	reminders := []wl.Reminder{}
@@ -150,7 +154,7 @@ func (c oauthClient) Reminder(reminderID uint) (wl.Reminder, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Reminder{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Reminder{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	reminder := wl.Reminder{}
@@ -163,7 +167,7 @@ func (c oauthClient) Reminder(reminderID uint) (wl.Reminder, error) {
 
 // CreateReminder creates a Reminder with the provided parameters.
 func (c oauthClient) CreateReminder(
-	date string,
+	date time.Time,
 	taskID uint,
 	createdByDeviceUdid string,
 ) (wl.Reminder, error) {
@@ -172,11 +176,14 @@ func (c oauthClient) CreateReminder(
 		return wl.Reminder{}, errors.New("taskID must be > 0")
 	}
 
+	// Reminder dates are always sent in UTC.
+	utcDate := date.UTC().Format(reminderDateLayout)
+
 	var body []byte
 	if createdByDeviceUdid == "" {
-		body = []byte(fmt.Sprintf(`{"date":"%s","task_id":%d}`, date, taskID))
+		body = []byte(fmt.Sprintf(`{"date":"%s","task_id":%d}`, utcDate, taskID))
 	} else {
-		body = []byte(fmt.Sprintf(`{"date":"%s","task_id":%d,"created_by_device_udid":"%s"}`, date, taskID, createdByDeviceUdid))
+		body = []byte(fmt.Sprintf(`{"date":"%s","task_id":%d,"created_by_device_udid":"%s"}`, utcDate, taskID, createdByDeviceUdid))
 	}
 
 	url := fmt.Sprintf("%s/reminders", c.apiURL)
@@ -192,7 +199,7 @@ func (c oauthClient) CreateReminder(
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return wl.Reminder{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusCreated)
+		return wl.Reminder{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusCreated}
 	}
 
 	reminder := wl.Reminder{}
@@ -205,6 +212,9 @@ func (c oauthClient) CreateReminder(
 
 // UpdateReminder updates the provided Reminder.
 func (c oauthClient) UpdateReminder(reminder wl.Reminder) (wl.Reminder, error) {
+	// Reminder dates are always sent in UTC.
+	reminder.Date = reminder.Date.UTC()
+
 	body, err := json.Marshal(reminder)
 	if err != nil {
 		return wl.Reminder{}, err
@@ -227,7 +237,7 @@ func (c oauthClient) UpdateReminder(reminder wl.Reminder) (wl.Reminder, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Reminder{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Reminder{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	returnedReminder := wl.Reminder{}
@@ -258,7 +268,7 @@ func (c oauthClient) DeleteReminder(reminder wl.Reminder) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusNoContent)
+		return wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusNoContent}
 	}
 
 	return nil
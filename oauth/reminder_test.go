@@ -3,6 +3,7 @@ package oauth_test
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -380,13 +381,14 @@ var _ = Describe("client - Reminder operations", func() {
 
 	Describe("creating a new Reminder", func() {
 		var (
-			date                string
+			date                time.Time
 			taskID              uint
 			createdByDeviceUdid string
 		)
 
 		BeforeEach(func() {
-			date = "2013-08-30T08:29:46.203Z"
+			// Reminder dates are converted to UTC
+			date = time.Date(2013, time.August, 30, 1, 29, 46, 203000000, time.FixedZone("test", -7*60*60))
 			taskID = 1234
 			createdByDeviceUdid = "some device"
 		})
@@ -525,6 +527,21 @@ var _ = Describe("client - Reminder operations", func() {
 			Expect(server.ReceivedRequests()).Should(HaveLen(1))
 		})
 
+		It("sends the date in UTC", func() {
+			reminder.Date = time.Date(2013, time.August, 30, 1, 29, 46, 0, time.FixedZone("test", -7*60*60))
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/reminders/1234"),
+					ghttp.VerifyJSON(`{"id":1234,"date":"2013-08-30T08:29:46Z","task_id":0,"revision":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}`),
+				),
+			)
+
+			client.UpdateReminder(reminder)
+
+			Expect(server.ReceivedRequests()).Should(HaveLen(1))
+		})
+
 		Context("when the request is valid", func() {
 			It("returns successfully", func() {
 				expectedReminder := wl.Reminder{ID: 2345}
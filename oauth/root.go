@@ -26,7 +26,7 @@ func (c oauthClient) Root() (wl.Root, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Root{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Root{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	root := wl.Root{}
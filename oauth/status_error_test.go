@@ -0,0 +1,49 @@
+package oauth_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/oauth"
+)
+
+var _ = Describe("client - status errors", func() {
+	It("returns a StatusError carrying the unexpected status code", func() {
+		server.AppendHandlers(
+			ghttp.RespondWith(http.StatusNotFound, nil),
+		)
+
+		_, err := client.Task(1234)
+
+		Expect(err).To(Equal(wl.StatusError{StatusCode: http.StatusNotFound, Expected: http.StatusOK}))
+		Expect(err).To(MatchError("Unexpected response code 404 - expected 200"))
+		Expect(wl.IsNotFound(err)).To(BeTrue())
+		Expect(wl.IsConflict(err)).To(BeFalse())
+	})
+
+	It("detects revision conflicts", func() {
+		server.AppendHandlers(
+			ghttp.RespondWith(http.StatusConflict, nil),
+		)
+
+		_, err := client.UpdateTask(wl.Task{ID: 1234, Title: "some task"})
+
+		Expect(wl.IsConflict(err)).To(BeTrue())
+		Expect(wl.IsNotFound(err)).To(BeFalse())
+	})
+
+	It("does not treat transport errors as status errors, even if their text contains a status code", func() {
+		// Nothing listens on port 1, so the request fails to connect.
+		client = oauth.NewClient("", "", "http://127.0.0.1:1", testLogger)
+
+		_, err := client.Task(14049)
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("14049"))
+		Expect(wl.IsNotFound(err)).To(BeFalse())
+		Expect(wl.IsConflict(err)).To(BeFalse())
+	})
+})
@@ -136,7 +136,7 @@ func (c oauthClient) SubtasksForListID(listID uint) ([]wl.Subtask, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	subtasks := []wl.Subtask{}
@@ -170,7 +170,7 @@ func (c oauthClient) SubtasksForTaskID(taskID uint) ([]wl.Subtask, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	subtasks := []wl.Subtask{}
@@ -206,7 +206,7 @@ func (c oauthClient) CompletedSubtasksForListID(listID uint, completed bool) ([]
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	subtasks := []wl.Subtask{}
@@ -242,7 +242,7 @@ func (c oauthClient) CompletedSubtasksForTaskID(taskID uint, completed bool) ([]
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	subtasks := []wl.Subtask{}
@@ -276,7 +276,7 @@ func (c oauthClient) Subtask(subtaskID uint) (wl.Subtask, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Subtask{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Subtask{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	subtask := wl.Subtask{}
@@ -318,7 +318,7 @@ func (c oauthClient) CreateSubtask(
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return wl.Subtask{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusCreated)
+		return wl.Subtask{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusCreated}
 	}
 
 	subtask := wl.Subtask{}
@@ -353,7 +353,7 @@ func (c oauthClient) UpdateSubtask(subtask wl.Subtask) (wl.Subtask, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Subtask{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Subtask{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	returnedSubtask := wl.Subtask{}
@@ -384,7 +384,7 @@ func (c oauthClient) DeleteSubtask(subtask wl.Subtask) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusNoContent)
+		return wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusNoContent}
 	}
 
 	return nil
@@ -86,7 +86,7 @@ func (c oauthClient) SubtaskPositionsForListID(listID uint) ([]wl.Position, erro
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	subtaskPositions := []wl.Position{}
@@ -122,7 +122,7 @@ func (c oauthClient) SubtaskPositionsForTaskID(taskID uint) ([]wl.Position, erro
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	subtaskPositions := []wl.Position{}
@@ -156,7 +156,7 @@ func (c oauthClient) SubtaskPosition(subTaskPositionID uint) (wl.Position, error
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Position{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Position{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	subtaskPosition := wl.Position{}
@@ -192,7 +192,7 @@ func (c oauthClient) UpdateSubtaskPosition(subTaskPosition wl.Position) (wl.Posi
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Position{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Position{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	returnedSubtaskPosition := wl.Position{}
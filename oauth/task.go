@@ -5,8 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/robdimsdale/wl"
@@ -138,7 +136,7 @@ func (c oauthClient) TasksForListID(listID uint) ([]wl.Task, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	tasks := []transportTask{}
@@ -173,7 +171,7 @@ func (c oauthClient) CompletedTasksForListID(listID uint, completed bool) ([]wl.
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	tasks := []transportTask{}
@@ -203,7 +201,7 @@ func (c oauthClient) Task(taskID uint) (wl.Task, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Task{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Task{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	task := transportTask{}
@@ -215,28 +213,28 @@ func (c oauthClient) Task(taskID uint) (wl.Task, error) {
 }
 
 type taskCreateConfig struct {
-	ListID          uint   `json:"list_id"`
-	Title           string `json:"title"`
-	AssigneeID      uint   `json:"assignee_id,omitempty"`
-	Completed       bool   `json:"completed,omitempty"`
-	RecurrenceType  string `json:"recurrence_type,omitempty"`
-	RecurrenceCount uint   `json:"recurrence_count,omitempty"`
-	DueDate         string `json:"due_date,omitempty"`
-	Starred         bool   `json:"starred,omitempty"`
+	ListID          uint              `json:"list_id"`
+	Title           string            `json:"title"`
+	AssigneeID      uint              `json:"assignee_id,omitempty"`
+	Completed       bool              `json:"completed,omitempty"`
+	RecurrenceType  wl.RecurrenceType `json:"recurrence_type,omitempty"`
+	RecurrenceCount uint              `json:"recurrence_count,omitempty"`
+	DueDate         string            `json:"due_date,omitempty"`
+	Starred         bool              `json:"starred,omitempty"`
 }
 
 // TaskUpdateConfig contains information required to update an existing task.
 type TaskUpdateConfig struct {
-	Title           string   `json:"title,omitempty"`
-	Revision        uint     `json:"revision"`
-	AssigneeID      uint     `json:"assignee_id,omitempty"`
-	ListID          uint     `json:"list_id,omitempty"`
-	Completed       bool     `json:"completed"`
-	RecurrenceType  string   `json:"recurrence_type,omitempty"`
-	RecurrenceCount uint     `json:"recurrence_count,omitempty"`
-	DueDate         string   `json:"due_date,omitempty"`
-	Starred         bool     `json:"starred"`
-	Remove          []string `json:"remove,omitempty"`
+	Title           string            `json:"title,omitempty"`
+	Revision        uint              `json:"revision"`
+	AssigneeID      uint              `json:"assignee_id,omitempty"`
+	ListID          uint              `json:"list_id,omitempty"`
+	Completed       bool              `json:"completed"`
+	RecurrenceType  wl.RecurrenceType `json:"recurrence_type,omitempty"`
+	RecurrenceCount uint              `json:"recurrence_count,omitempty"`
+	DueDate         string            `json:"due_date,omitempty"`
+	Starred         bool              `json:"starred"`
+	Remove          []string          `json:"remove,omitempty"`
 }
 
 // CreateTask creates a task with the provided parameters.
@@ -245,9 +243,9 @@ func (c oauthClient) CreateTask(
 	listID uint,
 	assigneeID uint,
 	completed bool,
-	recurrenceType string,
+	recurrenceType wl.RecurrenceType,
 	recurrenceCount uint,
-	dueDate time.Time,
+	dueDate wl.Date,
 	starred bool,
 ) (wl.Task, error) {
 
@@ -267,7 +265,7 @@ func (c oauthClient) CreateTask(
 		Completed:       completed,
 		RecurrenceType:  recurrenceType,
 		RecurrenceCount: recurrenceCount,
-		DueDate:         dueDateToString(dueDate),
+		DueDate:         dueDate.String(),
 		Starred:         starred,
 	}
 
@@ -289,7 +287,7 @@ func (c oauthClient) CreateTask(
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return wl.Task{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusCreated)
+		return wl.Task{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusCreated}
 	}
 
 	task := transportTask{}
@@ -333,12 +331,12 @@ func (c oauthClient) UpdateTask(task wl.Task) (wl.Task, error) {
 	}
 
 	if origTask.DueDate == task.DueDate {
-		tuc.DueDate = dueDateToString(origTask.DueDate)
+		tuc.DueDate = origTask.DueDate.String()
 	} else {
 		if task.DueDate.IsZero() {
 			tuc.Remove = append(tuc.Remove, "due_date")
 		} else {
-			tuc.DueDate = dueDateToString(task.DueDate)
+			tuc.DueDate = task.DueDate.String()
 		}
 	}
 
@@ -378,7 +376,7 @@ func (c oauthClient) UpdateTask(task wl.Task) (wl.Task, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Task{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Task{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	transport := transportTask{}
@@ -409,7 +407,7 @@ func (c oauthClient) DeleteTask(task wl.Task) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusNoContent)
+		return wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusNoContent}
 	}
 
 	return nil
@@ -461,21 +459,21 @@ func (c oauthClient) DeleteAllTasks() error {
 }
 
 type transportTask struct {
-	ID              uint      `json:"id" yaml:"id"`
-	AssigneeID      uint      `json:"assignee_id" yaml:"assignee_id"`
-	AssignerID      uint      `json:"assigner_id" yaml:"assigner_id"`
-	CreatedAt       time.Time `json:"created_at" yaml:"created_at"`
-	CreatedByID     uint      `json:"created_by_id" yaml:"created_by_id"`
-	DueDate         string    `json:"due_date" yaml:"due_date"`
-	ListID          uint      `json:"list_id" yaml:"list_id"`
-	Revision        uint      `json:"revision" yaml:"revision"`
-	Starred         bool      `json:"starred" yaml:"starred"`
-	Title           string    `json:"title" yaml:"title"`
-	Completed       bool      `json:"completed" yaml:"completed"`
-	CompletedAt     time.Time `json:"completed_at" yaml:"completed_at"`
-	CompletedByID   uint      `json:"completed_by" yaml:"completed_by"`
-	RecurrenceType  string    `json:"recurrence_type" yaml:"recurrence_type"`
-	RecurrenceCount uint      `json:"recurrence_count" yaml:"recurrence_count"`
+	ID              uint              `json:"id" yaml:"id"`
+	AssigneeID      uint              `json:"assignee_id" yaml:"assignee_id"`
+	AssignerID      uint              `json:"assigner_id" yaml:"assigner_id"`
+	CreatedAt       time.Time         `json:"created_at" yaml:"created_at"`
+	CreatedByID     uint              `json:"created_by_id" yaml:"created_by_id"`
+	DueDate         string            `json:"due_date" yaml:"due_date"`
+	ListID          uint              `json:"list_id" yaml:"list_id"`
+	Revision        uint              `json:"revision" yaml:"revision"`
+	Starred         bool              `json:"starred" yaml:"starred"`
+	Title           string            `json:"title" yaml:"title"`
+	Completed       bool              `json:"completed" yaml:"completed"`
+	CompletedAt     time.Time         `json:"completed_at" yaml:"completed_at"`
+	CompletedByID   uint              `json:"completed_by" yaml:"completed_by"`
+	RecurrenceType  wl.RecurrenceType `json:"recurrence_type" yaml:"recurrence_type"`
+	RecurrenceCount uint              `json:"recurrence_count" yaml:"recurrence_count"`
 }
 
 func tasksFromTransport(transportTasks []transportTask) ([]wl.Task, error) {
@@ -493,7 +491,7 @@ func tasksFromTransport(transportTasks []transportTask) ([]wl.Task, error) {
 }
 
 func taskFromTransport(t transportTask) (wl.Task, error) {
-	dueDate, err := parseDueDate(t.DueDate, t.CreatedAt.Location())
+	dueDate, err := wl.ParseDate(t.DueDate)
 	if err != nil {
 		return wl.Task{}, err
 	}
@@ -516,44 +514,3 @@ func taskFromTransport(t transportTask) (wl.Task, error) {
 		RecurrenceCount: t.RecurrenceCount,
 	}, nil
 }
-
-func parseDueDate(dueDate string, location *time.Location) (time.Time, error) {
-	if dueDate == "" {
-		return time.Time{}, nil
-	}
-
-	splitDate := strings.Split(dueDate, "-")
-	if len(splitDate) < 3 {
-		return time.Now(), fmt.Errorf("Failed to parse dueDate into expected YYYY-MM-DD format: %s", dueDate)
-	}
-
-	year, err := strconv.Atoi(splitDate[0])
-	if err != nil {
-		return time.Now(), err
-	}
-
-	monthInt, err := strconv.Atoi(splitDate[1])
-	if err != nil {
-		return time.Now(), err
-	}
-	month := time.Month(monthInt)
-
-	day, err := strconv.Atoi(splitDate[2])
-	if err != nil {
-		return time.Now(), err
-	}
-
-	hour := 0
-	minute := 0
-	second := 0
-	nano := 0
-
-	return time.Date(year, month, day, hour, minute, second, nano, location), nil
-}
-
-func dueDateToString(dueDate time.Time) string {
-	if (dueDate == time.Time{}) {
-		return ""
-	}
-	return fmt.Sprintf("%04d-%02d-%02d", dueDate.Year(), dueDate.Month(), dueDate.Day())
-}
@@ -84,7 +84,7 @@ func (c oauthClient) TaskCommentsForListID(listID uint) ([]wl.TaskComment, error
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	taskComments := []wl.TaskComment{}
@@ -118,7 +118,7 @@ func (c oauthClient) TaskCommentsForTaskID(taskID uint) ([]wl.TaskComment, error
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	taskComments := []wl.TaskComment{}
@@ -151,7 +151,7 @@ func (c oauthClient) CreateTaskComment(text string, taskID uint) (wl.TaskComment
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return wl.TaskComment{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusCreated)
+		return wl.TaskComment{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusCreated}
 	}
 
 	taskComment := wl.TaskComment{}
@@ -185,7 +185,7 @@ func (c oauthClient) TaskComment(taskCommentID uint) (wl.TaskComment, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.TaskComment{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.TaskComment{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	taskComment := wl.TaskComment{}
@@ -216,7 +216,7 @@ func (c oauthClient) DeleteTaskComment(taskComment wl.TaskComment) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusNoContent)
+		return wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusNoContent}
 	}
 
 	return nil
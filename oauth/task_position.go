@@ -86,7 +86,7 @@ func (c oauthClient) TaskPositionsForListID(listID uint) ([]wl.Position, error)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	taskPositions := []wl.Position{}
@@ -120,7 +120,7 @@ func (c oauthClient) TaskPosition(taskPositionID uint) (wl.Position, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Position{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Position{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	taskPosition := wl.Position{}
@@ -156,7 +156,7 @@ func (c oauthClient) UpdateTaskPosition(taskPosition wl.Position) (wl.Position,
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Position{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Position{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	returnedTaskPosition := wl.Position{}
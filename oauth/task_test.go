@@ -405,9 +405,9 @@ var _ = Describe("client - Task operations", func() {
 			listID          uint
 			assigneeID      uint
 			completed       bool
-			recurrenceType  string
+			recurrenceType  wl.RecurrenceType
 			recurrenceCount uint
-			dueDate         time.Time
+			dueDate         wl.Date
 			starred         bool
 		)
 
@@ -419,7 +419,7 @@ var _ = Describe("client - Task operations", func() {
 			completed = true
 			recurrenceType = "day"
 			recurrenceCount = uint(3)
-			dueDate = time.Date(1968, 1, 2, 0, 0, 0, 0, time.UTC)
+			dueDate = wl.NewDate(1968, 1, 2)
 			starred = true
 		})
 
@@ -528,6 +528,29 @@ var _ = Describe("client - Task operations", func() {
 			})
 		})
 
+		Context("when recurrenceType is not valid", func() {
+			BeforeEach(func() {
+				recurrenceType = "fortnight"
+			})
+
+			It("returns an error without making a request", func() {
+				_, err := client.CreateTask(
+					title,
+					listID,
+					assigneeID,
+					completed,
+					recurrenceType,
+					recurrenceCount,
+					dueDate,
+					starred,
+				)
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("recurrenceType"))
+				Expect(server.ReceivedRequests()).To(HaveLen(0))
+			})
+		})
+
 		Context("when creating request fails with error", func() {
 			BeforeEach(func() {
 				client = oauth.NewClient("", "", "", testLogger)
@@ -652,9 +675,9 @@ var _ = Describe("client - Task operations", func() {
 			revision        uint
 			assigneeID      uint
 			completed       bool
-			recurrenceType  string
+			recurrenceType  wl.RecurrenceType
 			recurrenceCount uint
-			dueDate         time.Time
+			dueDate         wl.Date
 			starred         bool
 
 			expectedTaskUpdateConfig oauth.TaskUpdateConfig
@@ -668,7 +691,7 @@ var _ = Describe("client - Task operations", func() {
 			completed = false
 			recurrenceType = "day"
 			recurrenceCount = uint(3)
-			dueDate = time.Date(1968, 1, 2, 0, 0, 0, 0, time.UTC)
+			dueDate = wl.NewDate(1968, 1, 2)
 			starred = false
 
 			task = wl.Task{
@@ -738,6 +761,16 @@ var _ = Describe("client - Task operations", func() {
 			})
 		})
 
+		Context("when recurrenceType is not valid", func() {
+			It("returns an error", func() {
+				task.RecurrenceType = "fortnight"
+				_, err := client.UpdateTask(task)
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("recurrenceType"))
+			})
+		})
+
 		Context("when recurrenceCount is zero", func() {
 			BeforeEach(func() {
 				task.RecurrenceCount = 0
@@ -870,7 +903,7 @@ var _ = Describe("client - Task operations", func() {
 
 				Context("and new task has empty due date", func() {
 					BeforeEach(func() {
-						task.DueDate = time.Time{}
+						task.DueDate = wl.Date{}
 
 						expectedTaskUpdateConfig.DueDate = ""
 						expectedTaskUpdateConfig.Remove = []string{"due_date"}
@@ -882,7 +915,7 @@ var _ = Describe("client - Task operations", func() {
 						actualTask, err := client.UpdateTask(task)
 						Expect(err).NotTo(HaveOccurred())
 
-						Expect(actualTask.DueDate).To(Equal(time.Time{}))
+						Expect(actualTask.DueDate).To(Equal(wl.Date{}))
 					})
 				})
 
@@ -897,7 +930,7 @@ var _ = Describe("client - Task operations", func() {
 
 				Context("and new task has different due date", func() {
 					BeforeEach(func() {
-						task.DueDate = time.Date(1921, 12, 24, 0, 0, 0, 0, time.UTC)
+						task.DueDate = wl.NewDate(1921, 12, 24)
 
 						expectedTaskUpdateConfig.DueDate = "1921-12-24"
 
@@ -920,7 +953,7 @@ var _ = Describe("client - Task operations", func() {
 
 				Context("and new task has empty due date", func() {
 					BeforeEach(func() {
-						task.DueDate = time.Time{}
+						task.DueDate = wl.Date{}
 
 						expectedTaskUpdateConfig.DueDate = ""
 
@@ -931,13 +964,13 @@ var _ = Describe("client - Task operations", func() {
 						actualTask, err := client.UpdateTask(task)
 						Expect(err).NotTo(HaveOccurred())
 
-						Expect(actualTask.DueDate).To(Equal(time.Time{}))
+						Expect(actualTask.DueDate).To(Equal(wl.Date{}))
 					})
 				})
 
 				Context("and new task has different due date", func() {
 					BeforeEach(func() {
-						task.DueDate = time.Date(1921, 12, 24, 0, 0, 0, 0, time.UTC)
+						task.DueDate = wl.NewDate(1921, 12, 24)
 
 						expectedTaskUpdateConfig.DueDate = "1921-12-24"
 
@@ -978,7 +1011,7 @@ var _ = Describe("client - Task operations", func() {
 						actualTask, err := client.UpdateTask(task)
 						Expect(err).NotTo(HaveOccurred())
 
-						Expect(actualTask.RecurrenceType).To(Equal(""))
+						Expect(actualTask.RecurrenceType).To(Equal(wl.RecurrenceNone))
 						Expect(actualTask.RecurrenceCount).To(Equal(uint(0)))
 					})
 				})
@@ -999,7 +1032,7 @@ var _ = Describe("client - Task operations", func() {
 						actualTask, err := client.UpdateTask(task)
 						Expect(err).NotTo(HaveOccurred())
 
-						Expect(actualTask.RecurrenceType).To(Equal("week"))
+						Expect(actualTask.RecurrenceType).To(Equal(wl.RecurrenceWeek))
 					})
 
 					It("does not change recurrence count", func() {
@@ -1416,21 +1449,21 @@ var _ = Describe("client - Task operations", func() {
 })
 
 type transportTask struct {
-	ID              uint      `json:"id" yaml:"id"`
-	AssigneeID      uint      `json:"assignee_id" yaml:"assignee_id"`
-	AssignerID      uint      `json:"assigner_id" yaml:"assigner_id"`
-	CreatedAt       time.Time `json:"created_at" yaml:"created_at"`
-	CreatedByID     uint      `json:"created_by_id" yaml:"created_by_id"`
-	DueDate         string    `json:"due_date" yaml:"due_date"`
-	ListID          uint      `json:"list_id" yaml:"list_id"`
-	Revision        uint      `json:"revision" yaml:"revision"`
-	Starred         bool      `json:"starred" yaml:"starred"`
-	Title           string    `json:"title" yaml:"title"`
-	Completed       bool      `json:"completed" yaml:"completed"`
-	CompletedAt     time.Time `json:"completed_at" yaml:"completed_at"`
-	CompletedByID   uint      `json:"completed_by" yaml:"completed_by"`
-	RecurrenceType  string    `json:"recurrence_type" yaml:"recurrence_type"`
-	RecurrenceCount uint      `json:"recurrence_count" yaml:"recurrence_count"`
+	ID              uint              `json:"id" yaml:"id"`
+	AssigneeID      uint              `json:"assignee_id" yaml:"assignee_id"`
+	AssignerID      uint              `json:"assigner_id" yaml:"assigner_id"`
+	CreatedAt       time.Time         `json:"created_at" yaml:"created_at"`
+	CreatedByID     uint              `json:"created_by_id" yaml:"created_by_id"`
+	DueDate         string            `json:"due_date" yaml:"due_date"`
+	ListID          uint              `json:"list_id" yaml:"list_id"`
+	Revision        uint              `json:"revision" yaml:"revision"`
+	Starred         bool              `json:"starred" yaml:"starred"`
+	Title           string            `json:"title" yaml:"title"`
+	Completed       bool              `json:"completed" yaml:"completed"`
+	CompletedAt     time.Time         `json:"completed_at" yaml:"completed_at"`
+	CompletedByID   uint              `json:"completed_by" yaml:"completed_by"`
+	RecurrenceType  wl.RecurrenceType `json:"recurrence_type" yaml:"recurrence_type"`
+	RecurrenceCount uint              `json:"recurrence_count" yaml:"recurrence_count"`
 }
 
 func transportsFromTasks(tasks []wl.Task) []transportTask {
@@ -1450,7 +1483,7 @@ func transportFromTask(t wl.Task) transportTask {
 		AssignerID:      t.AssignerID,
 		CreatedAt:       t.CreatedAt,
 		CreatedByID:     t.CreatedByID,
-		DueDate:         dueDateToString(t.DueDate),
+		DueDate:         t.DueDate.String(),
 		ListID:          t.ListID,
 		Revision:        t.Revision,
 		Starred:         t.Starred,
@@ -1462,7 +1495,3 @@ func transportFromTask(t wl.Task) transportTask {
 		RecurrenceCount: t.RecurrenceCount,
 	}
 }
-
-func dueDateToString(dueDate time.Time) string {
-	return fmt.Sprintf("%04d-%02d-%02d", dueDate.Year(), dueDate.Month(), dueDate.Day())
-}
@@ -76,7 +76,7 @@ func (c oauthClient) createUpload(
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return uploadResponse{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusCreated)
+		return uploadResponse{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusCreated}
 	}
 
 	uploadResp := uploadResponse{}
@@ -132,7 +132,7 @@ func (c oauthClient) uploadAPart(part uploadPart, fileContents []byte) error {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	return nil
@@ -154,7 +154,7 @@ func (c oauthClient) finishUpload(uploadID uint) (wl.Upload, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.Upload{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.Upload{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	returnedUpload := wl.Upload{}
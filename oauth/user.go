@@ -29,7 +29,7 @@ func (c oauthClient) User() (wl.User, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.User{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.User{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	if resp.Body == nil {
@@ -62,7 +62,7 @@ func (c oauthClient) UpdateUser(user wl.User) (wl.User, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return wl.User{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return wl.User{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	returnedUser := wl.User{}
@@ -100,7 +100,7 @@ func (c oauthClient) UsersForListID(listID uint) ([]wl.User, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	if resp.Body == nil {
@@ -84,7 +84,7 @@ func (c oauthClient) WebhooksForListID(listID uint) ([]wl.Webhook, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusOK)
+		return nil, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusOK}
 	}
 
 	webhooks := []wl.Webhook{}
@@ -144,7 +144,7 @@ func (c oauthClient) CreateWebhook(
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return wl.Webhook{}, fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusCreated)
+		return wl.Webhook{}, wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusCreated}
 	}
 
 	webhook := wl.Webhook{}
@@ -174,7 +174,7 @@ func (c oauthClient) DeleteWebhook(webhook wl.Webhook) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("Unexpected response code %d - expected %d", resp.StatusCode, http.StatusNoContent)
+		return wl.StatusError{StatusCode: resp.StatusCode, Expected: http.StatusNoContent}
 	}
 
 	return nil
@@ -0,0 +1,35 @@
+package position
+
+import "github.com/robdimsdale/wl"
+
+// TasksForListID returns the tasks for the provided listID,
+// ordered by their positions.
+func TasksForListID(client wl.Client, listID uint) ([]wl.Task, error) {
+	tasks, err := client.TasksForListID(listID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := client.TaskPositionsForListID(listID)
+	if err != nil {
+		return nil, err
+	}
+
+	return OrderTasks(tasks, positions), nil
+}
+
+// SubtasksForTaskID returns the subtasks for the provided taskID,
+// ordered by their positions.
+func SubtasksForTaskID(client wl.Client, taskID uint) ([]wl.Subtask, error) {
+	subtasks, err := client.SubtasksForTaskID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := client.SubtaskPositionsForTaskID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return OrderSubtasks(subtasks, positions), nil
+}
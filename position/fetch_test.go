@@ -0,0 +1,53 @@
+package position_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/position"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+var _ = Describe("Fetching", func() {
+	var client *wltest.Client
+
+	BeforeEach(func() {
+		client = wltest.NewClient()
+		client.AddList(wl.List{ID: 1234})
+		client.AddTask(wl.Task{ID: 1, ListID: 1234})
+		client.AddTask(wl.Task{ID: 2, ListID: 1234})
+		client.SetTaskPosition(1234, 2, 1)
+		client.AddSubtask(wl.Subtask{ID: 3, TaskID: 1})
+		client.AddSubtask(wl.Subtask{ID: 4, TaskID: 1})
+		client.SetSubtaskPosition(1, 4, 3)
+	})
+
+	It("returns ordered tasks for a list", func() {
+		tasks, err := position.TasksForListID(client, 1234)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(taskIDs(tasks)).To(Equal([]uint{2, 1}))
+	})
+
+	It("returns ordered subtasks for a task", func() {
+		subtasks, err := position.SubtasksForTaskID(client, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subtaskIDs(subtasks)).To(Equal([]uint{4, 3}))
+	})
+
+	Context("when getting positions fails", func() {
+		BeforeEach(func() {
+			client.Fail("TaskPositionsForListID", errors.New("some error"))
+			client.Fail("SubtaskPositionsForTaskID", errors.New("some error"))
+		})
+
+		It("forwards the error", func() {
+			_, err := position.TasksForListID(client, 1234)
+			Expect(err).To(HaveOccurred())
+
+			_, err = position.SubtasksForTaskID(client, 1)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
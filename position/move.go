@@ -0,0 +1,442 @@
+package position
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/retry"
+)
+
+type placementKind int
+
+const (
+	top placementKind = iota
+	bottom
+	before
+	after
+)
+
+// Placement describes where an item is moved to within its position.
+type Placement struct {
+	kind    placementKind
+	otherID uint
+}
+
+// Top places an item first.
+func Top() Placement {
+	return Placement{kind: top}
+}
+
+// Bottom places an item last.
+func Bottom() Placement {
+	return Placement{kind: bottom}
+}
+
+// Before places an item immediately before the item with otherID.
+func Before(otherID uint) Placement {
+	return Placement{kind: before, otherID: otherID}
+}
+
+// After places an item immediately after the item with otherID.
+func After(otherID uint) Placement {
+	return Placement{kind: after, otherID: otherID}
+}
+
+// Apply returns a copy of values with id moved to the placement.
+// If id is not present in values it is inserted. If the placement is
+// relative to an ID which is not present in values, that ID is first
+// appended, as unpositioned items are displayed after positioned ones.
+func (p Placement) Apply(values []uint, id uint) ([]uint, error) {
+	if (p.kind == before || p.kind == after) && p.otherID == id {
+		return nil, fmt.Errorf("cannot move %d relative to itself", id)
+	}
+
+	moved := make([]uint, 0, len(values)+2)
+	otherFound := false
+	for _, v := range values {
+		if v == id {
+			continue
+		}
+		if v == p.otherID {
+			otherFound = true
+		}
+		moved = append(moved, v)
+	}
+
+	switch p.kind {
+	case top:
+		return append([]uint{id}, moved...), nil
+	case bottom:
+		return append(moved, id), nil
+	}
+
+	if !otherFound {
+		moved = append(moved, p.otherID)
+	}
+
+	for i, v := range moved {
+		if v != p.otherID {
+			continue
+		}
+		if p.kind == after {
+			i++
+		}
+		return append(moved[:i], append([]uint{id}, moved[i:]...)...), nil
+	}
+
+	// Unreachable, as otherID is always present.
+	return nil, fmt.Errorf("failed to find %d", p.otherID)
+}
+
+func (p Placement) relative() bool {
+	return p.kind == before || p.kind == after
+}
+
+// Mover moves lists, tasks and subtasks by fetching, editing and updating
+// their positions. If an update fails because the position was modified
+// concurrently, the position is fetched again and the edit is reapplied.
+type Mover struct {
+	client      wl.Client
+	maxAttempts int
+}
+
+// NewMover returns a Mover which attempts each update retry.DefaultMaxAttempts times.
+func NewMover(client wl.Client) *Mover {
+	return &Mover{
+		client:      client,
+		maxAttempts: retry.DefaultMaxAttempts,
+	}
+}
+
+// MoveTask moves the task to the placement within its list,
+// and returns the updated task position.
+func (m Mover) MoveTask(taskID uint, p Placement) (wl.Position, error) {
+	task, err := m.client.Task(taskID)
+	if err != nil {
+		return wl.Position{}, err
+	}
+
+	if p.relative() {
+		other, err := m.client.Task(p.otherID)
+		if err != nil {
+			return wl.Position{}, err
+		}
+		if other.ListID != task.ListID {
+			return wl.Position{}, fmt.Errorf(
+				"task %d is in list %d, not list %d",
+				other.ID,
+				other.ListID,
+				task.ListID,
+			)
+		}
+	}
+
+	return m.updateTaskPosition(task.ListID, func(values []uint) ([]uint, error) {
+		return p.Apply(values, taskID)
+	})
+}
+
+// MoveTaskBefore moves the task immediately before the other task.
+func (m Mover) MoveTaskBefore(taskID uint, otherID uint) (wl.Position, error) {
+	return m.MoveTask(taskID, Before(otherID))
+}
+
+// MoveTaskAfter moves the task immediately after the other task.
+func (m Mover) MoveTaskAfter(taskID uint, otherID uint) (wl.Position, error) {
+	return m.MoveTask(taskID, After(otherID))
+}
+
+// MoveTaskToTop moves the task to the top of its list.
+func (m Mover) MoveTaskToTop(taskID uint) (wl.Position, error) {
+	return m.MoveTask(taskID, Top())
+}
+
+// MoveTaskToBottom moves the task to the bottom of its list.
+func (m Mover) MoveTaskToBottom(taskID uint) (wl.Position, error) {
+	return m.MoveTask(taskID, Bottom())
+}
+
+// MoveTaskToList moves the task to another list, removing it from the
+// positions of its original list and placing it in the positions of the
+// new list. It returns the updated task position of the new list.
+// The placement is checked against the new list before the task is moved.
+func (m Mover) MoveTaskToList(taskID uint, listID uint, p Placement) (wl.Position, error) {
+	if listID == 0 {
+		return wl.Position{}, errors.New("listID must be > 0")
+	}
+
+	task, err := m.client.Task(taskID)
+	if err != nil {
+		return wl.Position{}, err
+	}
+
+	if task.ListID == listID {
+		return m.MoveTask(taskID, p)
+	}
+
+	err = m.checkPlacement(listID, taskID, p)
+	if err != nil {
+		return wl.Position{}, err
+	}
+
+	oldListID := task.ListID
+	err = retry.OnConflict(m.maxAttempts, func() error {
+		task, err := m.client.Task(taskID)
+		if err != nil {
+			return err
+		}
+		task.ListID = listID
+		_, err = m.client.UpdateTask(task)
+		return err
+	})
+	if err != nil {
+		return wl.Position{}, err
+	}
+
+	_, err = m.updateTaskPosition(oldListID, func(values []uint) ([]uint, error) {
+		return remove(values, taskID), nil
+	})
+	if err != nil {
+		return wl.Position{}, err
+	}
+
+	return m.MoveTask(taskID, p)
+}
+
+// checkPlacement returns an error if the task cannot be placed in the list,
+// because the placement is relative to a task in another list.
+func (m Mover) checkPlacement(listID uint, taskID uint, p Placement) error {
+	current, err := first(m.client.TaskPositionsForListID(listID))
+	if err != nil {
+		return err
+	}
+
+	_, err = p.Apply(current.Values, taskID)
+	if err != nil {
+		return err
+	}
+
+	if !p.relative() {
+		return nil
+	}
+
+	other, err := m.client.Task(p.otherID)
+	if err != nil {
+		return err
+	}
+	if other.ListID != listID {
+		return fmt.Errorf(
+			"task %d is in list %d, not list %d",
+			other.ID,
+			other.ListID,
+			listID,
+		)
+	}
+	return nil
+}
+
+// MoveList moves the list to the placement, and returns the updated
+// list position.
+func (m Mover) MoveList(listID uint, p Placement) (wl.Position, error) {
+	return m.update(
+		func() (wl.Position, error) {
+			return first(m.client.ListPositions())
+		},
+		m.client.UpdateListPosition,
+		func(values []uint) ([]uint, error) {
+			return p.Apply(values, listID)
+		},
+	)
+}
+
+// MoveListBefore moves the list immediately before the other list.
+func (m Mover) MoveListBefore(listID uint, otherID uint) (wl.Position, error) {
+	return m.MoveList(listID, Before(otherID))
+}
+
+// MoveListAfter moves the list immediately after the other list.
+func (m Mover) MoveListAfter(listID uint, otherID uint) (wl.Position, error) {
+	return m.MoveList(listID, After(otherID))
+}
+
+// MoveListToTop moves the list to the top.
+func (m Mover) MoveListToTop(listID uint) (wl.Position, error) {
+	return m.MoveList(listID, Top())
+}
+
+// MoveListToBottom moves the list to the bottom.
+func (m Mover) MoveListToBottom(listID uint) (wl.Position, error) {
+	return m.MoveList(listID, Bottom())
+}
+
+// MoveSubtask moves the subtask to the placement within its task,
+// and returns the updated subtask position.
+func (m Mover) MoveSubtask(subtaskID uint, p Placement) (wl.Position, error) {
+	subtask, err := m.client.Subtask(subtaskID)
+	if err != nil {
+		return wl.Position{}, err
+	}
+
+	if p.relative() {
+		other, err := m.client.Subtask(p.otherID)
+		if err != nil {
+			return wl.Position{}, err
+		}
+		if other.TaskID != subtask.TaskID {
+			return wl.Position{}, fmt.Errorf(
+				"subtask %d belongs to task %d, not task %d",
+				other.ID,
+				other.TaskID,
+				subtask.TaskID,
+			)
+		}
+	}
+
+	return m.update(
+		func() (wl.Position, error) {
+			return first(m.client.SubtaskPositionsForTaskID(subtask.TaskID))
+		},
+		m.client.UpdateSubtaskPosition,
+		func(values []uint) ([]uint, error) {
+			return p.Apply(values, subtaskID)
+		},
+	)
+}
+
+// MoveListsToTop moves the lists, in order, to the top, followed by the
+// lists already positioned, and returns the updated list position.
+func (m Mover) MoveListsToTop(listIDs []uint) (wl.Position, error) {
+	return m.update(
+		func() (wl.Position, error) {
+			return first(m.client.ListPositions())
+		},
+		m.client.UpdateListPosition,
+		func(values []uint) ([]uint, error) {
+			return prepend(listIDs, values), nil
+		},
+	)
+}
+
+// MoveTasksToTop moves the tasks, in order, to the top of the list, followed
+// by the tasks already positioned, and returns the updated task position.
+// The tasks are not fetched, so they may have just been created in the list.
+func (m Mover) MoveTasksToTop(listID uint, taskIDs []uint) (wl.Position, error) {
+	return m.updateTaskPosition(listID, func(values []uint) ([]uint, error) {
+		return prepend(taskIDs, values), nil
+	})
+}
+
+// MoveSubtasksToTop moves the subtasks, in order, to the top of the task,
+// followed by the subtasks already positioned, and returns the updated
+// subtask position.
+func (m Mover) MoveSubtasksToTop(taskID uint, subtaskIDs []uint) (wl.Position, error) {
+	return m.update(
+		func() (wl.Position, error) {
+			return first(m.client.SubtaskPositionsForTaskID(taskID))
+		},
+		m.client.UpdateSubtaskPosition,
+		func(values []uint) ([]uint, error) {
+			return prepend(subtaskIDs, values), nil
+		},
+	)
+}
+
+func (m Mover) updateTaskPosition(
+	listID uint,
+	edit func(values []uint) ([]uint, error),
+) (wl.Position, error) {
+	return m.update(
+		func() (wl.Position, error) {
+			return first(m.client.TaskPositionsForListID(listID))
+		},
+		m.client.UpdateTaskPosition,
+		edit,
+	)
+}
+
+// update fetches a position, edits its values and updates it.
+// If the update conflicts with a concurrent modification the process
+// is repeated, up to maxAttempts times.
+func (m Mover) update(
+	fetch func() (wl.Position, error),
+	update func(wl.Position) (wl.Position, error),
+	edit func(values []uint) ([]uint, error),
+) (wl.Position, error) {
+	var updated wl.Position
+	err := retry.OnConflict(m.maxAttempts, func() error {
+		p, err := fetch()
+		if err != nil {
+			return err
+		}
+
+		values, err := edit(p.Values)
+		if err != nil {
+			return err
+		}
+
+		if equal(values, p.Values) {
+			updated = p
+			return nil
+		}
+		p.Values = values
+
+		updated, err = update(p)
+		return err
+	})
+	if err != nil {
+		return wl.Position{}, err
+	}
+	return updated, nil
+}
+
+// first returns the first position, which is the one the apps use.
+func first(positions []wl.Position, err error) (wl.Position, error) {
+	if err != nil {
+		return wl.Position{}, err
+	}
+	if len(positions) == 0 {
+		return wl.Position{}, errors.New("no position found")
+	}
+	return positions[0], nil
+}
+
+// prepend returns ids followed by the values which are not in ids.
+func prepend(ids []uint, values []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	prepended := make([]uint, 0, len(ids)+len(values))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			prepended = append(prepended, id)
+		}
+	}
+	for _, v := range values {
+		if !seen[v] {
+			prepended = append(prepended, v)
+		}
+	}
+	return prepended
+}
+
+func remove(values []uint, id uint) []uint {
+	removed := make([]uint, 0, len(values))
+	for _, v := range values {
+		if v != id {
+			removed = append(removed, v)
+		}
+	}
+	return removed
+}
+
+func equal(a []uint, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,238 @@
+package position_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/position"
+	"github.com/robdimsdale/wl/retry"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+var _ = Describe("Moving", func() {
+	table.DescribeTable("applying placements",
+		func(placement position.Placement, id uint, expected []uint) {
+			values, err := placement.Apply([]uint{1, 2, 3, 4}, id)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expected))
+		},
+		table.Entry("top", position.Top(), uint(3), []uint{3, 1, 2, 4}),
+		table.Entry("bottom", position.Bottom(), uint(2), []uint{1, 3, 4, 2}),
+		table.Entry("before", position.Before(2), uint(4), []uint{1, 4, 2, 3}),
+		table.Entry("after", position.After(1), uint(4), []uint{1, 4, 2, 3}),
+		table.Entry("after the last", position.After(4), uint(1), []uint{2, 3, 4, 1}),
+		table.Entry("new ID", position.Before(1), uint(5), []uint{5, 1, 2, 3, 4}),
+		table.Entry("relative to an unpositioned ID", position.Before(6), uint(1), []uint{2, 3, 4, 1, 6}),
+	)
+
+	It("does not move an item relative to itself", func() {
+		_, err := position.Before(1).Apply([]uint{1, 2}, 1)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("Mover", func() {
+		var (
+			client *wltest.Client
+			mover  *position.Mover
+		)
+
+		updates := func() int {
+			return client.Calls("UpdateListPosition") +
+				client.Calls("UpdateTaskPosition") +
+				client.Calls("UpdateSubtaskPosition")
+		}
+
+		task := func(taskID uint) wl.Task {
+			t, err := client.Task(taskID)
+			Expect(err).NotTo(HaveOccurred())
+			return t
+		}
+
+		taskPosition := func(listID uint) []uint {
+			positions, err := client.TaskPositionsForListID(listID)
+			Expect(err).NotTo(HaveOccurred())
+			return positions[0].Values
+		}
+
+		BeforeEach(func() {
+			client = wltest.NewClient()
+			client.AddList(wl.List{ID: 10})
+			client.AddList(wl.List{ID: 20})
+			client.SetListPosition(10, 20)
+
+			client.AddTask(wl.Task{ID: 1, ListID: 10})
+			client.AddTask(wl.Task{ID: 2, ListID: 10})
+			client.AddTask(wl.Task{ID: 3, ListID: 10})
+			client.AddTask(wl.Task{ID: 4, ListID: 20})
+			client.SetTaskPosition(10, 1, 2, 3)
+			client.SetTaskPosition(20, 4)
+
+			client.AddSubtask(wl.Subtask{ID: 5, TaskID: 1})
+			client.AddSubtask(wl.Subtask{ID: 6, TaskID: 1})
+			client.AddSubtask(wl.Subtask{ID: 7, TaskID: 2})
+			client.SetSubtaskPosition(1, 5, 6)
+
+			mover = position.NewMover(client)
+		})
+
+		It("moves a task before another", func() {
+			p, err := mover.MoveTaskBefore(3, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Values).To(Equal([]uint{3, 1, 2}))
+			Expect(p.Revision).To(Equal(uint(2)))
+		})
+
+		It("moves a task after another", func() {
+			p, err := mover.MoveTaskAfter(1, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Values).To(Equal([]uint{2, 1, 3}))
+		})
+
+		It("moves a task to the top and bottom", func() {
+			p, err := mover.MoveTaskToTop(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Values).To(Equal([]uint{2, 1, 3}))
+
+			p, err = mover.MoveTaskToBottom(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Values).To(Equal([]uint{1, 3, 2}))
+		})
+
+		It("does not update the position if nothing changes", func() {
+			p, err := mover.MoveTaskToTop(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Revision).To(Equal(uint(1)))
+			Expect(updates()).To(Equal(0))
+		})
+
+		It("does not move a task relative to a task in another list", func() {
+			_, err := mover.MoveTaskBefore(1, 4)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("moves a task to another list, updating both lists' positions", func() {
+			p, err := mover.MoveTaskToList(2, 20, position.Top())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Values).To(Equal([]uint{2, 4}))
+
+			Expect(task(2).ListID).To(Equal(uint(20)))
+			Expect(taskPosition(10)).To(Equal([]uint{1, 3}))
+			Expect(taskPosition(20)).To(Equal([]uint{2, 4}))
+		})
+
+		It("retries moving a task to another list when the task is concurrently modified", func() {
+			client.Conflict("UpdateTask", 1)
+
+			p, err := mover.MoveTaskToList(2, 20, position.Bottom())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Values).To(Equal([]uint{4, 2}))
+			Expect(client.Calls("UpdateTask")).To(Equal(2))
+		})
+
+		It("does not move a task to another list relative to a task outside that list", func() {
+			_, err := mover.MoveTaskToList(2, 20, position.Before(3))
+			Expect(err).To(MatchError("task 3 is in list 10, not list 20"))
+
+			Expect(client.Calls("UpdateTask")).To(Equal(0))
+			Expect(task(2).ListID).To(Equal(uint(10)))
+			Expect(taskPosition(10)).To(Equal([]uint{1, 2, 3}))
+		})
+
+		It("moves a list", func() {
+			p, err := mover.MoveListAfter(10, 20)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Values).To(Equal([]uint{20, 10}))
+		})
+
+		It("moves a subtask", func() {
+			p, err := mover.MoveSubtask(6, position.Before(5))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Values).To(Equal([]uint{6, 5}))
+		})
+
+		It("does not move a subtask relative to a subtask of another task", func() {
+			_, err := mover.MoveSubtask(6, position.Before(7))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("moves several lists to the top", func() {
+			client.AddList(wl.List{ID: 30})
+			client.SetListPosition(10, 20, 30)
+
+			p, err := mover.MoveListsToTop([]uint{30, 20})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Values).To(Equal([]uint{30, 20, 10}))
+		})
+
+		It("moves several tasks to the top of a list, including unpositioned tasks", func() {
+			p, err := mover.MoveTasksToTop(10, []uint{8, 3})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Values).To(Equal([]uint{8, 3, 1, 2}))
+			Expect(taskPosition(10)).To(Equal([]uint{8, 3, 1, 2}))
+		})
+
+		It("moves several subtasks to the top of a task", func() {
+			p, err := mover.MoveSubtasksToTop(1, []uint{6, 9})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Values).To(Equal([]uint{6, 9, 5}))
+		})
+
+		Context("when the position is concurrently modified", func() {
+			BeforeEach(func() {
+				client.Conflict("UpdateListPosition", 2)
+				client.Conflict("UpdateTaskPosition", 2)
+			})
+
+			It("fetches the position again and retries", func() {
+				p, err := mover.MoveListToTop(20)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(p.Values).To(Equal([]uint{20, 10}))
+				Expect(updates()).To(Equal(3))
+			})
+
+			It("fetches the position again and retries moving several items", func() {
+				p, err := mover.MoveTasksToTop(20, []uint{5, 6})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(p.Values).To(Equal([]uint{5, 6, 4}))
+				Expect(updates()).To(Equal(3))
+			})
+		})
+
+		Context("when the position is always concurrently modified", func() {
+			BeforeEach(func() {
+				client.Conflict("UpdateListPosition", retry.DefaultMaxAttempts)
+			})
+
+			It("gives up", func() {
+				_, err := mover.MoveListToTop(20)
+				Expect(err).To(HaveOccurred())
+				Expect(updates()).To(Equal(retry.DefaultMaxAttempts))
+			})
+		})
+
+		Context("when an update fails with an error other than a conflict", func() {
+			var updateErr error
+
+			BeforeEach(func() {
+				updateErr = errors.New("Get https://a.wunderlist.com/api/v1/list_positions/14093: connection refused")
+				client.Fail("UpdateListPosition", updateErr)
+			})
+
+			It("does not retry", func() {
+				_, err := mover.MoveListToTop(20)
+				Expect(err).To(Equal(updateErr))
+				Expect(updates()).To(Equal(1))
+			})
+		})
+
+		Context("when the task does not exist", func() {
+			It("forwards the error", func() {
+				_, err := mover.MoveTaskToTop(1234)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,170 @@
+/*
+Package position orders lists, tasks and subtasks by their user-defined
+positions, and provides operations to move them.
+
+The API returns lists, tasks and subtasks in an arbitrary order; the order
+the user sees is stored separately, as the Values of a wl.Position.
+As in the Wunderlist apps, items without a position are placed after
+those with one.
+*/
+package position
+
+import (
+	"sort"
+
+	"github.com/robdimsdale/wl"
+)
+
+// Index returns the index of each ID across the values of all the positions.
+// If an ID appears more than once, its first index is used.
+func Index(positions []wl.Position) map[uint]int {
+	index := map[uint]int{}
+	i := 0
+	for _, p := range positions {
+		for _, id := range p.Values {
+			if _, ok := index[id]; !ok {
+				index[id] = i
+			}
+			i++
+		}
+	}
+	return index
+}
+
+// OrderLists returns the lists ordered by the list positions.
+// The inbox is always first. Lists without a position are placed last,
+// in their original order.
+func OrderLists(lists []wl.List, positions []wl.Position) []wl.List {
+	index := Index(positions)
+	for _, l := range lists {
+		if l.ListType == "inbox" {
+			index[l.ID] = -1
+		}
+	}
+
+	ordered := make([]wl.List, len(lists))
+	copy(ordered, lists)
+
+	sort.Stable(byIndex{
+		len:   len(ordered),
+		swap:  func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] },
+		group: func(i int) uint { return 0 },
+		id:    func(i int) uint { return ordered[i].ID },
+		index: index,
+	})
+
+	return ordered
+}
+
+// OrderTasks returns the tasks ordered by the task positions.
+// Tasks are grouped by list, with lists in order of their first task.
+// Within each list, tasks without a position are placed last,
+// in their original order.
+func OrderTasks(tasks []wl.Task, positions []wl.Position) []wl.Task {
+	ordered := make([]wl.Task, len(tasks))
+	copy(ordered, tasks)
+
+	groups := map[uint]int{}
+	for _, t := range tasks {
+		if _, ok := groups[t.ListID]; !ok {
+			groups[t.ListID] = len(groups)
+		}
+	}
+
+	sort.Stable(byIndex{
+		len:   len(ordered),
+		swap:  func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] },
+		group: func(i int) uint { return uint(groups[ordered[i].ListID]) },
+		id:    func(i int) uint { return ordered[i].ID },
+		index: Index(positions),
+	})
+
+	return ordered
+}
+
+// OrderTasksByList returns the tasks ordered first by the position of their
+// list, as per OrderLists, and then by their position within that list.
+func OrderTasksByList(
+	tasks []wl.Task,
+	lists []wl.List,
+	listPositions []wl.Position,
+	taskPositions []wl.Position,
+) []wl.Task {
+	listOrder := map[uint]int{}
+	for i, l := range OrderLists(lists, listPositions) {
+		listOrder[l.ID] = i
+	}
+
+	// Tasks in unknown lists are placed last, grouped by list ID.
+	ordered := make([]wl.Task, len(tasks))
+	copy(ordered, tasks)
+
+	sort.Stable(byIndex{
+		len:  len(ordered),
+		swap: func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] },
+		group: func(i int) uint {
+			if o, ok := listOrder[ordered[i].ListID]; ok {
+				return uint(o)
+			}
+			return uint(len(listOrder)) + ordered[i].ListID
+		},
+		id:    func(i int) uint { return ordered[i].ID },
+		index: Index(taskPositions),
+	})
+
+	return ordered
+}
+
+// OrderSubtasks returns the subtasks ordered by the subtask positions.
+// Subtasks are grouped by task, with tasks in order of their first subtask.
+// Within each task, subtasks without a position are placed last,
+// in their original order.
+func OrderSubtasks(subtasks []wl.Subtask, positions []wl.Position) []wl.Subtask {
+	ordered := make([]wl.Subtask, len(subtasks))
+	copy(ordered, subtasks)
+
+	groups := map[uint]int{}
+	for _, s := range subtasks {
+		if _, ok := groups[s.TaskID]; !ok {
+			groups[s.TaskID] = len(groups)
+		}
+	}
+
+	sort.Stable(byIndex{
+		len:   len(ordered),
+		swap:  func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] },
+		group: func(i int) uint { return uint(groups[ordered[i].TaskID]) },
+		id:    func(i int) uint { return ordered[i].ID },
+		index: Index(positions),
+	})
+
+	return ordered
+}
+
+// byIndex sorts items by group, and then by the index of their ID
+// with unindexed items last.
+type byIndex struct {
+	len   int
+	swap  func(i, j int)
+	group func(i int) uint
+	id    func(i int) uint
+	index map[uint]int
+}
+
+func (s byIndex) Len() int      { return s.len }
+func (s byIndex) Swap(i, j int) { s.swap(i, j) }
+func (s byIndex) Less(i, j int) bool {
+	if gi, gj := s.group(i), s.group(j); gi != gj {
+		return gi < gj
+	}
+
+	ii, iok := s.index[s.id(i)]
+	ij, jok := s.index[s.id(j)]
+
+	switch {
+	case iok && jok:
+		return ii < ij
+	default:
+		return iok && !jok
+	}
+}
@@ -0,0 +1,133 @@
+package position_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/position"
+)
+
+func taskIDs(tasks []wl.Task) []uint {
+	ids := []uint{}
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func subtaskIDs(subtasks []wl.Subtask) []uint {
+	ids := []uint{}
+	for _, s := range subtasks {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+var _ = Describe("Ordering", func() {
+	Describe("Index", func() {
+		It("indexes IDs across all positions, keeping the first index of duplicates", func() {
+			index := position.Index([]wl.Position{
+				{Values: []uint{3, 1}},
+				{Values: []uint{2, 3}},
+			})
+
+			Expect(index).To(Equal(map[uint]int{3: 0, 1: 1, 2: 2}))
+		})
+	})
+
+	Describe("OrderLists", func() {
+		It("orders lists by position, with the inbox first and unpositioned lists last", func() {
+			lists := []wl.List{
+				{ID: 1},
+				{ID: 2},
+				{ID: 3, ListType: "inbox"},
+				{ID: 4},
+				{ID: 5},
+			}
+			positions := []wl.Position{{Values: []uint{5, 2, 3}}}
+
+			ordered := position.OrderLists(lists, positions)
+
+			ids := []uint{}
+			for _, l := range ordered {
+				ids = append(ids, l.ID)
+			}
+			Expect(ids).To(Equal([]uint{3, 5, 2, 1, 4}))
+		})
+	})
+
+	Describe("OrderTasks", func() {
+		It("orders tasks by position, with unpositioned tasks last in their original order", func() {
+			tasks := []wl.Task{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+			positions := []wl.Position{{Values: []uint{3, 1}}}
+
+			ordered := position.OrderTasks(tasks, positions)
+			Expect(taskIDs(ordered)).To(Equal([]uint{3, 1, 2, 4}))
+		})
+
+		It("keeps tasks grouped by list", func() {
+			tasks := []wl.Task{
+				{ID: 1, ListID: 10},
+				{ID: 2, ListID: 20},
+				{ID: 3, ListID: 10},
+				{ID: 4, ListID: 20},
+			}
+			positions := []wl.Position{
+				{Values: []uint{4, 2}},
+				{Values: []uint{3, 1}},
+			}
+
+			ordered := position.OrderTasks(tasks, positions)
+			Expect(taskIDs(ordered)).To(Equal([]uint{3, 1, 4, 2}))
+		})
+
+		It("does not modify the provided tasks", func() {
+			tasks := []wl.Task{{ID: 1}, {ID: 2}}
+			position.OrderTasks(tasks, []wl.Position{{Values: []uint{2, 1}}})
+
+			Expect(taskIDs(tasks)).To(Equal([]uint{1, 2}))
+		})
+	})
+
+	Describe("OrderTasksByList", func() {
+		It("orders by list position, inbox first, then task position", func() {
+			lists := []wl.List{
+				{ID: 100},
+				{ID: 200},
+				{ID: 300, ListType: "inbox"},
+			}
+			listPositions := []wl.Position{{Values: []uint{200, 100}}}
+			taskPositions := []wl.Position{
+				{Values: []uint{2, 1}},
+				{Values: []uint{4}},
+			}
+
+			tasks := []wl.Task{
+				{ID: 1, ListID: 100},
+				{ID: 2, ListID: 100},
+				{ID: 3, ListID: 100},
+				{ID: 4, ListID: 200},
+				{ID: 5, ListID: 300},
+				{ID: 6, ListID: 400},
+			}
+
+			ordered := position.OrderTasksByList(tasks, lists, listPositions, taskPositions)
+			Expect(taskIDs(ordered)).To(Equal([]uint{5, 4, 2, 1, 3, 6}))
+		})
+	})
+
+	Describe("OrderSubtasks", func() {
+		It("orders subtasks by position within each task", func() {
+			subtasks := []wl.Subtask{
+				{ID: 1, TaskID: 10},
+				{ID: 2, TaskID: 10},
+				{ID: 3, TaskID: 20},
+				{ID: 4, TaskID: 10},
+			}
+			positions := []wl.Position{{Values: []uint{4, 2}}}
+
+			ordered := position.OrderSubtasks(subtasks, positions)
+			Expect(subtaskIDs(ordered)).To(Equal([]uint{4, 2, 1, 3}))
+		})
+	})
+})
@@ -0,0 +1,62 @@
+package quickadd
+
+import (
+	"fmt"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/resolve"
+)
+
+// Create creates the task and its reminder, if it has one.
+// The list and assignee are resolved before anything is created.
+// Tasks without a list are created in the inbox.
+func Create(client wl.Client, t Task) (wl.Task, error) {
+	resolver := resolve.NewResolver(client)
+
+	var listID uint
+	if t.List != "" {
+		id, err := resolver.ListID(t.List)
+		if err != nil {
+			return wl.Task{}, err
+		}
+		listID = id
+	} else {
+		inbox, err := client.Inbox()
+		if err != nil {
+			return wl.Task{}, err
+		}
+		listID = inbox.ID
+	}
+
+	var assigneeID uint
+	if t.Assignee != "" {
+		id, err := resolver.UserID(t.Assignee)
+		if err != nil {
+			return wl.Task{}, err
+		}
+		assigneeID = id
+	}
+
+	task, err := client.CreateTask(
+		t.Title,
+		listID,
+		assigneeID,
+		false,
+		wl.RecurrenceNone,
+		0,
+		t.DueDate,
+		t.Starred,
+	)
+	if err != nil {
+		return wl.Task{}, err
+	}
+
+	if !t.Reminder.IsZero() {
+		_, err = client.CreateReminder(t.Reminder, task.ID, "")
+		if err != nil {
+			return task, fmt.Errorf("created task %d but failed to create reminder: %v", task.ID, err)
+		}
+	}
+
+	return task, nil
+}
@@ -0,0 +1,106 @@
+package quickadd_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/quickadd"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+var _ = Describe("Create", func() {
+	var (
+		client   *wltest.Client
+		reminder time.Time
+	)
+
+	reminders := func() []wl.Reminder {
+		reminders, err := client.Reminders()
+		Expect(err).NotTo(HaveOccurred())
+		return reminders
+	}
+
+	tasks := func() []wl.Task {
+		tasks, err := client.Tasks()
+		Expect(err).NotTo(HaveOccurred())
+		return tasks
+	}
+
+	BeforeEach(func() {
+		client = wltest.NewClient()
+		client.AddList(wl.List{ID: 1, Title: "inbox"})
+		client.AddList(wl.List{ID: 2, Title: "Ops"})
+		client.AddUser(wl.User{ID: 3, Name: "Bob", Email: "bob@example.com"})
+		reminder = time.Date(2016, time.January, 7, 8, 0, 0, 0, time.UTC)
+	})
+
+	It("resolves the list and assignee and creates the task and reminder", func() {
+		task, err := quickadd.Create(client, quickadd.Task{
+			Title:    "Renew certs",
+			List:     "ops",
+			Assignee: "bob",
+			Starred:  true,
+			DueDate:  wl.NewDate(2016, time.January, 7),
+			Reminder: reminder,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(task).To(Equal(wl.Task{
+			ID:         task.ID,
+			Title:      "Renew certs",
+			ListID:     2,
+			AssigneeID: 3,
+			DueDate:    wl.NewDate(2016, time.January, 7),
+			Starred:    true,
+			Revision:   1,
+		}))
+		Expect(tasks()).To(Equal([]wl.Task{task}))
+
+		Expect(reminders()).To(HaveLen(1))
+		Expect(reminders()[0].Date).To(Equal(reminder))
+		Expect(reminders()[0].TaskID).To(Equal(task.ID))
+	})
+
+	It("creates tasks without a list in the inbox", func() {
+		task, err := quickadd.Create(client, quickadd.Task{Title: "Buy milk"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(task.ListID).To(Equal(uint(1)))
+		Expect(reminders()).To(BeEmpty())
+	})
+
+	It("creates nothing if the list cannot be resolved", func() {
+		_, err := quickadd.Create(client, quickadd.Task{Title: "Buy milk", List: "groceries"})
+		Expect(err).To(HaveOccurred())
+
+		Expect(tasks()).To(BeEmpty())
+	})
+
+	It("creates nothing if the assignee cannot be resolved", func() {
+		_, err := quickadd.Create(client, quickadd.Task{Title: "Buy milk", Assignee: "carol"})
+		Expect(err).To(HaveOccurred())
+
+		Expect(tasks()).To(BeEmpty())
+	})
+
+	It("returns an error if the task cannot be created", func() {
+		client.Fail("CreateTask", errors.New("create task error"))
+
+		_, err := quickadd.Create(client, quickadd.Task{Title: "Buy milk", Reminder: reminder})
+		Expect(err).To(MatchError("create task error"))
+
+		Expect(reminders()).To(BeEmpty())
+	})
+
+	It("returns the task with an error if the reminder cannot be created", func() {
+		client.Fail("CreateReminder", errors.New("create reminder error"))
+
+		task, err := quickadd.Create(client, quickadd.Task{Title: "Buy milk", Reminder: reminder})
+		Expect(err).To(MatchError(ContainSubstring("create reminder error")))
+
+		Expect(tasks()).To(Equal([]wl.Task{task}))
+	})
+})
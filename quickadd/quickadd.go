@@ -0,0 +1,270 @@
+// Package quickadd parses a single line of text into a task, e.g.
+//
+//	Renew certs tomorrow 9am *starred #ops @bob !remind 8am
+//
+// The line may contain:
+//
+//	*, *star or *starred    to star the task
+//	#list                   the title or ID of the list
+//	@assignee               the name, email or ID of the assignee
+//	!remind <date or time>  a reminder, e.g. !remind 8am or !remind fri 5pm
+//
+// and a due date at the end of the title, e.g. tomorrow, next friday or +3d.
+// "now" and weekday abbreviations such as sat are not due dates on their
+// own, as they are more often part of the title; next sat or saturday are.
+// If the due date includes a time, a reminder is set at that time unless
+// !remind is provided. A reminder which is only a time is on the due date.
+//
+// Double quotes keep words in the title, e.g. Watch "Friday" is not due
+// on Friday, and allow names with spaces, e.g. #"Work stuff".
+package quickadd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/dateparse"
+)
+
+const (
+	remindKeyword = "!remind"
+
+	// maxPhraseWords is the most words considered for a date, e.g. "next friday at 9:30 pm".
+	maxPhraseWords = 5
+)
+
+// ambiguousDates are words which are dates on their own, but are more
+// likely to be part of a title, e.g. "Call mom now" or "Fix sun roof".
+var ambiguousDates = map[string]bool{
+	"now":   true,
+	"sun":   true,
+	"mon":   true,
+	"tue":   true,
+	"tues":  true,
+	"wed":   true,
+	"thu":   true,
+	"thur":  true,
+	"thurs": true,
+	"fri":   true,
+	"sat":   true,
+}
+
+// Task is a task parsed from a line of text.
+// The list and assignee are not resolved.
+type Task struct {
+	Title    string    `json:"title" yaml:"title"`
+	List     string    `json:"list" yaml:"list"`
+	Assignee string    `json:"assignee" yaml:"assignee"`
+	Starred  bool      `json:"starred" yaml:"starred"`
+	DueDate  wl.Date   `json:"due_date" yaml:"due_date"`
+	Reminder time.Time `json:"reminder" yaml:"reminder"`
+}
+
+// token is a whitespace-separated part of the input.
+type token struct {
+	text string
+	pos  int
+
+	// quoted is true if the token contains a quoted section.
+	quoted bool
+
+	// literal is true if the token starts with a quote,
+	// so it cannot be a marker such as #list.
+	literal bool
+}
+
+// Parse parses input, interpreting dates relative to now and in now's location.
+func Parse(input string, now time.Time) (Task, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return Task{}, err
+	}
+
+	var t Task
+	var words []token
+	var reminderPhrase string
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+
+		if tok.literal {
+			words = append(words, tok)
+			continue
+		}
+
+		switch {
+		case tok.text == "*" || tok.text == "*star" || tok.text == "*starred":
+			t.Starred = true
+
+		case strings.HasPrefix(tok.text, "#") && len(tok.text) > 1:
+			if t.List != "" {
+				return Task{}, fmt.Errorf("only one #list may be provided")
+			}
+			t.List = tok.text[1:]
+
+		case strings.HasPrefix(tok.text, "@") && len(tok.text) > 1:
+			if t.Assignee != "" {
+				return Task{}, fmt.Errorf("only one @assignee may be provided")
+			}
+			t.Assignee = tok.text[1:]
+
+		case strings.ToLower(tok.text) == remindKeyword:
+			if reminderPhrase != "" {
+				return Task{}, fmt.Errorf("only one %s may be provided", remindKeyword)
+			}
+
+			n := reminderWords(tokens[i+1:], now)
+			if n == 0 {
+				return Task{}, fmt.Errorf("%s must be followed by a date or time", remindKeyword)
+			}
+			reminderPhrase = joinTokens(tokens[i+1 : i+1+n])
+			i += n
+
+		default:
+			words = append(words, tok)
+		}
+	}
+
+	start, n, due, dueHasTime := findDueDate(words, now)
+	if n > 0 {
+		t.DueDate = wl.DateOf(due)
+		words = append(words[:start:start], words[start+n:]...)
+	}
+
+	t.Title = joinTokens(words)
+	if t.Title == "" {
+		return Task{}, fmt.Errorf("title must not be empty")
+	}
+
+	switch {
+	case reminderPhrase != "":
+		t.Reminder, err = reminderTime(reminderPhrase, t.DueDate, now)
+		if err != nil {
+			return Task{}, err
+		}
+	case dueHasTime:
+		t.Reminder = due
+	}
+
+	return t, nil
+}
+
+// tokenize splits input on whitespace outside double quotes,
+// removing the quotes.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	var current token
+	var inToken, inQuotes bool
+
+	for _, r := range input {
+		switch {
+		case r == '"':
+			if !inToken {
+				current.literal = true
+			}
+			inToken = true
+			inQuotes = !inQuotes
+			current.quoted = true
+
+		case !inQuotes && (r == ' ' || r == '\t' || r == '\n' || r == '\r'):
+			if inToken {
+				current.pos = len(tokens)
+				tokens = append(tokens, current)
+				current = token{}
+				inToken = false
+			}
+
+		default:
+			inToken = true
+			current.text += string(r)
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in %q", input)
+	}
+	if inToken {
+		current.pos = len(tokens)
+		tokens = append(tokens, current)
+	}
+	return tokens, nil
+}
+
+// findDueDate finds the longest run of consecutive unquoted words
+// at the end of words which is a date, other than an ambiguous one.
+// n is zero if there is no date.
+func findDueDate(words []token, now time.Time) (start int, n int, due time.Time, hasTime bool) {
+	for l := maxPhraseWords; l > 0; l-- {
+		i := len(words) - l
+		if i < 0 || !consecutive(words, i, l) {
+			continue
+		}
+
+		phrase := joinTokens(words[i:])
+		if ambiguousDates[strings.ToLower(phrase)] {
+			continue
+		}
+
+		d, h, err := dateparse.Parse(phrase, now)
+		if err != nil {
+			continue
+		}
+		return i, l, d, h
+	}
+	return 0, 0, time.Time{}, false
+}
+
+// reminderWords returns the number of leading tokens which form
+// the longest date or time, or zero if they do not start with one.
+func reminderWords(tokens []token, now time.Time) int {
+	for l := maxPhraseWords; l > 0; l-- {
+		if !consecutive(tokens, 0, l) {
+			continue
+		}
+
+		_, _, err := dateparse.Parse(joinTokens(tokens[:l]), now)
+		if err == nil {
+			return l
+		}
+	}
+	return 0
+}
+
+// consecutive returns true if tokens[start:start+n] exist, are unquoted
+// and are adjacent in the input.
+func consecutive(tokens []token, start int, n int) bool {
+	if start+n > len(tokens) {
+		return false
+	}
+
+	for i := start; i < start+n; i++ {
+		if tokens[i].quoted {
+			return false
+		}
+		if i > start && tokens[i].pos != tokens[i-1].pos+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// reminderTime returns the time of the reminder.
+// A time on its own is on the due date, if there is one.
+// Dates without a time are at dateparse.DefaultReminderHour.
+func reminderTime(phrase string, dueDate wl.Date, now time.Time) (time.Time, error) {
+	if hour, minute, err := dateparse.ParseTimeOfDay(phrase); err == nil && !dueDate.IsZero() {
+		return time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), hour, minute, 0, 0, now.Location()), nil
+	}
+
+	return dateparse.ParseDateTime(phrase, now)
+}
+
+func joinTokens(tokens []token) string {
+	texts := make([]string, len(tokens))
+	for i, t := range tokens {
+		texts[i] = t.text
+	}
+	return strings.Join(texts, " ")
+}
@@ -0,0 +1,13 @@
+package quickadd_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestQuickadd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Quickadd Suite")
+}
@@ -0,0 +1,107 @@
+package quickadd_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/quickadd"
+)
+
+var _ = Describe("Parse", func() {
+	var (
+		// Wednesday afternoon, west of UTC
+		zone = time.FixedZone("test", -8*60*60)
+		now  = time.Date(2016, time.January, 6, 15, 4, 5, 0, zone)
+	)
+
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2016, time.January, day, hour, minute, 0, 0, zone)
+	}
+
+	It("parses every field", func() {
+		t, err := quickadd.Parse("Renew certs tomorrow 9am *starred #ops @bob !remind 8am", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t).To(Equal(quickadd.Task{
+			Title:    "Renew certs",
+			List:     "ops",
+			Assignee: "bob",
+			Starred:  true,
+			DueDate:  wl.NewDate(2016, time.January, 7),
+			Reminder: at(7, 8, 0),
+		}))
+	})
+
+	table.DescribeTable("valid input",
+		func(input string, expected quickadd.Task) {
+			t, err := quickadd.Parse(input, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t).To(Equal(expected))
+		},
+		table.Entry("title only", "Buy milk",
+			quickadd.Task{Title: "Buy milk"}),
+		table.Entry("extra whitespace", "  Buy   milk  ",
+			quickadd.Task{Title: "Buy milk"}),
+		table.Entry("star", "* Buy milk",
+			quickadd.Task{Title: "Buy milk", Starred: true}),
+		table.Entry("short star", "Buy milk *star",
+			quickadd.Task{Title: "Buy milk", Starred: true}),
+		table.Entry("due date before markers", "Pay rent next friday #home",
+			quickadd.Task{Title: "Pay rent", List: "home", DueDate: wl.NewDate(2016, time.January, 8)}),
+		table.Entry("due date in the middle is part of the title", "Email bob about the 5 jan meeting",
+			quickadd.Task{Title: "Email bob about the 5 jan meeting"}),
+		table.Entry("weekday abbreviation in the title", "Fix sun roof",
+			quickadd.Task{Title: "Fix sun roof"}),
+		table.Entry("weekday abbreviation at the end", "Water plants sat",
+			quickadd.Task{Title: "Water plants sat"}),
+		table.Entry("weekday abbreviation with a time", "Water plants sat 9am",
+			quickadd.Task{Title: "Water plants", DueDate: wl.NewDate(2016, time.January, 9), Reminder: at(9, 9, 0)}),
+		table.Entry("now is part of the title", "Call mom now",
+			quickadd.Task{Title: "Call mom now"}),
+		table.Entry("due date with time sets reminder", "Call bank fri 2:30pm",
+			quickadd.Task{Title: "Call bank", DueDate: wl.NewDate(2016, time.January, 8), Reminder: at(8, 14, 30)}),
+		table.Entry("offset due date", "Water plants +3d",
+			quickadd.Task{Title: "Water plants", DueDate: wl.NewDate(2016, time.January, 9)}),
+		table.Entry("last of equally long dates", "Move monday meeting to tuesday",
+			quickadd.Task{Title: "Move monday meeting to", DueDate: wl.NewDate(2016, time.January, 12)}),
+		table.Entry("markers split dates", "Plan in 2 #work weeks",
+			quickadd.Task{Title: "Plan in 2 weeks", List: "work"}),
+		table.Entry("quoted words are not dates", `Watch "Friday"`,
+			quickadd.Task{Title: "Watch Friday"}),
+		table.Entry("quoted markers are not markers", `"#1" priority`,
+			quickadd.Task{Title: "#1 priority"}),
+		table.Entry("quoted names", `Review #"Work stuff" @"Bob Smith"`,
+			quickadd.Task{Title: "Review", List: "Work stuff", Assignee: "Bob Smith"}),
+		table.Entry("list ID", "Review #123",
+			quickadd.Task{Title: "Review", List: "123"}),
+		table.Entry("reminder without due date", "Stretch !remind 5pm",
+			quickadd.Task{Title: "Stretch", Reminder: at(6, 17, 0)}),
+		table.Entry("reminder with date", "Submit report friday !remind thursday 4pm",
+			quickadd.Task{Title: "Submit report", DueDate: wl.NewDate(2016, time.January, 8), Reminder: at(7, 16, 0)}),
+		table.Entry("reminder date without time", "Submit report !remind tomorrow",
+			quickadd.Task{Title: "Submit report", Reminder: at(7, 9, 0)}),
+		table.Entry("reminder followed by due date", "Submit report !remind 8am tomorrow",
+			quickadd.Task{Title: "Submit report", DueDate: wl.NewDate(2016, time.January, 7), Reminder: at(7, 8, 0)}),
+		table.Entry("reminder overrides due time", "Call bank tomorrow 9am !remind 8:45am",
+			quickadd.Task{Title: "Call bank", DueDate: wl.NewDate(2016, time.January, 7), Reminder: at(7, 8, 45)}),
+		table.Entry("lone marker characters", "Compare # and @ prices",
+			quickadd.Task{Title: "Compare # and @ prices"}),
+	)
+
+	table.DescribeTable("invalid input",
+		func(input string) {
+			_, err := quickadd.Parse(input, now)
+			Expect(err).To(HaveOccurred())
+		},
+		table.Entry("empty", ""),
+		table.Entry("no title", "tomorrow #ops *"),
+		table.Entry("two lists", "Review #ops #work"),
+		table.Entry("two assignees", "Review @bob @alice"),
+		table.Entry("two reminders", "Review !remind 8am !remind 9am"),
+		table.Entry("reminder without time", "Review !remind"),
+		table.Entry("reminder with invalid time", "Review !remind soon"),
+		table.Entry("unterminated quote", `Review "things`),
+	)
+})
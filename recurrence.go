@@ -0,0 +1,177 @@
+package wl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RecurrenceType is the unit of time in which a task recurs.
+type RecurrenceType string
+
+// The recurrence types supported by Wunderlist.
+// RecurrenceNone means a task does not recur.
+const (
+	RecurrenceNone  RecurrenceType = ""
+	RecurrenceDay   RecurrenceType = "day"
+	RecurrenceWeek  RecurrenceType = "week"
+	RecurrenceMonth RecurrenceType = "month"
+	RecurrenceYear  RecurrenceType = "year"
+)
+
+// RecurrenceTypes returns the valid recurrence types, other than RecurrenceNone.
+func RecurrenceTypes() []RecurrenceType {
+	return []RecurrenceType{
+		RecurrenceDay,
+		RecurrenceWeek,
+		RecurrenceMonth,
+		RecurrenceYear,
+	}
+}
+
+// Valid returns true if r is RecurrenceNone or one of RecurrenceTypes.
+func (r RecurrenceType) Valid() bool {
+	if r == RecurrenceNone {
+		return true
+	}
+	for _, t := range RecurrenceTypes() {
+		if r == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRecurrenceType parses a recurrence type case-insensitively,
+// also accepting plurals such as "weeks".
+func ParseRecurrenceType(s string) (RecurrenceType, error) {
+	r := RecurrenceType(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "s"))
+	if !r.Valid() {
+		names := make([]string, len(RecurrenceTypes()))
+		for i, t := range RecurrenceTypes() {
+			names[i] = string(t)
+		}
+		return RecurrenceNone, fmt.Errorf(
+			"invalid recurrence type %q - must be one of %s",
+			s,
+			strings.Join(names, ", "),
+		)
+	}
+	return r, nil
+}
+
+// Recurrence describes how often a task recurs, e.g. every 2 weeks.
+type Recurrence struct {
+	Type  RecurrenceType `json:"type" yaml:"type"`
+	Count uint           `json:"count" yaml:"count"`
+}
+
+// Recurrence returns the recurrence of the task.
+func (t Task) Recurrence() Recurrence {
+	return Recurrence{Type: t.RecurrenceType, Count: t.RecurrenceCount}
+}
+
+// IsZero returns true if the recurrence means a task does not recur.
+func (r Recurrence) IsZero() bool {
+	return r.Type == RecurrenceNone && r.Count == 0
+}
+
+// Validate returns an error if the type is not valid, or if only one of
+// the type and count is provided.
+func (r Recurrence) Validate() error {
+	if !r.Type.Valid() {
+		_, err := ParseRecurrenceType(string(r.Type))
+		return err
+	}
+
+	if r.Type == RecurrenceNone && r.Count > 0 {
+		return errors.New("recurrence count must be zero if recurrence type is not provided")
+	}
+
+	if r.Type != RecurrenceNone && r.Count == 0 {
+		return errors.New("recurrence count must be non-zero if recurrence type is provided")
+	}
+
+	return nil
+}
+
+// String returns a description of the recurrence, e.g. "every 2 weeks".
+func (r Recurrence) String() string {
+	switch {
+	case r.IsZero():
+		return "never"
+	case r.Count == 1:
+		return fmt.Sprintf("every %s", r.Type)
+	default:
+		return fmt.Sprintf("every %d %ss", r.Count, r.Type)
+	}
+}
+
+// Occurrence returns the date of the nth occurrence after due, where the
+// 0th occurrence is due itself. Monthly and yearly occurrences which fall
+// after the end of a month are moved back to its last day, without
+// affecting later occurrences, e.g. January 31st, February 29th, March 31st.
+func (r Recurrence) Occurrence(due Date, n int) Date {
+	steps := n * int(r.Count)
+
+	switch r.Type {
+	case RecurrenceDay:
+		return due.AddDays(steps)
+	case RecurrenceWeek:
+		return due.AddDays(7 * steps)
+	case RecurrenceMonth:
+		return addMonths(due, steps)
+	case RecurrenceYear:
+		return addMonths(due, 12*steps)
+	default:
+		return due
+	}
+}
+
+// Next returns the next n occurrences after due, not including due.
+// It returns nil if the recurrence is not valid, or due is the zero Date.
+func (r Recurrence) Next(due Date, n int) []Date {
+	if due.IsZero() || r.IsZero() || r.Validate() != nil {
+		return nil
+	}
+
+	dates := make([]Date, n)
+	for i := range dates {
+		dates[i] = r.Occurrence(due, i+1)
+	}
+	return dates
+}
+
+// Between returns the occurrences after due which are on or after from
+// and before to. It returns nil if the recurrence is not valid, or due
+// is the zero Date.
+func (r Recurrence) Between(due Date, from Date, to Date) []Date {
+	if due.IsZero() || r.IsZero() || r.Validate() != nil {
+		return nil
+	}
+
+	var dates []Date
+	for i := 1; ; i++ {
+		d := r.Occurrence(due, i)
+		if !d.Before(to) {
+			return dates
+		}
+		if !d.Before(from) {
+			dates = append(dates, d)
+		}
+	}
+}
+
+// addMonths adds n months to d, moving back to the last day of the month
+// if the day does not exist in the resulting month.
+func addMonths(d Date, n int) Date {
+	first := NewDate(d.year, d.month+time.Month(n), 1)
+	last := NewDate(first.year, first.month+1, 0).day
+
+	day := d.day
+	if day > last {
+		day = last
+	}
+	return NewDate(first.year, first.month, day)
+}
@@ -0,0 +1,118 @@
+package wl_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+)
+
+var _ = Describe("Recurrence", func() {
+	table.DescribeTable("parsing recurrence types",
+		func(input string, expected wl.RecurrenceType) {
+			r, err := wl.ParseRecurrenceType(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r).To(Equal(expected))
+		},
+		table.Entry("empty", "", wl.RecurrenceNone),
+		table.Entry("day", "day", wl.RecurrenceDay),
+		table.Entry("plural", "weeks", wl.RecurrenceWeek),
+		table.Entry("upper case", "Month", wl.RecurrenceMonth),
+		table.Entry("whitespace", " year ", wl.RecurrenceYear),
+	)
+
+	It("rejects invalid recurrence types", func() {
+		_, err := wl.ParseRecurrenceType("fortnight")
+		Expect(err).To(MatchError(`invalid recurrence type "fortnight" - must be one of day, week, month, year`))
+
+		Expect(wl.RecurrenceType("hour").Valid()).To(BeFalse())
+	})
+
+	table.DescribeTable("validating",
+		func(r wl.Recurrence, valid bool) {
+			if valid {
+				Expect(r.Validate()).To(Succeed())
+			} else {
+				Expect(r.Validate()).NotTo(Succeed())
+			}
+		},
+		table.Entry("no recurrence", wl.Recurrence{}, true),
+		table.Entry("type and count", wl.Recurrence{Type: wl.RecurrenceDay, Count: 3}, true),
+		table.Entry("count without type", wl.Recurrence{Count: 3}, false),
+		table.Entry("type without count", wl.Recurrence{Type: wl.RecurrenceDay}, false),
+		table.Entry("invalid type", wl.Recurrence{Type: "hour", Count: 1}, false),
+	)
+
+	It("describes the recurrence", func() {
+		Expect(wl.Recurrence{}.String()).To(Equal("never"))
+		Expect(wl.Recurrence{Type: wl.RecurrenceDay, Count: 1}.String()).To(Equal("every day"))
+		Expect(wl.Recurrence{Type: wl.RecurrenceWeek, Count: 2}.String()).To(Equal("every 2 weeks"))
+	})
+
+	It("returns the recurrence of a task", func() {
+		t := wl.Task{RecurrenceType: wl.RecurrenceMonth, RecurrenceCount: 3}
+		Expect(t.Recurrence()).To(Equal(wl.Recurrence{Type: wl.RecurrenceMonth, Count: 3}))
+	})
+
+	table.DescribeTable("next occurrences",
+		func(r wl.Recurrence, due wl.Date, expected []wl.Date) {
+			Expect(r.Next(due, len(expected))).To(Equal(expected))
+		},
+		table.Entry("daily",
+			wl.Recurrence{Type: wl.RecurrenceDay, Count: 1},
+			wl.NewDate(2016, time.December, 30),
+			[]wl.Date{wl.NewDate(2016, time.December, 31), wl.NewDate(2017, time.January, 1)},
+		),
+		table.Entry("every 3 days",
+			wl.Recurrence{Type: wl.RecurrenceDay, Count: 3},
+			wl.NewDate(2016, time.February, 27),
+			[]wl.Date{wl.NewDate(2016, time.March, 1), wl.NewDate(2016, time.March, 4)},
+		),
+		table.Entry("fortnightly",
+			wl.Recurrence{Type: wl.RecurrenceWeek, Count: 2},
+			wl.NewDate(2016, time.January, 5),
+			[]wl.Date{wl.NewDate(2016, time.January, 19), wl.NewDate(2016, time.February, 2)},
+		),
+		table.Entry("monthly from the end of a month",
+			wl.Recurrence{Type: wl.RecurrenceMonth, Count: 1},
+			wl.NewDate(2016, time.January, 31),
+			[]wl.Date{wl.NewDate(2016, time.February, 29), wl.NewDate(2016, time.March, 31), wl.NewDate(2016, time.April, 30)},
+		),
+		table.Entry("quarterly",
+			wl.Recurrence{Type: wl.RecurrenceMonth, Count: 3},
+			wl.NewDate(2016, time.November, 15),
+			[]wl.Date{wl.NewDate(2017, time.February, 15), wl.NewDate(2017, time.May, 15)},
+		),
+		table.Entry("yearly from a leap day",
+			wl.Recurrence{Type: wl.RecurrenceYear, Count: 1},
+			wl.NewDate(2016, time.February, 29),
+			[]wl.Date{wl.NewDate(2017, time.February, 28), wl.NewDate(2018, time.February, 28), wl.NewDate(2019, time.February, 28), wl.NewDate(2020, time.February, 29)},
+		),
+	)
+
+	It("returns no occurrences without a due date or a valid recurrence", func() {
+		daily := wl.Recurrence{Type: wl.RecurrenceDay, Count: 1}
+		due := wl.NewDate(2016, time.January, 5)
+
+		Expect(daily.Next(wl.Date{}, 3)).To(BeNil())
+		Expect(wl.Recurrence{}.Next(due, 3)).To(BeNil())
+		Expect(wl.Recurrence{Type: "hour", Count: 1}.Next(due, 3)).To(BeNil())
+		Expect(wl.Recurrence{Type: wl.RecurrenceDay}.Next(due, 3)).To(BeNil())
+	})
+
+	It("returns occurrences in a date range", func() {
+		weekly := wl.Recurrence{Type: wl.RecurrenceWeek, Count: 1}
+		due := wl.NewDate(2015, time.December, 1)
+
+		Expect(weekly.Between(due, wl.NewDate(2016, time.January, 1), wl.NewDate(2016, time.January, 15))).To(Equal([]wl.Date{
+			wl.NewDate(2016, time.January, 5),
+			wl.NewDate(2016, time.January, 12),
+		}))
+
+		Expect(weekly.Between(due, wl.NewDate(2015, time.November, 1), wl.NewDate(2015, time.December, 9))).To(Equal([]wl.Date{
+			wl.NewDate(2015, time.December, 8),
+		}))
+	})
+})
@@ -0,0 +1,209 @@
+/*
+Package report computes completion and throughput statistics for tasks
+over a date range.
+*/
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/robdimsdale/wl"
+)
+
+// Stats contains the throughput of a single user or list, or of all tasks.
+type Stats struct {
+	ID                    uint    `json:"id" yaml:"id"`
+	Name                  string  `json:"name" yaml:"name"`
+	Created               uint    `json:"created" yaml:"created"`
+	Completed             uint    `json:"completed" yaml:"completed"`
+	Overdue               uint    `json:"overdue" yaml:"overdue"`
+	AverageCycleTimeHours float64 `json:"average_cycle_time_hours" yaml:"average_cycle_time_hours"`
+
+	cycleTime  time.Duration
+	cycleCount int
+}
+
+// AverageCycleTime returns the mean time from creation to completion
+// of the tasks completed in the report range.
+func (s Stats) AverageCycleTime() time.Duration {
+	return time.Duration(s.AverageCycleTimeHours * float64(time.Hour))
+}
+
+// Report contains throughput statistics for the range [From, To).
+//
+// For users, Created and Completed are attributed via CreatedByID and
+// CompletedByID respectively, and Overdue via AssigneeID.
+type Report struct {
+	From  time.Time `json:"from" yaml:"from"`
+	To    time.Time `json:"to" yaml:"to"`
+	Total Stats     `json:"total" yaml:"total"`
+	Users []Stats   `json:"users" yaml:"users"`
+	Lists []Stats   `json:"lists" yaml:"lists"`
+}
+
+// Generate fetches all lists, users and tasks (completed and uncompleted)
+// via the provided client and computes a Report for the range [from, to).
+func Generate(client wl.Client, from time.Time, to time.Time) (Report, error) {
+	lists, err := client.Lists()
+	if err != nil {
+		return Report{}, err
+	}
+
+	users, err := client.Users()
+	if err != nil {
+		return Report{}, err
+	}
+
+	completedTasks, err := client.CompletedTasks(true)
+	if err != nil {
+		return Report{}, err
+	}
+
+	uncompletedTasks, err := client.CompletedTasks(false)
+	if err != nil {
+		return Report{}, err
+	}
+
+	return Compute(append(completedTasks, uncompletedTasks...), lists, users, from, to), nil
+}
+
+// Compute calculates a Report for the range [from, to) from the provided
+// tasks. Lists and users are used to resolve IDs to names.
+//
+// A task is considered overdue if it has a due date, and the end of
+// that day is no later than to, and it was not completed before to.
+func Compute(
+	tasks []wl.Task,
+	lists []wl.List,
+	users []wl.User,
+	from time.Time,
+	to time.Time,
+) Report {
+	total := &Stats{}
+	userStats := map[uint]*Stats{}
+	listStats := map[uint]*Stats{}
+
+	forUser := func(id uint) *Stats {
+		if _, ok := userStats[id]; !ok {
+			userStats[id] = &Stats{ID: id}
+		}
+		return userStats[id]
+	}
+
+	forList := func(id uint) *Stats {
+		if _, ok := listStats[id]; !ok {
+			listStats[id] = &Stats{ID: id}
+		}
+		return listStats[id]
+	}
+
+	for _, t := range tasks {
+		if inRange(t.CreatedAt, from, to) {
+			total.Created++
+			forList(t.ListID).Created++
+			if t.CreatedByID != 0 {
+				forUser(t.CreatedByID).Created++
+			}
+		}
+
+		if t.Completed && inRange(t.CompletedAt, from, to) {
+			total.Completed++
+			forList(t.ListID).Completed++
+			if t.CompletedByID != 0 {
+				forUser(t.CompletedByID).Completed++
+			}
+
+			if !t.CreatedAt.IsZero() && t.CompletedAt.After(t.CreatedAt) {
+				cycleTime := t.CompletedAt.Sub(t.CreatedAt)
+				total.addCycleTime(cycleTime)
+				forList(t.ListID).addCycleTime(cycleTime)
+				if t.CompletedByID != 0 {
+					forUser(t.CompletedByID).addCycleTime(cycleTime)
+				}
+			}
+		}
+
+		if isOverdue(t, to) {
+			total.Overdue++
+			forList(t.ListID).Overdue++
+			if t.AssigneeID != 0 {
+				forUser(t.AssigneeID).Overdue++
+			}
+		}
+	}
+
+	for _, u := range users {
+		if s, ok := userStats[u.ID]; ok {
+			s.Name = u.Name
+		}
+	}
+
+	for _, l := range lists {
+		if s, ok := listStats[l.ID]; ok {
+			s.Name = l.Title
+		}
+	}
+
+	return Report{
+		From:  from,
+		To:    to,
+		Total: total.finalize(),
+		Users: sortedStats(userStats),
+		Lists: sortedStats(listStats),
+	}
+}
+
+func (s *Stats) addCycleTime(d time.Duration) {
+	s.cycleTime += d
+	s.cycleCount++
+}
+
+func (s *Stats) finalize() Stats {
+	if s.cycleCount > 0 {
+		s.AverageCycleTimeHours = (s.cycleTime / time.Duration(s.cycleCount)).Hours()
+	}
+	return *s
+}
+
+func inRange(t time.Time, from time.Time, to time.Time) bool {
+	return !t.IsZero() && !t.Before(from) && t.Before(to)
+}
+
+func isOverdue(t wl.Task, to time.Time) bool {
+	if t.DueDate.IsZero() {
+		return false
+	}
+
+	if t.Completed && t.CompletedAt.Before(to) {
+		return false
+	}
+
+	endOfDueDay := t.DueDate.AddDays(1).In(to.Location())
+	return !endOfDueDay.After(to)
+}
+
+func sortedStats(m map[uint]*Stats) []Stats {
+	stats := make([]Stats, 0, len(m))
+	for _, s := range m {
+		stats = append(stats, s.finalize())
+	}
+	sort.Sort(byCompleted(stats))
+	return stats
+}
+
+// byCompleted sorts Stats by completed count descending,
+// then by name and finally by ID.
+type byCompleted []Stats
+
+func (s byCompleted) Len() int      { return len(s) }
+func (s byCompleted) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byCompleted) Less(i, j int) bool {
+	if s[i].Completed != s[j].Completed {
+		return s[i].Completed > s[j].Completed
+	}
+	if s[i].Name != s[j].Name {
+		return s[i].Name < s[j].Name
+	}
+	return s[i].ID < s[j].ID
+}
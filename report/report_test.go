@@ -0,0 +1,158 @@
+package report_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/report"
+)
+
+var _ = Describe("Compute", func() {
+	var (
+		from time.Time
+		to   time.Time
+
+		lists []wl.List
+		users []wl.User
+		tasks []wl.Task
+	)
+
+	BeforeEach(func() {
+		from = time.Date(2016, time.January, 4, 0, 0, 0, 0, time.UTC)
+		to = from.AddDate(0, 0, 7)
+
+		lists = []wl.List{
+			{ID: 1, Title: "Work"},
+			{ID: 2, Title: "Home"},
+		}
+
+		users = []wl.User{
+			{ID: 10, Name: "Alice"},
+			{ID: 20, Name: "Bob"},
+		}
+
+		tasks = []wl.Task{
+			{
+				ID:            100,
+				ListID:        1,
+				CreatedByID:   10,
+				CreatedAt:     from.Add(2 * time.Hour),
+				Completed:     true,
+				CompletedByID: 20,
+				CompletedAt:   from.Add(26 * time.Hour),
+			},
+			{
+				ID:            101,
+				ListID:        1,
+				CreatedByID:   10,
+				CreatedAt:     from.AddDate(0, 0, -10),
+				Completed:     true,
+				CompletedByID: 20,
+				CompletedAt:   from.Add(2 * time.Hour),
+			},
+			{
+				ID:          102,
+				ListID:      2,
+				CreatedByID: 20,
+				CreatedAt:   from.Add(time.Hour),
+				AssigneeID:  10,
+				DueDate:     wl.DateOf(from.AddDate(0, 0, 2)),
+			},
+			{
+				ID:          103,
+				ListID:      2,
+				CreatedByID: 20,
+				CreatedAt:   from.AddDate(0, 0, -1),
+				DueDate:     wl.DateOf(to),
+			},
+			{
+				ID:            104,
+				ListID:        2,
+				CreatedByID:   20,
+				CreatedAt:     from.AddDate(0, 0, -3),
+				Completed:     true,
+				CompletedByID: 10,
+				CompletedAt:   to.Add(time.Hour),
+			},
+		}
+	})
+
+	It("counts tasks created and completed in range", func() {
+		r := report.Compute(tasks, lists, users, from, to)
+
+		Expect(r.From).To(Equal(from))
+		Expect(r.To).To(Equal(to))
+		Expect(r.Total.Created).To(Equal(uint(2)))
+		Expect(r.Total.Completed).To(Equal(uint(2)))
+	})
+
+	It("counts completed tasks per user and resolves their names", func() {
+		r := report.Compute(tasks, lists, users, from, to)
+
+		Expect(r.Users).To(HaveLen(2))
+
+		Expect(r.Users[0].ID).To(Equal(uint(20)))
+		Expect(r.Users[0].Name).To(Equal("Bob"))
+		Expect(r.Users[0].Completed).To(Equal(uint(2)))
+		Expect(r.Users[0].Created).To(Equal(uint(1)))
+
+		Expect(r.Users[1].ID).To(Equal(uint(10)))
+		Expect(r.Users[1].Name).To(Equal("Alice"))
+		Expect(r.Users[1].Completed).To(Equal(uint(0)))
+		Expect(r.Users[1].Created).To(Equal(uint(1)))
+	})
+
+	It("counts created and completed tasks per list", func() {
+		r := report.Compute(tasks, lists, users, from, to)
+
+		Expect(r.Lists).To(HaveLen(2))
+
+		Expect(r.Lists[0].Name).To(Equal("Work"))
+		Expect(r.Lists[0].Created).To(Equal(uint(1)))
+		Expect(r.Lists[0].Completed).To(Equal(uint(2)))
+
+		Expect(r.Lists[1].Name).To(Equal("Home"))
+		Expect(r.Lists[1].Created).To(Equal(uint(1)))
+		Expect(r.Lists[1].Completed).To(Equal(uint(0)))
+	})
+
+	It("averages the cycle time of completed tasks", func() {
+		r := report.Compute(tasks, lists, users, from, to)
+
+		// (24h + (10*24h + 2h)) / 2
+		Expect(r.Total.AverageCycleTimeHours).To(Equal(133.0))
+		Expect(r.Total.AverageCycleTime()).To(Equal(133 * time.Hour))
+	})
+
+	It("counts overdue tasks by assignee and list", func() {
+		r := report.Compute(tasks, lists, users, from, to)
+
+		Expect(r.Total.Overdue).To(Equal(uint(1)))
+		Expect(r.Lists[1].Overdue).To(Equal(uint(1)))
+		Expect(r.Users[1].Overdue).To(Equal(uint(1)))
+	})
+
+	Context("when a task is due on the last day of the range", func() {
+		BeforeEach(func() {
+			tasks = []wl.Task{{ID: 1, ListID: 1, DueDate: wl.DateOf(to.AddDate(0, 0, -1))}}
+		})
+
+		It("is overdue", func() {
+			r := report.Compute(tasks, lists, users, from, to)
+
+			Expect(r.Total.Overdue).To(Equal(uint(1)))
+		})
+	})
+
+	Context("when there are no tasks", func() {
+		It("returns empty statistics", func() {
+			r := report.Compute(nil, lists, users, from, to)
+
+			Expect(r.Total).To(Equal(report.Stats{}))
+			Expect(r.Users).To(BeEmpty())
+			Expect(r.Lists).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,150 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	// FormatTable renders the report as column-aligned text.
+	FormatTable = "table"
+
+	// FormatCSV renders the report as comma-separated values,
+	// one row per user, list and total.
+	FormatCSV = "csv"
+
+	// FormatJSON renders the report as a JSON object.
+	FormatJSON = "json"
+)
+
+// Write renders the report to the writer in the provided format.
+func Write(w io.Writer, r Report, format string) error {
+	switch format {
+	case FormatTable:
+		return WriteTable(w, r)
+	case FormatCSV:
+		return WriteCSV(w, r)
+	case FormatJSON:
+		return WriteJSON(w, r)
+	default:
+		return fmt.Errorf("unrecognized format: %s", format)
+	}
+}
+
+// WriteTable renders the report as column-aligned text.
+func WriteTable(w io.Writer, r Report) error {
+	fmt.Fprintf(
+		w,
+		"Report from %s to %s\n\n",
+		r.From.Format(time.RFC3339),
+		r.To.Format(time.RFC3339),
+	)
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "USER\tCREATED\tCOMPLETED\tOVERDUE\tAVG CYCLE TIME")
+	for _, s := range r.Users {
+		fmt.Fprintln(tw, tableRow(s))
+	}
+	fmt.Fprintln(tw, "\t\t\t\t")
+
+	fmt.Fprintln(tw, "LIST\tCREATED\tCOMPLETED\tOVERDUE\tAVG CYCLE TIME")
+	for _, s := range r.Lists {
+		fmt.Fprintln(tw, tableRow(s))
+	}
+	fmt.Fprintln(tw, "\t\t\t\t")
+
+	total := r.Total
+	total.Name = "TOTAL"
+	fmt.Fprintln(tw, tableRow(total))
+
+	return tw.Flush()
+}
+
+func tableRow(s Stats) string {
+	name := s.Name
+	if name == "" {
+		name = strconv.FormatUint(uint64(s.ID), 10)
+	}
+
+	cycleTime := "-"
+	if s.AverageCycleTimeHours > 0 {
+		cycleTime = s.AverageCycleTime().Round(time.Minute).String()
+	}
+
+	return fmt.Sprintf(
+		"%s\t%d\t%d\t%d\t%s",
+		name,
+		s.Created,
+		s.Completed,
+		s.Overdue,
+		cycleTime,
+	)
+}
+
+// WriteCSV renders the report as comma-separated values.
+// Each row is prefixed with its type: user, list or total.
+func WriteCSV(w io.Writer, r Report) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write([]string{
+		"type",
+		"id",
+		"name",
+		"created",
+		"completed",
+		"overdue",
+		"average_cycle_time_hours",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, s := range r.Users {
+		if err := cw.Write(csvRow("user", s)); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range r.Lists {
+		if err := cw.Write(csvRow("list", s)); err != nil {
+			return err
+		}
+	}
+
+	if err := cw.Write(csvRow("total", r.Total)); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvRow(rowType string, s Stats) []string {
+	return []string{
+		rowType,
+		strconv.FormatUint(uint64(s.ID), 10),
+		s.Name,
+		strconv.FormatUint(uint64(s.Created), 10),
+		strconv.FormatUint(uint64(s.Completed), 10),
+		strconv.FormatUint(uint64(s.Overdue), 10),
+		strconv.FormatFloat(s.AverageCycleTimeHours, 'f', 2, 64),
+	}
+}
+
+// WriteJSON renders the report as a single JSON object.
+func WriteJSON(w io.Writer, r Report) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,82 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl/report"
+)
+
+var _ = Describe("Write", func() {
+	var (
+		r   report.Report
+		buf *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		from := time.Date(2016, time.January, 4, 0, 0, 0, 0, time.UTC)
+
+		r = report.Report{
+			From: from,
+			To:   from.AddDate(0, 0, 7),
+			Total: report.Stats{
+				Created:               3,
+				Completed:             2,
+				Overdue:               1,
+				AverageCycleTimeHours: 1.5,
+			},
+			Users: []report.Stats{
+				{ID: 10, Name: "Alice", Completed: 2, AverageCycleTimeHours: 1.5},
+			},
+			Lists: []report.Stats{
+				{ID: 1, Name: "Work", Created: 3, Completed: 2, Overdue: 1},
+			},
+		}
+
+		buf = &bytes.Buffer{}
+	})
+
+	It("renders a table", func() {
+		err := report.Write(buf, r, report.FormatTable)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(buf.String()).To(ContainSubstring("Report from 2016-01-04T00:00:00Z to 2016-01-11T00:00:00Z"))
+		Expect(buf.String()).To(MatchRegexp(`Alice\s+0\s+2\s+0\s+1h30m0s`))
+		Expect(buf.String()).To(MatchRegexp(`Work\s+3\s+2\s+1\s+-`))
+		Expect(buf.String()).To(MatchRegexp(`TOTAL\s+3\s+2\s+1\s+1h30m0s`))
+	})
+
+	It("renders CSV", func() {
+		err := report.Write(buf, r, report.FormatCSV)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(buf.String()).To(Equal(
+			"type,id,name,created,completed,overdue,average_cycle_time_hours\n" +
+				"user,10,Alice,0,2,0,1.50\n" +
+				"list,1,Work,3,2,1,0.00\n" +
+				"total,0,,3,2,1,1.50\n",
+		))
+	})
+
+	It("renders JSON", func() {
+		err := report.Write(buf, r, report.FormatJSON)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded report.Report
+		err = json.Unmarshal(buf.Bytes(), &decoded)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(decoded.Total.Completed).To(Equal(uint(2)))
+		Expect(decoded.Users[0].Name).To(Equal("Alice"))
+	})
+
+	Context("when the format is not recognized", func() {
+		It("returns an error", func() {
+			err := report.Write(buf, r, "xml")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,151 @@
+// Package resolve finds lists, folders and users by name.
+//
+// Queries are matched case-insensitively, first exactly and then as a prefix.
+// A query that is a number matches the item with that ID, if there is one.
+package resolve
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/robdimsdale/wl"
+)
+
+// Me is the user query which always resolves to the current user.
+const Me = "me"
+
+// NotFoundError is returned when nothing matches a query.
+type NotFoundError struct {
+	Kind  string
+	Query string
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("no %s matches %q", e.Kind, e.Query)
+}
+
+// AmbiguousError is returned when more than one item matches a query.
+type AmbiguousError struct {
+	Kind    string
+	Query   string
+	Matches []string
+}
+
+func (e AmbiguousError) Error() string {
+	return fmt.Sprintf(
+		"%s %q is ambiguous - it matches %s",
+		e.Kind,
+		e.Query,
+		strings.Join(e.Matches, ", "),
+	)
+}
+
+// candidate is an item which can be matched by any of its names.
+type candidate struct {
+	id    uint
+	label string
+	names []string
+}
+
+// match returns the index of the only candidate matching the query.
+func match(kind string, query string, candidates []candidate) (int, error) {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return 0, fmt.Errorf("%s must not be empty", kind)
+	}
+
+	if id, err := strconv.ParseUint(q, 10, 0); err == nil {
+		for i, c := range candidates {
+			if c.id == uint(id) {
+				return i, nil
+			}
+		}
+	}
+
+	matchers := []func(name string) bool{
+		func(name string) bool { return name == q },
+		func(name string) bool { return strings.HasPrefix(name, q) },
+	}
+
+	for _, matches := range matchers {
+		var found []int
+		for i, c := range candidates {
+			for _, n := range c.names {
+				if n != "" && matches(strings.ToLower(n)) {
+					found = append(found, i)
+					break
+				}
+			}
+		}
+
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], nil
+		default:
+			e := AmbiguousError{Kind: kind, Query: query}
+			for _, i := range found {
+				e.Matches = append(e.Matches, candidates[i].label)
+			}
+			return 0, e
+		}
+	}
+
+	return 0, NotFoundError{Kind: kind, Query: query}
+}
+
+// List returns the list whose ID or title matches the query.
+func List(lists []wl.List, query string) (wl.List, error) {
+	candidates := make([]candidate, len(lists))
+	for i, l := range lists {
+		candidates[i] = candidate{
+			id:    l.ID,
+			label: fmt.Sprintf("%q (%d)", l.Title, l.ID),
+			names: []string{l.Title},
+		}
+	}
+
+	i, err := match("list", query, candidates)
+	if err != nil {
+		return wl.List{}, err
+	}
+	return lists[i], nil
+}
+
+// Folder returns the folder whose ID or title matches the query.
+func Folder(folders []wl.Folder, query string) (wl.Folder, error) {
+	candidates := make([]candidate, len(folders))
+	for i, f := range folders {
+		candidates[i] = candidate{
+			id:    f.ID,
+			label: fmt.Sprintf("%q (%d)", f.Title, f.ID),
+			names: []string{f.Title},
+		}
+	}
+
+	i, err := match("folder", query, candidates)
+	if err != nil {
+		return wl.Folder{}, err
+	}
+	return folders[i], nil
+}
+
+// User returns the user whose ID, name or email matches the query.
+func User(users []wl.User, query string) (wl.User, error) {
+	candidates := make([]candidate, len(users))
+	for i, u := range users {
+		candidates[i] = candidate{
+			id:    u.ID,
+			label: fmt.Sprintf("%q <%s> (%d)", u.Name, u.Email, u.ID),
+			names: []string{u.Name, u.Email},
+		}
+	}
+
+	i, err := match("user", query, candidates)
+	if err != nil {
+		return wl.User{}, err
+	}
+	return users[i], nil
+}
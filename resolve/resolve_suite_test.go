@@ -0,0 +1,13 @@
+package resolve_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Resolve Suite")
+}
@@ -0,0 +1,125 @@
+package resolve_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/resolve"
+)
+
+var _ = Describe("Resolve", func() {
+	var lists []wl.List
+
+	BeforeEach(func() {
+		lists = []wl.List{
+			{ID: 1, Title: "inbox"},
+			{ID: 2, Title: "Groceries"},
+			{ID: 3, Title: "Work"},
+			{ID: 4, Title: "Work Projects"},
+			{ID: 5, Title: "Travel"},
+			{ID: 6, Title: "2016"},
+		}
+	})
+
+	Describe("List", func() {
+		It("matches titles case-insensitively", func() {
+			l, err := resolve.List(lists, "groceries")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(l.ID).To(Equal(uint(2)))
+		})
+
+		It("matches unique prefixes", func() {
+			l, err := resolve.List(lists, "gro")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(l.ID).To(Equal(uint(2)))
+		})
+
+		It("prefers an exact match over prefix matches", func() {
+			l, err := resolve.List(lists, "WORK")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(l.ID).To(Equal(uint(3)))
+		})
+
+		It("matches IDs", func() {
+			l, err := resolve.List(lists, "5")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(l.Title).To(Equal("Travel"))
+		})
+
+		It("falls back to titles for numbers which are not IDs", func() {
+			l, err := resolve.List(lists, "2016")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(l.ID).To(Equal(uint(6)))
+		})
+
+		It("ignores surrounding whitespace", func() {
+			l, err := resolve.List(lists, "  travel ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(l.ID).To(Equal(uint(5)))
+		})
+
+		It("returns an AmbiguousError listing every match", func() {
+			lists = append(lists, wl.List{ID: 7, Title: "groceries"})
+
+			_, err := resolve.List(lists, "Groceries")
+			Expect(err).To(Equal(resolve.AmbiguousError{
+				Kind:    "list",
+				Query:   "Groceries",
+				Matches: []string{`"Groceries" (2)`, `"groceries" (7)`},
+			}))
+			Expect(err.Error()).To(Equal(`list "Groceries" is ambiguous - it matches "Groceries" (2), "groceries" (7)`))
+		})
+
+		It("returns an AmbiguousError for a prefix of several titles", func() {
+			_, err := resolve.List(lists, "w")
+			Expect(err).To(BeAssignableToTypeOf(resolve.AmbiguousError{}))
+		})
+
+		It("returns a NotFoundError when nothing matches", func() {
+			_, err := resolve.List(lists, "garden")
+			Expect(err).To(Equal(resolve.NotFoundError{Kind: "list", Query: "garden"}))
+			Expect(err.Error()).To(Equal(`no list matches "garden"`))
+		})
+
+		It("returns an error for an empty query", func() {
+			_, err := resolve.List(lists, " ")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Folder", func() {
+		It("matches titles", func() {
+			f, err := resolve.Folder([]wl.Folder{{ID: 1, Title: "Home"}, {ID: 2, Title: "Work"}}, "wo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.ID).To(Equal(uint(2)))
+		})
+	})
+
+	Describe("User", func() {
+		var users []wl.User
+
+		BeforeEach(func() {
+			users = []wl.User{
+				{ID: 1, Name: "Alice Smith", Email: "alice@example.com"},
+				{ID: 2, Name: "Alan Jones", Email: "alan@example.com"},
+			}
+		})
+
+		It("matches emails", func() {
+			u, err := resolve.User(users, "ALICE@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(u.ID).To(Equal(uint(1)))
+		})
+
+		It("matches name prefixes", func() {
+			u, err := resolve.User(users, "alan")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(u.ID).To(Equal(uint(2)))
+		})
+
+		It("describes ambiguous users by name and email", func() {
+			_, err := resolve.User(users, "al")
+			Expect(err).To(MatchError(`user "al" is ambiguous - it matches "Alice Smith" <alice@example.com> (1), "Alan Jones" <alan@example.com> (2)`))
+		})
+	})
+})
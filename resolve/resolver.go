@@ -0,0 +1,84 @@
+package resolve
+
+import (
+	"strings"
+
+	"github.com/robdimsdale/wl"
+)
+
+// Resolver resolves queries using a wl.Client.
+// Lists, folders and users are each fetched at most once.
+type Resolver struct {
+	client wl.Client
+
+	lists   []wl.List
+	folders []wl.Folder
+	users   []wl.User
+}
+
+// NewResolver returns a Resolver which fetches from the provided client.
+func NewResolver(client wl.Client) *Resolver {
+	return &Resolver{client: client}
+}
+
+// List returns the list matching the query.
+func (r *Resolver) List(query string) (wl.List, error) {
+	if r.lists == nil {
+		lists, err := r.client.Lists()
+		if err != nil {
+			return wl.List{}, err
+		}
+		r.lists = lists
+	}
+	return List(r.lists, query)
+}
+
+// ListID returns the ID of the list matching the query.
+// Numeric queries match IDs before titles.
+func (r *Resolver) ListID(query string) (uint, error) {
+	l, err := r.List(query)
+	return l.ID, err
+}
+
+// Folder returns the folder matching the query.
+func (r *Resolver) Folder(query string) (wl.Folder, error) {
+	if r.folders == nil {
+		folders, err := r.client.Folders()
+		if err != nil {
+			return wl.Folder{}, err
+		}
+		r.folders = folders
+	}
+	return Folder(r.folders, query)
+}
+
+// FolderID returns the ID of the folder matching the query.
+// Numeric queries match IDs before titles.
+func (r *Resolver) FolderID(query string) (uint, error) {
+	f, err := r.Folder(query)
+	return f.ID, err
+}
+
+// User returns the user matching the query.
+// The query Me returns the current user.
+func (r *Resolver) User(query string) (wl.User, error) {
+	if strings.EqualFold(strings.TrimSpace(query), Me) {
+		return r.client.User()
+	}
+
+	if r.users == nil {
+		users, err := r.client.Users()
+		if err != nil {
+			return wl.User{}, err
+		}
+		r.users = users
+	}
+	return User(r.users, query)
+}
+
+// UserID returns the ID of the user matching the query.
+// Numeric queries match IDs before names.
+func (r *Resolver) UserID(query string) (uint, error) {
+	u, err := r.User(query)
+	return u.ID, err
+}
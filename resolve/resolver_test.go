@@ -0,0 +1,80 @@
+package resolve_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/resolve"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+var _ = Describe("Resolver", func() {
+	var (
+		client   *wltest.Client
+		resolver *resolve.Resolver
+	)
+
+	BeforeEach(func() {
+		client = wltest.NewClient()
+		client.AddList(wl.List{ID: 1, Title: "inbox"})
+		client.AddList(wl.List{ID: 2, Title: "Groceries"})
+		client.AddFolder(wl.Folder{ID: 3, Title: "Work"})
+		client.AddUser(wl.User{ID: 4, Name: "Alice", Email: "alice@example.com"})
+		client.SetUser(wl.User{ID: 5, Name: "Bob"})
+		resolver = resolve.NewResolver(client)
+	})
+
+	It("resolves list IDs and fetches lists only once", func() {
+		id, err := resolver.ListID("groc")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(uint(2)))
+
+		id, err = resolver.ListID("Inbox")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(uint(1)))
+
+		Expect(client.Calls("Lists")).To(Equal(1))
+	})
+
+	It("matches numeric queries as IDs and then as titles", func() {
+		client.AddList(wl.List{ID: 6, Title: "1234"})
+
+		id, err := resolver.ListID("2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(uint(2)))
+
+		id, err = resolver.ListID("1234")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(uint(6)))
+
+		_, err = resolver.ListID("5678")
+		Expect(err).To(MatchError(resolve.NotFoundError{Kind: "list", Query: "5678"}))
+	})
+
+	It("resolves folder IDs", func() {
+		id, err := resolver.FolderID("work")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(uint(3)))
+	})
+
+	It("resolves user IDs", func() {
+		id, err := resolver.UserID("alice@example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(uint(4)))
+	})
+
+	It("resolves me to the current user", func() {
+		id, err := resolver.UserID("Me")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(uint(5)))
+	})
+
+	It("returns client errors", func() {
+		client.Fail("Lists", errors.New("some error"))
+
+		_, err := resolver.ListID("groceries")
+		Expect(err).To(MatchError("some error"))
+	})
+})
@@ -0,0 +1,81 @@
+package restore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/robdimsdale/wl/atomicfile"
+)
+
+// journal records the IDs of the objects created so far, and the positions
+// updated so far, so that a restore can be resumed after a failure.
+type journal struct {
+	path string
+
+	ExportedAt time.Time       `json:"exported_at"`
+	IDs        map[string]uint `json:"ids"`
+	Done       map[string]bool `json:"done"`
+}
+
+// loadJournal loads the journal at path, or returns an empty journal if
+// there is none. A journal with an empty path is never saved.
+func loadJournal(path string) (*journal, error) {
+	j := &journal{
+		path: path,
+		IDs:  map[string]uint{},
+		Done: map[string]bool{},
+	}
+
+	if path == "" {
+		return j, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(b, j)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal %s: %v", path, err)
+	}
+
+	if j.IDs == nil {
+		j.IDs = map[string]uint{}
+	}
+	if j.Done == nil {
+		j.Done = map[string]bool{}
+	}
+	return j, nil
+}
+
+// save replaces the journal file atomically.
+func (j *journal) save() error {
+	if j.path == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.WriteFile(j.path, b, 0600)
+}
+
+func key(kind string, id uint) string {
+	return fmt.Sprintf("%s:%d", kind, id)
+}
+
+// newID returns the new ID of the object with the old ID,
+// and whether it has been created.
+func (j *journal) newID(kind string, oldID uint) (uint, bool) {
+	id, ok := j.IDs[key(kind, oldID)]
+	return id, ok
+}
@@ -0,0 +1,549 @@
+// Package restore recreates the contents of an export archive in an account,
+// which may be a different account from the one exported, and need not be empty.
+//
+// New objects have new IDs, so references between objects are remapped:
+// tasks are created in the new lists, folders contain the new lists,
+// and positions contain the new IDs. The archive's inbox is restored into
+// the existing inbox. Restored lists, tasks and subtasks are placed before
+// any which already exist. Memberships and webhooks are not restored,
+// and tasks are only assigned if they were assigned to the exported user,
+// in which case they are assigned to the current user.
+//
+// Progress is recorded in a journal, so that a restore which fails can be
+// run again with the same journal to continue where it stopped.
+package restore
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/export"
+	"github.com/robdimsdale/wl/position"
+)
+
+// Actions of a Step.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionMap    = "map"
+	ActionSkip   = "skip"
+)
+
+// Kinds of object restored.
+const (
+	KindList            = "list"
+	KindFolder          = "folder"
+	KindTask            = "task"
+	KindSubtask         = "subtask"
+	KindNote            = "note"
+	KindReminder        = "reminder"
+	KindTaskComment     = "task_comment"
+	KindFile            = "file"
+	KindListPosition    = "list_position"
+	KindTaskPosition    = "task_position"
+	KindSubtaskPosition = "subtask_position"
+)
+
+// Step is an action taken, or planned, to restore an object.
+// NewID is zero for planned steps.
+type Step struct {
+	Action string `json:"action" yaml:"action"`
+	Kind   string `json:"kind" yaml:"kind"`
+	OldID  uint   `json:"old_id" yaml:"old_id"`
+	NewID  uint   `json:"new_id" yaml:"new_id"`
+	Title  string `json:"title" yaml:"title"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// Options configure a restore.
+type Options struct {
+	// ListIDs restricts the restore to the lists with these IDs in the archive,
+	// and their contents. All lists are restored if it is empty.
+	ListIDs []uint
+
+	// JournalPath is the file in which progress is recorded.
+	// If it is empty progress is not recorded.
+	JournalPath string
+
+	// DryRun returns the steps which would be taken, without changing anything.
+	DryRun bool
+
+	// Progress is called after each step.
+	Progress func(Step)
+}
+
+type restorer struct {
+	client  wl.Client
+	mover   *position.Mover
+	archive export.Archive
+	opts    Options
+	journal *journal
+
+	userID uint
+	steps  []Step
+
+	selected map[uint]bool
+	listIDs  map[uint]uint // task ID to list ID
+	taskIDs  map[uint]uint // subtask ID to task ID
+}
+
+// Restore restores the archive, returning the steps taken.
+// Objects recorded in the journal as already restored are skipped.
+func Restore(client wl.Client, a export.Archive, opts Options) ([]Step, error) {
+	j, err := loadJournal(opts.JournalPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(j.IDs) > 0 && !j.ExportedAt.Equal(a.ExportedAt) {
+		return nil, fmt.Errorf(
+			"journal %s is for the archive exported at %s, not %s",
+			opts.JournalPath,
+			j.ExportedAt,
+			a.ExportedAt,
+		)
+	}
+	j.ExportedAt = a.ExportedAt
+
+	if opts.DryRun {
+		// Planned objects are recorded without being saved,
+		// so that their children are planned too.
+		j.path = ""
+	}
+
+	if opts.Progress == nil {
+		opts.Progress = func(Step) {}
+	}
+
+	r := &restorer{
+		client:   client,
+		mover:    position.NewMover(client),
+		archive:  a,
+		opts:     opts,
+		journal:  j,
+		selected: map[uint]bool{},
+		listIDs:  map[uint]uint{},
+		taskIDs:  map[uint]uint{},
+	}
+
+	for _, l := range a.Lists {
+		r.selected[l.ID] = len(opts.ListIDs) == 0
+	}
+	for _, id := range opts.ListIDs {
+		if _, ok := r.selected[id]; !ok {
+			return nil, fmt.Errorf("list %d is not in the archive", id)
+		}
+		r.selected[id] = true
+	}
+
+	for _, t := range a.Tasks {
+		r.listIDs[t.ID] = t.ListID
+	}
+	for _, s := range a.Subtasks {
+		r.taskIDs[s.ID] = s.TaskID
+	}
+
+	err = r.run()
+	return r.steps, err
+}
+
+func (r *restorer) run() error {
+	if !r.opts.DryRun {
+		user, err := r.client.User()
+		if err != nil {
+			return err
+		}
+		r.userID = user.ID
+	}
+
+	for _, f := range []func() error{
+		r.restoreLists,
+		r.restoreFolders,
+		r.restoreTasks,
+		r.restoreSubtasks,
+		r.restoreNotes,
+		r.restoreReminders,
+		r.restoreTaskComments,
+		r.restoreFiles,
+		r.restorePositions,
+	} {
+		err := f()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// create records the new ID of an object, unless it was already restored,
+// in which case create is not called. In a dry run create is never called.
+func (r *restorer) create(kind string, oldID uint, title string, create func() (uint, error)) error {
+	if _, ok := r.journal.newID(kind, oldID); ok {
+		return nil
+	}
+
+	var newID uint
+	if !r.opts.DryRun {
+		var err error
+		newID, err = create()
+		if err != nil {
+			return fmt.Errorf("failed to restore %s %d: %v", kind, oldID, err)
+		}
+	}
+
+	r.journal.IDs[key(kind, oldID)] = newID
+	err := r.journal.save()
+	if err != nil {
+		return err
+	}
+
+	r.step(Step{Action: ActionCreate, Kind: kind, OldID: oldID, NewID: newID, Title: title})
+	return nil
+}
+
+// update marks an update as done, unless it was already done,
+// in which case update is not called. In a dry run update is never called.
+func (r *restorer) update(kind string, oldID uint, update func() (uint, error)) error {
+	k := key(kind, oldID)
+	if r.journal.Done[k] {
+		return nil
+	}
+
+	var newID uint
+	if !r.opts.DryRun {
+		var err error
+		newID, err = update()
+		if err != nil {
+			return fmt.Errorf("failed to restore %s %d: %v", kind, oldID, err)
+		}
+	}
+
+	r.journal.Done[k] = true
+	err := r.journal.save()
+	if err != nil {
+		return err
+	}
+
+	r.step(Step{Action: ActionUpdate, Kind: kind, OldID: oldID, NewID: newID})
+	return nil
+}
+
+func (r *restorer) step(s Step) {
+	r.steps = append(r.steps, s)
+	r.opts.Progress(s)
+}
+
+func (r *restorer) taskSelected(taskID uint) bool {
+	listID, ok := r.listIDs[taskID]
+	return ok && r.selected[listID]
+}
+
+func (r *restorer) restoreLists() error {
+	for _, l := range r.archive.Lists {
+		if !r.selected[l.ID] {
+			continue
+		}
+
+		if l.ListType == "inbox" {
+			if _, ok := r.journal.newID(KindList, l.ID); ok {
+				continue
+			}
+
+			var newID uint
+			if !r.opts.DryRun {
+				inbox, err := r.client.Inbox()
+				if err != nil {
+					return err
+				}
+				newID = inbox.ID
+			}
+
+			r.journal.IDs[key(KindList, l.ID)] = newID
+			err := r.journal.save()
+			if err != nil {
+				return err
+			}
+
+			r.step(Step{Action: ActionMap, Kind: KindList, OldID: l.ID, NewID: newID, Title: l.Title})
+			continue
+		}
+
+		list := l
+		err := r.create(KindList, l.ID, l.Title, func() (uint, error) {
+			created, err := r.client.CreateList(list.Title)
+			return created.ID, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *restorer) restoreFolders() error {
+	for _, f := range r.archive.Folders {
+		var listIDs []uint
+		for _, id := range f.ListIDs {
+			if newID, ok := r.journal.newID(KindList, id); ok && r.selected[id] {
+				listIDs = append(listIDs, newID)
+			}
+		}
+		if len(listIDs) == 0 {
+			continue
+		}
+
+		folder := f
+		err := r.create(KindFolder, f.ID, f.Title, func() (uint, error) {
+			created, err := r.client.CreateFolder(folder.Title, listIDs)
+			return created.ID, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *restorer) restoreTasks() error {
+	for _, t := range r.archive.Tasks {
+		if !r.selected[t.ListID] {
+			continue
+		}
+
+		listID, _ := r.journal.newID(KindList, t.ListID)
+
+		var assigneeID uint
+		if t.AssigneeID != 0 && t.AssigneeID == r.archive.User.ID {
+			assigneeID = r.userID
+		}
+
+		task := t
+		err := r.create(KindTask, t.ID, t.Title, func() (uint, error) {
+			created, err := r.client.CreateTask(
+				task.Title,
+				listID,
+				assigneeID,
+				task.Completed,
+				task.RecurrenceType,
+				task.RecurrenceCount,
+				task.DueDate,
+				task.Starred,
+			)
+			return created.ID, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *restorer) restoreSubtasks() error {
+	for _, s := range r.archive.Subtasks {
+		taskID, ok := r.journal.newID(KindTask, s.TaskID)
+		if !ok || !r.taskSelected(s.TaskID) {
+			continue
+		}
+
+		subtask := s
+		err := r.create(KindSubtask, s.ID, s.Title, func() (uint, error) {
+			created, err := r.client.CreateSubtask(subtask.Title, taskID, subtask.Completed)
+			return created.ID, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *restorer) restoreNotes() error {
+	for _, n := range r.archive.Notes {
+		taskID, ok := r.journal.newID(KindTask, n.TaskID)
+		if !ok || !r.taskSelected(n.TaskID) {
+			continue
+		}
+
+		note := n
+		err := r.create(KindNote, n.ID, "", func() (uint, error) {
+			created, err := r.client.CreateNote(note.Content, taskID)
+			return created.ID, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *restorer) restoreReminders() error {
+	for _, rem := range r.archive.Reminders {
+		taskID, ok := r.journal.newID(KindTask, rem.TaskID)
+		if !ok || !r.taskSelected(rem.TaskID) {
+			continue
+		}
+
+		reminder := rem
+		err := r.create(KindReminder, rem.ID, "", func() (uint, error) {
+			created, err := r.client.CreateReminder(reminder.Date, taskID, "")
+			return created.ID, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *restorer) restoreTaskComments() error {
+	for _, c := range r.archive.TaskComments {
+		taskID, ok := r.journal.newID(KindTask, c.TaskID)
+		if !ok || !r.taskSelected(c.TaskID) {
+			continue
+		}
+
+		comment := c
+		err := r.create(KindTaskComment, c.ID, "", func() (uint, error) {
+			created, err := r.client.CreateTaskComment(comment.Text, taskID)
+			return created.ID, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *restorer) restoreFiles() error {
+	for _, f := range r.archive.Files {
+		taskID, ok := r.journal.newID(KindTask, f.TaskID)
+		if !ok || !r.taskSelected(f.TaskID) {
+			continue
+		}
+
+		contents, ok := r.archive.FileContents[f.ID]
+		if !ok {
+			if _, restored := r.journal.newID(KindFile, f.ID); !restored {
+				r.step(Step{Action: ActionSkip, Kind: KindFile, OldID: f.ID, Title: f.FileName, Reason: "contents not in archive"})
+			}
+			continue
+		}
+
+		file := f
+		err := r.create(KindFile, f.ID, f.FileName, func() (uint, error) {
+			return r.uploadFile(file, contents, taskID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uploadFile uploads the contents via a temporary file,
+// as the client uploads from the local filesystem.
+func (r *restorer) uploadFile(f wl.File, contents []byte, taskID uint) (uint, error) {
+	dir, err := ioutil.TempDir("", "wl-restore")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "file")
+	err = ioutil.WriteFile(path, contents, 0600)
+	if err != nil {
+		return 0, err
+	}
+
+	sum := md5.Sum(contents)
+	upload, err := r.client.UploadFile(path, f.FileName, f.ContentType, hex.EncodeToString(sum[:]))
+	if err != nil {
+		return 0, err
+	}
+
+	created, err := r.client.CreateFile(upload.ID, taskID)
+	return created.ID, err
+}
+
+func (r *restorer) restorePositions() error {
+	for _, p := range r.archive.ListPositions {
+		values := r.mapValues(KindList, p.Values, func(id uint) bool { return r.selected[id] })
+		if len(values) == 0 {
+			continue
+		}
+
+		err := r.update(KindListPosition, p.ID, func() (uint, error) {
+			updated, err := r.mover.MoveListsToTop(values)
+			return updated.ID, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, p := range r.archive.TaskPositions {
+		values := r.mapValues(KindTask, p.Values, r.taskSelected)
+		if len(values) == 0 {
+			continue
+		}
+
+		// The list is that of the tasks, which were all created in the same list.
+		listID, _ := r.journal.newID(KindList, r.listIDs[r.firstMapped(KindTask, p.Values)])
+
+		err := r.update(KindTaskPosition, p.ID, func() (uint, error) {
+			updated, err := r.mover.MoveTasksToTop(listID, values)
+			return updated.ID, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, p := range r.archive.SubtaskPositions {
+		values := r.mapValues(KindSubtask, p.Values, func(id uint) bool { return r.taskSelected(r.taskIDs[id]) })
+		if len(values) == 0 {
+			continue
+		}
+
+		taskID, _ := r.journal.newID(KindTask, r.taskIDs[r.firstMapped(KindSubtask, p.Values)])
+
+		err := r.update(KindSubtaskPosition, p.ID, func() (uint, error) {
+			updated, err := r.mover.MoveSubtasksToTop(taskID, values)
+			return updated.ID, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mapValues returns the new IDs of the selected, restored objects with the old IDs.
+func (r *restorer) mapValues(kind string, oldIDs []uint, selected func(uint) bool) []uint {
+	var values []uint
+	for _, id := range oldIDs {
+		if !selected(id) {
+			continue
+		}
+		if newID, ok := r.journal.newID(kind, id); ok {
+			values = append(values, newID)
+		}
+	}
+	return values
+}
+
+// firstMapped returns the first old ID which has been restored.
+func (r *restorer) firstMapped(kind string, oldIDs []uint) uint {
+	for _, id := range oldIDs {
+		if _, ok := r.journal.newID(kind, id); ok {
+			return id
+		}
+	}
+	return 0
+}
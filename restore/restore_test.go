@@ -0,0 +1,337 @@
+package restore_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/export"
+	"github.com/robdimsdale/wl/restore"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+func newClient() *wltest.Client {
+	client := wltest.NewClient()
+	client.SetUser(wl.User{ID: 7})
+	client.AddList(wl.List{ID: 1, Title: "inbox", ListType: "inbox"})
+	client.AddList(wl.List{ID: 2, Title: "Existing"})
+	client.SetListPosition(2)
+	return client
+}
+
+func newArchive() export.Archive {
+	due := wl.NewDate(2016, time.January, 6)
+	return export.Archive{
+		Version:    export.SchemaVersion,
+		ExportedAt: time.Date(2016, time.January, 5, 10, 0, 0, 0, time.UTC),
+		User:       wl.User{ID: 99},
+		Lists: []wl.List{
+			{ID: 10, Title: "inbox", ListType: "inbox"},
+			{ID: 20, Title: "Work"},
+			{ID: 30, Title: "Home"},
+		},
+		Folders:       []wl.Folder{{ID: 40, Title: "Projects", ListIDs: []uint{20, 30}}},
+		ListPositions: []wl.Position{{ID: 50, Values: []uint{30, 20}}},
+		TaskPositions: []wl.Position{{ID: 51, Values: []uint{22, 21}}},
+		SubtaskPositions: []wl.Position{
+			{ID: 52, Values: []uint{24, 23}},
+		},
+		Tasks: []wl.Task{
+			{ID: 11, ListID: 10, Title: "Buy milk"},
+			{ID: 21, ListID: 20, Title: "Renew certs", AssigneeID: 99, DueDate: due, Starred: true},
+			{ID: 22, ListID: 20, Title: "Deploy", AssigneeID: 98, Completed: true, RecurrenceType: wl.RecurrenceWeek, RecurrenceCount: 2},
+			{ID: 31, ListID: 30, Title: "Paint fence"},
+		},
+		Subtasks: []wl.Subtask{
+			{ID: 23, TaskID: 21, Title: "Check expiry"},
+			{ID: 24, TaskID: 21, Title: "Order certs", Completed: true},
+		},
+		Notes:        []wl.Note{{ID: 25, TaskID: 21, Content: "Use ACME"}},
+		Reminders:    []wl.Reminder{{ID: 26, TaskID: 21, Date: time.Date(2016, time.January, 6, 9, 0, 0, 0, time.UTC)}},
+		TaskComments: []wl.TaskComment{{ID: 27, TaskID: 21, Text: "Done for prod"}},
+		Files: []wl.File{
+			{ID: 28, TaskID: 21, FileName: "cert.pem"},
+			{ID: 29, TaskID: 31, FileName: "photo.jpg"},
+		},
+		FileContents: map[uint][]byte{28: []byte("certificate")},
+	}
+}
+
+var _ = Describe("Restore", func() {
+	var (
+		client  *wltest.Client
+		archive export.Archive
+		dir     string
+		opts    restore.Options
+	)
+
+	BeforeEach(func() {
+		client = newClient()
+		archive = newArchive()
+
+		var err error
+		dir, err = ioutil.TempDir("", "wl-restore-test")
+		Expect(err).NotTo(HaveOccurred())
+
+		opts = restore.Options{JournalPath: filepath.Join(dir, "journal.json")}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	lists := func() []wl.List {
+		lists, err := client.Lists()
+		Expect(err).NotTo(HaveOccurred())
+		return lists
+	}
+
+	folders := func() []wl.Folder {
+		folders, err := client.Folders()
+		Expect(err).NotTo(HaveOccurred())
+		return folders
+	}
+
+	tasks := func() []wl.Task {
+		incomplete, err := client.CompletedTasks(false)
+		Expect(err).NotTo(HaveOccurred())
+		completed, err := client.CompletedTasks(true)
+		Expect(err).NotTo(HaveOccurred())
+		return append(incomplete, completed...)
+	}
+
+	subtasks := func(taskID uint) []wl.Subtask {
+		incomplete, err := client.CompletedSubtasksForTaskID(taskID, false)
+		Expect(err).NotTo(HaveOccurred())
+		completed, err := client.CompletedSubtasksForTaskID(taskID, true)
+		Expect(err).NotTo(HaveOccurred())
+		return append(incomplete, completed...)
+	}
+
+	listPosition := func() []uint {
+		positions, err := client.ListPositions()
+		Expect(err).NotTo(HaveOccurred())
+		return positions[0].Values
+	}
+
+	taskNamed := func(title string) wl.Task {
+		for _, t := range tasks() {
+			if t.Title == title {
+				return t
+			}
+		}
+		Fail("no task named " + title)
+		return wl.Task{}
+	}
+
+	listNamed := func(title string) wl.List {
+		for _, l := range lists() {
+			if l.Title == title {
+				return l
+			}
+		}
+		Fail("no list named " + title)
+		return wl.List{}
+	}
+
+	It("recreates everything with remapped IDs", func() {
+		_, err := restore.Restore(client, archive, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		work := listNamed("Work")
+		home := listNamed("Home")
+		Expect(lists()).To(HaveLen(4))
+		Expect(folders()).To(HaveLen(1))
+		Expect(folders()[0].Title).To(Equal("Projects"))
+		Expect(folders()[0].ListIDs).To(Equal([]uint{work.ID, home.ID}))
+
+		Expect(taskNamed("Buy milk").ListID).To(Equal(uint(1)))
+
+		certs := taskNamed("Renew certs")
+		Expect(certs.ListID).To(Equal(work.ID))
+		Expect(certs.AssigneeID).To(Equal(uint(7)))
+		Expect(certs.DueDate).To(Equal(wl.NewDate(2016, time.January, 6)))
+		Expect(certs.Starred).To(BeTrue())
+
+		deploy := taskNamed("Deploy")
+		Expect(deploy.AssigneeID).To(BeZero())
+		Expect(deploy.Completed).To(BeTrue())
+		Expect(deploy.Recurrence()).To(Equal(wl.Recurrence{Type: wl.RecurrenceWeek, Count: 2}))
+
+		Expect(subtasks(certs.ID)).To(HaveLen(2))
+
+		notes, err := client.Notes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(notes).To(HaveLen(1))
+		Expect(notes[0].TaskID).To(Equal(certs.ID))
+		Expect(notes[0].Content).To(Equal("Use ACME"))
+
+		reminders, err := client.Reminders()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reminders[0].TaskID).To(Equal(certs.ID))
+
+		comments, err := client.TaskComments()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments[0].TaskID).To(Equal(certs.ID))
+
+		files, err := client.Files()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+		Expect(files[0].TaskID).To(Equal(certs.ID))
+		Expect(files[0].FileName).To(Equal("cert.pem"))
+		Expect(client.FileContents(files[0].ID)).To(Equal([]byte("certificate")))
+	})
+
+	It("restores positions with the new IDs, before existing values", func() {
+		_, err := restore.Restore(client, archive, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		work := listNamed("Work")
+		home := listNamed("Home")
+		Expect(listPosition()).To(Equal([]uint{home.ID, work.ID, 2}))
+
+		taskPositions, err := client.TaskPositionsForListID(work.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(taskPositions[0].Values).To(Equal([]uint{taskNamed("Deploy").ID, taskNamed("Renew certs").ID}))
+
+		certs := taskNamed("Renew certs")
+		s := subtasks(certs.ID)
+		subtaskPositions, err := client.SubtaskPositionsForTaskID(certs.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subtaskPositions[0].Values).To(Equal([]uint{s[1].ID, s[0].ID}))
+	})
+
+	It("retries position updates which conflict with concurrent changes", func() {
+		conflicts := 2
+		client.Before("UpdateListPosition", func(args ...interface{}) error {
+			if conflicts == 0 {
+				return nil
+			}
+			conflicts--
+			client.SetListPosition(append(listPosition(), 4)...)
+			return wl.StatusError{StatusCode: http.StatusConflict, Expected: http.StatusOK}
+		})
+
+		_, err := restore.Restore(client, archive, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(listPosition()).To(Equal([]uint{listNamed("Home").ID, listNamed("Work").ID, 2, 4, 4}))
+	})
+
+	It("skips files without contents", func() {
+		steps, err := restore.Restore(client, archive, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(steps).To(ContainElement(restore.Step{
+			Action: restore.ActionSkip,
+			Kind:   restore.KindFile,
+			OldID:  29,
+			Title:  "photo.jpg",
+			Reason: "contents not in archive",
+		}))
+	})
+
+	It("maps the inbox to the existing inbox", func() {
+		steps, err := restore.Restore(client, archive, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(steps[0]).To(Equal(restore.Step{Action: restore.ActionMap, Kind: restore.KindList, OldID: 10, NewID: 1, Title: "inbox"}))
+	})
+
+	It("plans without changing anything in a dry run", func() {
+		opts.DryRun = true
+
+		root, err := client.Root()
+		Expect(err).NotTo(HaveOccurred())
+
+		steps, err := restore.Restore(client, archive, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.Root()).To(Equal(root))
+		Expect(steps).To(HaveLen(18))
+		Expect(steps[1]).To(Equal(restore.Step{Action: restore.ActionCreate, Kind: restore.KindList, OldID: 20, Title: "Work"}))
+
+		_, err = os.Stat(opts.JournalPath)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("restores only the selected lists", func() {
+		opts.ListIDs = []uint{30}
+
+		_, err := restore.Restore(client, archive, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		home := listNamed("Home")
+		Expect(lists()).To(HaveLen(3))
+		Expect(folders()[0].ListIDs).To(Equal([]uint{home.ID}))
+		Expect(tasks()).To(HaveLen(1))
+		Expect(tasks()[0].ListID).To(Equal(home.ID))
+		Expect(tasks()[0].Title).To(Equal("Paint fence"))
+		Expect(client.Calls("CreateSubtask")).To(BeZero())
+		Expect(listPosition()).To(Equal([]uint{home.ID, 2}))
+	})
+
+	It("rejects lists which are not in the archive", func() {
+		opts.ListIDs = []uint{1234}
+
+		root, err := client.Root()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = restore.Restore(client, archive, opts)
+		Expect(err).To(HaveOccurred())
+		Expect(client.Root()).To(Equal(root))
+	})
+
+	Context("when a restore fails", func() {
+		BeforeEach(func() {
+			noteErr := errors.New("note error")
+			client.Before("CreateNote", func(args ...interface{}) error {
+				return noteErr
+			})
+
+			_, err := restore.Restore(client, archive, opts)
+			Expect(err).To(MatchError(ContainSubstring("note error")))
+			noteErr = nil
+		})
+
+		It("resumes without creating anything twice", func() {
+			steps, err := restore.Restore(client, archive, opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(steps[0].Kind).To(Equal(restore.KindNote))
+			Expect(lists()).To(HaveLen(4))
+			Expect(folders()).To(HaveLen(1))
+			Expect(tasks()).To(HaveLen(4))
+			Expect(subtasks(taskNamed("Renew certs").ID)).To(HaveLen(2))
+
+			notes, err := client.Notes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notes).To(HaveLen(1))
+
+			files, err := client.Files()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(1))
+		})
+
+		It("plans only the remaining steps in a dry run", func() {
+			opts.DryRun = true
+
+			steps, err := restore.Restore(client, archive, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(steps[0].Kind).To(Equal(restore.KindNote))
+		})
+
+		It("rejects the journal for a different archive", func() {
+			archive.ExportedAt = archive.ExportedAt.Add(time.Hour)
+
+			_, err := restore.Restore(client, archive, opts)
+			Expect(err).To(MatchError(ContainSubstring("journal")))
+		})
+	})
+})
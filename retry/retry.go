@@ -0,0 +1,30 @@
+// Package retry repeats updates which the API rejects because the
+// revision provided was out of date.
+package retry
+
+import (
+	"fmt"
+
+	"github.com/robdimsdale/wl"
+)
+
+const (
+	// DefaultMaxAttempts is the number of times an update is attempted
+	// before giving up, if the item is concurrently modified.
+	DefaultMaxAttempts = 5
+)
+
+// OnConflict calls f until it succeeds or fails with an error other than
+// a revision conflict, up to maxAttempts times. f should fetch the item
+// again before updating it, so that each attempt uses the latest revision.
+func OnConflict(maxAttempts int, f func() error) error {
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		err = f()
+		if err == nil || !wl.IsConflict(err) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %v", maxAttempts, err)
+}
@@ -0,0 +1,60 @@
+package retry_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/retry"
+)
+
+var _ = Describe("OnConflict", func() {
+	var (
+		calls   int
+		results []error
+	)
+
+	f := func() error {
+		calls++
+		if len(results) == 0 {
+			return nil
+		}
+		err := results[0]
+		results = results[1:]
+		return err
+	}
+
+	conflict := wl.StatusError{StatusCode: 409, Expected: 200}
+
+	BeforeEach(func() {
+		calls = 0
+		results = nil
+	})
+
+	It("calls f once if it succeeds", func() {
+		Expect(retry.OnConflict(3, f)).To(Succeed())
+		Expect(calls).To(Equal(1))
+	})
+
+	It("calls f again after a conflict", func() {
+		results = []error{conflict, conflict}
+
+		Expect(retry.OnConflict(3, f)).To(Succeed())
+		Expect(calls).To(Equal(3))
+	})
+
+	It("gives up after maxAttempts conflicts", func() {
+		results = []error{conflict, conflict, conflict}
+
+		err := retry.OnConflict(3, f)
+		Expect(err).To(MatchError("giving up after 3 attempts: Unexpected response code 409 - expected 200"))
+		Expect(calls).To(Equal(3))
+	})
+
+	It("returns other errors without calling f again", func() {
+		other := wl.StatusError{StatusCode: 404, Expected: 200}
+		results = []error{other}
+
+		Expect(retry.OnConflict(3, f)).To(Equal(other))
+		Expect(calls).To(Equal(1))
+	})
+})
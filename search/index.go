@@ -0,0 +1,311 @@
+// Package search provides full-text search across tasks, subtasks,
+// notes and task comments, using an inverted index which can be saved to disk.
+//
+// The index is kept up to date by revision: if the root revision has not
+// changed nothing is fetched, otherwise only lists whose revisions have
+// changed are fetched again.
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/atomicfile"
+)
+
+// indexVersion is incremented when the format of the saved index changes.
+// Indexes saved with other versions are discarded.
+const indexVersion = 1
+
+// Kind is the kind of a document.
+type Kind string
+
+// The kinds of documents which are indexed.
+const (
+	KindTask    Kind = "task"
+	KindSubtask Kind = "subtask"
+	KindNote    Kind = "note"
+	KindComment Kind = "comment"
+)
+
+// Document is an indexed piece of text.
+type Document struct {
+	Kind   Kind   `json:"kind"`
+	ID     uint   `json:"id"`
+	ListID uint   `json:"list_id"`
+	TaskID uint   `json:"task_id"`
+	Text   string `json:"text"`
+}
+
+func (d Document) key() string {
+	return fmt.Sprintf("%s:%d", d.Kind, d.ID)
+}
+
+type listEntry struct {
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	Revision uint   `json:"revision"`
+}
+
+// Index is an inverted index of documents.
+type Index struct {
+	userID       uint
+	rootRevision uint
+
+	lists    map[uint]listEntry
+	docs     map[string]Document
+	postings map[string]map[string]int
+}
+
+// indexFile is the format in which an index is saved.
+type indexFile struct {
+	Version      int                       `json:"version"`
+	UserID       uint                      `json:"user_id"`
+	RootRevision uint                      `json:"root_revision"`
+	Lists        []listEntry               `json:"lists"`
+	Documents    []Document                `json:"documents"`
+	Postings     map[string]map[string]int `json:"postings"`
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{
+		lists:    map[uint]listEntry{},
+		docs:     map[string]Document{},
+		postings: map[string]map[string]int{},
+	}
+}
+
+// Load loads the index saved at path. If there is no index at path,
+// or it was saved by an incompatible version, an empty index is returned.
+func Load(path string) (*Index, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewIndex(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f indexFile
+	err = json.Unmarshal(b, &f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read search index %s: %v", path, err)
+	}
+
+	if f.Version != indexVersion {
+		return NewIndex(), nil
+	}
+
+	i := NewIndex()
+	i.userID = f.UserID
+	i.rootRevision = f.RootRevision
+	for _, l := range f.Lists {
+		i.lists[l.ID] = l
+	}
+	for _, d := range f.Documents {
+		i.docs[d.key()] = d
+	}
+	if f.Postings != nil {
+		i.postings = f.Postings
+	}
+	return i, nil
+}
+
+// Save saves the index to path, creating its directory if necessary.
+// The file is replaced atomically, so a concurrent Load sees either
+// the previous or the new index.
+func (i *Index) Save(path string) error {
+	f := indexFile{
+		Version:      indexVersion,
+		UserID:       i.userID,
+		RootRevision: i.rootRevision,
+		Lists:        make([]listEntry, 0, len(i.lists)),
+		Documents:    make([]Document, 0, len(i.docs)),
+		Postings:     i.postings,
+	}
+	for _, l := range i.lists {
+		f.Lists = append(f.Lists, l)
+	}
+	for _, d := range i.docs {
+		f.Documents = append(f.Documents, d)
+	}
+	sort.Sort(listsByID(f.Lists))
+	sort.Sort(documentsByKey(f.Documents))
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.WriteFile(path, b, 0600)
+}
+
+// Len returns the number of indexed documents.
+func (i *Index) Len() int {
+	return len(i.docs)
+}
+
+// Sync brings the index up to date, fetching only the lists which have
+// changed since the last sync. It returns the number of lists fetched.
+func (i *Index) Sync(client wl.Client) (int, error) {
+	root, err := client.Root()
+	if err != nil {
+		return 0, err
+	}
+
+	if root.UserID != i.userID {
+		*i = *NewIndex()
+		i.userID = root.UserID
+	} else if root.Revision == i.rootRevision && len(i.lists) > 0 {
+		return 0, nil
+	}
+
+	lists, err := client.Lists()
+	if err != nil {
+		return 0, err
+	}
+
+	current := make(map[uint]bool, len(lists))
+	fetched := 0
+	for _, l := range lists {
+		current[l.ID] = true
+
+		if entry, ok := i.lists[l.ID]; ok && entry.Revision == l.Revision {
+			continue
+		}
+
+		docs, err := fetchList(client, l.ID)
+		if err != nil {
+			return fetched, err
+		}
+		i.replaceList(listEntry{ID: l.ID, Title: l.Title, Revision: l.Revision}, docs)
+		fetched++
+	}
+
+	for id := range i.lists {
+		if !current[id] {
+			i.removeList(id)
+		}
+	}
+
+	i.rootRevision = root.Revision
+	return fetched, nil
+}
+
+// fetchList returns the documents in the list, including completed tasks and subtasks.
+func fetchList(client wl.Client, listID uint) ([]Document, error) {
+	var docs []Document
+
+	for _, completed := range []bool{false, true} {
+		tasks, err := client.CompletedTasksForListID(listID, completed)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tasks {
+			docs = append(docs, Document{Kind: KindTask, ID: t.ID, ListID: listID, TaskID: t.ID, Text: t.Title})
+		}
+
+		subtasks, err := client.CompletedSubtasksForListID(listID, completed)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range subtasks {
+			docs = append(docs, Document{Kind: KindSubtask, ID: s.ID, ListID: listID, TaskID: s.TaskID, Text: s.Title})
+		}
+	}
+
+	notes, err := client.NotesForListID(listID)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range notes {
+		docs = append(docs, Document{Kind: KindNote, ID: n.ID, ListID: listID, TaskID: n.TaskID, Text: n.Content})
+	}
+
+	comments, err := client.TaskCommentsForListID(listID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comments {
+		docs = append(docs, Document{Kind: KindComment, ID: c.ID, ListID: listID, TaskID: c.TaskID, Text: c.Text})
+	}
+
+	return docs, nil
+}
+
+// replaceList replaces the documents of the list.
+func (i *Index) replaceList(entry listEntry, docs []Document) {
+	i.removeList(entry.ID)
+	i.lists[entry.ID] = entry
+	for _, d := range docs {
+		i.add(d)
+	}
+}
+
+// removeList removes the list and its documents.
+func (i *Index) removeList(listID uint) {
+	for key, d := range i.docs {
+		if d.ListID == listID {
+			i.remove(key)
+		}
+	}
+	delete(i.lists, listID)
+}
+
+func (i *Index) add(d Document) {
+	key := d.key()
+	if _, ok := i.docs[key]; ok {
+		i.remove(key)
+	}
+	i.docs[key] = d
+
+	for _, term := range terms(d.Text) {
+		if i.postings[term] == nil {
+			i.postings[term] = map[string]int{}
+		}
+		i.postings[term][key]++
+	}
+}
+
+func (i *Index) remove(key string) {
+	d, ok := i.docs[key]
+	if !ok {
+		return
+	}
+
+	for _, term := range terms(d.Text) {
+		delete(i.postings[term], key)
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+	delete(i.docs, key)
+}
+
+type listsByID []listEntry
+
+func (l listsByID) Len() int           { return len(l) }
+func (l listsByID) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
+func (l listsByID) Less(i, j int) bool { return l[i].ID < l[j].ID }
+
+type documentsByKey []Document
+
+func (d documentsByKey) Len() int      { return len(d) }
+func (d documentsByKey) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
+func (d documentsByKey) Less(i, j int) bool {
+	if d[i].Kind != d[j].Kind {
+		return d[i].Kind < d[j].Kind
+	}
+	return d[i].ID < d[j].ID
+}
@@ -0,0 +1,169 @@
+package search_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/search"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+func newClient() *wltest.Client {
+	client := wltest.NewClient()
+	client.SetUser(wl.User{ID: 100})
+
+	client.AddList(wl.List{ID: 1, Title: "inbox", Revision: 1})
+	client.AddList(wl.List{ID: 2, Title: "Ops", Revision: 1})
+
+	client.AddTask(wl.Task{ID: 11, ListID: 1, Title: "Buy milk"})
+	client.AddTask(wl.Task{ID: 21, ListID: 2, Title: "Renew certs", Revision: 1})
+	client.AddTask(wl.Task{ID: 22, ListID: 2, Title: "Rotate certificates for staging", Completed: true})
+	client.AddSubtask(wl.Subtask{ID: 31, TaskID: 21, Title: "Check expiry of certs"})
+	client.AddNote(wl.Note{ID: 41, TaskID: 21, Content: "Use the ACME client"})
+	client.AddTaskComment(wl.TaskComment{ID: 51, TaskID: 21, Text: "Certs renewed for prod"})
+	return client
+}
+
+var _ = Describe("Index", func() {
+	var (
+		client *wltest.Client
+		index  *search.Index
+
+		fetchedListIDs []uint
+	)
+
+	BeforeEach(func() {
+		client = newClient()
+		index = search.NewIndex()
+
+		fetchedListIDs = nil
+		client.Before("CompletedTasksForListID", func(args ...interface{}) error {
+			if !args[1].(bool) {
+				fetchedListIDs = append(fetchedListIDs, args[0].(uint))
+			}
+			return nil
+		})
+	})
+
+	It("indexes tasks, completed tasks, subtasks, notes and comments", func() {
+		fetched, err := index.Sync(client)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fetched).To(Equal(2))
+		Expect(index.Len()).To(Equal(6))
+	})
+
+	Context("after syncing", func() {
+		BeforeEach(func() {
+			_, err := index.Sync(client)
+			Expect(err).NotTo(HaveOccurred())
+			fetchedListIDs = nil
+		})
+
+		It("fetches nothing if the root revision is unchanged", func() {
+			fetched, err := index.Sync(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fetched).To(BeZero())
+			Expect(fetchedListIDs).To(BeEmpty())
+		})
+
+		It("fetches only lists whose revisions have changed", func() {
+			_, err := client.UpdateTask(wl.Task{ID: 21, ListID: 2, Title: "Renew TLS", Revision: 1})
+			Expect(err).NotTo(HaveOccurred())
+			err = client.DeleteTask(wl.Task{ID: 22})
+			Expect(err).NotTo(HaveOccurred())
+
+			fetched, err := index.Sync(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fetched).To(Equal(1))
+			Expect(fetchedListIDs).To(Equal([]uint{2}))
+			Expect(index.Search("tls")).To(HaveLen(1))
+			Expect(index.Search("rotate")).To(BeEmpty())
+		})
+
+		It("removes deleted lists", func() {
+			err := client.DeleteList(wl.List{ID: 2, Revision: 1})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = index.Sync(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(index.Len()).To(Equal(1))
+			Expect(index.Search("certs")).To(BeEmpty())
+		})
+
+		It("rebuilds the index for a different user", func() {
+			client.SetUser(wl.User{ID: 200})
+
+			fetched, err := index.Sync(client)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetched).To(Equal(2))
+		})
+
+		It("does not update the root revision if syncing fails", func() {
+			client.Fail("Root", errors.New("root error"))
+
+			_, err := index.Sync(client)
+			Expect(err).To(MatchError("root error"))
+		})
+	})
+
+	Describe("saving and loading", func() {
+		var (
+			dir  string
+			path string
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "wl-search")
+			Expect(err).NotTo(HaveOccurred())
+			path = filepath.Join(dir, "nested", "index.json")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("loads an empty index if there is no file", func() {
+			loaded, err := search.Load(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded.Len()).To(BeZero())
+		})
+
+		It("loads a saved index, which is up to date with the same revisions", func() {
+			_, err := index.Sync(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = index.Save(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			loaded, err := search.Load(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded.Len()).To(Equal(index.Len()))
+			Expect(loaded.Search("certs")).To(Equal(index.Search("certs")))
+
+			fetchedListIDs = nil
+			fetched, err := loaded.Sync(client)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetched).To(BeZero())
+		})
+
+		It("returns an error for a corrupt file", func() {
+			err := os.MkdirAll(filepath.Dir(path), 0700)
+			Expect(err).NotTo(HaveOccurred())
+			err = ioutil.WriteFile(path, []byte("not json"), 0600)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = search.Load(path)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
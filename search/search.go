@@ -0,0 +1,225 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// snippetLength is the maximum number of characters in a snippet,
+	// not including ellipses and highlighting.
+	snippetLength = 80
+
+	// snippetContext is the number of characters before the first match
+	// which are included in a snippet.
+	snippetContext = 20
+
+	highlight = "**"
+	ellipsis  = "..."
+)
+
+// kindOrder is the order of documents with the same score.
+var kindOrder = map[Kind]int{
+	KindTask:    0,
+	KindSubtask: 1,
+	KindNote:    2,
+	KindComment: 3,
+}
+
+// Result is a document matching a search, with its list and task,
+// and a snippet of its text in which matching words are surrounded by **.
+type Result struct {
+	Kind    Kind   `json:"kind" yaml:"kind"`
+	ID      uint   `json:"id" yaml:"id"`
+	ListID  uint   `json:"list_id" yaml:"list_id"`
+	List    string `json:"list" yaml:"list"`
+	TaskID  uint   `json:"task_id" yaml:"task_id"`
+	Task    string `json:"task" yaml:"task"`
+	Snippet string `json:"snippet" yaml:"snippet"`
+	Score   int    `json:"score" yaml:"score"`
+}
+
+// Search returns the documents containing every word in the query, best
+// matches first. Words match case-insensitively, and match any word which
+// they are a prefix of, e.g. "cert" matches "certs" but scores lower.
+func (i *Index) Search(query string) []Result {
+	queryTerms := unique(terms(query))
+	if len(queryTerms) == 0 {
+		return []Result{}
+	}
+
+	sortedTerms := i.sortedTerms()
+
+	var scores map[string]int
+	for _, q := range queryTerms {
+		termScores := map[string]int{}
+
+		start := sort.SearchStrings(sortedTerms, q)
+		for _, t := range sortedTerms[start:] {
+			if !strings.HasPrefix(t, q) {
+				break
+			}
+
+			weight := 1
+			if t == q {
+				weight = 2
+			}
+			for key, count := range i.postings[t] {
+				termScores[key] += weight * count
+			}
+		}
+
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for key := range scores {
+			if termScores[key] == 0 {
+				delete(scores, key)
+			} else {
+				scores[key] += termScores[key]
+			}
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for key, score := range scores {
+		d := i.docs[key]
+		results = append(results, Result{
+			Kind:    d.Kind,
+			ID:      d.ID,
+			ListID:  d.ListID,
+			List:    i.lists[d.ListID].Title,
+			TaskID:  d.TaskID,
+			Task:    i.docs[Document{Kind: KindTask, ID: d.TaskID}.key()].Text,
+			Snippet: snippet(d.Text, queryTerms),
+			Score:   score,
+		})
+	}
+	sort.Sort(byScore(results))
+	return results
+}
+
+// sortedTerms returns the indexed terms in order.
+func (i *Index) sortedTerms() []string {
+	sorted := make([]string, 0, len(i.postings))
+	for t := range i.postings {
+		sorted = append(sorted, t)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// terms returns the lower case words in text, including duplicates.
+func terms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordRune(r)
+	})
+}
+
+func unique(terms []string) []string {
+	seen := map[string]bool{}
+	var u []string
+	for _, t := range terms {
+		if !seen[t] {
+			seen[t] = true
+			u = append(u, t)
+		}
+	}
+	return u
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// snippet returns up to snippetLength characters of text around the first
+// word matching the query terms, with matching words highlighted.
+func snippet(text string, queryTerms []string) string {
+	r := []rune(strings.Join(strings.Fields(text), " "))
+
+	type span struct{ start, end int }
+	var matches []span
+
+	for s := 0; s < len(r); {
+		if !isWordRune(r[s]) {
+			s++
+			continue
+		}
+
+		e := s
+		for e < len(r) && isWordRune(r[e]) {
+			e++
+		}
+
+		word := strings.ToLower(string(r[s:e]))
+		for _, q := range queryTerms {
+			if strings.HasPrefix(word, q) {
+				matches = append(matches, span{s, e})
+				break
+			}
+		}
+		s = e
+	}
+
+	start := 0
+	if len(matches) > 0 && matches[0].start > snippetContext {
+		start = matches[0].start - snippetContext
+		for start < matches[0].start && r[start-1] != ' ' {
+			start++
+		}
+	}
+
+	end := len(r)
+	if end-start > snippetLength {
+		end = start + snippetLength
+		for end > start && r[end] != ' ' {
+			end--
+		}
+		if end == start {
+			end = start + snippetLength
+		}
+	}
+
+	var b []rune
+	if start > 0 {
+		b = append(b, []rune(ellipsis)...)
+	}
+
+	pos := start
+	for _, m := range matches {
+		if m.start < start || m.start >= end {
+			continue
+		}
+		matchEnd := m.end
+		if matchEnd > end {
+			matchEnd = end
+		}
+		b = append(b, r[pos:m.start]...)
+		b = append(b, []rune(highlight)...)
+		b = append(b, r[m.start:matchEnd]...)
+		b = append(b, []rune(highlight)...)
+		pos = matchEnd
+	}
+	b = append(b, r[pos:end]...)
+
+	if end < len(r) {
+		b = append(b, []rune(ellipsis)...)
+	}
+	return string(b)
+}
+
+type byScore []Result
+
+func (r byScore) Len() int      { return len(r) }
+func (r byScore) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r byScore) Less(i, j int) bool {
+	if r[i].Score != r[j].Score {
+		return r[i].Score > r[j].Score
+	}
+	if r[i].Kind != r[j].Kind {
+		return kindOrder[r[i].Kind] < kindOrder[r[j].Kind]
+	}
+	return r[i].ID < r[j].ID
+}
@@ -0,0 +1,68 @@
+package search_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl/search"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+var _ = Describe("Search", func() {
+	var (
+		client *wltest.Client
+		index  *search.Index
+	)
+
+	BeforeEach(func() {
+		client = newClient()
+		index = search.NewIndex()
+		_, err := index.Sync(client)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns matches with their list and task, best first", func() {
+		Expect(index.Search("certs")).To(Equal([]search.Result{
+			{Kind: search.KindTask, ID: 21, ListID: 2, List: "Ops", TaskID: 21, Task: "Renew certs", Snippet: "Renew **certs**", Score: 2},
+			{Kind: search.KindSubtask, ID: 31, ListID: 2, List: "Ops", TaskID: 21, Task: "Renew certs", Snippet: "Check expiry of **certs**", Score: 2},
+			{Kind: search.KindComment, ID: 51, ListID: 2, List: "Ops", TaskID: 21, Task: "Renew certs", Snippet: "**Certs** renewed for prod", Score: 2},
+		}))
+	})
+
+	It("matches words by prefix, scoring exact matches higher", func() {
+		Expect(index.Search("cert")).To(HaveLen(4))
+
+		results := index.Search("renew")
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].Snippet).To(Equal("**Renew** certs"))
+		Expect(results[0].Score).To(Equal(2))
+		Expect(results[1].Snippet).To(Equal("Certs **renewed** for prod"))
+		Expect(results[1].Score).To(Equal(1))
+	})
+
+	It("requires every word to match", func() {
+		results := index.Search("RENEW cert")
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].ID).To(Equal(uint(21)))
+		Expect(results[1].ID).To(Equal(uint(51)))
+	})
+
+	It("returns no results for unknown words or an empty query", func() {
+		Expect(index.Search("certs banana")).To(BeEmpty())
+		Expect(index.Search("  ")).To(BeEmpty())
+	})
+
+	It("truncates long text around the first match", func() {
+		long := strings.Repeat("lorem ipsum ", 10) + "deploy the acme thing " + strings.Repeat("dolor sit ", 10)
+		_, err := client.CreateNote(long, 11)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = index.Sync(client)
+		Expect(err).NotTo(HaveOccurred())
+
+		results := index.Search("acme thing")
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Snippet).To(Equal("...ipsum deploy the **acme** **thing** dolor sit dolor sit dolor sit dolor sit dolor sit..."))
+	})
+})
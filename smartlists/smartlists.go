@@ -0,0 +1,159 @@
+/*
+Package smartlists computes the smart lists shown by the Wunderlist apps
+(Today, Week, Starred, Assigned to me and Completed), which are not exposed
+by the API.
+
+Due dates are calendar dates, so they are compared with the calendar day
+of the provided time in its own location, rather than as instants.
+*/
+package smartlists
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/position"
+)
+
+const (
+	// Today contains uncompleted tasks that are due today or overdue.
+	Today = "today"
+
+	// Week contains uncompleted tasks that are overdue
+	// or due within the next seven days, including today.
+	Week = "week"
+
+	// Starred contains uncompleted starred tasks.
+	Starred = "starred"
+
+	// Assigned contains uncompleted tasks assigned to the current user.
+	Assigned = "assigned"
+
+	// Completed contains completed tasks, most recently completed first.
+	Completed = "completed"
+
+	weekDays = 7
+)
+
+// Names returns the names of all smart lists.
+func Names() []string {
+	return []string{Today, Week, Starred, Assigned, Completed}
+}
+
+// Build fetches the tasks, positions and (if required) the current user
+// via the provided client, and returns the tasks in the named smart list
+// as of now.
+//
+// Tasks are ordered as they are in the apps: by the position of their list,
+// with the inbox first, and then by their position within that list.
+func Build(client wl.Client, name string, now time.Time) ([]wl.Task, error) {
+	if !valid(name) {
+		return nil, fmt.Errorf("unrecognized smart list: %s", name)
+	}
+
+	var userID uint
+	if name == Assigned {
+		user, err := client.User()
+		if err != nil {
+			return nil, err
+		}
+		userID = user.ID
+	}
+
+	tasks, err := client.CompletedTasks(name == Completed)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err = Filter(name, tasks, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == Completed {
+		sort.Sort(byCompletedAt(tasks))
+		return tasks, nil
+	}
+
+	lists, err := client.Lists()
+	if err != nil {
+		return nil, err
+	}
+
+	listPositions, err := client.ListPositions()
+	if err != nil {
+		return nil, err
+	}
+
+	taskPositions, err := client.TaskPositions()
+	if err != nil {
+		return nil, err
+	}
+
+	return position.OrderTasksByList(tasks, lists, listPositions, taskPositions), nil
+}
+
+// Filter returns the tasks which belong in the named smart list as of now.
+// The userID is only used by the Assigned smart list.
+// The order of the tasks is preserved.
+func Filter(name string, tasks []wl.Task, userID uint, now time.Time) ([]wl.Task, error) {
+	var match func(t wl.Task) bool
+
+	today := wl.DateOf(now)
+
+	switch name {
+	case Today:
+		match = func(t wl.Task) bool {
+			return !t.Completed && dueBefore(t, today.AddDays(1))
+		}
+	case Week:
+		match = func(t wl.Task) bool {
+			return !t.Completed && dueBefore(t, today.AddDays(weekDays))
+		}
+	case Starred:
+		match = func(t wl.Task) bool {
+			return !t.Completed && t.Starred
+		}
+	case Assigned:
+		match = func(t wl.Task) bool {
+			return !t.Completed && userID != 0 && t.AssigneeID == userID
+		}
+	case Completed:
+		match = func(t wl.Task) bool {
+			return t.Completed
+		}
+	default:
+		return nil, fmt.Errorf("unrecognized smart list: %s", name)
+	}
+
+	filtered := []wl.Task{}
+	for _, t := range tasks {
+		if match(t) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered, nil
+}
+
+// dueBefore returns true if the task has a due date before end.
+func dueBefore(t wl.Task, end wl.Date) bool {
+	return !t.DueDate.IsZero() && t.DueDate.Before(end)
+}
+
+func valid(name string) bool {
+	for _, n := range Names() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// byCompletedAt sorts tasks by completion time, most recent first.
+type byCompletedAt []wl.Task
+
+func (s byCompletedAt) Len() int           { return len(s) }
+func (s byCompletedAt) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byCompletedAt) Less(i, j int) bool { return s[i].CompletedAt.After(s[j].CompletedAt) }
@@ -0,0 +1,13 @@
+package smartlists_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSmartlists(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Smartlists Suite")
+}
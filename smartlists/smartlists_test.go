@@ -0,0 +1,118 @@
+package smartlists_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/smartlists"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+func ids(tasks []wl.Task) []uint {
+	ids := []uint{}
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+var _ = Describe("Smart lists", func() {
+	var (
+		now   time.Time
+		tasks []wl.Task
+	)
+
+	BeforeEach(func() {
+		// Late in the evening west of UTC, when it is already
+		// the next day in UTC
+		now = time.Date(2016, time.January, 4, 22, 0, 0, 0, time.FixedZone("test", -8*60*60))
+
+		tasks = []wl.Task{
+			{ID: 1, DueDate: wl.NewDate(2016, time.January, 3)},
+			{ID: 2, DueDate: wl.NewDate(2016, time.January, 4), Starred: true},
+			{ID: 3, DueDate: wl.NewDate(2016, time.January, 5), AssigneeID: 10},
+			{ID: 4, DueDate: wl.NewDate(2016, time.January, 10)},
+			{ID: 5, DueDate: wl.NewDate(2016, time.January, 11)},
+			{ID: 6, Starred: true, AssigneeID: 10},
+			{ID: 7, Completed: true, Starred: true, AssigneeID: 10},
+		}
+	})
+
+	Describe("Filter", func() {
+		It("returns overdue tasks and tasks due today for today", func() {
+			filtered, err := smartlists.Filter(smartlists.Today, tasks, 10, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(filtered)).To(Equal([]uint{1, 2}))
+		})
+
+		It("returns overdue tasks and tasks due in the next seven days for week", func() {
+			filtered, err := smartlists.Filter(smartlists.Week, tasks, 10, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(filtered)).To(Equal([]uint{1, 2, 3, 4}))
+		})
+
+		It("returns uncompleted starred tasks for starred", func() {
+			filtered, err := smartlists.Filter(smartlists.Starred, tasks, 10, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(filtered)).To(Equal([]uint{2, 6}))
+		})
+
+		It("returns uncompleted tasks assigned to the user for assigned", func() {
+			filtered, err := smartlists.Filter(smartlists.Assigned, tasks, 10, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(filtered)).To(Equal([]uint{3, 6}))
+		})
+
+		It("returns completed tasks for completed", func() {
+			filtered, err := smartlists.Filter(smartlists.Completed, tasks, 10, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(filtered)).To(Equal([]uint{7}))
+		})
+
+		Context("when the smart list is not recognized", func() {
+			It("returns an error", func() {
+				_, err := smartlists.Filter("someday", tasks, 10, now)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Build", func() {
+		var client *wltest.Client
+
+		BeforeEach(func() {
+			client = wltest.NewClient()
+			client.SetUser(wl.User{ID: 10})
+			client.AddList(wl.List{ID: 100})
+			client.AddList(wl.List{ID: 200})
+			client.SetListPosition(200, 100)
+
+			client.AddTask(wl.Task{ID: 1, ListID: 100, AssigneeID: 10})
+			client.AddTask(wl.Task{ID: 2, ListID: 200, AssigneeID: 10})
+			client.AddTask(wl.Task{ID: 3, ListID: 200})
+			client.AddTask(wl.Task{ID: 4, ListID: 100, Completed: true, CompletedAt: now.Add(-2 * time.Hour)})
+			client.AddTask(wl.Task{ID: 5, ListID: 200, Completed: true, CompletedAt: now.Add(-1 * time.Hour)})
+		})
+
+		It("returns ordered tasks for the current user", func() {
+			tasks, err := smartlists.Build(client, smartlists.Assigned, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(Equal([]uint{2, 1}))
+		})
+
+		It("returns completed tasks most recently completed first", func() {
+			tasks, err := smartlists.Build(client, smartlists.Completed, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(Equal([]uint{5, 4}))
+		})
+
+		Context("when the smart list is not recognized", func() {
+			It("returns an error", func() {
+				_, err := smartlists.Build(client, "someday", now)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,36 @@
+package wl
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// StatusError is returned by a Client when the API responds with an
+// unexpected HTTP status code. Errors from the transport, such as a failure
+// to connect, are never StatusErrors.
+type StatusError struct {
+	StatusCode int
+	Expected   int
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("Unexpected response code %d - expected %d", e.StatusCode, e.Expected)
+}
+
+// IsStatus returns true if the error is a StatusError with the status code.
+func IsStatus(err error, statusCode int) bool {
+	e, ok := err.(StatusError)
+	return ok && e.StatusCode == statusCode
+}
+
+// IsNotFound returns true if the API responded that the resource
+// does not exist.
+func IsNotFound(err error) bool {
+	return IsStatus(err, http.StatusNotFound)
+}
+
+// IsConflict returns true if the API rejected an update because the
+// revision provided was out of date.
+func IsConflict(err error) bool {
+	return IsStatus(err, http.StatusConflict)
+}
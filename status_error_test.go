@@ -0,0 +1,30 @@
+package wl_test
+
+import (
+	"errors"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+)
+
+var _ = Describe("StatusError", func() {
+	It("detects missing objects and revision conflicts by status code", func() {
+		notFound := wl.StatusError{StatusCode: http.StatusNotFound, Expected: http.StatusOK}
+		conflict := wl.StatusError{StatusCode: http.StatusConflict, Expected: http.StatusOK}
+
+		Expect(notFound).To(MatchError("Unexpected response code 404 - expected 200"))
+		Expect(wl.IsNotFound(notFound)).To(BeTrue())
+		Expect(wl.IsConflict(notFound)).To(BeFalse())
+		Expect(wl.IsConflict(conflict)).To(BeTrue())
+		Expect(wl.IsNotFound(conflict)).To(BeFalse())
+	})
+
+	It("does not treat other errors as status errors", func() {
+		err := errors.New("Unexpected response code 404 - expected 200")
+
+		Expect(wl.IsNotFound(err)).To(BeFalse())
+		Expect(wl.IsStatus(nil, http.StatusNotFound)).To(BeFalse())
+	})
+})
@@ -0,0 +1,115 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Format renders a field value in full, e.g. for CSV.
+// Times are rendered in the location.
+func Format(v interface{}, loc *time.Location) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.In(loc).Format(time.RFC3339)
+	case []uint:
+		s := make([]string, len(v))
+		for i, id := range v {
+			s[i] = strconv.FormatUint(uint64(id), 10)
+		}
+		return strings.Join(s, ",")
+	case string:
+		return v
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice {
+		return fmt.Sprintf("%d items", rv.Len())
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// humanize renders a field value for display in a table.
+// Times are rendered relative to now, and newlines are collapsed.
+func humanize(v interface{}, now time.Time) string {
+	if t, ok := v.(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return RelativeTime(t, now)
+	}
+	return strings.Replace(Format(v, now.Location()), "\n", " ", -1)
+}
+
+// RelativeTime renders the time relative to now, e.g. 3 hours ago.
+func RelativeTime(t time.Time, now time.Time) string {
+	d := now.Sub(t)
+	suffix := "ago"
+	if d < 0 {
+		d = -d
+		suffix = "from now"
+	}
+
+	var amount int
+	var unit string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		amount, unit = int(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		amount, unit = int(d/time.Hour), "hour"
+	case d < 30*24*time.Hour:
+		amount, unit = int(d/(24*time.Hour)), "day"
+	case d < 365*24*time.Hour:
+		amount, unit = int(d/(30*24*time.Hour)), "month"
+	default:
+		amount, unit = int(d/(365*24*time.Hour)), "year"
+	}
+
+	if amount != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s %s", amount, unit, suffix)
+}
+
+// FitWidths shrinks the widest columns until the total width fits within max,
+// without shrinking any column below a minimum width.
+func FitWidths(widths []int, max int) {
+	for {
+		total, widest := 0, 0
+		for i, w := range widths {
+			total += w
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+
+		if total <= max || widths[widest] <= minColumnWidth {
+			return
+		}
+
+		widths[widest] -= total - max
+		if widths[widest] < minColumnWidth {
+			widths[widest] = minColumnWidth
+		}
+	}
+}
+
+// Truncate shortens the string to the width, ending it with an ellipsis
+// if it is too long.
+func Truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,67 @@
+package table_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl/table"
+)
+
+var _ = Describe("Format", func() {
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+	DescribeTable("Format",
+		func(v interface{}, expected string) {
+			Expect(table.Format(v, time.FixedZone("test", -5*60*60))).To(Equal(expected))
+		},
+		Entry("nil", nil, ""),
+		Entry("string", "Groceries", "Groceries"),
+		Entry("number", uint(12), "12"),
+		Entry("bool", true, "true"),
+		Entry("IDs", []uint{1, 2, 3}, "1,2,3"),
+		Entry("other slices", []string{"a", "b"}, "2 items"),
+		Entry("zero time", time.Time{}, ""),
+		Entry("time in the location", now, "2026-10-18T07:00:00-05:00"),
+	)
+
+	DescribeTable("RelativeTime",
+		func(d time.Duration, expected string) {
+			Expect(table.RelativeTime(now.Add(-d), now)).To(Equal(expected))
+		},
+		Entry("seconds ago", 30*time.Second, "just now"),
+		Entry("seconds from now", -30*time.Second, "just now"),
+		Entry("a minute ago", time.Minute, "1 minute ago"),
+		Entry("minutes ago", 59*time.Minute, "59 minutes ago"),
+		Entry("hours ago", 3*time.Hour, "3 hours ago"),
+		Entry("hours from now", -2*time.Hour, "2 hours from now"),
+		Entry("a day ago", 24*time.Hour, "1 day ago"),
+		Entry("days ago", 29*24*time.Hour, "29 days ago"),
+		Entry("months ago", 60*24*time.Hour, "2 months ago"),
+		Entry("a year from now", -400*24*time.Hour, "1 year from now"),
+		Entry("years ago", 3*365*24*time.Hour, "3 years ago"),
+	)
+
+	DescribeTable("FitWidths",
+		func(widths []int, max int, expected []int) {
+			table.FitWidths(widths, max)
+			Expect(widths).To(Equal(expected))
+		},
+		Entry("fits", []int{10, 20}, 30, []int{10, 20}),
+		Entry("shrinks the widest column", []int{10, 40, 20}, 50, []int{10, 20, 20}),
+		Entry("shrinks the next widest column", []int{10, 30, 29}, 40, []int{10, 8, 22}),
+		Entry("does not shrink below the minimum", []int{10, 20}, 12, []int{8, 8}),
+		Entry("leaves narrow columns that cannot fit", []int{5, 6}, 4, []int{5, 6}),
+	)
+
+	DescribeTable("Truncate",
+		func(s string, width int, expected string) {
+			Expect(table.Truncate(s, width)).To(Equal(expected))
+		},
+		Entry("short", "Groceries", 10, "Groceries"),
+		Entry("exact", "Groceries", 9, "Groceries"),
+		Entry("long", "Groceries", 8, "Groceri…"),
+		Entry("multibyte", "Café au lait", 5, "Café…"),
+	)
+})
@@ -0,0 +1,145 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/robdimsdale/wl"
+)
+
+// defaultColumns are the columns of tables created without explicit columns.
+// Types without an entry have all columns.
+var defaultColumns = map[reflect.Type][]string{
+	reflect.TypeOf(wl.Task{}):        {"id", "title", "list_id", "due_date", "starred", "completed", "assignee_id"},
+	reflect.TypeOf(wl.List{}):        {"id", "title", "list_type", "created_at"},
+	reflect.TypeOf(wl.Subtask{}):     {"id", "task_id", "title", "completed"},
+	reflect.TypeOf(wl.Note{}):        {"id", "task_id", "content"},
+	reflect.TypeOf(wl.Reminder{}):    {"id", "task_id", "date"},
+	reflect.TypeOf(wl.Folder{}):      {"id", "title", "list_ids"},
+	reflect.TypeOf(wl.User{}):        {"id", "name", "email"},
+	reflect.TypeOf(wl.Membership{}):  {"id", "user_id", "list_id", "state", "owner"},
+	reflect.TypeOf(wl.Webhook{}):     {"id", "list_id", "url", "processor_type"},
+	reflect.TypeOf(wl.File{}):        {"id", "task_id", "file_name", "content_type", "file_size"},
+	reflect.TypeOf(wl.TaskComment{}): {"id", "task_id", "text", "created_at"},
+}
+
+// Table is the tabular representation of some output.
+// Each row maps column names to field values.
+type Table struct {
+	Columns []string
+	Rows    []map[string]interface{}
+
+	// Available are all the columns of the rows, of which Columns is a subset.
+	Available []string
+}
+
+// New converts the output into a table with a row for each item.
+// Struct fields are named by their yaml tags, and other values have a single
+// column named value. If columns is nil, the default columns for the type
+// are used; if it is empty, all columns are used.
+func New(output interface{}, columns []string) (Table, error) {
+	t := Table{}
+	var itemType reflect.Type
+
+	for _, item := range Items(output) {
+		v := reflect.ValueOf(item)
+		for v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem()
+		}
+
+		row := map[string]interface{}{}
+		if v.Kind() != reflect.Struct {
+			if t.Available == nil {
+				t.Available = []string{"value"}
+			}
+			row["value"] = item
+			t.Rows = append(t.Rows, row)
+			continue
+		}
+
+		if t.Available == nil {
+			itemType = v.Type()
+			t.Available = fieldNames(v.Type())
+		}
+
+		for i := 0; i < v.NumField(); i++ {
+			name := fieldName(v.Type().Field(i))
+			if name != "" {
+				row[name] = v.Field(i).Interface()
+			}
+		}
+		t.Rows = append(t.Rows, row)
+	}
+
+	if columns == nil {
+		columns = defaultColumns[itemType]
+	}
+
+	t.Columns = t.Available
+	if len(columns) > 0 {
+		for _, c := range columns {
+			if len(t.Rows) > 0 && !t.has(c) {
+				return Table{}, fmt.Errorf("unknown column: %s - available columns are %s", c, strings.Join(t.Available, ", "))
+			}
+		}
+		t.Columns = columns
+	}
+
+	return t, nil
+}
+
+func (t Table) has(column string) bool {
+	for _, c := range t.Available {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// Items returns the elements of the output if it is a slice,
+// or the output itself otherwise.
+func Items(output interface{}) []interface{} {
+	v := reflect.ValueOf(output)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return []interface{}{output}
+	}
+
+	items := make([]interface{}, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		items[i] = v.Index(i).Interface()
+	}
+	return items
+}
+
+func fieldNames(t reflect.Type) []string {
+	names := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		if name := fieldName(t.Field(i)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func fieldName(f reflect.StructField) string {
+	if f.PkgPath != "" {
+		// unexported
+		return ""
+	}
+
+	tag := strings.Split(f.Tag.Get("yaml"), ",")[0]
+	switch tag {
+	case "-":
+		return ""
+	case "":
+		return strings.ToLower(f.Name)
+	default:
+		return tag
+	}
+}
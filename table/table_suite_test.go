@@ -0,0 +1,13 @@
+package table_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Table Suite")
+}
@@ -0,0 +1,87 @@
+package table_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/table"
+)
+
+var _ = Describe("Table", func() {
+	type item struct {
+		ID       uint   `yaml:"id"`
+		Title    string `yaml:"title,omitempty"`
+		Count    int
+		Internal string `yaml:"-"`
+		hidden   string
+	}
+
+	items := []item{
+		{ID: 1, Title: "Groceries", Count: 2},
+		{ID: 2, Title: "Chores", Count: 3},
+	}
+
+	Describe("New", func() {
+		It("names columns by their yaml tags, skipping unexported and omitted fields", func() {
+			t, err := table.New(items, []string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.Available).To(Equal([]string{"id", "title", "count"}))
+			Expect(t.Columns).To(Equal(t.Available))
+			Expect(t.Rows).To(Equal([]map[string]interface{}{
+				{"id": uint(1), "title": "Groceries", "count": 2},
+				{"id": uint(2), "title": "Chores", "count": 3},
+			}))
+		})
+
+		It("uses the default columns for the type", func() {
+			t, err := table.New([]wl.List{{ID: 1}}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.Columns).To(Equal([]string{"id", "title", "list_type", "created_at"}))
+		})
+
+		It("uses all columns for types without defaults", func() {
+			t, err := table.New(items, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.Columns).To(Equal([]string{"id", "title", "count"}))
+		})
+
+		It("uses the provided columns", func() {
+			t, err := table.New(&items[0], []string{"title", "id"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.Columns).To(Equal([]string{"title", "id"}))
+			Expect(t.Rows).To(HaveLen(1))
+		})
+
+		It("rejects unknown columns", func() {
+			_, err := table.New(items, []string{"id", "name"})
+			Expect(err).To(MatchError("unknown column: name - available columns are id, title, count"))
+		})
+
+		It("has a value column for other values", func() {
+			t, err := table.New([]string{"#home", "#work"}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.Columns).To(Equal([]string{"value"}))
+			Expect(t.Rows).To(Equal([]map[string]interface{}{
+				{"value": "#home"},
+				{"value": "#work"},
+			}))
+		})
+
+		It("accepts any columns when there are no rows", func() {
+			t, err := table.New([]item{}, []string{"name"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.Columns).To(Equal([]string{"name"}))
+			Expect(t.Rows).To(BeEmpty())
+		})
+	})
+
+	Describe("Items", func() {
+		It("returns the elements of slices", func() {
+			Expect(table.Items(&items)).To(Equal([]interface{}{items[0], items[1]}))
+		})
+
+		It("returns other values as a single item", func() {
+			Expect(table.Items(items[0])).To(Equal([]interface{}{items[0]}))
+		})
+	})
+})
@@ -0,0 +1,182 @@
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/robdimsdale/wl"
+)
+
+const (
+	minColumnWidth  = 8
+	columnSeparator = "  "
+)
+
+// userIDColumns are resolved to user names.
+var userIDColumns = map[string]bool{
+	"assignee_id":   true,
+	"assigner_id":   true,
+	"created_by_id": true,
+	"completed_by":  true,
+	"user_id":       true,
+}
+
+// Options configure how a table is written.
+type Options struct {
+	// Width is the maximum width of each line, or zero for no maximum.
+	Width int
+
+	// Now is the time relative to which times are rendered.
+	Now time.Time
+
+	// Client is used to resolve list and user IDs to names.
+	// IDs are left as-is if it is nil.
+	Client wl.Client
+}
+
+// Write renders the table with aligned columns, truncating the widest
+// columns so that each line fits within the width.
+func Write(w io.Writer, t Table, opts Options) error {
+	resolve := nameResolver(opts.Client, t.Columns)
+
+	cells := make([][]string, len(t.Rows)+1)
+	cells[0] = make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		cells[0][i] = strings.ToUpper(c)
+	}
+
+	for r, row := range t.Rows {
+		cells[r+1] = make([]string, len(t.Columns))
+		for i, c := range t.Columns {
+			cells[r+1][i] = resolve(c, humanize(row[c], opts.Now))
+		}
+	}
+
+	widths := make([]int, len(t.Columns))
+	for _, line := range cells {
+		for i, cell := range line {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	if opts.Width > 0 {
+		FitWidths(widths, opts.Width-len(columnSeparator)*(len(widths)-1))
+	}
+
+	var b bytes.Buffer
+	for _, line := range cells {
+		for i, cell := range line {
+			cell = Truncate(cell, widths[i])
+			if i == len(line)-1 {
+				b.WriteString(cell)
+				break
+			}
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+			b.WriteString(columnSeparator)
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := b.WriteTo(w)
+	return err
+}
+
+// WriteCSV renders the table as CSV with a header line.
+// Times are rendered in the location.
+func WriteCSV(w io.Writer, t Table, loc *time.Location) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(t.Columns); err != nil {
+		return err
+	}
+
+	for _, row := range t.Rows {
+		record := make([]string, len(t.Columns))
+		for i, c := range t.Columns {
+			record[i] = Format(row[c], loc)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteIDs renders the id of each row on its own line.
+// It returns an error if the rows have no id column.
+func WriteIDs(w io.Writer, t Table) error {
+	if len(t.Rows) > 0 && !t.has("id") {
+		return errors.New("output has no id column")
+	}
+
+	var b bytes.Buffer
+	for _, row := range t.Rows {
+		fmt.Fprintf(&b, "%v\n", row["id"])
+	}
+
+	_, err := b.WriteTo(w)
+	return err
+}
+
+// nameResolver returns a function which resolves list and user IDs
+// in the provided columns to names. IDs are left as-is if the client is nil
+// or the names cannot be fetched.
+func nameResolver(client wl.Client, columns []string) func(column string, value string) string {
+	needLists, needUsers := false, false
+	for _, c := range columns {
+		needLists = needLists || c == "list_id"
+		needUsers = needUsers || userIDColumns[c]
+	}
+
+	names := map[string]map[string]string{
+		"list": {},
+		"user": {},
+	}
+
+	if client != nil && needLists {
+		if lists, err := client.Lists(); err == nil {
+			for _, l := range lists {
+				names["list"][strconv.FormatUint(uint64(l.ID), 10)] = l.Title
+			}
+		}
+	}
+
+	if client != nil && needUsers {
+		if users, err := client.Users(); err == nil {
+			for _, u := range users {
+				names["user"][strconv.FormatUint(uint64(u.ID), 10)] = u.Name
+			}
+		}
+	}
+
+	return func(column string, value string) string {
+		kind := ""
+		switch {
+		case column == "list_id":
+			kind = "list"
+		case userIDColumns[column]:
+			kind = "user"
+		default:
+			return value
+		}
+
+		if value == "0" {
+			return ""
+		}
+		if name, ok := names[kind][value]; ok && name != "" {
+			return name
+		}
+		return value
+	}
+}
@@ -0,0 +1,112 @@
+package table_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/table"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+var _ = Describe("Write", func() {
+	var (
+		buf   *bytes.Buffer
+		now   time.Time
+		tasks []wl.Task
+	)
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		now = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+		tasks = []wl.Task{
+			{ID: 11, Title: "Buy milk", ListID: 1, AssigneeID: 5, CreatedAt: now.Add(-3 * time.Hour)},
+			{ID: 12, Title: "Take out\nthe bins", ListID: 2, CreatedAt: now.Add(-48 * time.Hour)},
+		}
+	})
+
+	write := func(columns []string, opts table.Options) string {
+		t, err := table.New(tasks, columns)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(table.Write(buf, t, opts)).To(Succeed())
+		return buf.String()
+	}
+
+	It("aligns columns and renders times relative to now", func() {
+		Expect(write([]string{"id", "title", "created_at"}, table.Options{Now: now})).To(Equal(
+			"ID  TITLE              CREATED_AT\n" +
+				"11  Buy milk           3 hours ago\n" +
+				"12  Take out the bins  2 days ago\n",
+		))
+	})
+
+	It("truncates the widest columns to fit the width", func() {
+		Expect(write([]string{"id", "title", "created_at"}, table.Options{Now: now, Width: 30})).To(Equal(
+			"ID  TITLE          CREATED_AT\n" +
+				"11  Buy milk       3 hours ago\n" +
+				"12  Take out the…  2 days ago\n",
+		))
+	})
+
+	It("resolves list and user IDs to names with the client", func() {
+		client := wltest.NewClient()
+		client.AddList(wl.List{ID: 1, Title: "Groceries"})
+		client.AddUser(wl.User{ID: 5, Name: "Alice"})
+
+		Expect(write([]string{"id", "list_id", "assignee_id"}, table.Options{Client: client})).To(Equal(
+			"ID  LIST_ID    ASSIGNEE_ID\n" +
+				"11  Groceries  Alice\n" +
+				"12  2          \n",
+		))
+	})
+
+	It("leaves IDs as-is without a client", func() {
+		Expect(write([]string{"id", "list_id", "assignee_id"}, table.Options{})).To(Equal(
+			"ID  LIST_ID  ASSIGNEE_ID\n" +
+				"11  1        5\n" +
+				"12  2        \n",
+		))
+	})
+
+	Describe("WriteCSV", func() {
+		It("renders values in full with times in the location", func() {
+			t, err := table.New(tasks, []string{"id", "title", "created_at"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(table.WriteCSV(buf, t, time.FixedZone("test", -5*60*60))).To(Succeed())
+			Expect(buf.String()).To(Equal(
+				"id,title,created_at\n" +
+					"11,Buy milk,2026-10-18T04:00:00-05:00\n" +
+					"12,\"Take out\nthe bins\",2026-10-16T07:00:00-05:00\n",
+			))
+		})
+	})
+
+	Describe("WriteIDs", func() {
+		It("renders the ID of each row", func() {
+			t, err := table.New(tasks, []string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(table.WriteIDs(buf, t)).To(Succeed())
+			Expect(buf.String()).To(Equal("11\n12\n"))
+		})
+
+		It("returns an error if there is no id column", func() {
+			t, err := table.New(struct{ Total int }{Total: 3}, []string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(table.WriteIDs(buf, t)).To(MatchError("output has no id column"))
+			Expect(buf.String()).To(BeEmpty())
+		})
+
+		It("renders nothing if there are no rows", func() {
+			t, err := table.New([]wl.Task{}, []string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(table.WriteIDs(buf, t)).To(Succeed())
+			Expect(buf.String()).To(BeEmpty())
+		})
+	})
+})
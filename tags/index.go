@@ -0,0 +1,129 @@
+package tags
+
+import (
+	"sort"
+
+	"github.com/robdimsdale/wl"
+)
+
+// Count describes how often a tag is used.
+type Count struct {
+	Tag      string `json:"tag" yaml:"tag"`
+	Count    int    `json:"count" yaml:"count"`
+	Tasks    int    `json:"tasks" yaml:"tasks"`
+	Subtasks int    `json:"subtasks" yaml:"subtasks"`
+	Notes    int    `json:"notes" yaml:"notes"`
+	ListIDs  []uint `json:"list_ids" yaml:"list_ids"`
+}
+
+// Index maps tags to the tasks in which they appear,
+// either in the task title or in one of its subtasks or notes.
+type Index struct {
+	counts  map[string]*Count
+	lists   map[string]map[uint]bool
+	taskIDs map[string]map[uint]bool
+}
+
+// NewIndex returns an Index of the tags in the tasks, subtasks and notes.
+// Subtasks and notes of tasks which are not provided are counted,
+// but are not associated with a list.
+func NewIndex(tasks []wl.Task, subtasks []wl.Subtask, notes []wl.Note) *Index {
+	i := &Index{
+		counts:  map[string]*Count{},
+		lists:   map[string]map[uint]bool{},
+		taskIDs: map[string]map[uint]bool{},
+	}
+
+	listIDs := make(map[uint]uint, len(tasks))
+	for _, t := range tasks {
+		listIDs[t.ID] = t.ListID
+	}
+
+	for _, t := range tasks {
+		for _, tag := range Extract(t.Title) {
+			i.add(tag, t.ID, listIDs).Tasks++
+		}
+	}
+
+	for _, s := range subtasks {
+		for _, tag := range Extract(s.Title) {
+			i.add(tag, s.TaskID, listIDs).Subtasks++
+		}
+	}
+
+	for _, n := range notes {
+		for _, tag := range Extract(n.Content) {
+			i.add(tag, n.TaskID, listIDs).Notes++
+		}
+	}
+
+	return i
+}
+
+func (i *Index) add(tag string, taskID uint, listIDs map[uint]uint) *Count {
+	c, ok := i.counts[tag]
+	if !ok {
+		c = &Count{Tag: tag}
+		i.counts[tag] = c
+		i.lists[tag] = map[uint]bool{}
+		i.taskIDs[tag] = map[uint]bool{}
+	}
+	c.Count++
+
+	i.taskIDs[tag][taskID] = true
+	if listID, ok := listIDs[taskID]; ok && !i.lists[tag][listID] {
+		i.lists[tag][listID] = true
+		c.ListIDs = append(c.ListIDs, listID)
+	}
+
+	return c
+}
+
+// Counts returns the count of each tag, most used first.
+func (i *Index) Counts() []Count {
+	counts := make([]Count, 0, len(i.counts))
+	for _, c := range i.counts {
+		listIDs := append([]uint{}, c.ListIDs...)
+		sort.Sort(byID(listIDs))
+
+		count := *c
+		count.ListIDs = listIDs
+		counts = append(counts, count)
+	}
+	sort.Sort(byCount(counts))
+	return counts
+}
+
+// HasTask returns true if the tag appears in the task,
+// its subtasks or its notes.
+func (i *Index) HasTask(taskID uint, tag string) bool {
+	return i.taskIDs[Normalize(tag)][taskID]
+}
+
+// Tasks returns the tasks in which the tag appears, in their original order.
+func (i *Index) Tasks(tasks []wl.Task, tag string) []wl.Task {
+	tagged := []wl.Task{}
+	for _, t := range tasks {
+		if i.HasTask(t.ID, tag) {
+			tagged = append(tagged, t)
+		}
+	}
+	return tagged
+}
+
+type byCount []Count
+
+func (c byCount) Len() int      { return len(c) }
+func (c byCount) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c byCount) Less(i, j int) bool {
+	if c[i].Count != c[j].Count {
+		return c[i].Count > c[j].Count
+	}
+	return c[i].Tag < c[j].Tag
+}
+
+type byID []uint
+
+func (ids byID) Len() int           { return len(ids) }
+func (ids byID) Swap(i, j int)      { ids[i], ids[j] = ids[j], ids[i] }
+func (ids byID) Less(i, j int) bool { return ids[i] < ids[j] }
@@ -0,0 +1,51 @@
+package tags_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/tags"
+)
+
+var _ = Describe("Index", func() {
+	var (
+		tasks []wl.Task
+		index *tags.Index
+	)
+
+	BeforeEach(func() {
+		tasks = []wl.Task{
+			{ID: 1, ListID: 10, Title: "Renew certs #ops"},
+			{ID: 2, ListID: 20, Title: "Deploy #ops #release"},
+			{ID: 3, ListID: 10, Title: "Write docs"},
+			{ID: 4, ListID: 20, Title: "Plan sprint"},
+		}
+		subtasks := []wl.Subtask{
+			{ID: 5, TaskID: 3, Title: "Ask #ops for access"},
+			{ID: 6, TaskID: 99, Title: "Orphan #release"},
+		}
+		notes := []wl.Note{
+			{ID: 7, TaskID: 4, Content: "Remember #release notes"},
+		}
+
+		index = tags.NewIndex(tasks, subtasks, notes)
+	})
+
+	It("counts tags across lists, most used first", func() {
+		Expect(index.Counts()).To(Equal([]tags.Count{
+			{Tag: "ops", Count: 3, Tasks: 2, Subtasks: 1, ListIDs: []uint{10, 20}},
+			{Tag: "release", Count: 3, Tasks: 1, Subtasks: 1, Notes: 1, ListIDs: []uint{20}},
+		}))
+	})
+
+	It("finds tasks tagged in their titles, subtasks or notes", func() {
+		Expect(index.Tasks(tasks, "#OPS")).To(Equal([]wl.Task{tasks[0], tasks[1], tasks[2]}))
+		Expect(index.Tasks(tasks, "release")).To(Equal([]wl.Task{tasks[1], tasks[3]}))
+		Expect(index.Tasks(tasks, "missing")).To(BeEmpty())
+	})
+
+	It("checks whether a task is tagged", func() {
+		Expect(index.HasTask(3, "ops")).To(BeTrue())
+		Expect(index.HasTask(3, "release")).To(BeFalse())
+	})
+})
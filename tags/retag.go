@@ -0,0 +1,84 @@
+package tags
+
+import (
+	"fmt"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/retry"
+)
+
+// Retagger renames tags in task titles. If an update fails because the
+// task was modified concurrently, the task is fetched again and the
+// rename is reapplied to its current title.
+type Retagger struct {
+	client      wl.Client
+	maxAttempts int
+}
+
+// NewRetagger returns a Retagger which attempts each update retry.DefaultMaxAttempts times.
+func NewRetagger(client wl.Client) *Retagger {
+	return &Retagger{
+		client:      client,
+		maxAttempts: retry.DefaultMaxAttempts,
+	}
+}
+
+// Retag replaces the tag old with the tag new in the titles of the tasks,
+// and returns the updated tasks. Tasks whose titles do not contain old
+// are not updated. It stops at the first task which cannot be updated,
+// returning the tasks updated so far.
+func (r Retagger) Retag(tasks []wl.Task, old string, new string) ([]wl.Task, error) {
+	if !Valid(old) {
+		return nil, fmt.Errorf("invalid tag %q", old)
+	}
+	if !Valid(new) {
+		return nil, fmt.Errorf("invalid tag %q", new)
+	}
+
+	updated := []wl.Task{}
+	for _, t := range tasks {
+		if !Has(t.Title, old) {
+			continue
+		}
+
+		task, err := r.retagTask(t, old, new)
+		if err != nil {
+			return updated, fmt.Errorf("failed to retag task %d: %v", t.ID, err)
+		}
+		updated = append(updated, task)
+	}
+	return updated, nil
+}
+
+// retagTask updates the title of the task, fetching the task again
+// if the update conflicts with a concurrent modification.
+func (r Retagger) retagTask(task wl.Task, old string, new string) (wl.Task, error) {
+	fetch := false
+	err := retry.OnConflict(r.maxAttempts, func() error {
+		if fetch {
+			var err error
+			task, err = r.client.Task(task.ID)
+			if err != nil {
+				return err
+			}
+		}
+		fetch = true
+
+		title := Replace(task.Title, old, new)
+		if title == task.Title {
+			return nil
+		}
+		task.Title = title
+
+		updated, err := r.client.UpdateTask(task)
+		if err != nil {
+			return err
+		}
+		task = updated
+		return nil
+	})
+	if err != nil {
+		return wl.Task{}, err
+	}
+	return task, nil
+}
@@ -0,0 +1,144 @@
+package tags_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/retry"
+	"github.com/robdimsdale/wl/tags"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+var _ = Describe("Retagger", func() {
+	var (
+		client   *wltest.Client
+		retagger *tags.Retagger
+		tasks    []wl.Task
+	)
+
+	// editConcurrently edits the task before each of the next n updates,
+	// as if it was edited concurrently.
+	editConcurrently := func(n int) {
+		var editing bool
+		client.Before("UpdateTask", func(args ...interface{}) error {
+			if editing || n == 0 {
+				return nil
+			}
+			n--
+
+			editing = true
+			defer func() { editing = false }()
+
+			t, err := client.Task(args[0].(wl.Task).ID)
+			if err != nil {
+				return err
+			}
+			t.Title += " (edited)"
+			_, err = client.UpdateTask(t)
+			return err
+		})
+	}
+
+	BeforeEach(func() {
+		tasks = []wl.Task{
+			{ID: 1, ListID: 10, Title: "Renew certs #ops", Revision: 1},
+			{ID: 2, ListID: 10, Title: "Write docs", Revision: 1},
+			{ID: 3, ListID: 10, Title: "#OPS deploy", Revision: 1},
+		}
+
+		client = wltest.NewClient()
+		client.AddList(wl.List{ID: 10})
+		for _, t := range tasks {
+			client.AddTask(t)
+		}
+
+		retagger = tags.NewRetagger(client)
+	})
+
+	It("updates the titles of tagged tasks", func() {
+		updated, err := retagger.Retag(tasks, "#ops", "#infra")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(updated).To(Equal([]wl.Task{
+			{ID: 1, ListID: 10, Title: "Renew certs #infra", Revision: 2},
+			{ID: 3, ListID: 10, Title: "#infra deploy", Revision: 2},
+		}))
+
+		unchanged, err := client.Task(2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unchanged.Revision).To(Equal(uint(1)))
+		Expect(client.Calls("UpdateTask")).To(Equal(2))
+	})
+
+	It("rejects invalid tags", func() {
+		_, err := retagger.Retag(tasks, "#ops", "on call")
+		Expect(err).To(HaveOccurred())
+
+		_, err = retagger.Retag(tasks, "", "#infra")
+		Expect(err).To(HaveOccurred())
+
+		Expect(client.Calls("UpdateTask")).To(BeZero())
+	})
+
+	Context("when a task is concurrently modified", func() {
+		BeforeEach(func() {
+			editConcurrently(2)
+		})
+
+		It("fetches the task again and reapplies the rename", func() {
+			updated, err := retagger.Retag(tasks[:1], "ops", "infra")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(updated).To(HaveLen(1))
+			Expect(updated[0].Title).To(Equal("Renew certs #infra (edited) (edited)"))
+			// Three attempts, and two concurrent edits.
+			Expect(client.Calls("UpdateTask")).To(Equal(3 + 2))
+		})
+	})
+
+	Context("when a task is always concurrently modified", func() {
+		BeforeEach(func() {
+			editConcurrently(retry.DefaultMaxAttempts)
+		})
+
+		It("gives up", func() {
+			updated, err := retagger.Retag(tasks, "ops", "infra")
+			Expect(err).To(HaveOccurred())
+
+			Expect(updated).To(BeEmpty())
+			Expect(client.Calls("UpdateTask")).To(Equal(2 * retry.DefaultMaxAttempts))
+		})
+	})
+
+	Context("when a task no longer has the tag", func() {
+		BeforeEach(func() {
+			client = wltest.NewClient()
+			client.AddList(wl.List{ID: 10})
+			client.AddTask(wl.Task{ID: 1, ListID: 10, Title: "Renew certs", Revision: 2})
+
+			retagger = tags.NewRetagger(client)
+		})
+
+		It("does not update it after fetching it again", func() {
+			updated, err := retagger.Retag(tasks[:1], "ops", "infra")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(updated).To(Equal([]wl.Task{{ID: 1, ListID: 10, Title: "Renew certs", Revision: 2}}))
+			Expect(client.Calls("UpdateTask")).To(Equal(1))
+		})
+	})
+
+	Context("when a task cannot be updated", func() {
+		BeforeEach(func() {
+			Expect(client.DeleteTask(tasks[2])).To(Succeed())
+		})
+
+		It("returns the tasks updated so far", func() {
+			updated, err := retagger.Retag(tasks, "ops", "infra")
+			Expect(err).To(HaveOccurred())
+
+			Expect(updated).To(HaveLen(1))
+			Expect(updated[0].ID).To(Equal(uint(1)))
+		})
+	})
+})
@@ -0,0 +1,134 @@
+// Package tags extracts hashtags, such as #ops, from task titles,
+// subtasks and notes.
+//
+// Tags are case-insensitive and are returned in lower case.
+// A tag is a # at the start of the text or after a character which is
+// not part of a word, followed by letters, digits, _, - or /,
+// at least one of which is not a digit. e.g. "#ops" and "#q1-review"
+// are tags but "#1", "issue#12" and "a##b" are not.
+package tags
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Extract returns the tags in text, without the leading #,
+// in the order in which they first appear.
+func Extract(text string) []string {
+	var tags []string
+	seen := map[string]bool{}
+
+	for _, m := range find(text) {
+		tag := strings.ToLower(text[m.start+1 : m.end])
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Has returns true if text contains the tag.
+func Has(text string, tag string) bool {
+	tag = Normalize(tag)
+	for _, t := range Extract(text) {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Replace replaces each occurrence of the tag old in text with the tag new.
+func Replace(text string, old string, new string) string {
+	old = Normalize(old)
+	new = strings.TrimPrefix(strings.TrimSpace(new), "#")
+
+	var replaced []byte
+	last := 0
+	for _, m := range find(text) {
+		if strings.ToLower(text[m.start+1:m.end]) != old {
+			continue
+		}
+		replaced = append(replaced, text[last:m.start]...)
+		replaced = append(replaced, '#')
+		replaced = append(replaced, new...)
+		last = m.end
+	}
+
+	if replaced == nil {
+		return text
+	}
+	return string(append(replaced, text[last:]...))
+}
+
+// Normalize returns the tag in lower case, without a leading #.
+func Normalize(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+// Valid returns true if tag, with or without a leading #, is a tag.
+func Valid(tag string) bool {
+	tag = "#" + strings.TrimPrefix(strings.TrimSpace(tag), "#")
+	matches := find(tag)
+	return len(matches) == 1 && matches[0].end == len(tag)
+}
+
+type match struct {
+	start int // index of the #
+	end   int // index after the last character of the tag
+}
+
+// find returns the locations of the tags in text.
+func find(text string) []match {
+	var matches []match
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != '#' {
+			continue
+		}
+
+		if i > 0 {
+			prev, _ := utf8.DecodeLastRuneInString(text[:i])
+			if isTagRune(prev) || prev == '#' {
+				continue
+			}
+		}
+
+		end := i + 1
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if !isTagRune(r) {
+				break
+			}
+			end += size
+		}
+
+		// Trailing punctuation, such as in "#ops-", is not part of the tag.
+		for end > i+1 && strings.ContainsRune("-/", rune(text[end-1])) {
+			end--
+		}
+
+		if end > i+1 && !isDigits(text[i+1:end]) {
+			matches = append(matches, match{start: i, end: end})
+		}
+		i = end - 1
+	}
+
+	return matches
+}
+
+func isTagRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '/'
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package tags_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl/tags"
+)
+
+var _ = Describe("Tags", func() {
+	table.DescribeTable("extracting tags",
+		func(text string, expected []string) {
+			Expect(tags.Extract(text)).To(Equal(expected))
+		},
+		table.Entry("no tags", "Renew certs", nil),
+		table.Entry("single tag", "Renew certs #ops", []string{"ops"}),
+		table.Entry("tag at start", "#ops renew certs", []string{"ops"}),
+		table.Entry("lower case and deduplicated", "#Ops #work #OPS", []string{"ops", "work"}),
+		table.Entry("punctuation around tags", "(#ops), #work.", []string{"ops", "work"}),
+		table.Entry("hyphens, underscores and slashes", "#q1-review #on_call #team/infra", []string{"q1-review", "on_call", "team/infra"}),
+		table.Entry("trailing hyphen", "#ops- done", []string{"ops"}),
+		table.Entry("unicode", "#café", []string{"café"}),
+		table.Entry("numbers are not tags", "Fix #12 and #3", nil),
+		table.Entry("tags may contain numbers", "#2016 #q3", []string{"q3"}),
+		table.Entry("# within a word", "issue#12 page#anchor", nil),
+		table.Entry("double #", "##ops a##b", nil),
+		table.Entry("lone #", "# ops", nil),
+	)
+
+	It("checks whether text has a tag", func() {
+		Expect(tags.Has("Renew certs #Ops", "ops")).To(BeTrue())
+		Expect(tags.Has("Renew certs #ops", "#OPS")).To(BeTrue())
+		Expect(tags.Has("Renew certs #ops-review", "ops")).To(BeFalse())
+		Expect(tags.Has("Renew certs", "ops")).To(BeFalse())
+	})
+
+	table.DescribeTable("replacing tags",
+		func(text string, old string, new string, expected string) {
+			Expect(tags.Replace(text, old, new)).To(Equal(expected))
+		},
+		table.Entry("single tag", "Renew certs #ops", "ops", "infra", "Renew certs #infra"),
+		table.Entry("with leading #", "Renew certs #ops", "#ops", "#infra", "Renew certs #infra"),
+		table.Entry("every occurrence", "#ops: renew certs (#OPS)", "ops", "infra", "#infra: renew certs (#infra)"),
+		table.Entry("other tags are unchanged", "#ops-review #ops #dev", "ops", "infra", "#ops-review #infra #dev"),
+		table.Entry("missing tag", "Renew certs #dev", "ops", "infra", "Renew certs #dev"),
+	)
+
+	table.DescribeTable("validating tags",
+		func(tag string, valid bool) {
+			Expect(tags.Valid(tag)).To(Equal(valid))
+		},
+		table.Entry("tag", "ops", true),
+		table.Entry("tag with #", "#ops", true),
+		table.Entry("empty", "", false),
+		table.Entry("number", "#12", false),
+		table.Entry("spaces", "on call", false),
+		table.Entry("punctuation", "ops!", false),
+	)
+})
@@ -0,0 +1,155 @@
+package template
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/dateparse"
+)
+
+const startKeyword = "start"
+
+// Offset is a number of days after the start date of a template.
+// It is written as start, start+5d, start-2d or start+2w.
+type Offset int
+
+// ParseOffset parses an offset such as start+5d.
+func ParseOffset(s string) (Offset, error) {
+	s = strings.ToLower(strings.Replace(s, " ", "", -1))
+	if !strings.HasPrefix(s, startKeyword) {
+		return 0, fmt.Errorf("offset %q must begin with %q", s, startKeyword)
+	}
+
+	rest := s[len(startKeyword):]
+	if rest == "" {
+		return 0, nil
+	}
+
+	if len(rest) < 3 || (rest[0] != '+' && rest[0] != '-') {
+		return 0, fmt.Errorf("cannot parse offset %q", s)
+	}
+
+	days := 1
+	switch rest[len(rest)-1] {
+	case 'd':
+	case 'w':
+		days = 7
+	default:
+		return 0, fmt.Errorf("offset %q must be in days (d) or weeks (w)", s)
+	}
+
+	n, err := strconv.Atoi(rest[1 : len(rest)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("cannot parse offset %q", s)
+	}
+	if rest[0] == '-' {
+		n = -n
+	}
+
+	return Offset(n * days), nil
+}
+
+// OffsetBetween returns the offset of the date from the start date.
+func OffsetBetween(start wl.Date, d wl.Date) Offset {
+	hours := d.In(time.UTC).Sub(start.In(time.UTC)).Hours()
+	return Offset(int(hours / 24))
+}
+
+// Date returns the date the offset after the start date.
+func (o Offset) Date(start wl.Date) wl.Date {
+	return start.AddDays(int(o))
+}
+
+// String returns the offset in days, such as start+5d.
+func (o Offset) String() string {
+	switch {
+	case o == 0:
+		return startKeyword
+	case o > 0:
+		return fmt.Sprintf("%s+%dd", startKeyword, o)
+	default:
+		return fmt.Sprintf("%s%dd", startKeyword, o)
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (o Offset) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (o *Offset) UnmarshalText(text []byte) error {
+	parsed, err := ParseOffset(string(text))
+	if err != nil {
+		return err
+	}
+	*o = parsed
+	return nil
+}
+
+// Reminder is a reminder at a time of day on a day relative to the start
+// date. It is written as an offset followed by a time, such as start+4d 09:00.
+type Reminder struct {
+	Offset Offset
+	Hour   int
+	Minute int
+}
+
+// ParseReminder parses a reminder such as start+4d 09:00 or start 9am.
+func ParseReminder(s string) (Reminder, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return Reminder{}, fmt.Errorf("reminder %q must have an offset and a time", s)
+	}
+
+	o, err := ParseOffset(fields[0])
+	if err != nil {
+		return Reminder{}, err
+	}
+
+	hour, minute, err := dateparse.ParseTimeOfDay(strings.Join(fields[1:], " "))
+	if err != nil {
+		return Reminder{}, err
+	}
+
+	return Reminder{Offset: o, Hour: hour, Minute: minute}, nil
+}
+
+// ReminderAt returns the reminder at the time, relative to the start date.
+func ReminderAt(start wl.Date, t time.Time) Reminder {
+	return Reminder{
+		Offset: OffsetBetween(start, wl.DateOf(t)),
+		Hour:   t.Hour(),
+		Minute: t.Minute(),
+	}
+}
+
+// Time returns the time of the reminder for the start date,
+// in the location.
+func (r Reminder) Time(start wl.Date, loc *time.Location) time.Time {
+	d := r.Offset.Date(start)
+	return time.Date(d.Year(), d.Month(), d.Day(), r.Hour, r.Minute, 0, 0, loc)
+}
+
+// String returns the reminder, such as start+4d 09:00.
+func (r Reminder) String() string {
+	return fmt.Sprintf("%s %02d:%02d", r.Offset, r.Hour, r.Minute)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Reminder) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Reminder) UnmarshalText(text []byte) error {
+	parsed, err := ParseReminder(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
@@ -0,0 +1,106 @@
+package template_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/template"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("Offset", func() {
+	table.DescribeTable("ParseOffset",
+		func(input string, expected template.Offset) {
+			o, err := template.ParseOffset(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(o).To(Equal(expected))
+		},
+		table.Entry("start", "start", template.Offset(0)),
+		table.Entry("days", "start+5d", template.Offset(5)),
+		table.Entry("negative days", "start-2d", template.Offset(-2)),
+		table.Entry("weeks", "start+2w", template.Offset(14)),
+		table.Entry("spaces and case", "Start + 3d", template.Offset(3)),
+	)
+
+	table.DescribeTable("invalid offsets",
+		func(input string) {
+			_, err := template.ParseOffset(input)
+			Expect(err).To(HaveOccurred())
+		},
+		table.Entry("empty", ""),
+		table.Entry("date", "2026-11-02"),
+		table.Entry("no unit", "start+5"),
+		table.Entry("months", "start+1m"),
+		table.Entry("no sign", "start5d"),
+		table.Entry("not a number", "start+xd"),
+	)
+
+	It("formats offsets in days", func() {
+		Expect(template.Offset(0).String()).To(Equal("start"))
+		Expect(template.Offset(14).String()).To(Equal("start+14d"))
+		Expect(template.Offset(-2).String()).To(Equal("start-2d"))
+	})
+
+	It("converts between dates and offsets", func() {
+		start := wl.NewDate(2026, time.October, 30)
+		Expect(template.Offset(5).Date(start)).To(Equal(wl.NewDate(2026, time.November, 4)))
+		Expect(template.OffsetBetween(start, wl.NewDate(2026, time.November, 4))).To(Equal(template.Offset(5)))
+		Expect(template.OffsetBetween(start, wl.NewDate(2026, time.October, 28))).To(Equal(template.Offset(-2)))
+	})
+})
+
+var _ = Describe("Reminder", func() {
+	It("parses an offset and a time of day", func() {
+		r, err := template.ParseReminder("start+4d 9:30am")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r).To(Equal(template.Reminder{Offset: 4, Hour: 9, Minute: 30}))
+		Expect(r.String()).To(Equal("start+4d 09:30"))
+	})
+
+	It("requires a time of day", func() {
+		_, err := template.ParseReminder("start+4d")
+		Expect(err).To(MatchError(`reminder "start+4d" must have an offset and a time`))
+	})
+
+	It("converts between times and reminders", func() {
+		start := wl.NewDate(2026, time.November, 2)
+		loc := time.FixedZone("test", -5*60*60)
+		at := time.Date(2026, time.November, 6, 9, 0, 0, 0, loc)
+
+		r := template.ReminderAt(start, at)
+		Expect(r).To(Equal(template.Reminder{Offset: 4, Hour: 9}))
+		Expect(r.Time(start, loc)).To(Equal(at))
+	})
+
+	It("round trips through YAML", func() {
+		due := template.Offset(5)
+		t := template.Template{
+			Title: "Onboarding",
+			Tasks: []template.Task{{
+				Title:     "Meet the team",
+				Due:       &due,
+				Reminders: []template.Reminder{{Offset: 4, Hour: 9}},
+			}, {
+				Title: "Read handbook",
+			}},
+		}
+
+		data, err := yaml.Marshal(t)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`title: Onboarding
+tasks:
+- title: Meet the team
+  due: start+5d
+  reminders:
+  - start+4d 09:00
+- title: Read handbook
+`))
+
+		var parsed template.Template
+		Expect(yaml.Unmarshal(data, &parsed)).To(Succeed())
+		Expect(parsed).To(Equal(t))
+	})
+})
@@ -0,0 +1,272 @@
+/*
+Package template creates lists from templates, whose due dates and reminders
+are relative to a start date, and captures templates from existing lists.
+*/
+package template
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/position"
+	"github.com/robdimsdale/wl/tree"
+)
+
+// Template is a list of tasks. Title is the title of lists created from it,
+// unless another is provided.
+type Template struct {
+	Title string `json:"title" yaml:"title"`
+	Tasks []Task `json:"tasks" yaml:"tasks"`
+}
+
+// Task is a task in a template, in the order it should appear.
+type Task struct {
+	Title     string     `json:"title" yaml:"title"`
+	Due       *Offset    `json:"due,omitempty" yaml:"due,omitempty"`
+	Starred   bool       `json:"starred,omitempty" yaml:"starred,omitempty"`
+	Note      string     `json:"note,omitempty" yaml:"note,omitempty"`
+	Subtasks  []string   `json:"subtasks,omitempty" yaml:"subtasks,omitempty"`
+	Reminders []Reminder `json:"reminders,omitempty" yaml:"reminders,omitempty"`
+}
+
+// Options configure the list created from a template.
+type Options struct {
+	// Title is the title of the list.
+	// It defaults to the title of the template.
+	Title string
+
+	// Start is the date offsets are relative to.
+	Start wl.Date
+
+	// FolderID is the folder to add the list to, if any.
+	FolderID uint
+
+	// Location is the time zone of reminders.
+	// It defaults to the local time zone.
+	Location *time.Location
+}
+
+// Result is the list created from a template and counts of its contents.
+type Result struct {
+	List      wl.List `json:"list" yaml:"list"`
+	Tasks     int     `json:"tasks" yaml:"tasks"`
+	Subtasks  int     `json:"subtasks" yaml:"subtasks"`
+	Notes     int     `json:"notes" yaml:"notes"`
+	Reminders int     `json:"reminders" yaml:"reminders"`
+}
+
+// Validate returns an error if a task or subtask has no title.
+func (t Template) Validate() error {
+	for i, task := range t.Tasks {
+		if task.Title == "" {
+			return fmt.Errorf("task %d has no title", i+1)
+		}
+		for _, s := range task.Subtasks {
+			if s == "" {
+				return fmt.Errorf("task %q has a subtask with no title", task.Title)
+			}
+		}
+	}
+	return nil
+}
+
+// Instantiate creates a list from the template, with its tasks in order and
+// their due dates and reminders relative to the start date.
+func Instantiate(client wl.Client, t Template, opts Options) (Result, error) {
+	err := t.Validate()
+	if err != nil {
+		return Result{}, err
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = t.Title
+	}
+	if title == "" {
+		return Result{}, errors.New("list title must be provided")
+	}
+
+	if opts.Start.IsZero() {
+		return Result{}, errors.New("start date must be provided")
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+
+	list, err := client.CreateList(title)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{List: list}
+
+	var taskIDs []uint
+	for _, task := range t.Tasks {
+		id, err := createTask(client, list.ID, task, opts, &result)
+		if err != nil {
+			return result, err
+		}
+		taskIDs = append(taskIDs, id)
+	}
+
+	if len(taskIDs) > 0 {
+		_, err = position.NewMover(client).MoveTasksToTop(list.ID, taskIDs)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if opts.FolderID != 0 {
+		f, err := client.Folder(opts.FolderID)
+		if err != nil {
+			return result, err
+		}
+		f.ListIDs = append(f.ListIDs, list.ID)
+
+		_, err = client.UpdateFolder(f)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// createTask creates the task and its subtasks, note and reminders.
+func createTask(client wl.Client, listID uint, t Task, opts Options, result *Result) (uint, error) {
+	var due wl.Date
+	if t.Due != nil {
+		due = t.Due.Date(opts.Start)
+	}
+
+	task, err := client.CreateTask(t.Title, listID, 0, false, wl.RecurrenceNone, 0, due, t.Starred)
+	if err != nil {
+		return 0, err
+	}
+	result.Tasks++
+
+	var subtaskIDs []uint
+	for _, s := range t.Subtasks {
+		subtask, err := client.CreateSubtask(s, task.ID, false)
+		if err != nil {
+			return 0, err
+		}
+		subtaskIDs = append(subtaskIDs, subtask.ID)
+		result.Subtasks++
+	}
+
+	if len(subtaskIDs) > 0 {
+		_, err = position.NewMover(client).MoveSubtasksToTop(task.ID, subtaskIDs)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if t.Note != "" {
+		_, err = client.CreateNote(t.Note, task.ID)
+		if err != nil {
+			return 0, err
+		}
+		result.Notes++
+	}
+
+	for _, r := range t.Reminders {
+		_, err = client.CreateReminder(r.Time(opts.Start, opts.Location), task.ID, "")
+		if err != nil {
+			return 0, err
+		}
+		result.Reminders++
+	}
+
+	return task.ID, nil
+}
+
+// CaptureOptions configure capturing a template.
+type CaptureOptions struct {
+	// Start is the date offsets are relative to. It defaults to the
+	// earliest due date or reminder in the list, or today if there are none.
+	Start wl.Date
+
+	// Now is the current time. Its location is the time zone of reminders.
+	Now time.Time
+}
+
+// Capture returns a template of the incomplete tasks of the list, in order,
+// with their due dates and reminders converted to offsets from the start date.
+func Capture(client wl.Client, listID uint, opts CaptureOptions) (Template, error) {
+	t, err := tree.Load(client, []uint{listID}, tree.Options{})
+	if err != nil {
+		return Template{}, err
+	}
+	l := t.Lists[0]
+
+	allReminders, err := client.RemindersForListID(listID)
+	if err != nil {
+		return Template{}, err
+	}
+	sort.Sort(remindersByDate(allReminders))
+
+	loc := opts.Now.Location()
+	reminders := map[uint][]time.Time{}
+	for _, r := range allReminders {
+		reminders[r.TaskID] = append(reminders[r.TaskID], r.Date.In(loc))
+	}
+
+	start := opts.Start
+	if start.IsZero() {
+		start = earliest(l.Tasks, reminders, wl.DateOf(opts.Now))
+	}
+
+	tmpl := Template{Title: l.Title, Tasks: []Task{}}
+	for _, task := range l.Tasks {
+		captured := Task{Title: task.Title, Starred: task.Starred, Note: task.Note}
+
+		if !task.DueDate.IsZero() {
+			o := OffsetBetween(start, task.DueDate)
+			captured.Due = &o
+		}
+
+		for _, s := range task.Subtasks {
+			captured.Subtasks = append(captured.Subtasks, s.Title)
+		}
+
+		for _, r := range reminders[task.ID] {
+			captured.Reminders = append(captured.Reminders, ReminderAt(start, r))
+		}
+
+		tmpl.Tasks = append(tmpl.Tasks, captured)
+	}
+
+	return tmpl, nil
+}
+
+// earliest returns the earliest due date or reminder date of the tasks,
+// or the default if there are none.
+func earliest(tasks []tree.Task, reminders map[uint][]time.Time, def wl.Date) wl.Date {
+	var first wl.Date
+	consider := func(d wl.Date) {
+		if !d.IsZero() && (first.IsZero() || d.Before(first)) {
+			first = d
+		}
+	}
+
+	for _, t := range tasks {
+		consider(t.DueDate)
+		for _, r := range reminders[t.ID] {
+			consider(wl.DateOf(r))
+		}
+	}
+
+	if first.IsZero() {
+		return def
+	}
+	return first
+}
+
+type remindersByDate []wl.Reminder
+
+func (r remindersByDate) Len() int           { return len(r) }
+func (r remindersByDate) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r remindersByDate) Less(i, j int) bool { return r[i].Date.Before(r[j].Date) }
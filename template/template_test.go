@@ -0,0 +1,190 @@
+package template_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/position"
+	"github.com/robdimsdale/wl/template"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+var _ = Describe("Template", func() {
+	var (
+		client *wltest.Client
+		loc    *time.Location
+	)
+
+	titles := func(listID uint) []string {
+		tasks, err := position.TasksForListID(client, listID)
+		Expect(err).NotTo(HaveOccurred())
+
+		var titles []string
+		for _, t := range tasks {
+			titles = append(titles, t.Title)
+		}
+		return titles
+	}
+
+	BeforeEach(func() {
+		client = wltest.NewClient()
+		client.AddList(wl.List{ID: 50, Title: "Onboarding Bob"})
+		client.AddFolder(wl.Folder{ID: 7, Title: "HR", ListIDs: []uint{50}})
+		loc = time.FixedZone("test", -5*60*60)
+	})
+
+	Describe("Instantiate", func() {
+		var tmpl template.Template
+
+		BeforeEach(func() {
+			due := template.Offset(5)
+			tmpl = template.Template{
+				Title: "Onboarding",
+				Tasks: []template.Task{
+					{
+						Title:     "Set up laptop",
+						Due:       &due,
+						Starred:   true,
+						Note:      "Ask IT",
+						Subtasks:  []string{"Email", "VPN"},
+						Reminders: []template.Reminder{{Offset: 4, Hour: 9}},
+					},
+					{Title: "Read handbook"},
+				},
+			}
+		})
+
+		It("creates the list with dates relative to the start and adds it to the folder", func() {
+			result, err := template.Instantiate(client, tmpl, template.Options{
+				Title:    "Onboarding Alice",
+				Start:    wl.NewDate(2026, time.November, 2),
+				FolderID: 7,
+				Location: loc,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			list := result.List
+			Expect(list.Title).To(Equal("Onboarding Alice"))
+			Expect(result).To(Equal(template.Result{
+				List:      list,
+				Tasks:     2,
+				Subtasks:  2,
+				Notes:     1,
+				Reminders: 1,
+			}))
+			Expect(titles(list.ID)).To(Equal([]string{"Set up laptop", "Read handbook"}))
+
+			tasks, err := position.TasksForListID(client, list.ID)
+			Expect(err).NotTo(HaveOccurred())
+			laptop := tasks[0]
+			Expect(laptop.DueDate).To(Equal(wl.NewDate(2026, time.November, 7)))
+			Expect(laptop.Starred).To(BeTrue())
+			Expect(tasks[1].DueDate).To(Equal(wl.Date{}))
+			Expect(tasks[1].Starred).To(BeFalse())
+
+			subtasks, err := position.SubtasksForTaskID(client, laptop.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subtasks).To(HaveLen(2))
+			Expect(subtasks[0].Title).To(Equal("Email"))
+			Expect(subtasks[1].Title).To(Equal("VPN"))
+
+			notes, err := client.NotesForTaskID(laptop.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notes).To(HaveLen(1))
+			Expect(notes[0].Content).To(Equal("Ask IT"))
+
+			reminders, err := client.RemindersForTaskID(laptop.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reminders).To(HaveLen(1))
+			Expect(reminders[0].Date.Format(time.RFC3339)).To(Equal("2026-11-06T09:00:00-05:00"))
+
+			folder, err := client.Folder(7)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(folder.ListIDs).To(Equal([]uint{50, list.ID}))
+		})
+
+		It("retries ordering tasks when the position is concurrently modified", func() {
+			client.Conflict("UpdateTaskPosition", 1)
+
+			result, err := template.Instantiate(client, tmpl, template.Options{Start: wl.NewDate(2026, time.November, 2)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Calls("UpdateTaskPosition")).To(Equal(2))
+			Expect(titles(result.List.ID)).To(Equal([]string{"Set up laptop", "Read handbook"}))
+		})
+
+		It("defaults to the title of the template", func() {
+			result, err := template.Instantiate(client, tmpl, template.Options{Start: wl.NewDate(2026, time.November, 2)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.List.Title).To(Equal("Onboarding"))
+		})
+
+		It("requires a title and a start date", func() {
+			root, err := client.Root()
+			Expect(err).NotTo(HaveOccurred())
+
+			tmpl.Title = ""
+			_, err = template.Instantiate(client, tmpl, template.Options{Start: wl.NewDate(2026, time.November, 2)})
+			Expect(err).To(MatchError("list title must be provided"))
+
+			_, err = template.Instantiate(client, tmpl, template.Options{Title: "Onboarding Alice"})
+			Expect(err).To(MatchError("start date must be provided"))
+			Expect(client.Root()).To(Equal(root))
+		})
+
+		It("rejects tasks without titles", func() {
+			tmpl.Tasks[1].Title = ""
+			_, err := template.Instantiate(client, tmpl, template.Options{Start: wl.NewDate(2026, time.November, 2)})
+			Expect(err).To(MatchError("task 2 has no title"))
+		})
+	})
+
+	Describe("Capture", func() {
+		BeforeEach(func() {
+			client.AddTask(wl.Task{ID: 1, ListID: 50, Title: "Set up laptop", DueDate: wl.NewDate(2026, time.March, 7), Starred: true})
+			client.AddTask(wl.Task{ID: 2, ListID: 50, Title: "Read handbook"})
+			client.AddSubtask(wl.Subtask{ID: 10, TaskID: 1, Title: "Email"})
+			client.AddSubtask(wl.Subtask{ID: 11, TaskID: 1, Title: "VPN", Completed: true})
+			client.AddNote(wl.Note{TaskID: 1, Content: "Ask IT"})
+			client.AddReminder(wl.Reminder{TaskID: 1, Date: time.Date(2026, time.March, 6, 14, 0, 0, 0, time.UTC)})
+			client.AddReminder(wl.Reminder{TaskID: 2, Date: time.Date(2026, time.March, 2, 13, 30, 0, 0, time.UTC)})
+		})
+
+		It("converts dates to offsets from the earliest date", func() {
+			tmpl, err := template.Capture(client, 50, template.CaptureOptions{
+				Now: time.Date(2026, time.October, 18, 0, 0, 0, 0, loc),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			due := template.Offset(5)
+			Expect(tmpl).To(Equal(template.Template{
+				Title: "Onboarding Bob",
+				Tasks: []template.Task{
+					{
+						Title:     "Set up laptop",
+						Due:       &due,
+						Starred:   true,
+						Note:      "Ask IT",
+						Subtasks:  []string{"Email", "VPN"},
+						Reminders: []template.Reminder{{Offset: 4, Hour: 9}},
+					},
+					{
+						Title:     "Read handbook",
+						Reminders: []template.Reminder{{Offset: 0, Hour: 8, Minute: 30}},
+					},
+				},
+			}))
+		})
+
+		It("converts dates to offsets from the provided start", func() {
+			tmpl, err := template.Capture(client, 50, template.CaptureOptions{
+				Start: wl.NewDate(2026, time.March, 9),
+				Now:   time.Date(2026, time.October, 18, 0, 0, 0, 0, loc),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*tmpl.Tasks[0].Due).To(Equal(template.Offset(-2)))
+			Expect(tmpl.Tasks[1].Reminders[0].Offset).To(Equal(template.Offset(-7)))
+		})
+	})
+})
@@ -0,0 +1,167 @@
+package todotxt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robdimsdale/wl"
+)
+
+const (
+	// IDTag is the key of the tag which stores the ID of the task.
+	IDTag = "wl"
+
+	// DueTag is the key of the tag which stores the due date of the task.
+	DueTag = "due"
+
+	// PriorityTag is the key of the tag which stores the priority of a
+	// completed task, as completed tasks do not have a priority.
+	PriorityTag = "pri"
+
+	starredPriority = "A"
+)
+
+// ID returns the ID of the task in the IDTag, if any.
+func (t Task) ID() (uint, bool) {
+	v, ok := t.Tag(IDTag)
+	if !ok {
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(v, 10, 0)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// Name returns the project or context for the title of a list or the
+// name of a user, which has its whitespace replaced with "_".
+func Name(title string) string {
+	return strings.Join(strings.Fields(title), "_")
+}
+
+// Mapper converts between Wunderlist tasks and todo.txt tasks.
+//
+// Starred tasks have priority A, the titles of lists are projects, the
+// names of assignees are contexts, the due date is stored in the DueTag
+// and the ID in the IDTag.
+type Mapper struct {
+	lists []wl.List
+	users []wl.User
+	loc   *time.Location
+}
+
+// NewMapper returns a Mapper for the lists and users. Creation and
+// completion dates are the dates of those times in the location.
+func NewMapper(lists []wl.List, users []wl.User, loc *time.Location) Mapper {
+	return Mapper{lists: lists, users: users, loc: loc}
+}
+
+// Line returns the todo.txt task for the task.
+func (m Mapper) Line(t wl.Task) Task {
+	line := Task{Completed: t.Completed}
+
+	if !t.CreatedAt.IsZero() {
+		line.CreationDate = wl.DateOf(t.CreatedAt.In(m.loc))
+	}
+	if t.Completed && !t.CompletedAt.IsZero() {
+		line.CompletionDate = wl.DateOf(t.CompletedAt.In(m.loc))
+	}
+	if t.Starred && !t.Completed {
+		line.Priority = starredPriority
+	}
+
+	parts := []string{t.Title}
+
+	for _, l := range m.lists {
+		if l.ID == t.ListID {
+			parts = append(parts, "+"+Name(l.Title))
+			break
+		}
+	}
+
+	if t.AssigneeID != 0 {
+		for _, u := range m.users {
+			if u.ID == t.AssigneeID {
+				parts = append(parts, "@"+Name(u.Name))
+				break
+			}
+		}
+	}
+
+	if !t.DueDate.IsZero() {
+		parts = append(parts, DueTag+":"+t.DueDate.String())
+	}
+	if t.Starred && t.Completed {
+		parts = append(parts, PriorityTag+":"+starredPriority)
+	}
+	if t.ID != 0 {
+		parts = append(parts, fmt.Sprintf("%s:%d", IDTag, t.ID))
+	}
+
+	line.Description = strings.Join(parts, " ")
+	return line
+}
+
+// Apply returns the task updated with the title, completion, priority,
+// due date, project and context of the line. Any priority stars the task.
+// The first project which is the title of a list moves the task to it, and
+// the first context which is the name of a user assigns the task to them;
+// without such a context the task is unassigned. It returns an error if
+// the line has no title or an invalid due date.
+func (m Mapper) Apply(line Task, t wl.Task) (wl.Task, error) {
+	t.Title = line.Title()
+	if t.Title == "" {
+		return wl.Task{}, fmt.Errorf("line has no title: %s", line)
+	}
+
+	t.Completed = line.Completed
+	_, hasPriorityTag := line.Tag(PriorityTag)
+	t.Starred = line.Priority != "" || hasPriorityTag
+
+	t.DueDate = wl.Date{}
+	if due, ok := line.Tag(DueTag); ok {
+		d, err := wl.ParseDate(due)
+		if err != nil {
+			return wl.Task{}, err
+		}
+		t.DueDate = d
+	}
+
+	if id, ok := m.ListID(line.Projects()); ok {
+		t.ListID = id
+	}
+
+	t.AssigneeID, _ = m.AssigneeID(line.Contexts())
+
+	return t, nil
+}
+
+// ListID returns the ID of the list for the first project which matches
+// the title of a list, ignoring case.
+func (m Mapper) ListID(projects []string) (uint, bool) {
+	for _, p := range projects {
+		for _, l := range m.lists {
+			if strings.EqualFold(p, Name(l.Title)) {
+				return l.ID, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// AssigneeID returns the ID of the user for the first context which
+// matches the name of a user, ignoring case.
+func (m Mapper) AssigneeID(contexts []string) (uint, bool) {
+	for _, c := range contexts {
+		for _, u := range m.users {
+			if strings.EqualFold(c, Name(u.Name)) {
+				return u.ID, true
+			}
+		}
+	}
+	return 0, false
+}
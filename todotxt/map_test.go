@@ -0,0 +1,106 @@
+package todotxt_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/todotxt"
+)
+
+var _ = Describe("Mapper", func() {
+	var (
+		m    todotxt.Mapper
+		task wl.Task
+		loc  = time.FixedZone("test", -8*60*60)
+	)
+
+	BeforeEach(func() {
+		m = todotxt.NewMapper(
+			[]wl.List{{ID: 1, Title: "inbox"}, {ID: 2, Title: "Home Stuff"}},
+			[]wl.User{{ID: 7, Name: "Jane Doe"}},
+			loc,
+		)
+
+		task = wl.Task{
+			ID:         12,
+			Title:      "Paint fence",
+			ListID:     2,
+			AssigneeID: 7,
+			Starred:    true,
+			DueDate:    wl.NewDate(2016, time.January, 6),
+			CreatedAt:  time.Date(2016, time.January, 5, 12, 0, 0, 0, loc),
+		}
+	})
+
+	Describe("Line", func() {
+		It("maps star, list, assignee, due date and ID", func() {
+			Expect(m.Line(task).String()).To(Equal(
+				"(A) 2016-01-05 Paint fence +Home_Stuff @Jane_Doe due:2016-01-06 wl:12",
+			))
+		})
+
+		It("marks completed tasks, keeping the star as a tag", func() {
+			task.Completed = true
+			task.CompletedAt = time.Date(2016, time.January, 7, 12, 0, 0, 0, loc)
+
+			Expect(m.Line(task).String()).To(Equal(
+				"x 2016-01-07 2016-01-05 Paint fence +Home_Stuff @Jane_Doe due:2016-01-06 pri:A wl:12",
+			))
+		})
+
+		It("uses the dates of the creation and completion times in the location", func() {
+			task.CreatedAt = time.Date(2016, time.January, 5, 3, 0, 0, 0, time.UTC)
+			task.Completed = true
+			task.CompletedAt = time.Date(2016, time.January, 8, 7, 59, 0, 0, time.UTC)
+
+			line := m.Line(task)
+			Expect(line.CreationDate).To(Equal(wl.NewDate(2016, time.January, 4)))
+			Expect(line.CompletionDate).To(Equal(wl.NewDate(2016, time.January, 7)))
+		})
+	})
+
+	Describe("Apply", func() {
+		It("round trips", func() {
+			applied, err := m.Apply(m.Line(task), wl.Task{ID: 12})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(applied.Title).To(Equal(task.Title))
+			Expect(applied.ListID).To(Equal(task.ListID))
+			Expect(applied.AssigneeID).To(Equal(task.AssigneeID))
+			Expect(applied.Starred).To(BeTrue())
+			Expect(applied.DueDate).To(Equal(task.DueDate))
+		})
+
+		It("updates from an edited line", func() {
+			line := todotxt.Parse("x Paint the fence +INBOX wl:12")
+
+			applied, err := m.Apply(line, task)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(applied.Title).To(Equal("Paint the fence"))
+			Expect(applied.Completed).To(BeTrue())
+			Expect(applied.Starred).To(BeFalse())
+			Expect(applied.ListID).To(Equal(uint(1)))
+			Expect(applied.AssigneeID).To(Equal(uint(0)))
+			Expect(applied.DueDate.IsZero()).To(BeTrue())
+		})
+
+		It("keeps the list if no project matches a list", func() {
+			applied, err := m.Apply(todotxt.Parse("Paint +Other"), task)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(applied.ListID).To(Equal(uint(2)))
+		})
+
+		It("returns an error for a line without a title", func() {
+			_, err := m.Apply(todotxt.Parse("+Home_Stuff wl:12"), task)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns an error for an invalid due date", func() {
+			_, err := m.Apply(todotxt.Parse("Paint due:tomorrow"), task)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,422 @@
+package todotxt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/atomicfile"
+	"github.com/robdimsdale/wl/position"
+)
+
+// Actions taken by a sync.
+const (
+	// ActionCreate creates a task for a new line.
+	ActionCreate = "create"
+
+	// ActionUpdate updates a task from its edited line.
+	ActionUpdate = "update"
+
+	// ActionDelete deletes a task whose line was removed.
+	ActionDelete = "delete"
+
+	// ActionAdd adds a line for a task created since the last sync.
+	ActionAdd = "add"
+
+	// ActionRefresh replaces an unedited line with the current task.
+	ActionRefresh = "refresh"
+
+	// ActionRemove removes the line of a task which no longer exists.
+	ActionRemove = "remove"
+)
+
+// Change is a change made by a sync.
+type Change struct {
+	Action string `json:"action" yaml:"action"`
+	TaskID uint   `json:"task_id" yaml:"task_id"`
+	Title  string `json:"title" yaml:"title"`
+}
+
+// State records the lines of a todo.txt file as of its last export or sync,
+// by task ID, and the lists it contains.
+type State struct {
+	ListIDs []uint          `json:"list_ids"`
+	Lines   map[uint]string `json:"lines"`
+}
+
+// NewState returns the state of the lines, which are of tasks in the lists.
+func NewState(lines []Task, listIDs []uint) State {
+	s := State{
+		ListIDs: append([]uint{}, listIDs...),
+		Lines:   map[uint]string{},
+	}
+	sort.Sort(uintSlice(s.ListIDs))
+
+	for _, l := range lines {
+		if id, ok := l.ID(); ok {
+			s.Lines[id] = l.String()
+		}
+	}
+	return s
+}
+
+// LoadState loads the state at path, or returns an empty state if there is none.
+func LoadState(path string) (State, error) {
+	s := State{Lines: map[uint]string{}}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return State{}, err
+	}
+
+	err = json.Unmarshal(b, &s)
+	if err != nil {
+		return State{}, fmt.Errorf("failed to read state %s: %v", path, err)
+	}
+
+	if s.Lines == nil {
+		s.Lines = map[uint]string{}
+	}
+	return s, nil
+}
+
+// Save replaces the state file atomically.
+func (s State) Save(path string) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, b, 0600)
+}
+
+// WriteFile replaces the todo.txt file atomically.
+func WriteFile(path string, tasks []Task) error {
+	var lines []string
+	for _, t := range tasks {
+		lines = append(lines, t.String()+"\n")
+	}
+	return atomicfile.WriteFile(path, []byte(strings.Join(lines, "")), 0644)
+}
+
+// Export returns the lines for the incomplete tasks in the lists, ordered
+// by list and then by position, and the state of those lines. Creation
+// dates are in the location.
+func Export(client wl.Client, listIDs []uint, loc *time.Location) ([]Task, State, error) {
+	m, err := newMapper(client, loc)
+	if err != nil {
+		return nil, State{}, err
+	}
+
+	lines := []Task{}
+	for _, listID := range listIDs {
+		tasks, err := position.TasksForListID(client, listID)
+		if err != nil {
+			return nil, State{}, err
+		}
+
+		for _, t := range tasks {
+			if !t.Completed {
+				lines = append(lines, m.Line(t))
+			}
+		}
+	}
+
+	return lines, NewState(lines, listIDs), nil
+}
+
+// Options configure a sync.
+type Options struct {
+	// ListID is the list in which to create tasks for new lines without a
+	// project matching a list. It defaults to the inbox.
+	ListID uint
+
+	// DryRun reports the changes which would be made without making them.
+	// The returned lines are then those of the file.
+	DryRun bool
+
+	// Location is the time zone of creation and completion dates.
+	// It defaults to the local time zone.
+	Location *time.Location
+}
+
+// Result is the outcome of a sync. If the sync fails, the lines not yet
+// synced are included unchanged, and the state records them as they were
+// before, so that the file and state can still be written and synced again.
+type Result struct {
+	// Lines are the new contents of the file.
+	Lines []Task
+
+	// State is the state of the new lines.
+	State State
+
+	Changes []Change
+}
+
+// Sync applies the edits to the lines since the state was recorded, and
+// updates the lines with the changes to their tasks since then.
+//
+// Lines without an ID create tasks. Lines which differ from the state update
+// their task, replacing its title, completion, star, due date, list and
+// assignee. Created tasks, and tasks moved to another list, are positioned
+// after the task of the previous line in that list, or first. Other lines
+// are replaced with the current task, and are removed if it no longer exists.
+// Tasks whose lines have been removed are deleted, unless those lines were
+// completed, as completed lines are often archived.
+// Incomplete tasks in the lists of the state without a line are added.
+//
+// Without a recorded state all lines are treated as edited,
+// and no tasks are deleted or added.
+func Sync(client wl.Client, lines []Task, state State, opts Options) (Result, error) {
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+
+	m, err := newMapper(client, opts.Location)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if opts.ListID == 0 {
+		inbox, err := client.Inbox()
+		if err != nil {
+			return Result{}, err
+		}
+		opts.ListID = inbox.ID
+	}
+
+	mover := position.NewMover(client)
+
+	// previous is the task of the last line synced in each list.
+	previous := map[uint]uint{}
+	placement := func(listID uint) position.Placement {
+		if id, ok := previous[listID]; ok {
+			return position.After(id)
+		}
+		return position.Top()
+	}
+
+	result := Result{Lines: []Task{}, Changes: []Change{}}
+	seen := map[uint]bool{}
+	listIDs := map[uint]bool{}
+	for _, id := range state.ListIDs {
+		listIDs[id] = true
+	}
+
+	// failed completes the result after an error syncing the line at index i.
+	failed := func(i int, err error) (Result, error) {
+		result.Lines = append(result.Lines, lines[i:]...)
+
+		var ids []uint
+		for id := range listIDs {
+			ids = append(ids, id)
+		}
+
+		synced := NewState(result.Lines[:len(result.Lines)-len(lines[i:])], ids)
+		for id, line := range state.Lines {
+			if _, ok := synced.Lines[id]; !ok {
+				synced.Lines[id] = line
+			}
+		}
+		result.State = synced
+		return result, err
+	}
+
+	for i, line := range lines {
+		id, ok := line.ID()
+		if !ok {
+			t, err := m.Apply(line, wl.Task{ListID: opts.ListID})
+			if err != nil {
+				return failed(i, err)
+			}
+
+			if !opts.DryRun {
+				t, err = client.CreateTask(
+					t.Title,
+					t.ListID,
+					t.AssigneeID,
+					t.Completed,
+					wl.RecurrenceNone,
+					0,
+					t.DueDate,
+					t.Starred,
+				)
+				if err != nil {
+					return failed(i, err)
+				}
+				_, err = mover.MoveTask(t.ID, placement(t.ListID))
+				if err != nil {
+					return failed(i, err)
+				}
+				line = m.Line(t)
+				seen[t.ID] = true
+				previous[t.ListID] = t.ID
+			}
+
+			result.Lines = append(result.Lines, line)
+			result.Changes = append(result.Changes, Change{Action: ActionCreate, TaskID: t.ID, Title: t.Title})
+			listIDs[t.ListID] = true
+			continue
+		}
+
+		if seen[id] {
+			return failed(i, fmt.Errorf("task %d appears more than once", id))
+		}
+		seen[id] = true
+
+		task, err := client.Task(id)
+		if err != nil {
+			if !wl.IsNotFound(err) {
+				return failed(i, err)
+			}
+
+			result.Changes = append(result.Changes, Change{Action: ActionRemove, TaskID: id, Title: line.Title()})
+			if opts.DryRun {
+				result.Lines = append(result.Lines, line)
+			}
+			continue
+		}
+		listIDs[task.ListID] = true
+
+		if base, ok := state.Lines[id]; ok && base == line.String() {
+			current := m.Line(task)
+			if current.String() != line.String() {
+				result.Changes = append(result.Changes, Change{Action: ActionRefresh, TaskID: id, Title: task.Title})
+				if !opts.DryRun {
+					line = current
+				}
+			}
+			result.Lines = append(result.Lines, line)
+			previous[task.ListID] = id
+			continue
+		}
+
+		updated, err := m.Apply(line, task)
+		if err != nil {
+			return failed(i, err)
+		}
+
+		if m.Line(updated).String() != m.Line(task).String() {
+			result.Changes = append(result.Changes, Change{Action: ActionUpdate, TaskID: id, Title: updated.Title})
+			if !opts.DryRun {
+				updated, err = update(client, mover, m, task, updated, placement(updated.ListID))
+				if err != nil {
+					return failed(i, err)
+				}
+			}
+		}
+
+		if !opts.DryRun {
+			line = m.Line(updated)
+		}
+		result.Lines = append(result.Lines, line)
+		previous[updated.ListID] = id
+	}
+
+	var removed []uint
+	for id := range state.Lines {
+		if !seen[id] {
+			removed = append(removed, id)
+		}
+	}
+	sort.Sort(uintSlice(removed))
+
+	for _, id := range removed {
+		if Parse(state.Lines[id]).Completed {
+			continue
+		}
+
+		task, err := client.Task(id)
+		if err != nil {
+			if wl.IsNotFound(err) {
+				continue
+			}
+			return failed(len(lines), err)
+		}
+
+		result.Changes = append(result.Changes, Change{Action: ActionDelete, TaskID: id, Title: task.Title})
+		if !opts.DryRun {
+			err = client.DeleteTask(task)
+			if err != nil {
+				return failed(len(lines), err)
+			}
+		}
+	}
+
+	for _, listID := range state.ListIDs {
+		tasks, err := position.TasksForListID(client, listID)
+		if err != nil {
+			return failed(len(lines), err)
+		}
+
+		for _, t := range tasks {
+			_, known := state.Lines[t.ID]
+			if t.Completed || seen[t.ID] || known {
+				continue
+			}
+
+			result.Changes = append(result.Changes, Change{Action: ActionAdd, TaskID: t.ID, Title: t.Title})
+			if !opts.DryRun {
+				result.Lines = append(result.Lines, m.Line(t))
+			}
+		}
+	}
+
+	var ids []uint
+	for id := range listIDs {
+		ids = append(ids, id)
+	}
+	result.State = NewState(result.Lines, ids)
+
+	return result, nil
+}
+
+// update updates the task with the edits of its line. A task whose list
+// changed is moved to the placement in its new list with the mover, so that
+// the positions of both lists are updated, before any other edits are made.
+func update(client wl.Client, mover *position.Mover, m Mapper, task wl.Task, updated wl.Task, p position.Placement) (wl.Task, error) {
+	if updated.ListID != task.ListID {
+		_, err := mover.MoveTaskToList(task.ID, updated.ListID, p)
+		if err != nil {
+			return wl.Task{}, err
+		}
+
+		task, err = client.Task(task.ID)
+		if err != nil {
+			return wl.Task{}, err
+		}
+		if m.Line(updated).String() == m.Line(task).String() {
+			return task, nil
+		}
+		updated.Revision = task.Revision
+	}
+
+	return client.UpdateTask(updated)
+}
+
+func newMapper(client wl.Client, loc *time.Location) (Mapper, error) {
+	lists, err := client.Lists()
+	if err != nil {
+		return Mapper{}, err
+	}
+
+	users, err := client.Users()
+	if err != nil {
+		return Mapper{}, err
+	}
+
+	return NewMapper(lists, users, loc), nil
+}
+
+type uintSlice []uint
+
+func (s uintSlice) Len() int           { return len(s) }
+func (s uintSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s uintSlice) Less(i, j int) bool { return s[i] < s[j] }
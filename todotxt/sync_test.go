@@ -0,0 +1,288 @@
+package todotxt_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/todotxt"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+func newClient() *wltest.Client {
+	client := wltest.NewClient()
+	client.AddUser(wl.User{ID: 7, Name: "Jane"})
+
+	client.AddList(wl.List{ID: 1, Title: "inbox", ListType: "inbox"})
+	client.AddList(wl.List{ID: 2, Title: "Work"})
+
+	client.AddTask(wl.Task{ID: 10, ListID: 2, Title: "Unchanged"})
+	client.AddTask(wl.Task{ID: 11, ListID: 2, Title: "Changed in wl", Starred: true})
+	client.AddTask(wl.Task{ID: 12, ListID: 2, Title: "Edited"})
+	client.AddTask(wl.Task{ID: 13, ListID: 2, Title: "Removed"})
+	client.AddTask(wl.Task{ID: 14, ListID: 2, Title: "Archived", Completed: true})
+	client.AddTask(wl.Task{ID: 15, ListID: 2, Title: "Added in wl"})
+	return client
+}
+
+var _ = Describe("Export", func() {
+	It("exports incomplete tasks in the lists with their state", func() {
+		client := newClient()
+
+		lines, state, err := todotxt.Export(client, []uint{2}, time.UTC)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(lines).To(HaveLen(5))
+		Expect(lines[0].String()).To(Equal("Unchanged +Work wl:10"))
+		Expect(lines[1].String()).To(Equal("(A) Changed in wl +Work wl:11"))
+
+		Expect(state.ListIDs).To(Equal([]uint{2}))
+		Expect(state.Lines).To(HaveLen(5))
+		Expect(state.Lines[uint(10)]).To(Equal("Unchanged +Work wl:10"))
+	})
+})
+
+var _ = Describe("Sync", func() {
+	var (
+		client *wltest.Client
+		state  todotxt.State
+		lines  []todotxt.Task
+	)
+
+	task := func(taskID uint) wl.Task {
+		t, err := client.Task(taskID)
+		Expect(err).NotTo(HaveOccurred())
+		return t
+	}
+
+	BeforeEach(func() {
+		client = newClient()
+
+		state = todotxt.State{
+			ListIDs: []uint{2},
+			Lines: map[uint]string{
+				10: "Unchanged +Work wl:10",
+				11: "Changed in wl +Work wl:11",
+				12: "Edited +Work wl:12",
+				13: "Removed +Work wl:13",
+				14: "x Archived +Work wl:14",
+				9:  "Deleted in wl +Work wl:9",
+			},
+		}
+
+		lines = []todotxt.Task{
+			todotxt.Parse("Unchanged +Work wl:10"),
+			todotxt.Parse("Changed in wl +Work wl:11"),
+			todotxt.Parse("x Edited title +Work @jane due:2016-01-06 wl:12"),
+			todotxt.Parse("Deleted in wl +Work wl:9"),
+			todotxt.Parse("(A) New task +Work"),
+			todotxt.Parse("New inbox task"),
+		}
+	})
+
+	It("applies edits in both directions", func() {
+		result, err := todotxt.Sync(client, lines, state, todotxt.Options{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(task(12).Title).To(Equal("Edited title"))
+		Expect(task(12).Completed).To(BeTrue())
+		Expect(task(12).AssigneeID).To(Equal(uint(7)))
+		Expect(task(12).DueDate).To(Equal(wl.NewDate(2016, time.January, 6)))
+
+		Expect(task(16).Title).To(Equal("New task"))
+		Expect(task(16).ListID).To(Equal(uint(2)))
+		Expect(task(16).Starred).To(BeTrue())
+		Expect(task(17).ListID).To(Equal(uint(1)))
+
+		_, err = client.Task(13)
+		Expect(wl.IsNotFound(err)).To(BeTrue())
+		Expect(client.Calls("DeleteTask")).To(Equal(1))
+		Expect(task(14).Completed).To(BeTrue())
+
+		var out []string
+		for _, l := range result.Lines {
+			out = append(out, l.String())
+		}
+		Expect(out).To(Equal([]string{
+			"Unchanged +Work wl:10",
+			"(A) Changed in wl +Work wl:11",
+			"x Edited title +Work @Jane due:2016-01-06 wl:12",
+			"(A) New task +Work wl:16",
+			"New inbox task +inbox wl:17",
+			"Added in wl +Work wl:15",
+		}))
+
+		Expect(result.Changes).To(Equal([]todotxt.Change{
+			{Action: todotxt.ActionRefresh, TaskID: 11, Title: "Changed in wl"},
+			{Action: todotxt.ActionUpdate, TaskID: 12, Title: "Edited title"},
+			{Action: todotxt.ActionRemove, TaskID: 9, Title: "Deleted in wl"},
+			{Action: todotxt.ActionCreate, TaskID: 16, Title: "New task"},
+			{Action: todotxt.ActionCreate, TaskID: 17, Title: "New inbox task"},
+			{Action: todotxt.ActionDelete, TaskID: 13, Title: "Removed"},
+			{Action: todotxt.ActionAdd, TaskID: 15, Title: "Added in wl"},
+		}))
+
+		Expect(result.State.ListIDs).To(Equal([]uint{1, 2}))
+		Expect(result.State.Lines).To(HaveLen(6))
+	})
+
+	It("positions moved and created tasks after the previous line in their list", func() {
+		client.SetTaskPosition(2, 10, 11, 12, 13, 15)
+		lines = []todotxt.Task{
+			todotxt.Parse("Unchanged +Work wl:10"),
+			todotxt.Parse("(A) New task +Work"),
+			todotxt.Parse("Changed in wl +Work wl:11"),
+			todotxt.Parse("Edited +inbox wl:12"),
+			todotxt.Parse("Removed +Work wl:13"),
+			todotxt.Parse("New inbox task"),
+		}
+
+		_, err := todotxt.Sync(client, lines, state, todotxt.Options{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(task(12).ListID).To(Equal(uint(1)))
+
+		positions, err := client.TaskPositionsForListID(1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(positions[0].Values).To(Equal([]uint{12, 17}))
+
+		positions, err = client.TaskPositionsForListID(2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(positions[0].Values).To(Equal([]uint{10, 16, 11, 13, 15}))
+	})
+
+	It("is idempotent", func() {
+		result, err := todotxt.Sync(client, lines, state, todotxt.Options{})
+		Expect(err).NotTo(HaveOccurred())
+
+		again, err := todotxt.Sync(client, result.Lines, result.State, todotxt.Options{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(again.Changes).To(BeEmpty())
+		Expect(again.Lines).To(Equal(result.Lines))
+	})
+
+	It("changes nothing in a dry run", func() {
+		root, err := client.Root()
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := todotxt.Sync(client, lines, state, todotxt.Options{DryRun: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result.Changes).To(HaveLen(7))
+		Expect(client.Root()).To(Equal(root))
+		Expect(result.Lines).To(HaveLen(len(lines)))
+	})
+
+	It("treats all lines as edited without a state", func() {
+		result, err := todotxt.Sync(client, lines[:3], todotxt.State{}, todotxt.Options{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(task(11).Starred).To(BeFalse())
+		Expect(client.Calls("DeleteTask")).To(BeZero())
+		Expect(result.Lines).To(HaveLen(3))
+		Expect(result.State.ListIDs).To(Equal([]uint{2}))
+	})
+
+	It("keeps the lines and state which were not synced after an error", func() {
+		client.Fail("UpdateTask", errors.New("some error"))
+
+		result, err := todotxt.Sync(client, lines, state, todotxt.Options{})
+		Expect(err).To(MatchError("some error"))
+
+		Expect(result.Lines).To(HaveLen(len(lines)))
+		Expect(result.Lines[1].String()).To(Equal("(A) Changed in wl +Work wl:11"))
+		Expect(result.Lines[2]).To(Equal(lines[2]))
+
+		Expect(result.State.Lines[uint(11)]).To(Equal("(A) Changed in wl +Work wl:11"))
+		Expect(result.State.Lines[uint(12)]).To(Equal("Edited +Work wl:12"))
+		Expect(result.State.Lines[uint(13)]).To(Equal("Removed +Work wl:13"))
+		Expect(client.Calls("DeleteTask")).To(BeZero())
+	})
+
+	It("keeps the line of a task which cannot be fetched", func() {
+		transportErr := errors.New("Get https://a.wunderlist.com/api/v1/tasks/9: connection refused")
+		client.Before("Task", func(args ...interface{}) error {
+			if args[0].(uint) == 9 {
+				return transportErr
+			}
+			return nil
+		})
+
+		result, err := todotxt.Sync(client, lines, state, todotxt.Options{})
+		Expect(err).To(Equal(transportErr))
+
+		Expect(result.Lines).To(ContainElement(lines[3]))
+		Expect(result.State.Lines[uint(9)]).To(Equal("Deleted in wl +Work wl:9"))
+		Expect(result.Changes).NotTo(ContainElement(
+			todotxt.Change{Action: todotxt.ActionRemove, TaskID: 9, Title: "Deleted in wl"},
+		))
+	})
+
+	It("returns an error if a task appears more than once", func() {
+		lines = append(lines, todotxt.Parse("Again wl:10"))
+
+		_, err := todotxt.Sync(client, lines, state, todotxt.Options{})
+		Expect(err).To(MatchError("task 10 appears more than once"))
+	})
+})
+
+var _ = Describe("State", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "todotxt")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("saves and loads", func() {
+		path := filepath.Join(dir, "todo.txt.state")
+
+		empty, err := todotxt.LoadState(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(empty.Lines).To(BeEmpty())
+
+		state := todotxt.NewState([]todotxt.Task{todotxt.Parse("One wl:3"), todotxt.Parse("Two")}, []uint{5, 2})
+		Expect(state.Save(path)).To(Succeed())
+
+		loaded, err := todotxt.LoadState(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(Equal(state))
+		Expect(loaded.ListIDs).To(Equal([]uint{2, 5}))
+		Expect(loaded.Lines).To(Equal(map[uint]string{3: "One wl:3"}))
+	})
+
+	It("writes files", func() {
+		path := filepath.Join(dir, "todo.txt")
+
+		err := todotxt.WriteFile(path, []todotxt.Task{todotxt.Parse("One"), todotxt.Parse("x Two")})
+		Expect(err).NotTo(HaveOccurred())
+
+		b, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(fmt.Sprintf("%s\n%s\n", "One", "x Two")))
+	})
+
+	It("keeps the permissions of existing files", func() {
+		path := filepath.Join(dir, "todo.txt")
+		Expect(ioutil.WriteFile(path, []byte("Old\n"), 0644)).To(Succeed())
+		Expect(os.Chmod(path, 0664)).To(Succeed())
+
+		Expect(todotxt.WriteFile(path, []todotxt.Task{todotxt.Parse("New")})).To(Succeed())
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0664)))
+	})
+})
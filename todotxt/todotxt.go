@@ -0,0 +1,206 @@
+// Package todotxt converts between Wunderlist tasks and todo.txt lines,
+// and syncs todo.txt files with Wunderlist.
+//
+// The todo.txt format is described at https://github.com/todotxt/todo.txt.
+package todotxt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/robdimsdale/wl"
+)
+
+// Task is a line of a todo.txt file.
+type Task struct {
+	Completed      bool
+	Priority       string
+	CompletionDate wl.Date
+	CreationDate   wl.Date
+
+	// Description is the rest of the line, including any projects,
+	// contexts and tags.
+	Description string
+}
+
+// Parse parses a line of a todo.txt file.
+func Parse(line string) Task {
+	var t Task
+	rest := strings.TrimSpace(line)
+
+	if strings.HasPrefix(rest, "x ") {
+		t.Completed = true
+		rest = strings.TrimLeft(rest[2:], " ")
+
+		var ok bool
+		t.CompletionDate, rest, ok = parseDate(rest)
+		if ok {
+			t.CreationDate, rest, _ = parseDate(rest)
+		}
+	} else {
+		if len(rest) >= 4 && rest[0] == '(' && rest[2] == ')' && rest[3] == ' ' &&
+			rest[1] >= 'A' && rest[1] <= 'Z' {
+			t.Priority = rest[1:2]
+			rest = strings.TrimLeft(rest[4:], " ")
+		}
+
+		t.CreationDate, rest, _ = parseDate(rest)
+	}
+
+	t.Description = rest
+	return t
+}
+
+// parseDate parses a leading date followed by a space.
+func parseDate(s string) (wl.Date, string, bool) {
+	if len(s) < 11 || s[10] != ' ' {
+		return wl.Date{}, s, false
+	}
+
+	d, err := wl.ParseDate(s[:10])
+	if err != nil {
+		return wl.Date{}, s, false
+	}
+	return d, strings.TrimLeft(s[11:], " "), true
+}
+
+// String returns the task as a todo.txt line.
+// The creation date of a completed task is only written with its completion date.
+func (t Task) String() string {
+	var parts []string
+
+	if t.Completed {
+		parts = append(parts, "x")
+		if !t.CompletionDate.IsZero() {
+			parts = append(parts, t.CompletionDate.String())
+			if !t.CreationDate.IsZero() {
+				parts = append(parts, t.CreationDate.String())
+			}
+		}
+	} else {
+		if t.Priority != "" {
+			parts = append(parts, fmt.Sprintf("(%s)", t.Priority))
+		}
+		if !t.CreationDate.IsZero() {
+			parts = append(parts, t.CreationDate.String())
+		}
+	}
+
+	return strings.Join(append(parts, t.Description), " ")
+}
+
+// Projects returns the projects in the description, without their "+".
+func (t Task) Projects() []string {
+	var projects []string
+	for _, w := range strings.Fields(t.Description) {
+		if isProject(w) {
+			projects = append(projects, w[1:])
+		}
+	}
+	return projects
+}
+
+// Contexts returns the contexts in the description, without their "@".
+func (t Task) Contexts() []string {
+	var contexts []string
+	for _, w := range strings.Fields(t.Description) {
+		if isContext(w) {
+			contexts = append(contexts, w[1:])
+		}
+	}
+	return contexts
+}
+
+// Tag returns the value of the first key:value tag with the key.
+func (t Task) Tag(key string) (string, bool) {
+	for _, w := range strings.Fields(t.Description) {
+		k, v, ok := splitTag(w)
+		if ok && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Title returns the description without the projects, contexts and tags
+// at its end. Those elsewhere in the description are kept.
+func (t Task) Title() string {
+	title := strings.TrimSpace(t.Description)
+	for title != "" {
+		i := strings.LastIndexAny(title, " \t")
+		last := title[i+1:]
+		if !isProject(last) && !isContext(last) && !isTag(last) {
+			break
+		}
+		title = strings.TrimSpace(title[:i+1])
+	}
+	return title
+}
+
+func isProject(w string) bool {
+	return len(w) > 1 && w[0] == '+'
+}
+
+func isContext(w string) bool {
+	return len(w) > 1 && w[0] == '@'
+}
+
+func isTag(w string) bool {
+	_, _, ok := splitTag(w)
+	return ok
+}
+
+// splitTag splits a key:value tag. Keys start with a letter and contain
+// letters, digits, "-" and "_". Values are not empty and do not contain
+// a colon or start with "/", so that times and URLs are not tags.
+func splitTag(w string) (string, string, bool) {
+	i := strings.Index(w, ":")
+	if i < 1 {
+		return "", "", false
+	}
+
+	key, value := w[:i], w[i+1:]
+	if value == "" || strings.Contains(value, ":") || value[0] == '/' {
+		return "", "", false
+	}
+
+	for j, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case j > 0 && (r >= '0' && r <= '9' || r == '-' || r == '_'):
+		default:
+			return "", "", false
+		}
+	}
+	return key, value, true
+}
+
+// Read reads the lines of a todo.txt file, skipping blank lines.
+func Read(r io.Reader) ([]Task, error) {
+	var tasks []Task
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if strings.TrimSpace(s.Text()) == "" {
+			continue
+		}
+		tasks = append(tasks, Parse(s.Text()))
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// Write writes the tasks as a todo.txt file.
+func Write(w io.Writer, tasks []Task) error {
+	for _, t := range tasks {
+		_, err := fmt.Fprintln(w, t.String())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package todotxt_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTodotxt(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Todotxt Suite")
+}
@@ -0,0 +1,100 @@
+package todotxt_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/todotxt"
+)
+
+var _ = Describe("Task", func() {
+	Describe("Parse", func() {
+		It("parses priority and creation date", func() {
+			t := todotxt.Parse("(A) 2016-01-05 Call mom +Family @phone due:2016-01-06")
+
+			Expect(t.Completed).To(BeFalse())
+			Expect(t.Priority).To(Equal("A"))
+			Expect(t.CreationDate).To(Equal(wl.NewDate(2016, time.January, 5)))
+			Expect(t.Description).To(Equal("Call mom +Family @phone due:2016-01-06"))
+		})
+
+		It("parses completion and creation dates", func() {
+			t := todotxt.Parse("x 2016-01-06 2016-01-05 Call mom")
+
+			Expect(t.Completed).To(BeTrue())
+			Expect(t.CompletionDate).To(Equal(wl.NewDate(2016, time.January, 6)))
+			Expect(t.CreationDate).To(Equal(wl.NewDate(2016, time.January, 5)))
+			Expect(t.Description).To(Equal("Call mom"))
+		})
+
+		It("treats a single date of a completed task as its completion date", func() {
+			t := todotxt.Parse("x 2016-01-06 Call mom")
+
+			Expect(t.CompletionDate).To(Equal(wl.NewDate(2016, time.January, 6)))
+			Expect(t.CreationDate.IsZero()).To(BeTrue())
+		})
+
+		It("does not treat text resembling a priority or completion as one", func() {
+			Expect(todotxt.Parse("(a) lowercase").Priority).To(BeEmpty())
+			Expect(todotxt.Parse("xylophone lessons").Completed).To(BeFalse())
+			Expect(todotxt.Parse("X marks the spot").Completed).To(BeFalse())
+			Expect(todotxt.Parse("2016-01-05T10:00 meeting").CreationDate.IsZero()).To(BeTrue())
+		})
+
+		It("round trips", func() {
+			for _, line := range []string{
+				"(B) 2016-01-05 Call mom +Family",
+				"x 2016-01-06 2016-01-05 Call mom",
+				"x Call mom",
+				"Plain task",
+			} {
+				Expect(todotxt.Parse(line).String()).To(Equal(line))
+			}
+		})
+	})
+
+	Describe("Projects, Contexts and Tag", func() {
+		It("finds them anywhere in the description", func() {
+			t := todotxt.Parse("Plan +Launch with @bob at 10:30 see http://example.com due:2016-01-06 +Work")
+
+			Expect(t.Projects()).To(Equal([]string{"Launch", "Work"}))
+			Expect(t.Contexts()).To(Equal([]string{"bob"}))
+
+			due, ok := t.Tag("due")
+			Expect(ok).To(BeTrue())
+			Expect(due).To(Equal("2016-01-06"))
+
+			_, ok = t.Tag("http")
+			Expect(ok).To(BeFalse())
+			_, ok = t.Tag("10")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("Title", func() {
+		It("removes trailing projects, contexts and tags only", func() {
+			t := todotxt.Parse("(A) Plan +Launch with @bob today +Work @home due:2016-01-06 wl:12")
+			Expect(t.Title()).To(Equal("Plan +Launch with @bob today"))
+		})
+
+		It("is empty if there are only projects, contexts and tags", func() {
+			Expect(todotxt.Parse("+Work @home").Title()).To(BeEmpty())
+		})
+	})
+
+	Describe("Read and Write", func() {
+		It("skips blank lines", func() {
+			tasks, err := todotxt.Read(strings.NewReader("(A) One\n\n  \nx Two\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tasks).To(HaveLen(2))
+
+			var buf bytes.Buffer
+			Expect(todotxt.Write(&buf, tasks)).To(Succeed())
+			Expect(buf.String()).To(Equal("(A) One\nx Two\n"))
+		})
+	})
+})
@@ -0,0 +1,134 @@
+package tree
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/robdimsdale/wl"
+)
+
+// WriteMarkdown renders the tree as Markdown. The title of the tree is the
+// top heading, if any, followed by a heading for each list. Tasks are a task
+// list, with their due dates and assignees inline, followed by their notes
+// and comments as a quote and their subtasks as a nested task list.
+// Comments are only rendered if they were loaded, dated in the location.
+func WriteMarkdown(w io.Writer, t Tree, loc *time.Location) error {
+	var b bytes.Buffer
+
+	listHeading := "#"
+	if t.Title != "" {
+		fmt.Fprintf(&b, "# %s\n", escapeMarkdown(t.Title))
+		listHeading = "##"
+	}
+
+	err := Walk(t, func(n Node) error {
+		switch n.Kind {
+		case ListNode:
+			if b.Len() > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "%s %s\n\n", listHeading, escapeMarkdown(n.List.Title))
+			if len(n.List.Tasks) == 0 {
+				b.WriteString("_No tasks_\n")
+			}
+
+		case TaskNode:
+			fmt.Fprintf(
+				&b,
+				"- %s %s%s\n",
+				checkbox(n.Task.Completed),
+				escapeMarkdown(n.Task.Title),
+				details(n.Task),
+			)
+			writeQuote(&b, "  ", quoted(n.Task, loc))
+
+		case SubtaskNode:
+			fmt.Fprintf(&b, "  - %s %s\n", checkbox(n.Subtask.Completed), escapeMarkdown(n.Subtask.Title))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = b.WriteTo(w)
+	return err
+}
+
+func checkbox(completed bool) string {
+	if completed {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+// details returns the due date and assignee of the task
+// in the form " (due YYYY-MM-DD, @name)", omitting whichever is not present.
+func details(t Task) string {
+	parts := []string{}
+	if !t.DueDate.IsZero() {
+		parts = append(parts, "due "+t.DueDate.String())
+	}
+	if t.Assignee.Name != "" {
+		parts = append(parts, "@"+escapeMarkdown(t.Assignee.Name))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// quoted returns the paragraphs quoted below the task:
+// its note followed by its comments.
+func quoted(t Task, loc *time.Location) []string {
+	var paragraphs []string
+	if note := strings.TrimSpace(t.Note); note != "" {
+		paragraphs = append(paragraphs, note)
+	}
+
+	for _, c := range t.Comments {
+		paragraphs = append(paragraphs, fmt.Sprintf(
+			"**Comment, %s:** %s",
+			wl.DateOf(c.CreatedAt.In(loc)),
+			strings.TrimSpace(c.Text),
+		))
+	}
+	return paragraphs
+}
+
+// writeQuote writes the paragraphs as a single block quote, with each line
+// indented so that the quote belongs to the preceding list item. The
+// paragraphs are not escaped, as notes are often written in Markdown.
+func writeQuote(b *bytes.Buffer, indent string, paragraphs []string) {
+	for i, p := range paragraphs {
+		if i > 0 {
+			fmt.Fprintf(b, "%s>\n", indent)
+		}
+
+		for _, line := range strings.Split(p, "\n") {
+			line = strings.TrimRight(line, "\r")
+			if line == "" {
+				fmt.Fprintf(b, "%s>\n", indent)
+				continue
+			}
+			fmt.Fprintf(b, "%s> %s\n", indent, line)
+		}
+	}
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
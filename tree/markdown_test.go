@@ -0,0 +1,74 @@
+package tree_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/tree"
+)
+
+var _ = Describe("WriteMarkdown", func() {
+	It("renders a folder with headings, task lists, notes and comments", func() {
+		t := tree.Tree{
+			Title: "Projects",
+			Lists: []tree.List{
+				{
+					List: wl.List{Title: "Work_stuff"},
+					Tasks: []tree.Task{
+						{
+							Task: wl.Task{
+								Title:   "Write [report]",
+								DueDate: wl.NewDate(2016, time.January, 6),
+							},
+							Assignee: wl.User{Name: "Jane Doe"},
+							Note:     "Some **notes**\n\nSecond paragraph\n",
+							Subtasks: []wl.Subtask{
+								{Title: "Draft", Completed: true},
+								{Title: "Review"},
+							},
+							Comments: []wl.TaskComment{
+								{Text: "Looks good", CreatedAt: time.Date(2016, time.January, 6, 3, 0, 0, 0, time.UTC)},
+							},
+						},
+						{Task: wl.Task{Title: "Done", Completed: true}},
+					},
+				},
+				{List: wl.List{Title: "Empty"}},
+			},
+		}
+
+		var buf bytes.Buffer
+		Expect(tree.WriteMarkdown(&buf, t, time.FixedZone("test", -8*60*60))).To(Succeed())
+
+		Expect(buf.String()).To(Equal(`# Projects
+
+## Work\_stuff
+
+- [ ] Write \[report\] (due 2016-01-06, @Jane Doe)
+  > Some **notes**
+  >
+  > Second paragraph
+  >
+  > **Comment, 2016-01-05:** Looks good
+  - [x] Draft
+  - [ ] Review
+- [x] Done
+
+## Empty
+
+_No tasks_
+`))
+	})
+
+	It("renders lists without a title as top-level headings", func() {
+		t := tree.Tree{Lists: []tree.List{{List: wl.List{Title: "Work"}, Tasks: []tree.Task{{Task: wl.Task{Title: "Task"}}}}}}
+
+		var buf bytes.Buffer
+		Expect(tree.WriteMarkdown(&buf, t, time.UTC)).To(Succeed())
+
+		Expect(buf.String()).To(Equal("# Work\n\n- [ ] Task\n"))
+	})
+})
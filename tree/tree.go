@@ -0,0 +1,253 @@
+/*
+Package tree fetches lists with their tasks, subtasks, notes and comments as
+a tree, and renders it in formats such as Markdown.
+*/
+package tree
+
+import (
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/position"
+)
+
+// Task is a task with its assignee, note, subtasks and comments.
+type Task struct {
+	wl.Task
+
+	// Assignee is the user the task is assigned to,
+	// or the zero User if it is not assigned.
+	Assignee wl.User
+
+	Note     string
+	Subtasks []wl.Subtask
+	Comments []wl.TaskComment
+}
+
+// List is a list with its tasks.
+type List struct {
+	wl.List
+	Tasks []Task
+}
+
+// Tree is a group of lists, such as a folder. Title is empty if the
+// lists are not a folder.
+type Tree struct {
+	Title string
+	Lists []List
+}
+
+// Options configure which parts of lists are loaded.
+type Options struct {
+	// Completed includes completed tasks after the incomplete tasks.
+	// Completed subtasks are always included.
+	Completed bool
+
+	// Comments includes the comments of tasks.
+	Comments bool
+}
+
+// Load fetches the lists with the provided IDs, in that order.
+func Load(client wl.Client, listIDs []uint, opts Options) (Tree, error) {
+	var lists []wl.List
+	for _, id := range listIDs {
+		l, err := client.List(id)
+		if err != nil {
+			return Tree{}, err
+		}
+		lists = append(lists, l)
+	}
+
+	return load(client, "", lists, opts)
+}
+
+// LoadFolder fetches the lists in the folder, ordered by their positions.
+// The title of the tree is the title of the folder.
+func LoadFolder(client wl.Client, folderID uint, opts Options) (Tree, error) {
+	folder, err := client.Folder(folderID)
+	if err != nil {
+		return Tree{}, err
+	}
+
+	lists, err := orderedLists(client)
+	if err != nil {
+		return Tree{}, err
+	}
+
+	inFolder := map[uint]bool{}
+	for _, id := range folder.ListIDs {
+		inFolder[id] = true
+	}
+
+	var folderLists []wl.List
+	for _, l := range lists {
+		if inFolder[l.ID] {
+			folderLists = append(folderLists, l)
+		}
+	}
+
+	return load(client, folder.Title, folderLists, opts)
+}
+
+// LoadAll fetches all lists, ordered by their positions.
+func LoadAll(client wl.Client, opts Options) (Tree, error) {
+	lists, err := orderedLists(client)
+	if err != nil {
+		return Tree{}, err
+	}
+
+	return load(client, "", lists, opts)
+}
+
+func orderedLists(client wl.Client) ([]wl.List, error) {
+	lists, err := client.Lists()
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := client.ListPositions()
+	if err != nil {
+		return nil, err
+	}
+
+	return position.OrderLists(lists, positions), nil
+}
+
+func load(client wl.Client, title string, lists []wl.List, opts Options) (Tree, error) {
+	users, err := client.Users()
+	if err != nil {
+		return Tree{}, err
+	}
+
+	usersByID := map[uint]wl.User{}
+	for _, u := range users {
+		usersByID[u.ID] = u
+	}
+
+	t := Tree{Title: title, Lists: []List{}}
+	for _, l := range lists {
+		list, err := loadList(client, l, usersByID, opts)
+		if err != nil {
+			return Tree{}, err
+		}
+		t.Lists = append(t.Lists, list)
+	}
+
+	return t, nil
+}
+
+func loadList(client wl.Client, l wl.List, users map[uint]wl.User, opts Options) (List, error) {
+	tasks, err := position.TasksForListID(client, l.ID)
+	if err != nil {
+		return List{}, err
+	}
+
+	if opts.Completed {
+		completed, err := client.CompletedTasksForListID(l.ID, true)
+		if err != nil {
+			return List{}, err
+		}
+		tasks = append(tasks, completed...)
+	}
+
+	var subtasks []wl.Subtask
+	for _, completed := range []bool{false, true} {
+		s, err := client.CompletedSubtasksForListID(l.ID, completed)
+		if err != nil {
+			return List{}, err
+		}
+		subtasks = append(subtasks, s...)
+	}
+
+	subtaskPositions, err := client.SubtaskPositionsForListID(l.ID)
+	if err != nil {
+		return List{}, err
+	}
+
+	notes, err := client.NotesForListID(l.ID)
+	if err != nil {
+		return List{}, err
+	}
+
+	var comments []wl.TaskComment
+	if opts.Comments {
+		comments, err = client.TaskCommentsForListID(l.ID)
+		if err != nil {
+			return List{}, err
+		}
+	}
+
+	subtasksByTask := map[uint][]wl.Subtask{}
+	for _, s := range position.OrderSubtasks(subtasks, subtaskPositions) {
+		subtasksByTask[s.TaskID] = append(subtasksByTask[s.TaskID], s)
+	}
+
+	notesByTask := map[uint]string{}
+	for _, n := range notes {
+		notesByTask[n.TaskID] = n.Content
+	}
+
+	commentsByTask := map[uint][]wl.TaskComment{}
+	for _, c := range comments {
+		commentsByTask[c.TaskID] = append(commentsByTask[c.TaskID], c)
+	}
+
+	list := List{List: l, Tasks: []Task{}}
+	for _, t := range tasks {
+		list.Tasks = append(list.Tasks, Task{
+			Task:     t,
+			Assignee: users[t.AssigneeID],
+			Note:     notesByTask[t.ID],
+			Subtasks: subtasksByTask[t.ID],
+			Comments: commentsByTask[t.ID],
+		})
+	}
+
+	return list, nil
+}
+
+// NodeKind is the kind of a node visited by Walk.
+type NodeKind int
+
+// The kinds of node in a tree.
+const (
+	ListNode NodeKind = iota
+	TaskNode
+	SubtaskNode
+)
+
+// Node is a list, task or subtask visited by Walk. List is the list of the
+// node, Task is set for tasks and subtasks, and Subtask is set for subtasks.
+// Depth is 0 for lists, 1 for tasks and 2 for subtasks.
+type Node struct {
+	Kind    NodeKind
+	Depth   int
+	List    List
+	Task    Task
+	Subtask wl.Subtask
+}
+
+// Walk calls fn for each list in the tree, followed by each of its tasks in
+// order, each followed by its subtasks. It stops at the first error from fn
+// and returns it.
+func Walk(t Tree, fn func(Node) error) error {
+	for _, l := range t.Lists {
+		err := fn(Node{Kind: ListNode, List: l})
+		if err != nil {
+			return err
+		}
+
+		for _, task := range l.Tasks {
+			err := fn(Node{Kind: TaskNode, Depth: 1, List: l, Task: task})
+			if err != nil {
+				return err
+			}
+
+			for _, s := range task.Subtasks {
+				err := fn(Node{Kind: SubtaskNode, Depth: 2, List: l, Task: task, Subtask: s})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package tree_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/tree"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+func newClient() *wltest.Client {
+	client := wltest.NewClient()
+	client.AddUser(wl.User{ID: 7, Name: "Jane Doe"})
+
+	client.AddList(wl.List{ID: 1, Title: "inbox", ListType: "inbox"})
+	client.AddList(wl.List{ID: 2, Title: "Work"})
+	client.AddList(wl.List{ID: 3, Title: "Home"})
+	client.AddFolder(wl.Folder{ID: 5, Title: "Projects", ListIDs: []uint{2, 3}})
+	client.SetListPosition(3, 2)
+
+	client.AddTask(wl.Task{ID: 10, ListID: 2, Title: "Second", AssigneeID: 7})
+	client.AddTask(wl.Task{ID: 11, ListID: 2, Title: "First", DueDate: wl.NewDate(2016, time.January, 6)})
+	client.AddTask(wl.Task{ID: 12, ListID: 2, Title: "Done", Completed: true})
+	client.AddTask(wl.Task{ID: 13, ListID: 3, Title: "Elsewhere"})
+	client.SetTaskPosition(2, 11, 10)
+
+	client.AddSubtask(wl.Subtask{ID: 20, TaskID: 11, Title: "Later step"})
+	client.AddSubtask(wl.Subtask{ID: 21, TaskID: 11, Title: "Earlier step", Completed: true})
+	client.SetSubtaskPosition(11, 21, 20)
+
+	client.AddNote(wl.Note{TaskID: 11, Content: "Some **notes**\n\nSecond paragraph\n"})
+	client.AddTaskComment(wl.TaskComment{
+		TaskID:    11,
+		Text:      "Looks good",
+		CreatedAt: time.Date(2016, time.January, 5, 12, 0, 0, 0, time.UTC),
+	})
+	return client
+}
+
+func titles(t tree.Tree) []string {
+	var titles []string
+	tree.Walk(t, func(n tree.Node) error {
+		switch n.Kind {
+		case tree.ListNode:
+			titles = append(titles, n.List.Title)
+		case tree.TaskNode:
+			titles = append(titles, "- "+n.Task.Title)
+		case tree.SubtaskNode:
+			titles = append(titles, "  - "+n.Subtask.Title)
+		}
+		return nil
+	})
+	return titles
+}
+
+var _ = Describe("Tree", func() {
+	var client *wltest.Client
+
+	BeforeEach(func() {
+		client = newClient()
+	})
+
+	Describe("Load", func() {
+		It("loads the lists in order with their tasks and subtasks in position order", func() {
+			t, err := tree.Load(client, []uint{2}, tree.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(t.Title).To(BeEmpty())
+			Expect(titles(t)).To(Equal([]string{
+				"Work",
+				"- First",
+				"  - Earlier step",
+				"  - Later step",
+				"- Second",
+			}))
+
+			first := t.Lists[0].Tasks[0]
+			Expect(first.Note).To(Equal("Some **notes**\n\nSecond paragraph\n"))
+			Expect(first.Comments).To(BeEmpty())
+			Expect(t.Lists[0].Tasks[1].Assignee.Name).To(Equal("Jane Doe"))
+		})
+
+		It("includes completed tasks and comments if requested", func() {
+			t, err := tree.Load(client, []uint{2}, tree.Options{Completed: true, Comments: true})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(t.Lists[0].Tasks).To(HaveLen(3))
+			Expect(t.Lists[0].Tasks[2].Title).To(Equal("Done"))
+			comments, err := client.TaskCommentsForTaskID(11)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.Lists[0].Tasks[0].Comments).To(Equal(comments))
+		})
+
+		It("returns errors from the client", func() {
+			_, err := tree.Load(client, []uint{99}, tree.Options{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("LoadFolder", func() {
+		It("loads the lists in the folder in position order", func() {
+			t, err := tree.LoadFolder(client, 5, tree.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(t.Title).To(Equal("Projects"))
+			Expect(t.Lists).To(HaveLen(2))
+			Expect(t.Lists[0].Title).To(Equal("Home"))
+			Expect(t.Lists[1].Title).To(Equal("Work"))
+		})
+	})
+
+	Describe("LoadAll", func() {
+		It("loads all lists with the inbox first", func() {
+			t, err := tree.LoadAll(client, tree.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(t.Lists).To(HaveLen(3))
+			Expect(t.Lists[0].Title).To(Equal("inbox"))
+		})
+	})
+
+	Describe("Walk", func() {
+		It("stops at the first error", func() {
+			t, err := tree.Load(client, []uint{2, 3}, tree.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			var visited int
+			err = tree.Walk(t, func(n tree.Node) error {
+				visited++
+				if n.Kind == tree.SubtaskNode {
+					return errors.New("stop")
+				}
+				return nil
+			})
+			Expect(err).To(MatchError("stop"))
+			Expect(visited).To(Equal(3))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package wl_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestWl(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Wl Suite")
+}
@@ -0,0 +1,199 @@
+// Package wltest provides an in-memory wl.Client for tests.
+//
+// The Client behaves like an account: objects are created, updated and
+// deleted in memory, updates and deletes are rejected with a 409 if the
+// revision provided is out of date, and missing objects are reported with
+// a 404, as wl.StatusError values. Any change to a list or its contents
+// increments the revisions of the list and of the root, so code which syncs
+// by revision can be tested too.
+//
+// Accounts are seeded with the Add and Set methods, which store objects
+// as given without changing any revisions. Calls can be made to fail with
+// Before, Fail and Conflict, and counted with Calls.
+package wltest
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/robdimsdale/wl"
+)
+
+// Hook is called with the arguments of a call before the call is made.
+// If it returns an error the call returns the error without doing anything.
+type Hook func(args ...interface{}) error
+
+// Client is an in-memory account implementing wl.Client.
+// It is safe for concurrent use.
+type Client struct {
+	mutex sync.Mutex
+
+	hooks map[string][]Hook
+	calls map[string]int
+
+	nextID uint
+	root   wl.Root
+
+	user  wl.User
+	users []wl.User
+
+	lists       []wl.List
+	folders     []wl.Folder
+	tasks       []wl.Task
+	subtasks    []wl.Subtask
+	notes       []wl.Note
+	reminders   []wl.Reminder
+	comments    []wl.TaskComment
+	memberships []wl.Membership
+	webhooks    []wl.Webhook
+	files       []wl.File
+
+	uploads  map[uint]upload
+	contents map[uint][]byte
+
+	listPosition     wl.Position
+	taskPositions    []wl.Position
+	subtaskPositions []wl.Position
+}
+
+var _ wl.Client = (*Client)(nil)
+
+// NewClient returns a Client for an empty account.
+func NewClient() *Client {
+	return &Client{
+		hooks:        map[string][]Hook{},
+		calls:        map[string]int{},
+		root:         wl.Root{ID: 1, Revision: 1},
+		uploads:      map[uint]upload{},
+		contents:     map[uint][]byte{},
+		listPosition: wl.Position{ID: 1, Values: []uint{}, Revision: 1},
+	}
+}
+
+var clientType = reflect.TypeOf((*wl.Client)(nil)).Elem()
+
+// Before registers the hook to be called before each call of the method,
+// after any hooks already registered for it. It panics if the method is
+// not a method of wl.Client.
+func (c *Client) Before(method string, hook Hook) {
+	if _, ok := clientType.MethodByName(method); !ok {
+		panic(fmt.Sprintf("wltest: %s is not a method of wl.Client", method))
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.hooks[method] = append(c.hooks[method], hook)
+}
+
+// Fail makes every subsequent call of the method return err.
+func (c *Client) Fail(method string, err error) {
+	c.Before(method, func(...interface{}) error {
+		return err
+	})
+}
+
+// Conflict makes the next n calls of the method fail with a 409,
+// as if the object had been concurrently modified.
+func (c *Client) Conflict(method string, n int) {
+	var mutex sync.Mutex
+	c.Before(method, func(...interface{}) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if n == 0 {
+			return nil
+		}
+		n--
+		return conflict(http.StatusOK)
+	})
+}
+
+// Calls returns the number of calls of the method, including those which failed.
+func (c *Client) Calls(method string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.calls[method]
+}
+
+// call counts the call and runs the hooks of the method.
+func (c *Client) call(method string, args ...interface{}) error {
+	c.mutex.Lock()
+	c.calls[method]++
+	hooks := c.hooks[method]
+	c.mutex.Unlock()
+
+	for _, hook := range hooks {
+		err := hook(args...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Root returns the root of the account.
+func (c *Client) Root() (wl.Root, error) {
+	if err := c.call("Root"); err != nil {
+		return wl.Root{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	root := c.root
+	root.UserID = c.user.ID
+	return root, nil
+}
+
+// id returns the ID of a new object, which is id if it is set.
+func (c *Client) id(id uint) uint {
+	if id == 0 {
+		c.nextID++
+		return c.nextID
+	}
+	if id > c.nextID {
+		c.nextID = id
+	}
+	return id
+}
+
+// touch increments the revision of the root and, if listID is set,
+// of the list, as the API does when anything in the list changes.
+func (c *Client) touch(listID uint) {
+	c.root.Revision++
+
+	if i := c.listIndex(listID); i >= 0 {
+		c.lists[i].Revision++
+	}
+}
+
+// touchTask touches the list of the task.
+func (c *Client) touchTask(taskID uint) {
+	c.touch(c.listIDOfTask(taskID))
+}
+
+func (c *Client) listIDOfTask(taskID uint) uint {
+	if i := c.taskIndex(taskID); i >= 0 {
+		return c.tasks[i].ListID
+	}
+	return 0
+}
+
+func notFound(expected int) error {
+	return wl.StatusError{StatusCode: http.StatusNotFound, Expected: expected}
+}
+
+func conflict(expected int) error {
+	return wl.StatusError{StatusCode: http.StatusConflict, Expected: expected}
+}
+
+func copyIDs(ids []uint) []uint {
+	if ids == nil {
+		return nil
+	}
+	return append([]uint{}, ids...)
+}
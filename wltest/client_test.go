@@ -0,0 +1,240 @@
+package wltest_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robdimsdale/wl"
+	"github.com/robdimsdale/wl/wltest"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		client *wltest.Client
+		list   wl.List
+		task   wl.Task
+	)
+
+	BeforeEach(func() {
+		client = wltest.NewClient()
+		client.SetUser(wl.User{ID: 7, Name: "Jane"})
+
+		list = client.AddList(wl.List{Title: "Work", Revision: 1})
+		task = client.AddTask(wl.Task{ListID: list.ID, Title: "Write report", Revision: 1})
+	})
+
+	It("assigns IDs after those already added", func() {
+		created, err := client.CreateTask("Review", list.ID, 0, false, wl.RecurrenceNone, 0, wl.Date{}, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created.ID).To(BeNumerically(">", task.ID))
+		Expect(created.CreatedByID).To(Equal(uint(7)))
+		Expect(created.Revision).To(Equal(uint(1)))
+	})
+
+	It("returns typed errors for missing objects", func() {
+		_, err := client.Task(1234)
+		Expect(wl.IsNotFound(err)).To(BeTrue())
+
+		_, err = client.CreateTask("Orphan", 1234, 0, false, wl.RecurrenceNone, 0, wl.Date{}, false)
+		Expect(wl.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("increments revisions and rejects updates with old revisions", func() {
+		task.Title = "Write the report"
+		updated, err := client.UpdateTask(task)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Revision).To(Equal(uint(2)))
+
+		_, err = client.UpdateTask(task)
+		Expect(wl.IsConflict(err)).To(BeTrue())
+
+		err = client.DeleteTask(task)
+		Expect(wl.IsConflict(err)).To(BeTrue())
+	})
+
+	It("increments the revisions of the list and root when the list changes", func() {
+		root, err := client.Root()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(root.UserID).To(Equal(uint(7)))
+
+		_, err = client.CreateNote("Keep it short", task.ID)
+		Expect(err).NotTo(HaveOccurred())
+
+		l, err := client.List(list.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(l.Revision).To(Equal(list.Revision + 1))
+
+		updatedRoot, err := client.Root()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updatedRoot.Revision).To(Equal(root.Revision + 1))
+	})
+
+	It("filters tasks and subtasks by whether they are completed", func() {
+		done := client.AddTask(wl.Task{ListID: list.ID, Title: "Done", Completed: true})
+		client.AddSubtask(wl.Subtask{TaskID: done.ID, Title: "Step", Completed: true})
+
+		tasks, err := client.TasksForListID(list.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(Equal([]wl.Task{task}))
+
+		completed, err := client.CompletedTasks(true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(completed).To(Equal([]wl.Task{done}))
+
+		subtasks, err := client.CompletedSubtasksForListID(list.ID, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subtasks).To(HaveLen(1))
+	})
+
+	It("deletes the contents of deleted tasks and lists", func() {
+		client.AddNote(wl.Note{TaskID: task.ID, Content: "Keep it short"})
+		folder := client.AddFolder(wl.Folder{Title: "Projects", ListIDs: []uint{list.ID}})
+
+		err := client.DeleteList(list)
+		Expect(err).NotTo(HaveOccurred())
+
+		tasks, err := client.CompletedTasks(false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(BeEmpty())
+
+		notes, err := client.Notes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(notes).To(BeEmpty())
+
+		f, err := client.Folder(folder.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.ListIDs).To(BeEmpty())
+	})
+
+	Describe("positions", func() {
+		It("has a task position for each list and a subtask position for each task", func() {
+			client.SetTaskPosition(list.ID, task.ID)
+
+			positions, err := client.TaskPositionsForListID(list.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(positions).To(Equal([]wl.Position{{ID: list.ID, Values: []uint{task.ID}, Revision: 1}}))
+
+			positions, err = client.SubtaskPositionsForTaskID(task.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(positions).To(Equal([]wl.Position{{ID: task.ID, Values: []uint{}, Revision: 1}}))
+		})
+
+		It("does not share values with callers", func() {
+			positions, err := client.ListPositions()
+			Expect(err).NotTo(HaveOccurred())
+
+			p := positions[0]
+			p.Values = append(p.Values, list.ID)
+			_, err = client.UpdateListPosition(p)
+			Expect(err).NotTo(HaveOccurred())
+
+			p.Values[0] = 1234
+
+			positions, err = client.ListPositions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(positions[0].Values).To(Equal([]uint{list.ID}))
+		})
+
+		It("rejects updates with old revisions", func() {
+			positions, err := client.TaskPositionsForListID(list.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.UpdateTaskPosition(positions[0])
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.UpdateTaskPosition(positions[0])
+			Expect(wl.IsConflict(err)).To(BeTrue())
+		})
+	})
+
+	Describe("memberships", func() {
+		It("invites users by email address", func() {
+			jane := client.AddUser(wl.User{Email: "jane@example.com"})
+
+			m, err := client.AddMemberToListViaEmailAddress("Jane@example.com", list.ID, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.UserID).To(Equal(jane.ID))
+			Expect(m.State).To(Equal("pending"))
+
+			users, err := client.UsersForListID(list.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(users).To(Equal([]wl.User{jane}))
+		})
+	})
+
+	Describe("files", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "wltest")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("attaches uploaded files to tasks", func() {
+			path := filepath.Join(dir, "file")
+			Expect(ioutil.WriteFile(path, []byte("contents"), 0600)).To(Succeed())
+
+			upload, err := client.UploadFile(path, "notes.txt", "text/plain", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			file, err := client.CreateFile(upload.ID, task.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.ListID).To(Equal(list.ID))
+			Expect(file.FileName).To(Equal("notes.txt"))
+			Expect(client.FileContents(file.ID)).To(Equal([]byte("contents")))
+		})
+	})
+
+	Describe("hooks", func() {
+		It("fails calls", func() {
+			client.Fail("CreateNote", errors.New("some error"))
+
+			_, err := client.CreateNote("Keep it short", task.ID)
+			Expect(err).To(MatchError("some error"))
+
+			notes, err := client.Notes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notes).To(BeEmpty())
+		})
+
+		It("fails a number of calls with conflicts", func() {
+			client.Conflict("Task", 2)
+
+			_, err := client.Task(task.ID)
+			Expect(wl.IsConflict(err)).To(BeTrue())
+			_, err = client.Task(task.ID)
+			Expect(wl.IsConflict(err)).To(BeTrue())
+			_, err = client.Task(task.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.Calls("Task")).To(Equal(3))
+		})
+
+		It("passes the arguments of calls", func() {
+			var dates []time.Time
+			client.Before("CreateReminder", func(args ...interface{}) error {
+				dates = append(dates, args[0].(time.Time))
+				return nil
+			})
+
+			date := time.Date(2026, time.November, 2, 9, 0, 0, 0, time.UTC)
+			_, err := client.CreateReminder(date, task.ID, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dates).To(Equal([]time.Time{date}))
+		})
+
+		It("rejects methods which are not methods of wl.Client", func() {
+			Expect(func() { client.Fail("CreateTodo", errors.New("some error")) }).To(Panic())
+		})
+	})
+})
@@ -0,0 +1,218 @@
+package wltest
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/robdimsdale/wl"
+)
+
+type upload struct {
+	fileName    string
+	contentType string
+	contents    []byte
+}
+
+// AddFile adds the file with the contents. If the file has no ID, one is assigned.
+func (c *Client) AddFile(file wl.File, contents []byte) wl.File {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	file.ID = c.id(file.ID)
+	c.files = append(c.files, file)
+	c.contents[file.ID] = contents
+	return file
+}
+
+// FileContents returns the contents of the file, or nil if there is no such file.
+func (c *Client) FileContents(fileID uint) []byte {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.contents[fileID]
+}
+
+func (c *Client) fileIndex(fileID uint) int {
+	for i, f := range c.files {
+		if f.ID == fileID {
+			return i
+		}
+	}
+	return -1
+}
+
+// filesWhere returns the files for which keep returns true.
+func (c *Client) filesWhere(keep func(wl.File) bool) []wl.File {
+	files := []wl.File{}
+	for _, f := range c.files {
+		if keep(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// UploadFile reads the local file and stores its contents, to be attached
+// to a task with CreateFile.
+func (c *Client) UploadFile(
+	localFilePath string,
+	remoteFileName string,
+	contentType string,
+	md5sum string,
+) (wl.Upload, error) {
+	if err := c.call("UploadFile", localFilePath, remoteFileName, contentType, md5sum); err != nil {
+		return wl.Upload{}, err
+	}
+
+	if remoteFileName == "" {
+		return wl.Upload{}, errors.New("remoteFileName must be non-empty")
+	}
+
+	contents, err := ioutil.ReadFile(localFilePath)
+	if err != nil {
+		return wl.Upload{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id := c.id(0)
+	c.uploads[id] = upload{
+		fileName:    remoteFileName,
+		contentType: contentType,
+		contents:    contents,
+	}
+	return wl.Upload{ID: id, UserID: c.user.ID, State: "finished"}, nil
+}
+
+// Files returns the files of all lists.
+func (c *Client) Files() ([]wl.File, error) {
+	if err := c.call("Files"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.filesWhere(func(wl.File) bool { return true }), nil
+}
+
+// FilesForTaskID returns the files of the task.
+func (c *Client) FilesForTaskID(taskID uint) ([]wl.File, error) {
+	if err := c.call("FilesForTaskID", taskID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.taskIndex(taskID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.filesWhere(func(f wl.File) bool { return f.TaskID == taskID }), nil
+}
+
+// FilesForListID returns the files of tasks in the list.
+func (c *Client) FilesForListID(listID uint) ([]wl.File, error) {
+	if err := c.call("FilesForListID", listID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.filesWhere(func(f wl.File) bool { return f.ListID == listID }), nil
+}
+
+// File returns the file.
+func (c *Client) File(fileID uint) (wl.File, error) {
+	if err := c.call("File", fileID); err != nil {
+		return wl.File{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.fileIndex(fileID)
+	if i < 0 {
+		return wl.File{}, notFound(http.StatusOK)
+	}
+	return c.files[i], nil
+}
+
+// CreateFile attaches the uploaded file to the task.
+func (c *Client) CreateFile(uploadID uint, taskID uint) (wl.File, error) {
+	if err := c.call("CreateFile", uploadID, taskID); err != nil {
+		return wl.File{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	u, ok := c.uploads[uploadID]
+	if !ok || c.taskIndex(taskID) < 0 {
+		return wl.File{}, notFound(http.StatusCreated)
+	}
+
+	id := c.id(0)
+	file := wl.File{
+		ID:          id,
+		URL:         fmt.Sprintf("https://files.example.com/%d/%s", id, u.fileName),
+		TaskID:      taskID,
+		ListID:      c.listIDOfTask(taskID),
+		UserID:      c.user.ID,
+		FileName:    u.fileName,
+		ContentType: u.contentType,
+		FileSize:    len(u.contents),
+		Revision:    1,
+	}
+	c.files = append(c.files, file)
+	c.contents[id] = u.contents
+	c.touch(file.ListID)
+	return file, nil
+}
+
+// DestroyFile deletes the file.
+func (c *Client) DestroyFile(file wl.File) error {
+	if err := c.call("DestroyFile", file); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.fileIndex(file.ID)
+	if i < 0 {
+		return notFound(http.StatusNoContent)
+	}
+	if c.files[i].Revision != file.Revision {
+		return conflict(http.StatusNoContent)
+	}
+
+	listID := c.files[i].ListID
+	c.files = append(c.files[:i], c.files[i+1:]...)
+	delete(c.contents, file.ID)
+	c.touch(listID)
+	return nil
+}
+
+// FilePreview returns a preview of the file at the URL of the file.
+func (c *Client) FilePreview(fileID uint, platform string, size string) (wl.FilePreview, error) {
+	if err := c.call("FilePreview", fileID, platform, size); err != nil {
+		return wl.FilePreview{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.fileIndex(fileID)
+	if i < 0 {
+		return wl.FilePreview{}, notFound(http.StatusOK)
+	}
+	return wl.FilePreview{URL: c.files[i].URL, Size: size}, nil
+}
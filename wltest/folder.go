@@ -0,0 +1,180 @@
+package wltest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/robdimsdale/wl"
+)
+
+// AddFolder adds the folder. If the folder has no ID, one is assigned.
+func (c *Client) AddFolder(folder wl.Folder) wl.Folder {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	folder.ID = c.id(folder.ID)
+	folder.ListIDs = copyIDs(folder.ListIDs)
+	c.folders = append(c.folders, folder)
+	return copyFolder(folder)
+}
+
+func (c *Client) folderIndex(folderID uint) int {
+	for i, f := range c.folders {
+		if f.ID == folderID {
+			return i
+		}
+	}
+	return -1
+}
+
+func copyFolder(f wl.Folder) wl.Folder {
+	f.ListIDs = copyIDs(f.ListIDs)
+	return f
+}
+
+// Folders returns all folders.
+func (c *Client) Folders() ([]wl.Folder, error) {
+	if err := c.call("Folders"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	folders := []wl.Folder{}
+	for _, f := range c.folders {
+		folders = append(folders, copyFolder(f))
+	}
+	return folders, nil
+}
+
+// CreateFolder creates a folder containing the lists.
+func (c *Client) CreateFolder(title string, listIDs []uint) (wl.Folder, error) {
+	if err := c.call("CreateFolder", title, listIDs); err != nil {
+		return wl.Folder{}, err
+	}
+
+	if title == "" {
+		return wl.Folder{}, errors.New("title must be non-empty")
+	}
+	if len(listIDs) == 0 {
+		return wl.Folder{}, errors.New("listIDs must be non-nil and non-empty")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	folder := wl.Folder{
+		ID:         c.id(0),
+		Title:      title,
+		ListIDs:    copyIDs(listIDs),
+		TypeString: "folder",
+		Revision:   1,
+	}
+	c.folders = append(c.folders, folder)
+	c.touch(0)
+	return copyFolder(folder), nil
+}
+
+// Folder returns the folder.
+func (c *Client) Folder(folderID uint) (wl.Folder, error) {
+	if err := c.call("Folder", folderID); err != nil {
+		return wl.Folder{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.folderIndex(folderID)
+	if i < 0 {
+		return wl.Folder{}, notFound(http.StatusOK)
+	}
+	return copyFolder(c.folders[i]), nil
+}
+
+// UpdateFolder updates the folder.
+func (c *Client) UpdateFolder(folder wl.Folder) (wl.Folder, error) {
+	if err := c.call("UpdateFolder", folder); err != nil {
+		return wl.Folder{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.folderIndex(folder.ID)
+	if i < 0 {
+		return wl.Folder{}, notFound(http.StatusOK)
+	}
+	if c.folders[i].Revision != folder.Revision {
+		return wl.Folder{}, conflict(http.StatusOK)
+	}
+
+	folder.Revision++
+	c.folders[i] = copyFolder(folder)
+	c.touch(0)
+	return copyFolder(folder), nil
+}
+
+// DeleteFolder deletes the folder, but not its lists.
+func (c *Client) DeleteFolder(folder wl.Folder) error {
+	if err := c.call("DeleteFolder", folder); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.deleteFolder(folder)
+}
+
+func (c *Client) deleteFolder(folder wl.Folder) error {
+	i := c.folderIndex(folder.ID)
+	if i < 0 {
+		return notFound(http.StatusNoContent)
+	}
+	if c.folders[i].Revision != folder.Revision {
+		return conflict(http.StatusNoContent)
+	}
+
+	c.folders = append(c.folders[:i], c.folders[i+1:]...)
+	c.touch(0)
+	return nil
+}
+
+// FolderRevisions returns the revisions of all folders.
+func (c *Client) FolderRevisions() ([]wl.FolderRevision, error) {
+	if err := c.call("FolderRevisions"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	revisions := []wl.FolderRevision{}
+	for _, f := range c.folders {
+		revisions = append(revisions, wl.FolderRevision{
+			ID:         f.ID,
+			TypeString: "folder_revision",
+			Revision:   f.Revision,
+		})
+	}
+	return revisions, nil
+}
+
+// DeleteAllFolders deletes all folders, but not their lists.
+func (c *Client) DeleteAllFolders() error {
+	if err := c.call("DeleteAllFolders"); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, f := range append([]wl.Folder{}, c.folders...) {
+		err := c.deleteFolder(f)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
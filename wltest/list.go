@@ -0,0 +1,239 @@
+package wltest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/robdimsdale/wl"
+)
+
+// AddList adds the list, with an empty task position.
+// If the list has no ID, one is assigned.
+func (c *Client) AddList(list wl.List) wl.List {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.addList(list)
+}
+
+func (c *Client) addList(list wl.List) wl.List {
+	list.ID = c.id(list.ID)
+	c.lists = append(c.lists, list)
+	c.taskPositions = append(c.taskPositions, wl.Position{ID: list.ID, Values: []uint{}, Revision: 1})
+	return list
+}
+
+func (c *Client) listIndex(listID uint) int {
+	for i, l := range c.lists {
+		if l.ID == listID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Lists returns all lists, in the order they were added.
+func (c *Client) Lists() ([]wl.List, error) {
+	if err := c.call("Lists"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]wl.List{}, c.lists...), nil
+}
+
+// List returns the list.
+func (c *Client) List(listID uint) (wl.List, error) {
+	if err := c.call("List", listID); err != nil {
+		return wl.List{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.listIndex(listID)
+	if i < 0 {
+		return wl.List{}, notFound(http.StatusOK)
+	}
+	return c.lists[i], nil
+}
+
+// CreateList creates a list.
+func (c *Client) CreateList(title string) (wl.List, error) {
+	if err := c.call("CreateList", title); err != nil {
+		return wl.List{}, err
+	}
+
+	if title == "" {
+		return wl.List{}, errors.New("title must be non-empty")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	list := c.addList(wl.List{
+		Title:      title,
+		ListType:   "list",
+		Revision:   1,
+		TypeString: "list",
+	})
+	c.touch(0)
+	return list, nil
+}
+
+// UpdateList updates the list.
+func (c *Client) UpdateList(list wl.List) (wl.List, error) {
+	if err := c.call("UpdateList", list); err != nil {
+		return wl.List{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.listIndex(list.ID)
+	if i < 0 {
+		return wl.List{}, notFound(http.StatusOK)
+	}
+	if c.lists[i].Revision != list.Revision {
+		return wl.List{}, conflict(http.StatusOK)
+	}
+
+	c.lists[i] = list
+	c.touch(list.ID)
+	return c.lists[i], nil
+}
+
+// DeleteList deletes the list and everything in it,
+// and removes it from its folder.
+func (c *Client) DeleteList(list wl.List) error {
+	if err := c.call("DeleteList", list); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.deleteList(list)
+}
+
+func (c *Client) deleteList(list wl.List) error {
+	i := c.listIndex(list.ID)
+	if i < 0 {
+		return notFound(http.StatusNoContent)
+	}
+	if c.lists[i].Revision != list.Revision {
+		return conflict(http.StatusNoContent)
+	}
+
+	c.lists = append(c.lists[:i], c.lists[i+1:]...)
+
+	var tasks []wl.Task
+	for _, t := range c.tasks {
+		if t.ListID == list.ID {
+			c.deleteTaskContents(t.ID)
+		} else {
+			tasks = append(tasks, t)
+		}
+	}
+	c.tasks = tasks
+
+	var memberships []wl.Membership
+	for _, m := range c.memberships {
+		if m.ListID != list.ID {
+			memberships = append(memberships, m)
+		}
+	}
+	c.memberships = memberships
+
+	var webhooks []wl.Webhook
+	for _, w := range c.webhooks {
+		if w.ListID != list.ID {
+			webhooks = append(webhooks, w)
+		}
+	}
+	c.webhooks = webhooks
+
+	if i := c.taskPositionIndex(list.ID); i >= 0 {
+		c.taskPositions = append(c.taskPositions[:i], c.taskPositions[i+1:]...)
+	}
+
+	for i, f := range c.folders {
+		for j, id := range f.ListIDs {
+			if id == list.ID {
+				c.folders[i].ListIDs = append(copyIDs(f.ListIDs[:j]), f.ListIDs[j+1:]...)
+				c.folders[i].Revision++
+				break
+			}
+		}
+	}
+
+	c.touch(0)
+	return nil
+}
+
+// DeleteAllLists deletes all lists except the inbox.
+func (c *Client) DeleteAllLists() error {
+	if err := c.call("DeleteAllLists"); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, l := range append([]wl.List{}, c.lists...) {
+		if l.ListType == "inbox" {
+			continue
+		}
+		err := c.deleteList(l)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Inbox returns the list titled inbox.
+func (c *Client) Inbox() (wl.List, error) {
+	if err := c.call("Inbox"); err != nil {
+		return wl.List{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, l := range c.lists {
+		if l.Title == "inbox" {
+			return l, nil
+		}
+	}
+	return wl.List{}, errors.New("Inbox not found")
+}
+
+// ListTaskCount returns the number of completed and uncompleted tasks in the list.
+func (c *Client) ListTaskCount(listID uint) (wl.ListTaskCount, error) {
+	if err := c.call("ListTaskCount", listID); err != nil {
+		return wl.ListTaskCount{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return wl.ListTaskCount{}, notFound(http.StatusOK)
+	}
+
+	count := wl.ListTaskCount{ID: listID}
+	for _, t := range c.tasks {
+		if t.ListID != listID {
+			continue
+		}
+		if t.Completed {
+			count.CompletedCount++
+		} else {
+			count.UncompletedCount++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,188 @@
+package wltest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/robdimsdale/wl"
+)
+
+// AddMembership adds the membership. If the membership has no ID, one is assigned.
+func (c *Client) AddMembership(membership wl.Membership) wl.Membership {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	membership.ID = c.id(membership.ID)
+	c.memberships = append(c.memberships, membership)
+	return membership
+}
+
+func (c *Client) membershipIndex(membershipID uint) int {
+	for i, m := range c.memberships {
+		if m.ID == membershipID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Memberships returns the memberships of all lists.
+func (c *Client) Memberships() ([]wl.Membership, error) {
+	if err := c.call("Memberships"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]wl.Membership{}, c.memberships...), nil
+}
+
+// MembershipsForListID returns the memberships of the list.
+func (c *Client) MembershipsForListID(listID uint) ([]wl.Membership, error) {
+	if err := c.call("MembershipsForListID", listID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+
+	memberships := []wl.Membership{}
+	for _, m := range c.memberships {
+		if m.ListID == listID {
+			memberships = append(memberships, m)
+		}
+	}
+	return memberships, nil
+}
+
+// Membership returns the membership.
+func (c *Client) Membership(membershipID uint) (wl.Membership, error) {
+	if err := c.call("Membership", membershipID); err != nil {
+		return wl.Membership{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.membershipIndex(membershipID)
+	if i < 0 {
+		return wl.Membership{}, notFound(http.StatusOK)
+	}
+	return c.memberships[i], nil
+}
+
+// AddMemberToListViaUserID invites the user to the list.
+func (c *Client) AddMemberToListViaUserID(userID uint, listID uint, muted bool) (wl.Membership, error) {
+	if err := c.call("AddMemberToListViaUserID", userID, listID, muted); err != nil {
+		return wl.Membership{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.addMember(userID, listID, muted)
+}
+
+// AddMemberToListViaEmailAddress invites the user with the email address
+// to the list. If there is no such user the membership has no user ID.
+func (c *Client) AddMemberToListViaEmailAddress(emailAddress string, listID uint, muted bool) (wl.Membership, error) {
+	if err := c.call("AddMemberToListViaEmailAddress", emailAddress, listID, muted); err != nil {
+		return wl.Membership{}, err
+	}
+
+	if emailAddress == "" {
+		return wl.Membership{}, errors.New("emailAddress must not be empty")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	user, _ := c.userByEmail(emailAddress)
+	return c.addMember(user.ID, listID, muted)
+}
+
+func (c *Client) addMember(userID uint, listID uint, muted bool) (wl.Membership, error) {
+	if c.listIndex(listID) < 0 {
+		return wl.Membership{}, notFound(http.StatusCreated)
+	}
+
+	membership := wl.Membership{
+		ID:       c.id(0),
+		UserID:   userID,
+		ListID:   listID,
+		State:    "pending",
+		Muted:    muted,
+		Revision: 1,
+	}
+	c.memberships = append(c.memberships, membership)
+	c.touch(listID)
+	return membership, nil
+}
+
+// RejectInvite deletes the pending membership.
+func (c *Client) RejectInvite(membership wl.Membership) error {
+	if err := c.call("RejectInvite", membership); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.deleteMembership(membership)
+}
+
+// RemoveMemberFromList deletes the membership.
+func (c *Client) RemoveMemberFromList(membership wl.Membership) error {
+	if err := c.call("RemoveMemberFromList", membership); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.deleteMembership(membership)
+}
+
+func (c *Client) deleteMembership(membership wl.Membership) error {
+	i := c.membershipIndex(membership.ID)
+	if i < 0 {
+		return notFound(http.StatusNoContent)
+	}
+	if c.memberships[i].Revision != membership.Revision {
+		return conflict(http.StatusNoContent)
+	}
+
+	listID := c.memberships[i].ListID
+	c.memberships = append(c.memberships[:i], c.memberships[i+1:]...)
+	c.touch(listID)
+	return nil
+}
+
+// AcceptMember accepts the pending membership.
+func (c *Client) AcceptMember(membership wl.Membership) (wl.Membership, error) {
+	if err := c.call("AcceptMember", membership); err != nil {
+		return wl.Membership{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.membershipIndex(membership.ID)
+	if i < 0 {
+		return wl.Membership{}, notFound(http.StatusOK)
+	}
+	if c.memberships[i].Revision != membership.Revision {
+		return wl.Membership{}, conflict(http.StatusOK)
+	}
+
+	c.memberships[i].State = "accepted"
+	c.memberships[i].Muted = membership.Muted
+	c.memberships[i].Revision++
+	c.touch(c.memberships[i].ListID)
+	return c.memberships[i], nil
+}
@@ -0,0 +1,160 @@
+package wltest
+
+import (
+	"net/http"
+
+	"github.com/robdimsdale/wl"
+)
+
+// AddNote adds the note. If the note has no ID, one is assigned.
+func (c *Client) AddNote(note wl.Note) wl.Note {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	note.ID = c.id(note.ID)
+	c.notes = append(c.notes, note)
+	return note
+}
+
+func (c *Client) noteIndex(noteID uint) int {
+	for i, n := range c.notes {
+		if n.ID == noteID {
+			return i
+		}
+	}
+	return -1
+}
+
+// notesWhere returns the notes for which keep returns true.
+func (c *Client) notesWhere(keep func(wl.Note) bool) []wl.Note {
+	notes := []wl.Note{}
+	for _, n := range c.notes {
+		if keep(n) {
+			notes = append(notes, n)
+		}
+	}
+	return notes
+}
+
+// Notes returns the notes of all lists.
+func (c *Client) Notes() ([]wl.Note, error) {
+	if err := c.call("Notes"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.notesWhere(func(wl.Note) bool { return true }), nil
+}
+
+// NotesForListID returns the notes of tasks in the list.
+func (c *Client) NotesForListID(listID uint) ([]wl.Note, error) {
+	if err := c.call("NotesForListID", listID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.notesWhere(func(n wl.Note) bool { return c.listIDOfTask(n.TaskID) == listID }), nil
+}
+
+// NotesForTaskID returns the notes of the task.
+func (c *Client) NotesForTaskID(taskID uint) ([]wl.Note, error) {
+	if err := c.call("NotesForTaskID", taskID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.taskIndex(taskID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.notesWhere(func(n wl.Note) bool { return n.TaskID == taskID }), nil
+}
+
+// Note returns the note.
+func (c *Client) Note(noteID uint) (wl.Note, error) {
+	if err := c.call("Note", noteID); err != nil {
+		return wl.Note{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.noteIndex(noteID)
+	if i < 0 {
+		return wl.Note{}, notFound(http.StatusOK)
+	}
+	return c.notes[i], nil
+}
+
+// CreateNote creates a note on the task.
+func (c *Client) CreateNote(content string, taskID uint) (wl.Note, error) {
+	if err := c.call("CreateNote", content, taskID); err != nil {
+		return wl.Note{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.taskIndex(taskID) < 0 {
+		return wl.Note{}, notFound(http.StatusCreated)
+	}
+
+	note := wl.Note{ID: c.id(0), TaskID: taskID, Content: content, Revision: 1}
+	c.notes = append(c.notes, note)
+	c.touchTask(taskID)
+	return note, nil
+}
+
+// UpdateNote updates the note.
+func (c *Client) UpdateNote(note wl.Note) (wl.Note, error) {
+	if err := c.call("UpdateNote", note); err != nil {
+		return wl.Note{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.noteIndex(note.ID)
+	if i < 0 {
+		return wl.Note{}, notFound(http.StatusOK)
+	}
+	if c.notes[i].Revision != note.Revision {
+		return wl.Note{}, conflict(http.StatusOK)
+	}
+
+	note.Revision++
+	c.notes[i] = note
+	c.touchTask(note.TaskID)
+	return note, nil
+}
+
+// DeleteNote deletes the note.
+func (c *Client) DeleteNote(note wl.Note) error {
+	if err := c.call("DeleteNote", note); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.noteIndex(note.ID)
+	if i < 0 {
+		return notFound(http.StatusNoContent)
+	}
+	if c.notes[i].Revision != note.Revision {
+		return conflict(http.StatusNoContent)
+	}
+
+	taskID := c.notes[i].TaskID
+	c.notes = append(c.notes[:i], c.notes[i+1:]...)
+	c.touchTask(taskID)
+	return nil
+}
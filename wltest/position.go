@@ -0,0 +1,280 @@
+package wltest
+
+import (
+	"net/http"
+
+	"github.com/robdimsdale/wl"
+)
+
+// Each list has a task position with the ID of the list, and each task has
+// a subtask position with the ID of the task. There is one list position.
+
+// SetListPosition sets the values of the list position.
+func (c *Client) SetListPosition(values ...uint) wl.Position {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.listPosition.Values = copyIDs(values)
+	return copyPosition(c.listPosition)
+}
+
+// SetTaskPosition sets the values of the task position of the list.
+// It panics if the list has not been added.
+func (c *Client) SetTaskPosition(listID uint, values ...uint) wl.Position {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.taskPositionIndex(listID)
+	c.taskPositions[i].Values = copyIDs(values)
+	return copyPosition(c.taskPositions[i])
+}
+
+// SetSubtaskPosition sets the values of the subtask position of the task.
+// It panics if the task has not been added.
+func (c *Client) SetSubtaskPosition(taskID uint, values ...uint) wl.Position {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.subtaskPositionIndex(taskID)
+	c.subtaskPositions[i].Values = copyIDs(values)
+	return copyPosition(c.subtaskPositions[i])
+}
+
+func (c *Client) taskPositionIndex(id uint) int {
+	return positionIndex(c.taskPositions, id)
+}
+
+func (c *Client) subtaskPositionIndex(id uint) int {
+	return positionIndex(c.subtaskPositions, id)
+}
+
+func positionIndex(positions []wl.Position, id uint) int {
+	for i, p := range positions {
+		if p.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func copyPosition(p wl.Position) wl.Position {
+	p.Values = copyIDs(p.Values)
+	return p
+}
+
+// positionsWhere returns copies of the positions for which keep returns true.
+func positionsWhere(positions []wl.Position, keep func(wl.Position) bool) []wl.Position {
+	kept := []wl.Position{}
+	for _, p := range positions {
+		if keep(p) {
+			kept = append(kept, copyPosition(p))
+		}
+	}
+	return kept
+}
+
+// updatePosition replaces the position, if its revision is current.
+func updatePosition(current *wl.Position, p wl.Position) (wl.Position, error) {
+	if current.Revision != p.Revision {
+		return wl.Position{}, conflict(http.StatusOK)
+	}
+
+	p.Revision++
+	*current = copyPosition(p)
+	return copyPosition(p), nil
+}
+
+// ListPositions returns the list position.
+func (c *Client) ListPositions() ([]wl.Position, error) {
+	if err := c.call("ListPositions"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return []wl.Position{copyPosition(c.listPosition)}, nil
+}
+
+// ListPosition returns the list position.
+func (c *Client) ListPosition(listPositionID uint) (wl.Position, error) {
+	if err := c.call("ListPosition", listPositionID); err != nil {
+		return wl.Position{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if listPositionID != c.listPosition.ID {
+		return wl.Position{}, notFound(http.StatusOK)
+	}
+	return copyPosition(c.listPosition), nil
+}
+
+// UpdateListPosition updates the list position.
+func (c *Client) UpdateListPosition(listPosition wl.Position) (wl.Position, error) {
+	if err := c.call("UpdateListPosition", listPosition); err != nil {
+		return wl.Position{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if listPosition.ID != c.listPosition.ID {
+		return wl.Position{}, notFound(http.StatusOK)
+	}
+
+	updated, err := updatePosition(&c.listPosition, listPosition)
+	if err != nil {
+		return wl.Position{}, err
+	}
+	c.touch(0)
+	return updated, nil
+}
+
+// TaskPositions returns the task positions of all lists.
+func (c *Client) TaskPositions() ([]wl.Position, error) {
+	if err := c.call("TaskPositions"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return positionsWhere(c.taskPositions, func(wl.Position) bool { return true }), nil
+}
+
+// TaskPositionsForListID returns the task position of the list.
+func (c *Client) TaskPositionsForListID(listID uint) ([]wl.Position, error) {
+	if err := c.call("TaskPositionsForListID", listID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return positionsWhere(c.taskPositions, func(p wl.Position) bool { return p.ID == listID }), nil
+}
+
+// TaskPosition returns the task position.
+func (c *Client) TaskPosition(taskPositionID uint) (wl.Position, error) {
+	if err := c.call("TaskPosition", taskPositionID); err != nil {
+		return wl.Position{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.taskPositionIndex(taskPositionID)
+	if i < 0 {
+		return wl.Position{}, notFound(http.StatusOK)
+	}
+	return copyPosition(c.taskPositions[i]), nil
+}
+
+// UpdateTaskPosition updates the task position.
+func (c *Client) UpdateTaskPosition(taskPosition wl.Position) (wl.Position, error) {
+	if err := c.call("UpdateTaskPosition", taskPosition); err != nil {
+		return wl.Position{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.taskPositionIndex(taskPosition.ID)
+	if i < 0 {
+		return wl.Position{}, notFound(http.StatusOK)
+	}
+
+	updated, err := updatePosition(&c.taskPositions[i], taskPosition)
+	if err != nil {
+		return wl.Position{}, err
+	}
+	c.touch(taskPosition.ID)
+	return updated, nil
+}
+
+// SubtaskPositions returns the subtask positions of all tasks.
+func (c *Client) SubtaskPositions() ([]wl.Position, error) {
+	if err := c.call("SubtaskPositions"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return positionsWhere(c.subtaskPositions, func(wl.Position) bool { return true }), nil
+}
+
+// SubtaskPositionsForListID returns the subtask positions of tasks in the list.
+func (c *Client) SubtaskPositionsForListID(listID uint) ([]wl.Position, error) {
+	if err := c.call("SubtaskPositionsForListID", listID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return positionsWhere(c.subtaskPositions, func(p wl.Position) bool {
+		return c.listIDOfTask(p.ID) == listID
+	}), nil
+}
+
+// SubtaskPositionsForTaskID returns the subtask position of the task.
+func (c *Client) SubtaskPositionsForTaskID(taskID uint) ([]wl.Position, error) {
+	if err := c.call("SubtaskPositionsForTaskID", taskID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.taskIndex(taskID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return positionsWhere(c.subtaskPositions, func(p wl.Position) bool { return p.ID == taskID }), nil
+}
+
+// SubtaskPosition returns the subtask position.
+func (c *Client) SubtaskPosition(subtaskPositionID uint) (wl.Position, error) {
+	if err := c.call("SubtaskPosition", subtaskPositionID); err != nil {
+		return wl.Position{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.subtaskPositionIndex(subtaskPositionID)
+	if i < 0 {
+		return wl.Position{}, notFound(http.StatusOK)
+	}
+	return copyPosition(c.subtaskPositions[i]), nil
+}
+
+// UpdateSubtaskPosition updates the subtask position.
+func (c *Client) UpdateSubtaskPosition(subtaskPosition wl.Position) (wl.Position, error) {
+	if err := c.call("UpdateSubtaskPosition", subtaskPosition); err != nil {
+		return wl.Position{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.subtaskPositionIndex(subtaskPosition.ID)
+	if i < 0 {
+		return wl.Position{}, notFound(http.StatusOK)
+	}
+
+	updated, err := updatePosition(&c.subtaskPositions[i], subtaskPosition)
+	if err != nil {
+		return wl.Position{}, err
+	}
+	c.touchTask(subtaskPosition.ID)
+	return updated, nil
+}
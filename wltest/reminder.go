@@ -0,0 +1,161 @@
+package wltest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/robdimsdale/wl"
+)
+
+// AddReminder adds the reminder. If the reminder has no ID, one is assigned.
+func (c *Client) AddReminder(reminder wl.Reminder) wl.Reminder {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	reminder.ID = c.id(reminder.ID)
+	c.reminders = append(c.reminders, reminder)
+	return reminder
+}
+
+func (c *Client) reminderIndex(reminderID uint) int {
+	for i, r := range c.reminders {
+		if r.ID == reminderID {
+			return i
+		}
+	}
+	return -1
+}
+
+// remindersWhere returns the reminders for which keep returns true.
+func (c *Client) remindersWhere(keep func(wl.Reminder) bool) []wl.Reminder {
+	reminders := []wl.Reminder{}
+	for _, r := range c.reminders {
+		if keep(r) {
+			reminders = append(reminders, r)
+		}
+	}
+	return reminders
+}
+
+// Reminders returns the reminders of all lists.
+func (c *Client) Reminders() ([]wl.Reminder, error) {
+	if err := c.call("Reminders"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.remindersWhere(func(wl.Reminder) bool { return true }), nil
+}
+
+// RemindersForListID returns the reminders of tasks in the list.
+func (c *Client) RemindersForListID(listID uint) ([]wl.Reminder, error) {
+	if err := c.call("RemindersForListID", listID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.remindersWhere(func(r wl.Reminder) bool { return c.listIDOfTask(r.TaskID) == listID }), nil
+}
+
+// RemindersForTaskID returns the reminders of the task.
+func (c *Client) RemindersForTaskID(taskID uint) ([]wl.Reminder, error) {
+	if err := c.call("RemindersForTaskID", taskID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.taskIndex(taskID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.remindersWhere(func(r wl.Reminder) bool { return r.TaskID == taskID }), nil
+}
+
+// Reminder returns the reminder.
+func (c *Client) Reminder(reminderID uint) (wl.Reminder, error) {
+	if err := c.call("Reminder", reminderID); err != nil {
+		return wl.Reminder{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.reminderIndex(reminderID)
+	if i < 0 {
+		return wl.Reminder{}, notFound(http.StatusOK)
+	}
+	return c.reminders[i], nil
+}
+
+// CreateReminder creates a reminder on the task.
+func (c *Client) CreateReminder(date time.Time, taskID uint, createdByDeviceUdid string) (wl.Reminder, error) {
+	if err := c.call("CreateReminder", date, taskID, createdByDeviceUdid); err != nil {
+		return wl.Reminder{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.taskIndex(taskID) < 0 {
+		return wl.Reminder{}, notFound(http.StatusCreated)
+	}
+
+	reminder := wl.Reminder{ID: c.id(0), TaskID: taskID, Date: date, Revision: 1}
+	c.reminders = append(c.reminders, reminder)
+	c.touchTask(taskID)
+	return reminder, nil
+}
+
+// UpdateReminder updates the reminder.
+func (c *Client) UpdateReminder(reminder wl.Reminder) (wl.Reminder, error) {
+	if err := c.call("UpdateReminder", reminder); err != nil {
+		return wl.Reminder{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.reminderIndex(reminder.ID)
+	if i < 0 {
+		return wl.Reminder{}, notFound(http.StatusOK)
+	}
+	if c.reminders[i].Revision != reminder.Revision {
+		return wl.Reminder{}, conflict(http.StatusOK)
+	}
+
+	reminder.Revision++
+	c.reminders[i] = reminder
+	c.touchTask(reminder.TaskID)
+	return reminder, nil
+}
+
+// DeleteReminder deletes the reminder.
+func (c *Client) DeleteReminder(reminder wl.Reminder) error {
+	if err := c.call("DeleteReminder", reminder); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.reminderIndex(reminder.ID)
+	if i < 0 {
+		return notFound(http.StatusNoContent)
+	}
+	if c.reminders[i].Revision != reminder.Revision {
+		return conflict(http.StatusNoContent)
+	}
+
+	taskID := c.reminders[i].TaskID
+	c.reminders = append(c.reminders[:i], c.reminders[i+1:]...)
+	c.touchTask(taskID)
+	return nil
+}
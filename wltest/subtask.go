@@ -0,0 +1,215 @@
+package wltest
+
+import (
+	"net/http"
+
+	"github.com/robdimsdale/wl"
+)
+
+// AddSubtask adds the subtask. If the subtask has no ID, one is assigned.
+func (c *Client) AddSubtask(subtask wl.Subtask) wl.Subtask {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	subtask.ID = c.id(subtask.ID)
+	c.subtasks = append(c.subtasks, subtask)
+	return subtask
+}
+
+func (c *Client) subtaskIndex(subtaskID uint) int {
+	for i, s := range c.subtasks {
+		if s.ID == subtaskID {
+			return i
+		}
+	}
+	return -1
+}
+
+// subtasksWhere returns the subtasks for which keep returns true.
+func (c *Client) subtasksWhere(keep func(wl.Subtask) bool) []wl.Subtask {
+	subtasks := []wl.Subtask{}
+	for _, s := range c.subtasks {
+		if keep(s) {
+			subtasks = append(subtasks, s)
+		}
+	}
+	return subtasks
+}
+
+// Subtasks returns the uncompleted subtasks of all lists.
+func (c *Client) Subtasks() ([]wl.Subtask, error) {
+	if err := c.call("Subtasks"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.subtasksWhere(func(s wl.Subtask) bool { return !s.Completed }), nil
+}
+
+// SubtasksForListID returns the uncompleted subtasks of tasks in the list.
+func (c *Client) SubtasksForListID(listID uint) ([]wl.Subtask, error) {
+	if err := c.call("SubtasksForListID", listID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.subtasksWhere(func(s wl.Subtask) bool {
+		return c.listIDOfTask(s.TaskID) == listID && !s.Completed
+	}), nil
+}
+
+// SubtasksForTaskID returns the uncompleted subtasks of the task.
+func (c *Client) SubtasksForTaskID(taskID uint) ([]wl.Subtask, error) {
+	if err := c.call("SubtasksForTaskID", taskID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.taskIndex(taskID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.subtasksWhere(func(s wl.Subtask) bool { return s.TaskID == taskID && !s.Completed }), nil
+}
+
+// CompletedSubtasks returns the subtasks of all lists which are, or are not, completed.
+func (c *Client) CompletedSubtasks(completed bool) ([]wl.Subtask, error) {
+	if err := c.call("CompletedSubtasks", completed); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.subtasksWhere(func(s wl.Subtask) bool { return s.Completed == completed }), nil
+}
+
+// CompletedSubtasksForListID returns the subtasks of tasks in the list
+// which are, or are not, completed.
+func (c *Client) CompletedSubtasksForListID(listID uint, completed bool) ([]wl.Subtask, error) {
+	if err := c.call("CompletedSubtasksForListID", listID, completed); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.subtasksWhere(func(s wl.Subtask) bool {
+		return c.listIDOfTask(s.TaskID) == listID && s.Completed == completed
+	}), nil
+}
+
+// CompletedSubtasksForTaskID returns the subtasks of the task
+// which are, or are not, completed.
+func (c *Client) CompletedSubtasksForTaskID(taskID uint, completed bool) ([]wl.Subtask, error) {
+	if err := c.call("CompletedSubtasksForTaskID", taskID, completed); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.taskIndex(taskID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.subtasksWhere(func(s wl.Subtask) bool { return s.TaskID == taskID && s.Completed == completed }), nil
+}
+
+// Subtask returns the subtask.
+func (c *Client) Subtask(subtaskID uint) (wl.Subtask, error) {
+	if err := c.call("Subtask", subtaskID); err != nil {
+		return wl.Subtask{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.subtaskIndex(subtaskID)
+	if i < 0 {
+		return wl.Subtask{}, notFound(http.StatusOK)
+	}
+	return c.subtasks[i], nil
+}
+
+// CreateSubtask creates a subtask of the task, created by the current user.
+func (c *Client) CreateSubtask(title string, taskID uint, completed bool) (wl.Subtask, error) {
+	if err := c.call("CreateSubtask", title, taskID, completed); err != nil {
+		return wl.Subtask{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.taskIndex(taskID) < 0 {
+		return wl.Subtask{}, notFound(http.StatusCreated)
+	}
+
+	subtask := wl.Subtask{
+		ID:          c.id(0),
+		TaskID:      taskID,
+		Title:       title,
+		Completed:   completed,
+		CreatedByID: c.user.ID,
+		Revision:    1,
+	}
+	c.subtasks = append(c.subtasks, subtask)
+	c.touchTask(taskID)
+	return subtask, nil
+}
+
+// UpdateSubtask updates the subtask.
+func (c *Client) UpdateSubtask(subtask wl.Subtask) (wl.Subtask, error) {
+	if err := c.call("UpdateSubtask", subtask); err != nil {
+		return wl.Subtask{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.subtaskIndex(subtask.ID)
+	if i < 0 {
+		return wl.Subtask{}, notFound(http.StatusOK)
+	}
+	if c.subtasks[i].Revision != subtask.Revision {
+		return wl.Subtask{}, conflict(http.StatusOK)
+	}
+
+	subtask.Revision++
+	c.subtasks[i] = subtask
+	c.touchTask(subtask.TaskID)
+	return subtask, nil
+}
+
+// DeleteSubtask deletes the subtask.
+func (c *Client) DeleteSubtask(subtask wl.Subtask) error {
+	if err := c.call("DeleteSubtask", subtask); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.subtaskIndex(subtask.ID)
+	if i < 0 {
+		return notFound(http.StatusNoContent)
+	}
+	if c.subtasks[i].Revision != subtask.Revision {
+		return conflict(http.StatusNoContent)
+	}
+
+	taskID := c.subtasks[i].TaskID
+	c.subtasks = append(c.subtasks[:i], c.subtasks[i+1:]...)
+	c.touchTask(taskID)
+	return nil
+}
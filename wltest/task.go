@@ -0,0 +1,284 @@
+package wltest
+
+import (
+	"net/http"
+
+	"github.com/robdimsdale/wl"
+)
+
+// AddTask adds the task, with an empty subtask position.
+// If the task has no ID, one is assigned.
+func (c *Client) AddTask(task wl.Task) wl.Task {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.addTask(task)
+}
+
+func (c *Client) addTask(task wl.Task) wl.Task {
+	task.ID = c.id(task.ID)
+	c.tasks = append(c.tasks, task)
+	c.subtaskPositions = append(c.subtaskPositions, wl.Position{ID: task.ID, Values: []uint{}, Revision: 1})
+	return task
+}
+
+func (c *Client) taskIndex(taskID uint) int {
+	for i, t := range c.tasks {
+		if t.ID == taskID {
+			return i
+		}
+	}
+	return -1
+}
+
+// tasksWhere returns the tasks for which keep returns true.
+func (c *Client) tasksWhere(keep func(wl.Task) bool) []wl.Task {
+	tasks := []wl.Task{}
+	for _, t := range c.tasks {
+		if keep(t) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+// Tasks returns the uncompleted tasks of all lists.
+func (c *Client) Tasks() ([]wl.Task, error) {
+	if err := c.call("Tasks"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.tasksWhere(func(t wl.Task) bool { return !t.Completed }), nil
+}
+
+// CompletedTasks returns the tasks of all lists which are, or are not, completed.
+func (c *Client) CompletedTasks(completed bool) ([]wl.Task, error) {
+	if err := c.call("CompletedTasks", completed); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.tasksWhere(func(t wl.Task) bool { return t.Completed == completed }), nil
+}
+
+// TasksForListID returns the uncompleted tasks of the list.
+func (c *Client) TasksForListID(listID uint) ([]wl.Task, error) {
+	if err := c.call("TasksForListID", listID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.tasksWhere(func(t wl.Task) bool { return t.ListID == listID && !t.Completed }), nil
+}
+
+// CompletedTasksForListID returns the tasks of the list which are, or are not, completed.
+func (c *Client) CompletedTasksForListID(listID uint, completed bool) ([]wl.Task, error) {
+	if err := c.call("CompletedTasksForListID", listID, completed); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.tasksWhere(func(t wl.Task) bool { return t.ListID == listID && t.Completed == completed }), nil
+}
+
+// Task returns the task.
+func (c *Client) Task(taskID uint) (wl.Task, error) {
+	if err := c.call("Task", taskID); err != nil {
+		return wl.Task{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.taskIndex(taskID)
+	if i < 0 {
+		return wl.Task{}, notFound(http.StatusOK)
+	}
+	return c.tasks[i], nil
+}
+
+// CreateTask creates a task in the list, created by the current user.
+func (c *Client) CreateTask(
+	title string,
+	listID uint,
+	assigneeID uint,
+	completed bool,
+	recurrenceType wl.RecurrenceType,
+	recurrenceCount uint,
+	dueDate wl.Date,
+	starred bool,
+) (wl.Task, error) {
+	err := c.call(
+		"CreateTask",
+		title,
+		listID,
+		assigneeID,
+		completed,
+		recurrenceType,
+		recurrenceCount,
+		dueDate,
+		starred,
+	)
+	if err != nil {
+		return wl.Task{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return wl.Task{}, notFound(http.StatusCreated)
+	}
+
+	task := c.addTask(wl.Task{
+		Title:           title,
+		ListID:          listID,
+		AssigneeID:      assigneeID,
+		Completed:       completed,
+		RecurrenceType:  recurrenceType,
+		RecurrenceCount: recurrenceCount,
+		DueDate:         dueDate,
+		Starred:         starred,
+		CreatedByID:     c.user.ID,
+		Revision:        1,
+	})
+	c.touch(listID)
+	return task, nil
+}
+
+// UpdateTask updates the task, which may be moved to another list.
+func (c *Client) UpdateTask(task wl.Task) (wl.Task, error) {
+	if err := c.call("UpdateTask", task); err != nil {
+		return wl.Task{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.taskIndex(task.ID)
+	if i < 0 || c.listIndex(task.ListID) < 0 {
+		return wl.Task{}, notFound(http.StatusOK)
+	}
+	if c.tasks[i].Revision != task.Revision {
+		return wl.Task{}, conflict(http.StatusOK)
+	}
+
+	oldListID := c.tasks[i].ListID
+	task.Revision++
+	c.tasks[i] = task
+
+	c.touch(task.ListID)
+	if oldListID != task.ListID {
+		c.touch(oldListID)
+	}
+	return task, nil
+}
+
+// DeleteTask deletes the task and everything on it.
+func (c *Client) DeleteTask(task wl.Task) error {
+	if err := c.call("DeleteTask", task); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.deleteTask(task)
+}
+
+func (c *Client) deleteTask(task wl.Task) error {
+	i := c.taskIndex(task.ID)
+	if i < 0 {
+		return notFound(http.StatusNoContent)
+	}
+	if c.tasks[i].Revision != task.Revision {
+		return conflict(http.StatusNoContent)
+	}
+
+	listID := c.tasks[i].ListID
+	c.tasks = append(c.tasks[:i], c.tasks[i+1:]...)
+	c.deleteTaskContents(task.ID)
+	c.touch(listID)
+	return nil
+}
+
+// deleteTaskContents deletes the subtasks, notes, reminders, comments,
+// files and subtask position of the task.
+func (c *Client) deleteTaskContents(taskID uint) {
+	var subtasks []wl.Subtask
+	for _, s := range c.subtasks {
+		if s.TaskID != taskID {
+			subtasks = append(subtasks, s)
+		}
+	}
+	c.subtasks = subtasks
+
+	var notes []wl.Note
+	for _, n := range c.notes {
+		if n.TaskID != taskID {
+			notes = append(notes, n)
+		}
+	}
+	c.notes = notes
+
+	var reminders []wl.Reminder
+	for _, r := range c.reminders {
+		if r.TaskID != taskID {
+			reminders = append(reminders, r)
+		}
+	}
+	c.reminders = reminders
+
+	var comments []wl.TaskComment
+	for _, tc := range c.comments {
+		if tc.TaskID != taskID {
+			comments = append(comments, tc)
+		}
+	}
+	c.comments = comments
+
+	var files []wl.File
+	for _, f := range c.files {
+		if f.TaskID != taskID {
+			files = append(files, f)
+		}
+	}
+	c.files = files
+
+	if i := c.subtaskPositionIndex(taskID); i >= 0 {
+		c.subtaskPositions = append(c.subtaskPositions[:i], c.subtaskPositions[i+1:]...)
+	}
+}
+
+// DeleteAllTasks deletes the uncompleted tasks of all lists.
+func (c *Client) DeleteAllTasks() error {
+	if err := c.call("DeleteAllTasks"); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, t := range c.tasksWhere(func(t wl.Task) bool { return !t.Completed }) {
+		err := c.deleteTask(t)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
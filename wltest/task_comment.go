@@ -0,0 +1,137 @@
+package wltest
+
+import (
+	"net/http"
+
+	"github.com/robdimsdale/wl"
+)
+
+// AddTaskComment adds the comment. If the comment has no ID, one is assigned.
+func (c *Client) AddTaskComment(comment wl.TaskComment) wl.TaskComment {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	comment.ID = c.id(comment.ID)
+	c.comments = append(c.comments, comment)
+	return comment
+}
+
+func (c *Client) taskCommentIndex(taskCommentID uint) int {
+	for i, tc := range c.comments {
+		if tc.ID == taskCommentID {
+			return i
+		}
+	}
+	return -1
+}
+
+// taskCommentsWhere returns the comments for which keep returns true.
+func (c *Client) taskCommentsWhere(keep func(wl.TaskComment) bool) []wl.TaskComment {
+	comments := []wl.TaskComment{}
+	for _, tc := range c.comments {
+		if keep(tc) {
+			comments = append(comments, tc)
+		}
+	}
+	return comments
+}
+
+// TaskComments returns the comments of all lists.
+func (c *Client) TaskComments() ([]wl.TaskComment, error) {
+	if err := c.call("TaskComments"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.taskCommentsWhere(func(wl.TaskComment) bool { return true }), nil
+}
+
+// TaskCommentsForListID returns the comments of tasks in the list.
+func (c *Client) TaskCommentsForListID(listID uint) ([]wl.TaskComment, error) {
+	if err := c.call("TaskCommentsForListID", listID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.taskCommentsWhere(func(tc wl.TaskComment) bool { return c.listIDOfTask(tc.TaskID) == listID }), nil
+}
+
+// TaskCommentsForTaskID returns the comments of the task.
+func (c *Client) TaskCommentsForTaskID(taskID uint) ([]wl.TaskComment, error) {
+	if err := c.call("TaskCommentsForTaskID", taskID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.taskIndex(taskID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+	return c.taskCommentsWhere(func(tc wl.TaskComment) bool { return tc.TaskID == taskID }), nil
+}
+
+// CreateTaskComment creates a comment on the task.
+func (c *Client) CreateTaskComment(text string, taskID uint) (wl.TaskComment, error) {
+	if err := c.call("CreateTaskComment", text, taskID); err != nil {
+		return wl.TaskComment{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.taskIndex(taskID) < 0 {
+		return wl.TaskComment{}, notFound(http.StatusCreated)
+	}
+
+	comment := wl.TaskComment{ID: c.id(0), TaskID: taskID, Text: text, Revision: 1}
+	c.comments = append(c.comments, comment)
+	c.touchTask(taskID)
+	return comment, nil
+}
+
+// TaskComment returns the comment.
+func (c *Client) TaskComment(taskCommentID uint) (wl.TaskComment, error) {
+	if err := c.call("TaskComment", taskCommentID); err != nil {
+		return wl.TaskComment{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.taskCommentIndex(taskCommentID)
+	if i < 0 {
+		return wl.TaskComment{}, notFound(http.StatusOK)
+	}
+	return c.comments[i], nil
+}
+
+// DeleteTaskComment deletes the comment.
+func (c *Client) DeleteTaskComment(taskComment wl.TaskComment) error {
+	if err := c.call("DeleteTaskComment", taskComment); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.taskCommentIndex(taskComment.ID)
+	if i < 0 {
+		return notFound(http.StatusNoContent)
+	}
+	if c.comments[i].Revision != taskComment.Revision {
+		return conflict(http.StatusNoContent)
+	}
+
+	taskID := c.comments[i].TaskID
+	c.comments = append(c.comments[:i], c.comments[i+1:]...)
+	c.touchTask(taskID)
+	return nil
+}
@@ -0,0 +1,148 @@
+package wltest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/robdimsdale/wl"
+)
+
+// SetUser makes the user the current user, adding it to the users.
+func (c *Client) SetUser(user wl.User) wl.User {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	user.ID = c.id(user.ID)
+	c.user = user
+	c.addUser(user)
+	return user
+}
+
+// AddUser adds a user who is not the current user.
+func (c *Client) AddUser(user wl.User) wl.User {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	user.ID = c.id(user.ID)
+	c.addUser(user)
+	return user
+}
+
+func (c *Client) addUser(user wl.User) {
+	for i, u := range c.users {
+		if u.ID == user.ID {
+			c.users[i] = user
+			return
+		}
+	}
+	c.users = append(c.users, user)
+}
+
+// User returns the current user.
+func (c *Client) User() (wl.User, error) {
+	if err := c.call("User"); err != nil {
+		return wl.User{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.user, nil
+}
+
+// UpdateUser updates the current user.
+func (c *Client) UpdateUser(user wl.User) (wl.User, error) {
+	if err := c.call("UpdateUser", user); err != nil {
+		return wl.User{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if user.ID != c.user.ID {
+		return wl.User{}, notFound(http.StatusOK)
+	}
+	if user.Revision != c.user.Revision {
+		return wl.User{}, conflict(http.StatusOK)
+	}
+
+	user.Revision++
+	c.user = user
+	c.addUser(user)
+	c.touch(0)
+	return user, nil
+}
+
+// Users returns all users, including the current user.
+func (c *Client) Users() ([]wl.User, error) {
+	if err := c.call("Users"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]wl.User{}, c.users...), nil
+}
+
+// UsersForListID returns the users who are members of the list.
+func (c *Client) UsersForListID(listID uint) ([]wl.User, error) {
+	if err := c.call("UsersForListID", listID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+
+	users := []wl.User{}
+	for _, u := range c.users {
+		for _, m := range c.memberships {
+			if m.ListID == listID && m.UserID == u.ID {
+				users = append(users, u)
+				break
+			}
+		}
+	}
+	return users, nil
+}
+
+// userByEmail returns the user with the email address, ignoring case.
+func (c *Client) userByEmail(email string) (wl.User, bool) {
+	for _, u := range c.users {
+		if strings.EqualFold(u.Email, email) {
+			return u, true
+		}
+	}
+	return wl.User{}, false
+}
+
+// AvatarURL returns a URL for the avatar of the user. Like the API,
+// sizes which are not supported are rejected.
+func (c *Client) AvatarURL(userID uint, size int, fallback bool) (string, error) {
+	if err := c.call("AvatarURL", userID, size, fallback); err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/avatar?user_id=%d", wl.APIURL, userID)
+	if size > 0 {
+		if !validSize(size) {
+			return "", fmt.Errorf("Invalid size: %d", size)
+		}
+		url = fmt.Sprintf("%s&size=%d", url, size)
+	}
+	return url, nil
+}
+
+func validSize(size int) bool {
+	for _, s := range []int{25, 28, 30, 32, 50, 54, 56, 60, 64, 108, 128, 135, 256, 270, 512} {
+		if s == size {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package wltest
+
+import (
+	"net/http"
+
+	"github.com/robdimsdale/wl"
+)
+
+// AddWebhook adds the webhook. If the webhook has no ID, one is assigned.
+func (c *Client) AddWebhook(webhook wl.Webhook) wl.Webhook {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	webhook.ID = c.id(webhook.ID)
+	c.webhooks = append(c.webhooks, webhook)
+	return webhook
+}
+
+func (c *Client) webhookIndex(webhookID uint) int {
+	for i, w := range c.webhooks {
+		if w.ID == webhookID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Webhooks returns the webhooks of all lists.
+func (c *Client) Webhooks() ([]wl.Webhook, error) {
+	if err := c.call("Webhooks"); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]wl.Webhook{}, c.webhooks...), nil
+}
+
+// WebhooksForListID returns the webhooks of the list.
+func (c *Client) WebhooksForListID(listID uint) ([]wl.Webhook, error) {
+	if err := c.call("WebhooksForListID", listID); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return nil, notFound(http.StatusOK)
+	}
+
+	webhooks := []wl.Webhook{}
+	for _, w := range c.webhooks {
+		if w.ListID == listID {
+			webhooks = append(webhooks, w)
+		}
+	}
+	return webhooks, nil
+}
+
+// Webhook returns the webhook.
+func (c *Client) Webhook(webhookID uint) (wl.Webhook, error) {
+	if err := c.call("Webhook", webhookID); err != nil {
+		return wl.Webhook{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.webhookIndex(webhookID)
+	if i < 0 {
+		return wl.Webhook{}, notFound(http.StatusOK)
+	}
+	return c.webhooks[i], nil
+}
+
+// CreateWebhook creates a webhook on the list.
+func (c *Client) CreateWebhook(listID uint, url string, processorType string, configuration string) (wl.Webhook, error) {
+	if err := c.call("CreateWebhook", listID, url, processorType, configuration); err != nil {
+		return wl.Webhook{}, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listIndex(listID) < 0 {
+		return wl.Webhook{}, notFound(http.StatusCreated)
+	}
+
+	webhook := wl.Webhook{
+		ID:            c.id(0),
+		ListID:        listID,
+		URL:           url,
+		ProcessorType: processorType,
+		Configuration: configuration,
+	}
+	c.webhooks = append(c.webhooks, webhook)
+	c.touch(listID)
+	return webhook, nil
+}
+
+// DeleteWebhook deletes the webhook. Webhooks have no revision.
+func (c *Client) DeleteWebhook(webhook wl.Webhook) error {
+	if err := c.call("DeleteWebhook", webhook); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.webhookIndex(webhook.ID)
+	if i < 0 {
+		return notFound(http.StatusNoContent)
+	}
+
+	listID := c.webhooks[i].ListID
+	c.webhooks = append(c.webhooks[:i], c.webhooks[i+1:]...)
+	c.touch(listID)
+	return nil
+}
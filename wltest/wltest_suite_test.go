@@ -0,0 +1,13 @@
+package wltest_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestWltest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Wltest Suite")
+}